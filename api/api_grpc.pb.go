@@ -18,6 +18,8 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DexClient interface {
+	// GetClient gets a client.
+	GetClient(ctx context.Context, in *GetClientReq, opts ...grpc.CallOption) (*GetClientResp, error)
 	// CreateClient creates a client.
 	CreateClient(ctx context.Context, in *CreateClientReq, opts ...grpc.CallOption) (*CreateClientResp, error)
 	// UpdateClient updates an existing client
@@ -52,6 +54,15 @@ func NewDexClient(cc grpc.ClientConnInterface) DexClient {
 	return &dexClient{cc}
 }
 
+func (c *dexClient) GetClient(ctx context.Context, in *GetClientReq, opts ...grpc.CallOption) (*GetClientResp, error) {
+	out := new(GetClientResp)
+	err := c.cc.Invoke(ctx, "/api.Dex/GetClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dexClient) CreateClient(ctx context.Context, in *CreateClientReq, opts ...grpc.CallOption) (*CreateClientResp, error) {
 	out := new(CreateClientResp)
 	err := c.cc.Invoke(ctx, "/api.Dex/CreateClient", in, out, opts...)
@@ -155,6 +166,8 @@ func (c *dexClient) VerifyPassword(ctx context.Context, in *VerifyPasswordReq, o
 // All implementations must embed UnimplementedDexServer
 // for forward compatibility
 type DexServer interface {
+	// GetClient gets a client.
+	GetClient(context.Context, *GetClientReq) (*GetClientResp, error)
 	// CreateClient creates a client.
 	CreateClient(context.Context, *CreateClientReq) (*CreateClientResp, error)
 	// UpdateClient updates an existing client
@@ -186,6 +199,9 @@ type DexServer interface {
 type UnimplementedDexServer struct {
 }
 
+func (UnimplementedDexServer) GetClient(context.Context, *GetClientReq) (*GetClientResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClient not implemented")
+}
 func (UnimplementedDexServer) CreateClient(context.Context, *CreateClientReq) (*CreateClientResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateClient not implemented")
 }
@@ -232,6 +248,24 @@ func RegisterDexServer(s grpc.ServiceRegistrar, srv DexServer) {
 	s.RegisterService(&Dex_ServiceDesc, srv)
 }
 
+func _Dex_GetClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexServer).GetClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Dex/GetClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexServer).GetClient(ctx, req.(*GetClientReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Dex_CreateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateClientReq)
 	if err := dec(in); err != nil {
@@ -437,6 +471,10 @@ var Dex_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Dex",
 	HandlerType: (*DexServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetClient",
+			Handler:    _Dex_GetClient_Handler,
+		},
 		{
 			MethodName: "CreateClient",
 			Handler:    _Dex_CreateClient_Handler,
@@ -0,0 +1,739 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
+)
+
+// Storage archives are newline delimited JSON. The first line is an
+// archiveHeader, every following line is an archiveRecord holding a single
+// object in the JSON encoding of its storage type. The last record is of kind
+// kindEnd and holds the number of records before it, so truncated archives are
+// detected.
+//
+// In encrypted archives the additional data of every record binds it to the
+// header and to its position, see recordAdditionalData. Records therefore
+// can't be reordered, dropped or moved to another archive, and the header
+// can't be modified.
+//
+// archiveVersion must be incremented whenever a change to the storage types
+// can't be decoded by older versions of decodeRecord. New fields don't require
+// a new version.
+const archiveVersion = 1
+
+const (
+	kindClient         = "client"
+	kindPassword       = "password"
+	kindConnector      = "connector"
+	kindKeys           = "keys"
+	kindRefreshToken   = "refreshToken"
+	kindOfflineSession = "offlineSession"
+
+	// kindEnd terminates an archive.
+	kindEnd = "end"
+)
+
+const (
+	archiveKDF    = "scrypt"
+	archiveCipher = "aes-256-gcm"
+
+	// Recommended scrypt parameters for interactive logins as of 2017.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+
+	// archiveCheck is encrypted into the header to detect wrong passphrases.
+	archiveCheck = "dex storage archive"
+)
+
+type archiveHeader struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+
+	// Redacted archives don't contain any secrets. They're only imported on
+	// request, see loadArchive.
+	Redacted bool `json:"redacted,omitempty"`
+
+	// Encryption is set if the data of all records is encrypted.
+	Encryption *archiveEncryption `json:"encryption,omitempty"`
+}
+
+// archiveEnd is the data of the terminating record.
+type archiveEnd struct {
+	Records int `json:"records"`
+}
+
+type archiveEncryption struct {
+	KDF    string `json:"kdf"`
+	Salt   []byte `json:"salt"`
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	Cipher string `json:"cipher"`
+	Check  []byte `json:"check"`
+}
+
+type archiveRecord struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+	// Ciphertext replaces Data in encrypted archives.
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+// newArchiveAEAD derives the archive key from a passphrase.
+func newArchiveAEAD(passphrase []byte, enc *archiveEncryption) (cipher.AEAD, error) {
+	if enc.KDF != archiveKDF || enc.Cipher != archiveCipher {
+		return nil, fmt.Errorf("unsupported archive encryption %s/%s", enc.KDF, enc.Cipher)
+	}
+	key, err := scrypt.Key(passphrase, enc.Salt, enc.N, enc.R, enc.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// recordAdditionalData returns the additional data of the record at position
+// seq, starting at zero, of an encrypted archive. headerSum is the SHA-256
+// hash of the header line.
+func recordAdditionalData(headerSum []byte, seq int, kind string) []byte {
+	ad := make([]byte, 0, len(headerSum)+8+len(kind))
+	ad = append(ad, headerSum...)
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(seq))
+	ad = append(ad, n[:]...)
+	return append(ad, kind...)
+}
+
+func sealArchive(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("read random: %v", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func openArchive(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// archiveWriter writes records to an archive, encrypting them if aead is set.
+// close must be called after the last record.
+type archiveWriter struct {
+	enc       *json.Encoder
+	aead      cipher.AEAD
+	headerSum []byte
+	records   int
+	counts    map[string]int
+}
+
+func newArchiveWriter(w io.Writer, header archiveHeader, passphrase []byte) (*archiveWriter, error) {
+	aw := &archiveWriter{enc: json.NewEncoder(w), counts: make(map[string]int)}
+	if passphrase != nil {
+		salt := make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return nil, fmt.Errorf("read random: %v", err)
+		}
+		header.Encryption = &archiveEncryption{
+			KDF:    archiveKDF,
+			Salt:   salt,
+			N:      scryptN,
+			R:      scryptR,
+			P:      scryptP,
+			Cipher: archiveCipher,
+		}
+		aead, err := newArchiveAEAD(passphrase, header.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		if header.Encryption.Check, err = sealArchive(aead, []byte(archiveCheck), nil); err != nil {
+			return nil, err
+		}
+		aw.aead = aead
+	}
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("marshal archive header: %v", err)
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("write archive header: %v", err)
+	}
+	sum := sha256.Sum256(line)
+	aw.headerSum = sum[:]
+	return aw, nil
+}
+
+func (aw *archiveWriter) write(kind string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %v", kind, err)
+	}
+	record := archiveRecord{Kind: kind, Data: data}
+	if aw.aead != nil {
+		ad := recordAdditionalData(aw.headerSum, aw.records, kind)
+		if record.Ciphertext, err = sealArchive(aw.aead, data, ad); err != nil {
+			return err
+		}
+		record.Data = nil
+	}
+	if err := aw.enc.Encode(record); err != nil {
+		return fmt.Errorf("write %s: %v", kind, err)
+	}
+	aw.records++
+	aw.counts[kind]++
+	return nil
+}
+
+// close writes the terminating record.
+func (aw *archiveWriter) close() error {
+	return aw.write(kindEnd, archiveEnd{Records: aw.records})
+}
+
+// exportStorage writes all persistent objects of s to w. Short lived objects
+// such as auth requests and auth codes are not exported.
+//
+// If redact is set client secrets, password hashes, connector configs and the
+// tokens and connector data of refresh tokens and offline sessions are removed
+// and signing keys are not exported at all.
+func exportStorage(s storage.Storage, w io.Writer, redact bool, passphrase []byte) (map[string]int, error) {
+	header := archiveHeader{
+		Version:  archiveVersion,
+		Created:  time.Now().UTC(),
+		Redacted: redact,
+	}
+	aw, err := newArchiveWriter(w, header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := s.ListClients()
+	if err != nil {
+		return nil, fmt.Errorf("list clients: %v", err)
+	}
+	for _, c := range clients {
+		if redact {
+			c.Secret = ""
+		}
+		if err := aw.write(kindClient, c); err != nil {
+			return nil, err
+		}
+	}
+
+	passwords, err := s.ListPasswords()
+	if err != nil {
+		return nil, fmt.Errorf("list passwords: %v", err)
+	}
+	for _, p := range passwords {
+		if redact {
+			p.Hash = nil
+		}
+		if err := aw.write(kindPassword, p); err != nil {
+			return nil, err
+		}
+	}
+
+	connectors, err := s.ListConnectors()
+	if err != nil {
+		return nil, fmt.Errorf("list connectors: %v", err)
+	}
+	for _, c := range connectors {
+		if redact {
+			// Connector configs usually contain credentials of upstream providers.
+			c.Config = nil
+		}
+		if err := aw.write(kindConnector, c); err != nil {
+			return nil, err
+		}
+	}
+
+	if !redact {
+		keys, err := s.GetKeys()
+		switch {
+		case err == storage.ErrNotFound:
+		case err != nil:
+			return nil, fmt.Errorf("get keys: %v", err)
+		case keys.SigningKey != nil || len(keys.VerificationKeys) != 0:
+			if err := aw.write(kindKeys, keys); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tokens, err := s.ListRefreshTokens()
+	if err != nil {
+		return nil, fmt.Errorf("list refresh tokens: %v", err)
+	}
+	for _, t := range tokens {
+		if redact {
+			t.Token = ""
+			t.ObsoleteToken = ""
+			t.ConnectorData = nil
+		}
+		if err := aw.write(kindRefreshToken, t); err != nil {
+			return nil, err
+		}
+	}
+
+	sessions, err := s.ListOfflineSessions()
+	if err != nil {
+		return nil, fmt.Errorf("list offline sessions: %v", err)
+	}
+	for _, session := range sessions {
+		if redact {
+			session.ConnectorData = nil
+		}
+		if err := aw.write(kindOfflineSession, session); err != nil {
+			return nil, err
+		}
+	}
+	if err := aw.close(); err != nil {
+		return nil, err
+	}
+	return aw.counts, nil
+}
+
+// maxArchiveLine bounds the size of a single archive record.
+const maxArchiveLine = 16 << 20
+
+// errRedacted is returned by decodeRecord for records which are useless
+// without the secrets removed from a redacted archive.
+var errRedacted = errors.New("record is redacted")
+
+// loadArchive reads an archive into an in-memory storage.
+//
+// Redacted archives are only loaded if allowRedacted is set. Confidential
+// clients get a random secret which has to be reset before they can be used,
+// their IDs are returned. Passwords, connectors, refresh tokens and offline
+// sessions are skipped, since they can't be used without their secrets.
+func loadArchive(r io.Reader, logger log.Logger, passphrase []byte, allowRedacted bool) (storage.Storage, []string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxArchiveLine)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("read archive header: %v", err)
+		}
+		return nil, nil, errors.New("empty archive")
+	}
+	var header archiveHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, nil, fmt.Errorf("parse archive header: %v", err)
+	}
+	headerSum := sha256.Sum256(scanner.Bytes())
+	if header.Version < 1 || header.Version > archiveVersion {
+		return nil, nil, fmt.Errorf("unsupported archive version %d, this version of dex supports versions up to %d", header.Version, archiveVersion)
+	}
+	if header.Redacted && !allowRedacted {
+		return nil, nil, errors.New("archive is redacted, its secrets have to be reset after importing it")
+	}
+
+	var aead cipher.AEAD
+	if header.Encryption != nil {
+		if passphrase == nil {
+			return nil, nil, errors.New("archive is encrypted, a passphrase is required")
+		}
+		var err error
+		if aead, err = newArchiveAEAD(passphrase, header.Encryption); err != nil {
+			return nil, nil, err
+		}
+		check, err := openArchive(aead, header.Encryption.Check, nil)
+		if err != nil || string(check) != archiveCheck {
+			return nil, nil, errors.New("wrong passphrase")
+		}
+	}
+
+	s := memory.New(logger)
+	skipped := make(map[string]int)
+	records, ended := 0, false
+	for line := 2; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if ended {
+			return nil, nil, fmt.Errorf("archive line %d: unexpected data after the end of the archive", line)
+		}
+		var record archiveRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, nil, fmt.Errorf("parse archive line %d: %v", line, err)
+		}
+		if aead != nil {
+			data, err := openArchive(aead, record.Ciphertext, recordAdditionalData(headerSum[:], records, record.Kind))
+			if err != nil {
+				return nil, nil, fmt.Errorf("decrypt archive line %d: %v", line, err)
+			}
+			record.Data = data
+		}
+		if record.Kind == kindEnd {
+			var end archiveEnd
+			if err := json.Unmarshal(record.Data, &end); err != nil {
+				return nil, nil, fmt.Errorf("parse archive line %d: %v", line, err)
+			}
+			if end.Records != records {
+				return nil, nil, fmt.Errorf("archive line %d: expected %d records, found %d", line, end.Records, records)
+			}
+			ended = true
+			continue
+		}
+		records++
+		err := decodeRecord(s, header.Version, record, header.Redacted)
+		if err == errRedacted {
+			skipped[record.Kind]++
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("archive line %d: %v", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read archive: %v", err)
+	}
+	if !ended {
+		return nil, nil, errors.New("archive is truncated")
+	}
+	if !header.Redacted {
+		return s, nil, nil
+	}
+	for _, kind := range []string{kindPassword, kindConnector, kindRefreshToken, kindOfflineSession} {
+		if skipped[kind] > 0 {
+			logger.Warnf("archive is redacted, skipped %d %s records", skipped[kind], kind)
+		}
+	}
+	clients, err := s.ListClients()
+	if err != nil {
+		return nil, nil, fmt.Errorf("list clients: %v", err)
+	}
+	var newSecrets []string
+	for _, c := range clients {
+		if !c.Public {
+			newSecrets = append(newSecrets, c.ID)
+		}
+	}
+	return s, newSecrets, nil
+}
+
+// decodeRecord stores a single archive record. Conversions from older archive
+// versions belong here.
+func decodeRecord(s storage.Storage, version int, record archiveRecord, redacted bool) error {
+	switch record.Kind {
+	case kindPassword, kindConnector, kindRefreshToken, kindOfflineSession:
+		if redacted {
+			return errRedacted
+		}
+	}
+
+	switch record.Kind {
+	case kindClient:
+		var c storage.Client
+		if err := json.Unmarshal(record.Data, &c); err != nil {
+			return fmt.Errorf("decode client: %v", err)
+		}
+		if redacted && !c.Public {
+			// An empty secret would let anyone authenticate as the client.
+			c.Secret = storage.NewID()
+		}
+		return s.CreateClient(c)
+	case kindPassword:
+		var p storage.Password
+		if err := json.Unmarshal(record.Data, &p); err != nil {
+			return fmt.Errorf("decode password: %v", err)
+		}
+		return s.CreatePassword(p)
+	case kindConnector:
+		var c storage.Connector
+		if err := json.Unmarshal(record.Data, &c); err != nil {
+			return fmt.Errorf("decode connector: %v", err)
+		}
+		return s.CreateConnector(c)
+	case kindKeys:
+		var keys storage.Keys
+		if err := json.Unmarshal(record.Data, &keys); err != nil {
+			return fmt.Errorf("decode keys: %v", err)
+		}
+		return s.UpdateKeys(func(storage.Keys) (storage.Keys, error) { return keys, nil })
+	case kindRefreshToken:
+		var t storage.RefreshToken
+		if err := json.Unmarshal(record.Data, &t); err != nil {
+			return fmt.Errorf("decode refresh token: %v", err)
+		}
+		return s.CreateRefresh(t)
+	case kindOfflineSession:
+		var session storage.OfflineSessions
+		if err := json.Unmarshal(record.Data, &session); err != nil {
+			return fmt.Errorf("decode offline session: %v", err)
+		}
+		return s.CreateOfflineSessions(session)
+	default:
+		return fmt.Errorf("unknown record kind %q", record.Kind)
+	}
+}
+
+func readPassphrase(file string) ([]byte, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase file: %v", err)
+	}
+	passphrase := bytes.TrimRight(data, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+type storageExportOptions struct {
+	config string
+	output string
+
+	redact         bool
+	passphraseFile string
+}
+
+func commandStorageExport() *cobra.Command {
+	options := storageExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [flags] [config file]",
+		Short: "Write the persistent state of a storage to an archive",
+		Long: `Write clients, passwords, connectors, signing keys, refresh tokens and offline
+sessions of the configured storage to a newline delimited JSON archive.
+
+Archives contain secrets in plain text unless they're encrypted with
+--passphrase-file or redacted with --redact. Redacted archives are only
+imported with --allow-redacted.`,
+		Example: "dex storage export --output dex.ndjson --passphrase-file passphrase.txt config.yaml",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			options.config = args[0]
+
+			return runStorageExport(options, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&options.output, "output", "o", "-", "Archive file, \"-\" writes to stdout")
+	flags.BoolVar(&options.redact, "redact", false, "Remove secrets from the archive")
+	flags.StringVar(&options.passphraseFile, "passphrase-file", "", "File containing the passphrase used to encrypt the archive")
+
+	return cmd
+}
+
+func runStorageExport(options storageExportOptions, stdout, stderr io.Writer) error {
+	if options.redact && options.passphraseFile != "" {
+		return errors.New("--redact and --passphrase-file are exclusive")
+	}
+	passphrase, err := readPassphrase(options.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	c, err := loadStorageConfig(options.config)
+	if err != nil {
+		return err
+	}
+	logger, err := newLogger(c.Logger.Level, c.Logger.Format)
+	if err != nil {
+		return fmt.Errorf("invalid config: %v", err)
+	}
+	s, err := c.Storage.Config.Open(logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	defer s.Close()
+
+	w := stdout
+	var f *os.File
+	if options.output != "-" {
+		if f, err = os.OpenFile(options.output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600); err != nil {
+			return fmt.Errorf("failed to create archive: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	counts, err := exportStorage(s, bw, options.redact, passphrase)
+	if err != nil {
+		return fmt.Errorf("export failed: %v", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write archive: %v", err)
+	}
+	if f != nil {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write archive: %v", err)
+		}
+	}
+
+	for _, kind := range []string{kindClient, kindPassword, kindConnector, kindKeys, kindRefreshToken, kindOfflineSession} {
+		fmt.Fprintf(stderr, "%s: %d exported\n", kind, counts[kind])
+	}
+	return nil
+}
+
+type storageImportOptions struct {
+	config  string
+	archive string
+
+	passphraseFile string
+	conflict       string
+	dryRun         bool
+	allowRedacted  bool
+}
+
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+)
+
+func commandStorageImport() *cobra.Command {
+	options := storageImportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import [flags] [config file] [archive file]",
+		Short: "Load an archive written by export into a storage",
+		Long: `Load an archive written by "dex storage export" into the configured storage.
+
+Objects which already exist in the storage are skipped unless --conflict is
+set to "overwrite". An archive file of "-" reads from stdin.
+
+Redacted archives are refused unless --allow-redacted is set. Confidential
+clients which don't exist yet are then imported with a random secret which has
+to be reset, all other objects need their secrets and are skipped.
+--allow-redacted can't be combined with --conflict overwrite, since that would
+replace the secrets of existing clients.`,
+		Example: "dex storage import --passphrase-file passphrase.txt config.yaml dex.ndjson",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			options.config = args[0]
+			options.archive = args[1]
+
+			return runStorageImport(options, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVar(&options.passphraseFile, "passphrase-file", "", "File containing the passphrase used to decrypt the archive")
+	flags.StringVar(&options.conflict, "conflict", conflictSkip, "What to do with existing objects, \"skip\" or \"overwrite\"")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Report what would be imported without writing to the storage")
+	flags.BoolVar(&options.allowRedacted, "allow-redacted", false, "Import the clients of a redacted archive, their secrets have to be reset")
+
+	return cmd
+}
+
+func runStorageImport(options storageImportOptions, stdin io.Reader, out io.Writer) error {
+	switch options.conflict {
+	case conflictSkip, conflictOverwrite:
+	default:
+		return fmt.Errorf("unknown conflict policy %q", options.conflict)
+	}
+	if options.allowRedacted && options.conflict == conflictOverwrite {
+		return errors.New("--allow-redacted and --conflict overwrite are exclusive, existing clients would lose their secrets")
+	}
+	passphrase, err := readPassphrase(options.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	c, err := loadStorageConfig(options.config)
+	if err != nil {
+		return err
+	}
+	logger, err := newLogger(c.Logger.Level, c.Logger.Format)
+	if err != nil {
+		return fmt.Errorf("invalid config: %v", err)
+	}
+
+	r := stdin
+	if options.archive != "-" {
+		f, err := os.Open(options.archive)
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	archive, newSecrets, err := loadArchive(r, logger, passphrase, options.allowRedacted)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %v", err)
+	}
+	defer archive.Close()
+
+	s, err := c.Storage.Config.Open(logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	defer s.Close()
+
+	// Existing clients are skipped and keep their secret, only the new ones
+	// have to be reset.
+	var resetClients []string
+	for _, id := range newSecrets {
+		switch _, err := s.GetClient(id); err {
+		case nil:
+		case storage.ErrNotFound:
+			resetClients = append(resetClients, id)
+		default:
+			return fmt.Errorf("get client %q: %v", id, err)
+		}
+	}
+
+	m := &migrator{
+		from:         archive,
+		to:           s,
+		dryRun:       options.dryRun,
+		logger:       logger,
+		skipExisting: options.conflict == conflictSkip,
+	}
+	results, err := m.migrate()
+	printMigrateResults(out, results, options.dryRun)
+	if err != nil {
+		return fmt.Errorf("import failed: %v", err)
+	}
+	if len(resetClients) > 0 {
+		fmt.Fprintf(out, "clients with a random secret which has to be reset: %s\n", strings.Join(resetClients, ", "))
+	}
+	if options.dryRun {
+		return nil
+	}
+	if err := m.verify(results); err != nil {
+		return fmt.Errorf("verification failed: %v", err)
+	}
+	fmt.Fprintln(out, "import complete")
+	return nil
+}
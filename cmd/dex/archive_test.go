@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
+)
+
+func newArchiveTestStorage(t *testing.T) storage.Storage {
+	s := memory.New(testLogger(t))
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := storage.Keys{
+		SigningKey:    &jose.JSONWebKey{Key: key, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+		SigningKeyPub: &jose.JSONWebKey{Key: key.Public(), KeyID: "key", Algorithm: "RS256", Use: "sig"},
+		VerificationKeys: []storage.VerificationKey{
+			{PublicKey: &jose.JSONWebKey{Key: key.Public(), KeyID: "old", Algorithm: "RS256", Use: "sig"}, Expiry: time.Now().Add(time.Hour)},
+		},
+		NextRotation: time.Now().Add(time.Hour),
+	}
+	for _, err := range []error{
+		s.CreateClient(storage.Client{ID: "client", Secret: "client-secret", Name: "Client"}),
+		s.CreatePassword(storage.Password{Email: "jane@example.com", Hash: []byte("password-hash"), Username: "jane", UserID: "jane-id"}),
+		s.CreateConnector(storage.Connector{ID: "ldap", Type: "ldap", Name: "LDAP", Config: []byte(`{"bindPW":"connector-secret"}`)}),
+		s.UpdateKeys(func(storage.Keys) (storage.Keys, error) { return keys, nil }),
+		s.CreateRefresh(storage.RefreshToken{
+			ID:          "refresh",
+			Token:       "refresh-secret",
+			ClientID:    "client",
+			ConnectorID: "ldap",
+			Claims:      storage.Claims{UserID: "jane-id"},
+		}),
+		s.CreateOfflineSessions(storage.OfflineSessions{
+			UserID:  "jane-id",
+			ConnID:  "ldap",
+			Refresh: map[string]*storage.RefreshTokenRef{"client": {ID: "refresh", ClientID: "client"}},
+		}),
+	} {
+		if err != nil {
+			t.Fatalf("populate storage: %v", err)
+		}
+	}
+	return s
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := newArchiveTestStorage(t)
+
+	tests := []struct {
+		name       string
+		passphrase []byte
+	}{
+		{name: "plain"},
+		{name: "encrypted", passphrase: []byte("correct horse battery staple")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			counts, err := exportStorage(src, &buf, false, tc.passphrase)
+			if err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			for _, kind := range []string{kindClient, kindPassword, kindConnector, kindKeys, kindRefreshToken, kindOfflineSession} {
+				if counts[kind] != 1 {
+					t.Errorf("expected 1 %s to be exported, got %d", kind, counts[kind])
+				}
+			}
+			if tc.passphrase != nil && strings.Contains(buf.String(), "client-secret") {
+				t.Errorf("encrypted archive contains a plain text secret")
+			}
+
+			archive, _, err := loadArchive(&buf, testLogger(t), tc.passphrase, false)
+			if err != nil {
+				t.Fatalf("load archive: %v", err)
+			}
+			dst := memory.New(testLogger(t))
+			m := &migrator{from: archive, to: dst, logger: testLogger(t)}
+			results, err := m.migrate()
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if err := m.verify(results); err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+
+			if c, err := dst.GetClient("client"); err != nil || c.Secret != "client-secret" {
+				t.Errorf("get client: %+v (%v)", c, err)
+			}
+			if keys, err := dst.GetKeys(); err != nil || keys.SigningKey == nil || !keys.SigningKey.Valid() || len(keys.VerificationKeys) != 1 {
+				t.Errorf("get keys: %+v (%v)", keys, err)
+			}
+			if r, err := dst.GetRefresh("refresh"); err != nil || r.Token != "refresh-secret" {
+				t.Errorf("get refresh token: %+v (%v)", r, err)
+			}
+		})
+	}
+}
+
+func TestArchiveRejected(t *testing.T) {
+	src := newArchiveTestStorage(t)
+
+	var encrypted bytes.Buffer
+	if _, err := exportStorage(src, &encrypted, false, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+	var redacted bytes.Buffer
+	if _, err := exportStorage(src, &redacted, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"client-secret", "password-hash", "connector-secret", "refresh-secret", `"kind":"keys"`} {
+		if strings.Contains(redacted.String(), secret) {
+			t.Errorf("redacted archive contains %q", secret)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		archive    string
+		passphrase []byte
+		wantErr    string
+	}{
+		{name: "wrong passphrase", archive: encrypted.String(), passphrase: []byte("wrong"), wantErr: "wrong passphrase"},
+		{name: "missing passphrase", archive: encrypted.String(), wantErr: "passphrase is required"},
+		{name: "redacted", archive: redacted.String(), wantErr: "redacted"},
+		{name: "newer version", archive: `{"version":2}`, wantErr: "unsupported archive version 2"},
+		{name: "unknown kind", archive: "{\"version\":1}\n{\"kind\":\"user\",\"data\":{}}", wantErr: `unknown record kind "user"`},
+		{name: "missing end", archive: "{\"version\":1}\n", wantErr: "truncated"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := loadArchive(strings.NewReader(tc.archive), testLogger(t), tc.passphrase, false)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestArchiveTampered(t *testing.T) {
+	src := newArchiveTestStorage(t)
+	passphrase := []byte("passphrase")
+
+	var encrypted, plain bytes.Buffer
+	if _, err := exportStorage(src, &encrypted, false, passphrase); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exportStorage(src, &plain, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	// The header, six records and the end record.
+	lines := strings.Split(strings.TrimSuffix(encrypted.String(), "\n"), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("expected 8 archive lines, got %d", len(lines))
+	}
+	plainLines := strings.Split(strings.TrimSuffix(plain.String(), "\n"), "\n")
+	otherHeader := strings.Replace(lines[0], `"version":1`, `"version":1,"redacted":true`, 1)
+
+	join := func(lines ...string) string { return strings.Join(lines, "\n") + "\n" }
+	tests := []struct {
+		name       string
+		archive    string
+		passphrase []byte
+		wantErr    string
+	}{
+		{name: "truncated", archive: join(lines[:7]...), passphrase: passphrase, wantErr: "truncated"},
+		{name: "truncated plain", archive: join(plainLines[:7]...), wantErr: "truncated"},
+		{name: "record dropped", archive: join(append(lines[:2:2], lines[3:]...)...), passphrase: passphrase, wantErr: "decrypt archive line 3"},
+		{name: "record dropped plain", archive: join(append(plainLines[:2:2], plainLines[3:]...)...), wantErr: "expected 6 records, found 5"},
+		{name: "reordered", archive: join(lines[0], lines[2], lines[1], lines[3], lines[4], lines[5], lines[6], lines[7]), passphrase: passphrase, wantErr: "decrypt archive line 2"},
+		{name: "header modified", archive: join(append([]string{otherHeader}, lines[1:]...)...), passphrase: passphrase, wantErr: "decrypt archive line 2"},
+		{name: "data after end", archive: join(append(lines, lines[1])...), passphrase: passphrase, wantErr: "after the end"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := loadArchive(strings.NewReader(tc.archive), testLogger(t), tc.passphrase, true)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestArchiveRedactedImport(t *testing.T) {
+	src := newArchiveTestStorage(t)
+	if err := src.CreateClient(storage.Client{ID: "public", Public: true, Name: "Public"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := exportStorage(src, &buf, true, nil); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	archive, newSecrets, err := loadArchive(&buf, testLogger(t), nil, true)
+	if err != nil {
+		t.Fatalf("load archive: %v", err)
+	}
+	if len(newSecrets) != 1 || newSecrets[0] != "client" {
+		t.Errorf("expected only the confidential client to need a new secret, got %v", newSecrets)
+	}
+	dst := memory.New(testLogger(t))
+	m := &migrator{from: archive, to: dst, logger: testLogger(t)}
+	results, err := m.migrate()
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if err := m.verify(results); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	// Confidential clients must not be usable with an empty secret.
+	if c, err := dst.GetClient("client"); err != nil || c.Secret == "" || c.Secret == "client-secret" {
+		t.Errorf("expected client with a new secret, got %+v (%v)", c, err)
+	}
+	if c, err := dst.GetClient("public"); err != nil || c.Secret != "" {
+		t.Errorf("expected public client without a secret, got %+v (%v)", c, err)
+	}
+
+	if _, err := dst.GetPassword("jane@example.com"); err != storage.ErrNotFound {
+		t.Errorf("expected redacted password to be skipped, got %v", err)
+	}
+	if _, err := dst.GetConnector("ldap"); err != storage.ErrNotFound {
+		t.Errorf("expected redacted connector to be skipped, got %v", err)
+	}
+	if _, err := dst.GetRefresh("refresh"); err != storage.ErrNotFound {
+		t.Errorf("expected redacted refresh token to be skipped, got %v", err)
+	}
+	if _, err := dst.GetOfflineSessions("jane-id", "ldap"); err != storage.ErrNotFound {
+		t.Errorf("expected redacted offline session to be skipped, got %v", err)
+	}
+}
+
+func TestStorageImportRedacted(t *testing.T) {
+	dir := t.TempDir()
+	config, dbFile := writeSQLiteConfig(t, dir, "dex")
+
+	src := newArchiveTestStorage(t)
+	if err := src.CreateClient(storage.Client{ID: "new", Secret: "new-secret", Name: "New"}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := exportStorage(src, &buf, true, nil); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	archive := filepath.Join(dir, "dex.ndjson")
+	if err := os.WriteFile(archive, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := openSQLite(t, dbFile)
+	if err := dst.CreateClient(storage.Client{ID: "client", Secret: "existing"}); err != nil {
+		t.Fatal(err)
+	}
+	dst.Close()
+
+	options := storageImportOptions{config: config, archive: archive, conflict: conflictOverwrite, allowRedacted: true}
+	if err := runStorageImport(options, nil, io.Discard); err == nil || !strings.Contains(err.Error(), "exclusive") {
+		t.Errorf("expected redacted imports with --conflict overwrite to be refused, got %v", err)
+	}
+
+	var out bytes.Buffer
+	options.conflict = conflictSkip
+	if err := runStorageImport(options, nil, &out); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !strings.Contains(out.String(), "clients with a random secret which has to be reset: new\n") {
+		t.Errorf("expected only the new client to need a new secret, got:\n%s", out.String())
+	}
+
+	dst = openSQLite(t, dbFile)
+	defer dst.Close()
+	if c, err := dst.GetClient("client"); err != nil || c.Secret != "existing" {
+		t.Errorf("expected existing client to keep its secret, got %+v (%v)", c, err)
+	}
+	if c, err := dst.GetClient("new"); err != nil || c.Secret == "" || c.Secret == "new-secret" {
+		t.Errorf("expected new client with a random secret, got %+v (%v)", c, err)
+	}
+}
+
+func TestArchiveConflictPolicy(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := exportStorage(newArchiveTestStorage(t), &buf, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.String()
+
+	for _, skip := range []bool{true, false} {
+		dst := memory.New(testLogger(t))
+		if err := dst.CreateClient(storage.Client{ID: "client", Secret: "existing"}); err != nil {
+			t.Fatal(err)
+		}
+		from, _, err := loadArchive(strings.NewReader(archive), testLogger(t), nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := &migrator{from: from, to: dst, logger: testLogger(t), skipExisting: skip}
+		results, err := m.migrate()
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+		if err := m.verify(results); err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+
+		want := "client-secret"
+		if skip {
+			want = "existing"
+			if results[0].skipped != 1 {
+				t.Errorf("expected the client to be skipped, got %+v", results[0])
+			}
+		}
+		if c, err := dst.GetClient("client"); err != nil || c.Secret != want {
+			t.Errorf("skip %t: expected client secret %q, got %q (%v)", skip, want, c.Secret, err)
+		}
+	}
+}
+
+func testLogger(t *testing.T) log.Logger {
+	l, err := newLogger("error", "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
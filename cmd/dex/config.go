@@ -17,6 +17,7 @@ import (
 	"github.com/dexidp/dex/storage/etcd"
 	"github.com/dexidp/dex/storage/kubernetes"
 	"github.com/dexidp/dex/storage/memory"
+	"github.com/dexidp/dex/storage/redis"
 	"github.com/dexidp/dex/storage/sql"
 )
 
@@ -30,6 +31,11 @@ type Config struct {
 	GRPC      GRPC      `json:"grpc"`
 	Expiry    Expiry    `json:"expiry"`
 	Logger    Logger    `json:"logger"`
+	CIBA      CIBA      `json:"ciba"`
+
+	// LeaderElection restricts key rotation and garbage collection to one of
+	// the instances sharing the storage, if the storage supports leases.
+	LeaderElection LeaderElection `json:"leaderElection"`
 
 	Frontend server.WebConfig `json:"frontend"`
 
@@ -68,6 +74,7 @@ func (c Config) Validate() error {
 		{c.GRPC.TLSKey != "" && c.GRPC.Addr == "", "no address specified for gRPC"},
 		{(c.GRPC.TLSCert == "") != (c.GRPC.TLSKey == ""), "must specific both a gRPC TLS cert and key"},
 		{c.GRPC.TLSCert == "" && c.GRPC.TLSClientCA != "", "cannot specify gRPC TLS client CA without a gRPC TLS cert"},
+		{c.CIBA.Webhook != nil && c.CIBA.Webhook.URL == "", "no url specified for the CIBA webhook"},
 	}
 
 	var checkErrors []string
@@ -151,6 +158,23 @@ type Web struct {
 // Telemetry is the config format for telemetry including the HTTP server config.
 type Telemetry struct {
 	HTTP string `json:"http"`
+
+	// InstrumentStorage records the latency and errors of storage calls as
+	// metrics and traces, and the results of garbage collection as metrics.
+	InstrumentStorage bool `json:"instrumentStorage"`
+
+	// Tracing exports traces to an OpenTelemetry collector.
+	Tracing *Tracing `json:"tracing"`
+}
+
+// Tracing is the config of the OpenTelemetry trace exporter.
+type Tracing struct {
+	// Endpoint is the host and port of a collector accepting OTLP over HTTP,
+	// e.g. "localhost:4318".
+	Endpoint string `json:"endpoint"`
+
+	// Insecure uses HTTP instead of HTTPS to reach the collector.
+	Insecure bool `json:"insecure"`
 }
 
 // GRPC is the config for the gRPC API.
@@ -167,6 +191,21 @@ type GRPC struct {
 type Storage struct {
 	Type   string        `json:"type"`
 	Config StorageConfig `json:"config"`
+
+	// Cache keeps clients, connectors and passwords in memory.
+	Cache *StorageCache `json:"cache"`
+}
+
+// StorageCache holds how long clients, connectors and passwords are cached.
+// Kinds without a duration aren't cached.
+type StorageCache struct {
+	Clients    string `json:"clients"`
+	Connectors string `json:"connectors"`
+	Passwords  string `json:"passwords"`
+
+	// Watch removes objects changed by other instances from the cache, if the
+	// storage can report changes. Supported by etcd, Postgres and Kubernetes.
+	Watch bool `json:"watch"`
 }
 
 // StorageConfig is a configuration that can create a storage.
@@ -178,21 +217,26 @@ var (
 	_ StorageConfig = (*etcd.Etcd)(nil)
 	_ StorageConfig = (*kubernetes.Config)(nil)
 	_ StorageConfig = (*memory.Config)(nil)
+	_ StorageConfig = (*redis.Redis)(nil)
 	_ StorageConfig = (*sql.SQLite3)(nil)
 	_ StorageConfig = (*sql.Postgres)(nil)
 	_ StorageConfig = (*sql.MySQL)(nil)
+	_ StorageConfig = (*sql.CockroachDB)(nil)
+	_ StorageConfig = (*sql.MariaDB)(nil)
 	_ StorageConfig = (*ent.SQLite3)(nil)
 	_ StorageConfig = (*ent.Postgres)(nil)
 	_ StorageConfig = (*ent.MySQL)(nil)
+	_ StorageConfig = (*ent.CockroachDB)(nil)
+	_ StorageConfig = (*ent.MariaDB)(nil)
 )
 
-func getORMBasedSQLStorage(normal, entBased StorageConfig) func() StorageConfig {
+func getORMBasedSQLStorage(normal, entBased func() StorageConfig) func() StorageConfig {
 	return func() StorageConfig {
 		switch os.Getenv("DEX_ENT_ENABLED") {
 		case "true", "yes":
-			return entBased
+			return entBased()
 		default:
-			return normal
+			return normal()
 		}
 	}
 }
@@ -201,9 +245,27 @@ var storages = map[string]func() StorageConfig{
 	"etcd":       func() StorageConfig { return new(etcd.Etcd) },
 	"kubernetes": func() StorageConfig { return new(kubernetes.Config) },
 	"memory":     func() StorageConfig { return new(memory.Config) },
-	"sqlite3":    getORMBasedSQLStorage(&sql.SQLite3{}, &ent.SQLite3{}),
-	"postgres":   getORMBasedSQLStorage(&sql.Postgres{}, &ent.Postgres{}),
-	"mysql":      getORMBasedSQLStorage(&sql.MySQL{}, &ent.MySQL{}),
+	"redis":      func() StorageConfig { return new(redis.Redis) },
+	"sqlite3": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.SQLite3) },
+		func() StorageConfig { return new(ent.SQLite3) },
+	),
+	"postgres": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.Postgres) },
+		func() StorageConfig { return new(ent.Postgres) },
+	),
+	"mysql": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.MySQL) },
+		func() StorageConfig { return new(ent.MySQL) },
+	),
+	"cockroachdb": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.CockroachDB) },
+		func() StorageConfig { return new(ent.CockroachDB) },
+	),
+	"mariadb": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.MariaDB) },
+		func() StorageConfig { return new(ent.MariaDB) },
+	),
 }
 
 // isExpandEnvEnabled returns if os.ExpandEnv should be used for each storage and connector config.
@@ -225,6 +287,7 @@ func (s *Storage) UnmarshalJSON(b []byte) error {
 	var store struct {
 		Type   string          `json:"type"`
 		Config json.RawMessage `json:"config"`
+		Cache  *StorageCache   `json:"cache"`
 	}
 	if err := json.Unmarshal(b, &store); err != nil {
 		return fmt.Errorf("parse storage: %v", err)
@@ -248,6 +311,7 @@ func (s *Storage) UnmarshalJSON(b []byte) error {
 	*s = Storage{
 		Type:   store.Type,
 		Config: storageConfig,
+		Cache:  store.Cache,
 	}
 	return nil
 }
@@ -329,10 +393,43 @@ type Expiry struct {
 	// DeviceRequests defines the duration of time for which the DeviceRequests will be valid.
 	DeviceRequests string `json:"deviceRequests"`
 
+	// CIBARequests defines the duration of time for which backchannel authentication requests will be valid.
+	CIBARequests string `json:"cibaRequests"`
+
 	// RefreshTokens defines refresh tokens expiry policy
 	RefreshTokens RefreshToken `json:"refreshTokens"`
 }
 
+// CIBA is the config for Client-Initiated Backchannel Authentication.
+// Backchannel authentication is disabled unless a notifier is configured.
+type CIBA struct {
+	// Webhook notifies users of backchannel authentication requests by posting
+	// them to an external service.
+	Webhook *CIBAWebhook `json:"webhook"`
+}
+
+// CIBAWebhook is the config of the webhook used to notify users.
+type CIBAWebhook struct {
+	URL string `json:"url"`
+	// Headers are added to every notification, e.g. for authenticating dex.
+	Headers map[string]string `json:"headers"`
+}
+
+// LeaderElection holds the configuration of the election of the instance which
+// rotates keys and collects garbage.
+type LeaderElection struct {
+	// Disabled lets every instance rotate keys and collect garbage.
+	Disabled bool `json:"disabled"`
+
+	// Identity identifies this instance in the lease. Defaults to the hostname
+	// followed by a random suffix.
+	Identity string `json:"identity"`
+
+	// LeaseDuration is how long the leader is trusted to hold the lease
+	// without renewing it. Defaults to 15s.
+	LeaseDuration string `json:"leaseDuration"`
+}
+
 // Logger holds configuration required to customize logging for dex.
 type Logger struct {
 	// Level sets logging level severity.
@@ -347,4 +444,5 @@ type RefreshToken struct {
 	ReuseInterval     string `json:"reuseInterval"`
 	AbsoluteLifetime  string `json:"absoluteLifetime"`
 	ValidIfNotUsedFor string `json:"validIfNotUsedFor"`
+	RevokeOnReuse     bool   `json:"revokeOnReuse"`
 }
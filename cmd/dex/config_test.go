@@ -70,6 +70,9 @@ storage:
     maxIdleConns: 3
     connMaxLifetime: 30
     connectionTimeout: 3
+  cache:
+    clients: 1m
+    watch: true
 web:
   http: 127.0.0.1:5556
 
@@ -119,6 +122,13 @@ expiry:
   idTokens: "25h"
   authRequests: "25h"
   deviceRequests: "10m"
+  cibaRequests: "3m"
+
+ciba:
+  webhook:
+    url: https://notify.example.com/dex
+    headers:
+      Authorization: Bearer foo
 
 logger:
   level: "debug"
@@ -139,6 +149,10 @@ logger:
 					ConnectionTimeout: 3,
 				},
 			},
+			Cache: &StorageCache{
+				Clients: "1m",
+				Watch:   true,
+			},
 		},
 		Web: Web{
 			HTTP: "127.0.0.1:5556",
@@ -201,6 +215,13 @@ logger:
 			IDTokens:       "25h",
 			AuthRequests:   "25h",
 			DeviceRequests: "10m",
+			CIBARequests:   "3m",
+		},
+		CIBA: CIBA{
+			Webhook: &CIBAWebhook{
+				URL:     "https://notify.example.com/dex",
+				Headers: map[string]string{"Authorization": "Bearer foo"},
+			},
 		},
 		Logger: Logger{
 			Level:  "debug",
@@ -25,6 +25,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
@@ -186,6 +191,79 @@ func runServe(options serveOptions) error {
 
 	logger.Infof("config storage: %s", c.Storage.Type)
 
+	// Storages such as the Kubernetes read cache export their own metrics.
+	if collector, ok := s.(prometheus.Collector); ok {
+		if err := prometheusRegistry.Register(collector); err != nil {
+			return fmt.Errorf("failed to register storage metrics: %v", err)
+		}
+	}
+
+	// Leases and changes are taken from the storage before it's wrapped.
+	leases, supportsLeases := s.(storage.LeaseStorage)
+	watcher, supportsWatch := s.(storage.ChangeWatcher)
+
+	if c.Telemetry.Tracing != nil {
+		if c.Telemetry.Tracing.Endpoint == "" {
+			return fmt.Errorf("invalid config: no endpoint specified for tracing")
+		}
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Telemetry.Tracing.Endpoint)}
+		if c.Telemetry.Tracing.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return fmt.Errorf("failed to initialize trace exporter: %v", err)
+		}
+		tracerProvider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String("dex"))),
+		)
+		defer tracerProvider.Shutdown(context.Background())
+		otel.SetTracerProvider(tracerProvider)
+
+		logger.Infof("config tracing: exporting to %s", c.Telemetry.Tracing.Endpoint)
+	}
+
+	if c.Telemetry.InstrumentStorage {
+		s, err = storage.WithInstrumentation(s, c.Storage.Type, prometheusRegistry, otel.GetTracerProvider())
+		if err != nil {
+			return fmt.Errorf("failed to instrument storage: %v", err)
+		}
+		logger.Infof("config storage instrumentation enabled")
+	}
+
+	if c.Storage.Cache != nil {
+		var cacheConfig storage.CacheConfig
+		for _, ttl := range []struct {
+			kind   string
+			value  string
+			target *time.Duration
+		}{
+			{"clients", c.Storage.Cache.Clients, &cacheConfig.Clients},
+			{"connectors", c.Storage.Cache.Connectors, &cacheConfig.Connectors},
+			{"passwords", c.Storage.Cache.Passwords, &cacheConfig.Passwords},
+		} {
+			if ttl.value == "" {
+				continue
+			}
+			if *ttl.target, err = time.ParseDuration(ttl.value); err != nil {
+				return fmt.Errorf("invalid config value %q for cached %s: %v", ttl.value, ttl.kind, err)
+			}
+			logger.Infof("config storage cache: %s cached for %v", ttl.kind, *ttl.target)
+		}
+
+		var cacheWatcher storage.ChangeWatcher
+		if c.Storage.Cache.Watch {
+			if !supportsWatch {
+				return fmt.Errorf("invalid config: %s storage can't watch for changes", c.Storage.Type)
+			}
+			cacheWatcher = watcher
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s = storage.WithCache(ctx, s, cacheConfig, cacheWatcher, logger)
+	}
+
 	if len(c.StaticClients) > 0 {
 		for i, client := range c.StaticClients {
 			if client.Name == "" {
@@ -209,6 +287,12 @@ func runServe(options serveOptions) error {
 				}
 				c.StaticClients[i].Secret = os.Getenv(client.SecretEnv)
 			}
+			if err := client.TokenPolicy.Validate(); err != nil {
+				return fmt.Errorf("invalid config: invalid token policy for client %q: %v", client.Name, err)
+			}
+			if err := server.ValidateClientRestrictions(client); err != nil {
+				return fmt.Errorf("invalid config: invalid restrictions for client %q: %v", client.Name, err)
+			}
 			logger.Infof("config static client: %s", client.Name)
 		}
 		s = storage.WithStaticClients(s, c.StaticClients)
@@ -314,12 +398,51 @@ func runServe(options serveOptions) error {
 		logger.Infof("config device requests valid for: %v", deviceRequests)
 		serverConfig.DeviceRequestsValidFor = deviceRequests
 	}
+	if c.Expiry.CIBARequests != "" {
+		cibaRequests, err := time.ParseDuration(c.Expiry.CIBARequests)
+		if err != nil {
+			return fmt.Errorf("invalid config value %q for ciba request expiry: %v", c.Expiry.CIBARequests, err)
+		}
+		logger.Infof("config ciba requests valid for: %v", cibaRequests)
+		serverConfig.CIBARequestsValidFor = cibaRequests
+	}
+	if c.CIBA.Webhook != nil {
+		logger.Infof("config ciba notifications sent to: %s", c.CIBA.Webhook.URL)
+		serverConfig.CIBANotifier = server.NewWebhookNotifier(c.CIBA.Webhook.URL, c.CIBA.Webhook.Headers, nil)
+	}
+	if !c.LeaderElection.Disabled {
+		if !supportsLeases {
+			logger.Infof("config leader election: not supported by %s storage, every instance rotates keys and collects garbage", c.Storage.Type)
+		} else {
+			leaderElection := &server.LeaderElectionConfig{
+				Leases:   leases,
+				Identity: c.LeaderElection.Identity,
+			}
+			if leaderElection.Identity == "" {
+				hostname, err := os.Hostname()
+				if err != nil {
+					return fmt.Errorf("failed to get hostname for leader election identity: %v", err)
+				}
+				leaderElection.Identity = hostname + "_" + storage.NewID()[:8]
+			}
+			if c.LeaderElection.LeaseDuration != "" {
+				leaseDuration, err := time.ParseDuration(c.LeaderElection.LeaseDuration)
+				if err != nil {
+					return fmt.Errorf("invalid config value %q for leader election lease duration: %v", c.LeaderElection.LeaseDuration, err)
+				}
+				leaderElection.LeaseDuration = leaseDuration
+			}
+			logger.Infof("config leader election identity: %s", leaderElection.Identity)
+			serverConfig.LeaderElection = leaderElection
+		}
+	}
 	refreshTokenPolicy, err := server.NewRefreshTokenPolicy(
 		logger,
 		c.Expiry.RefreshTokens.DisableRotation,
 		c.Expiry.RefreshTokens.ValidIfNotUsedFor,
 		c.Expiry.RefreshTokens.AbsoluteLifetime,
 		c.Expiry.RefreshTokens.ReuseInterval,
+		c.Expiry.RefreshTokens.RevokeOnReuse,
 	)
 	if err != nil {
 		return fmt.Errorf("invalid refresh token expiration policy config: %v", err)
@@ -355,6 +478,34 @@ func runServe(options serveOptions) error {
 		gosundheit.InitiallyPassing(true),
 	)
 
+	healthChecker.RegisterCheck(
+		&checks.CustomCheck{
+			CheckName: "connectors",
+			CheckFunc: serv.CheckConnectorsHealth,
+		},
+		gosundheit.ExecutionPeriod(30*time.Second),
+		gosundheit.ExecutionTimeout(15*time.Second),
+		gosundheit.InitiallyPassing(true),
+	)
+
+	if serverConfig.LeaderElection != nil {
+		healthChecker.RegisterCheck(
+			&checks.CustomCheck{
+				CheckName: "leader",
+				CheckFunc: func(context.Context) (details interface{}, err error) {
+					leader, isLeader := serv.Leader()
+					return map[string]interface{}{
+						"identity": serverConfig.LeaderElection.Identity,
+						"leader":   leader,
+						"isLeader": isLeader,
+					}, nil
+				},
+			},
+			gosundheit.ExecutionPeriod(5*time.Second),
+			gosundheit.InitiallyPassing(true),
+		)
+	}
+
 	var group run.Group
 
 	// Set up telemetry server
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+)
+
+func commandStorage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Manage the contents of a storage",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+			os.Exit(2)
+		},
+	}
+	cmd.AddCommand(commandStorageMigrate())
+	cmd.AddCommand(commandStorageExport())
+	cmd.AddCommand(commandStorageImport())
+	return cmd
+}
+
+type storageMigrateOptions struct {
+	// Config file paths
+	from string
+	to   string
+
+	dryRun bool
+}
+
+func commandStorageMigrate() *cobra.Command {
+	options := storageMigrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate --from [config file] --to [config file]",
+		Short: "Copy the persistent state of one storage into another",
+		Long: `Copy clients, passwords, connectors, signing keys, refresh tokens and offline
+sessions from the storage configured in --from to the storage configured in
+--to. Only the "storage" and "logger" sections of the config files are used.
+
+Objects which already exist in the destination are overwritten, so the
+migration can be re-run after a partial failure. Short lived objects such as
+auth requests and auth codes are not copied.`,
+		Example: "dex storage migrate --from config-etcd.yaml --to config-postgres.yaml",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			return runStorageMigrate(options, cmd.OutOrStdout())
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVar(&options.from, "from", "", "Config file of the source storage")
+	flags.StringVar(&options.to, "to", "", "Config file of the destination storage")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Report what would be copied without writing to the destination")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// storageConfig is the subset of the config file needed to open a storage.
+type storageConfig struct {
+	Storage Storage `json:"storage"`
+	Logger  Logger  `json:"logger"`
+}
+
+func loadStorageConfig(configFile string) (storageConfig, error) {
+	var c storageConfig
+	configData, err := os.ReadFile(configFile)
+	if err != nil {
+		return c, fmt.Errorf("failed to read config file %s: %v", configFile, err)
+	}
+	if err := yaml.Unmarshal(configData, &c); err != nil {
+		return c, fmt.Errorf("error parse config file %s: %v", configFile, err)
+	}
+	if c.Storage.Config == nil {
+		return c, fmt.Errorf("invalid config file %s: no storage supplied", configFile)
+	}
+	return c, nil
+}
+
+func runStorageMigrate(options storageMigrateOptions, out io.Writer) error {
+	from, err := loadStorageConfig(options.from)
+	if err != nil {
+		return err
+	}
+	to, err := loadStorageConfig(options.to)
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(from.Logger.Level, from.Logger.Format)
+	if err != nil {
+		return fmt.Errorf("invalid config: %v", err)
+	}
+
+	src, err := from.Storage.Config.Open(logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize source storage: %v", err)
+	}
+	defer src.Close()
+
+	dst, err := to.Storage.Config.Open(logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize destination storage: %v", err)
+	}
+	defer dst.Close()
+
+	logger.Infof("migrating storage %s to %s", from.Storage.Type, to.Storage.Type)
+
+	m := &migrator{from: src, to: dst, dryRun: options.dryRun, logger: logger}
+	results, err := m.migrate()
+	printMigrateResults(out, results, options.dryRun)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+	if options.dryRun {
+		return nil
+	}
+
+	if err := m.verify(results); err != nil {
+		return fmt.Errorf("verification failed: %v", err)
+	}
+	fmt.Fprintln(out, "migration complete")
+	return nil
+}
+
+// migrateResult counts the objects of one kind handled by a migration.
+type migrateResult struct {
+	kind string
+	// gets reads each migrated object back from the destination.
+	gets []func() error
+
+	created int
+	updated int
+	skipped int
+}
+
+func (r *migrateResult) total() int { return r.created + r.updated + r.skipped }
+
+func printMigrateResults(out io.Writer, results []migrateResult, dryRun bool) {
+	for _, r := range results {
+		if dryRun {
+			fmt.Fprintf(out, "%s: %d to create, %d to overwrite, %d to skip\n", r.kind, r.created, r.updated, r.skipped)
+		} else {
+			fmt.Fprintf(out, "%s: %d created, %d overwritten, %d skipped\n", r.kind, r.created, r.updated, r.skipped)
+		}
+	}
+}
+
+// migrator copies the persistent objects of one storage into another.
+type migrator struct {
+	from   storage.Storage
+	to     storage.Storage
+	dryRun bool
+	logger log.Logger
+
+	// skipExisting leaves objects which already exist in the destination
+	// untouched instead of overwriting them.
+	skipExisting bool
+}
+
+// put stores a single object in the destination. If the object already exists
+// it's overwritten or skipped, so a migration can be resumed after a partial
+// failure. In dry-run mode the destination is only queried.
+func (m *migrator) put(r *migrateResult, id string, get, create, update func() error) error {
+	if m.dryRun {
+		switch err := get(); err {
+		case nil:
+			if m.skipExisting {
+				r.skipped++
+			} else {
+				r.updated++
+			}
+		case storage.ErrNotFound:
+			r.created++
+		default:
+			return fmt.Errorf("get %s %q: %v", r.kind, id, err)
+		}
+		return nil
+	}
+
+	err := create()
+	switch err {
+	case nil:
+		r.created++
+	case storage.ErrAlreadyExists:
+		if m.skipExisting {
+			r.skipped++
+			break
+		}
+		if err := update(); err != nil {
+			return fmt.Errorf("update %s %q: %v", r.kind, id, err)
+		}
+		r.updated++
+	default:
+		return fmt.Errorf("create %s %q: %v", r.kind, id, err)
+	}
+	r.gets = append(r.gets, get)
+	return nil
+}
+
+// migrate copies all objects and returns the results collected so far, even
+// if a copy fails.
+func (m *migrator) migrate() ([]migrateResult, error) {
+	var results []migrateResult
+	steps := []func() (migrateResult, error){
+		m.migrateClients,
+		m.migratePasswords,
+		m.migrateConnectors,
+		m.migrateKeys,
+		m.migrateRefreshTokens,
+		m.migrateOfflineSessions,
+	}
+	for _, step := range steps {
+		r, err := step()
+		results = append(results, r)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func (m *migrator) migrateClients() (migrateResult, error) {
+	r := migrateResult{kind: "clients"}
+	clients, err := m.from.ListClients()
+	if err != nil {
+		return r, fmt.Errorf("list clients: %v", err)
+	}
+	for _, c := range clients {
+		c := c
+		err := m.put(&r, c.ID,
+			func() error { _, err := m.to.GetClient(c.ID); return err },
+			func() error { return m.to.CreateClient(c) },
+			func() error {
+				return m.to.UpdateClient(c.ID, func(storage.Client) (storage.Client, error) { return c, nil })
+			},
+		)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (m *migrator) migratePasswords() (migrateResult, error) {
+	r := migrateResult{kind: "passwords"}
+	passwords, err := m.from.ListPasswords()
+	if err != nil {
+		return r, fmt.Errorf("list passwords: %v", err)
+	}
+	for _, p := range passwords {
+		p := p
+		err := m.put(&r, p.Email,
+			func() error { _, err := m.to.GetPassword(p.Email); return err },
+			func() error { return m.to.CreatePassword(p) },
+			func() error {
+				return m.to.UpdatePassword(p.Email, func(storage.Password) (storage.Password, error) { return p, nil })
+			},
+		)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (m *migrator) migrateConnectors() (migrateResult, error) {
+	r := migrateResult{kind: "connectors"}
+	connectors, err := m.from.ListConnectors()
+	if err != nil {
+		return r, fmt.Errorf("list connectors: %v", err)
+	}
+	for _, c := range connectors {
+		c := c
+		err := m.put(&r, c.ID,
+			func() error { _, err := m.to.GetConnector(c.ID); return err },
+			func() error { return m.to.CreateConnector(c) },
+			func() error {
+				return m.to.UpdateConnector(c.ID, func(storage.Connector) (storage.Connector, error) { return c, nil })
+			},
+		)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (m *migrator) migrateKeys() (migrateResult, error) {
+	r := migrateResult{kind: "keys"}
+	keys, err := m.from.GetKeys()
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return r, nil
+		}
+		return r, fmt.Errorf("get keys: %v", err)
+	}
+	if keys.SigningKey == nil && len(keys.VerificationKeys) == 0 {
+		// Nothing has been rotated yet, the destination will create its own keys.
+		return r, nil
+	}
+
+	// There's no create method for keys, UpdateKeys creates them if needed.
+	exists := func() error {
+		k, err := m.to.GetKeys()
+		if err == nil && k.SigningKey == nil && len(k.VerificationKeys) == 0 {
+			return storage.ErrNotFound
+		}
+		return err
+	}
+	update := func() error {
+		return m.to.UpdateKeys(func(storage.Keys) (storage.Keys, error) { return keys, nil })
+	}
+	create := func() error {
+		switch err := exists(); err {
+		case nil:
+			return storage.ErrAlreadyExists
+		case storage.ErrNotFound:
+			return update()
+		default:
+			return err
+		}
+	}
+	err = m.put(&r, "keys", exists, create, update)
+	return r, err
+}
+
+func (m *migrator) migrateRefreshTokens() (migrateResult, error) {
+	r := migrateResult{kind: "refresh tokens"}
+	tokens, err := m.from.ListRefreshTokens()
+	if err != nil {
+		return r, fmt.Errorf("list refresh tokens: %v", err)
+	}
+	for _, t := range tokens {
+		t := t
+		err := m.put(&r, t.ID,
+			func() error { _, err := m.to.GetRefresh(t.ID); return err },
+			func() error { return m.to.CreateRefresh(t) },
+			func() error {
+				return m.to.UpdateRefreshToken(t.ID, func(storage.RefreshToken) (storage.RefreshToken, error) { return t, nil })
+			},
+		)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (m *migrator) migrateOfflineSessions() (migrateResult, error) {
+	r := migrateResult{kind: "offline sessions"}
+	sessions, err := m.from.ListOfflineSessions()
+	if err != nil {
+		return r, fmt.Errorf("list offline sessions: %v", err)
+	}
+	for _, s := range sessions {
+		s := s
+		err = m.put(&r, s.UserID+"/"+s.ConnID,
+			func() error { _, err := m.to.GetOfflineSessions(s.UserID, s.ConnID); return err },
+			func() error { return m.to.CreateOfflineSessions(s) },
+			func() error {
+				return m.to.UpdateOfflineSessions(s.UserID, s.ConnID, func(storage.OfflineSessions) (storage.OfflineSessions, error) {
+					return s, nil
+				})
+			},
+		)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// verify checks that every object reported by migrate can be read from the
+// destination.
+func (m *migrator) verify(results []migrateResult) error {
+	for _, r := range results {
+		found := 0
+		for _, get := range r.gets {
+			switch err := get(); err {
+			case nil:
+				found++
+			case storage.ErrNotFound:
+			default:
+				return fmt.Errorf("verify %s: %v", r.kind, err)
+			}
+		}
+		if found != r.total() {
+			return fmt.Errorf("expected %d %s in destination, found %d", r.total(), r.kind, found)
+		}
+	}
+	return nil
+}
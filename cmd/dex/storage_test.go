@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/sql"
+)
+
+func writeSQLiteConfig(t *testing.T, dir, name string) (configFile, dbFile string) {
+	dbFile = filepath.Join(dir, name+".db")
+	configFile = filepath.Join(dir, name+".yaml")
+	config := fmt.Sprintf("storage:\n  type: sqlite3\n  config:\n    file: %s\nlogger:\n  level: error\n", dbFile)
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configFile, dbFile
+}
+
+func openSQLite(t *testing.T, file string) storage.Storage {
+	s, err := (&sql.SQLite3{File: file}).Open(testLogger(t))
+	if err != nil {
+		t.Fatalf("open %s: %v", file, err)
+	}
+	return s
+}
+
+func TestStorageMigrate(t *testing.T) {
+	dir := t.TempDir()
+	fromConfig, fromDB := writeSQLiteConfig(t, dir, "from")
+	toConfig, toDB := writeSQLiteConfig(t, dir, "to")
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := storage.Keys{
+		SigningKey:    &jose.JSONWebKey{Key: key, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+		SigningKeyPub: &jose.JSONWebKey{Key: key.Public(), KeyID: "key", Algorithm: "RS256", Use: "sig"},
+		NextRotation:  time.Now().Add(time.Hour).UTC().Round(time.Millisecond),
+	}
+	client := storage.Client{ID: "client", Secret: "secret", RedirectURIs: []string{"https://example.com/callback"}, Name: "Client"}
+	password := storage.Password{Email: "jane@example.com", Hash: []byte("hash"), Username: "jane", UserID: "jane-id"}
+	connector := storage.Connector{ID: "ldap", Type: "ldap", Name: "LDAP", ResourceVersion: "1", Config: []byte(`{}`)}
+	refresh := storage.RefreshToken{
+		ID:          "refresh",
+		Token:       "token",
+		ClientID:    client.ID,
+		ConnectorID: connector.ID,
+		Claims:      storage.Claims{UserID: "jane-id", Username: "jane", Email: password.Email},
+		CreatedAt:   time.Now().UTC().Round(time.Millisecond),
+		LastUsed:    time.Now().UTC().Round(time.Millisecond),
+	}
+	session := storage.OfflineSessions{
+		UserID:  "jane-id",
+		ConnID:  connector.ID,
+		Refresh: map[string]*storage.RefreshTokenRef{client.ID: {ID: refresh.ID, ClientID: client.ID}},
+	}
+	// Sessions without a live refresh token are migrated too.
+	idleSession := storage.OfflineSessions{
+		UserID:  "john-id",
+		ConnID:  connector.ID,
+		Refresh: map[string]*storage.RefreshTokenRef{},
+	}
+
+	src := openSQLite(t, fromDB)
+	for _, err := range []error{
+		src.CreateClient(client),
+		src.CreatePassword(password),
+		src.CreateConnector(connector),
+		src.UpdateKeys(func(storage.Keys) (storage.Keys, error) { return keys, nil }),
+		src.CreateRefresh(refresh),
+		src.CreateOfflineSessions(session),
+		src.CreateOfflineSessions(idleSession),
+	} {
+		if err != nil {
+			t.Fatalf("populate source storage: %v", err)
+		}
+	}
+	src.Close()
+
+	run := func(dryRun bool) string {
+		t.Helper()
+		var out bytes.Buffer
+		err := runStorageMigrate(storageMigrateOptions{from: fromConfig, to: toConfig, dryRun: dryRun}, &out)
+		if err != nil {
+			t.Fatalf("migrate (dry run %t): %v", dryRun, err)
+		}
+		return out.String()
+	}
+
+	out := run(true)
+	if !strings.Contains(out, "clients: 1 to create, 0 to overwrite") {
+		t.Errorf("unexpected dry run output:\n%s", out)
+	}
+	dst := openSQLite(t, toDB)
+	if clients, err := dst.ListClients(); err != nil || len(clients) != 0 {
+		t.Errorf("dry run wrote to the destination: %v (%v)", clients, err)
+	}
+	dst.Close()
+
+	out = run(false)
+	for _, want := range []string{
+		"clients: 1 created, 0 overwritten",
+		"passwords: 1 created, 0 overwritten",
+		"connectors: 1 created, 0 overwritten",
+		"keys: 1 created, 0 overwritten",
+		"refresh tokens: 1 created, 0 overwritten",
+		"offline sessions: 2 created, 0 overwritten",
+		"migration complete",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// Running the migration again overwrites everything.
+	out = run(false)
+	if !strings.Contains(out, "refresh tokens: 0 created, 1 overwritten") {
+		t.Errorf("unexpected output of the second migration:\n%s", out)
+	}
+
+	dst = openSQLite(t, toDB)
+	defer dst.Close()
+	if got, err := dst.GetClient(client.ID); err != nil || got.Secret != client.Secret {
+		t.Errorf("get client: %+v (%v)", got, err)
+	}
+	if got, err := dst.GetPassword(password.Email); err != nil || got.UserID != password.UserID {
+		t.Errorf("get password: %+v (%v)", got, err)
+	}
+	if got, err := dst.GetKeys(); err != nil || got.SigningKey == nil || got.SigningKey.KeyID != "key" {
+		t.Errorf("get keys: %+v (%v)", got, err)
+	}
+	if got, err := dst.GetRefresh(refresh.ID); err != nil || got.Token != refresh.Token {
+		t.Errorf("get refresh token: %+v (%v)", got, err)
+	}
+	if got, err := dst.GetOfflineSessions(session.UserID, session.ConnID); err != nil || got.Refresh[client.ID] == nil {
+		t.Errorf("get offline session: %+v (%v)", got, err)
+	}
+	if _, err := dst.GetOfflineSessions(idleSession.UserID, idleSession.ConnID); err != nil {
+		t.Errorf("get offline session without refresh tokens: %v", err)
+	}
+}
@@ -47,6 +47,62 @@ type PasswordConnector interface {
 	Login(ctx context.Context, s Scopes, username, password string) (identity Identity, validPassword bool, err error)
 }
 
+// PasswordPolicyReason is the reason the password policy of the upstream
+// identity service restricted a login.
+type PasswordPolicyReason string
+
+const (
+	// PasswordExpired means the password has expired.
+	PasswordExpired PasswordPolicyReason = "passwordExpired"
+	// PasswordMustChange means the password was reset by an administrator and
+	// must be changed before the user can log in.
+	PasswordMustChange PasswordPolicyReason = "passwordMustChange"
+	// AccountLocked means the account is locked, e.g. after too many failed
+	// logins.
+	AccountLocked PasswordPolicyReason = "accountLocked"
+	// PasswordGraceLogin means the password has expired, but the user logged in
+	// using one of a limited number of grace logins.
+	PasswordGraceLogin PasswordPolicyReason = "passwordGraceLogin"
+	// PasswordRejected means a new password doesn't satisfy the password policy.
+	PasswordRejected PasswordPolicyReason = "passwordRejected"
+)
+
+// PasswordPolicyError is returned by a PasswordConnector when the password
+// policy of the upstream identity service restricts the login of a user, or
+// rejects a new password.
+//
+// For PasswordGraceLogin the login succeeded: Login returns the identity and
+// reports the password as valid along with the error.
+type PasswordPolicyError struct {
+	Reason PasswordPolicyReason
+
+	// Number of grace logins left, for PasswordGraceLogin.
+	GraceLoginsRemaining int
+
+	// Whether the user can change the password through the PasswordChanger
+	// interface of the connector.
+	CanChangePassword bool
+
+	// Details reported by the upstream identity service, for logging.
+	Details string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	if e.Details == "" {
+		return string(e.Reason)
+	}
+	return string(e.Reason) + ": " + e.Details
+}
+
+// PasswordChanger is an interface implemented by PasswordConnectors which let
+// users change their password, e.g. after it has expired.
+type PasswordChanger interface {
+	// ChangePassword changes the password of the user. validPassword is false
+	// if the old password is incorrect. It returns a PasswordPolicyError with
+	// PasswordRejected if the new password doesn't satisfy the password policy.
+	ChangePassword(ctx context.Context, username, oldPassword, newPassword string) (validPassword bool, err error)
+}
+
 // CallbackConnector is an interface implemented by connectors which use an OAuth
 // style redirect flow to determine user information.
 type CallbackConnector interface {
@@ -69,6 +125,22 @@ type CallbackConnector interface {
 	HandleCallback(s Scopes, r *http.Request) (identity Identity, err error)
 }
 
+// CallbackStateConnector is an interface implemented by CallbackConnectors
+// which keep data between redirecting the user and handling the callback, e.g.
+// a PKCE code verifier. The server keeps the data with the auth request, so
+// it's never exposed to the user agent.
+type CallbackStateConnector interface {
+	CallbackConnector
+
+	// LoginURLWithState returns the initial URL to redirect the user to, like
+	// LoginURL, and the data to pass to HandleCallbackWithState.
+	LoginURLWithState(s Scopes, callbackURL, state string) (loginURL string, connData []byte, err error)
+
+	// HandleCallbackWithState handles the callback to the server with the data
+	// returned by LoginURLWithState.
+	HandleCallbackWithState(s Scopes, connData []byte, r *http.Request) (identity Identity, err error)
+}
+
 // SAMLConnector represents SAML connectors which implement the HTTP POST binding.
 //  RelayState is handled by the server.
 //
@@ -91,6 +163,33 @@ type SAMLConnector interface {
 	HandlePOST(s Scopes, samlResponse, inResponseTo string) (identity Identity, err error)
 }
 
+// SAMLRedirectConnector is a SAMLConnector which can send the SAML request
+// through the HTTP Redirect binding. The response is still sent to the server
+// through the HTTP POST binding.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+// "3.4 HTTP Redirect Binding"
+type SAMLRedirectConnector interface {
+	SAMLConnector
+
+	// RedirectURL returns the SSO URL with the encoded SAML request and the
+	// request ID as RelayState to redirect the user to. It returns an empty URL
+	// if the request has to be sent through the HTTP POST binding instead.
+	RedirectURL(s Scopes, requestID string) (redirectURL string, err error)
+}
+
+// SAMLMetadataConnector is a SAMLConnector which describes the server as a
+// service provider, so the identity provider can be configured from its
+// metadata.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf
+type SAMLMetadataConnector interface {
+	SAMLConnector
+
+	// Metadata returns the SAML metadata of the service provider.
+	Metadata() ([]byte, error)
+}
+
 // RefreshConnector is a connector that can update the client claims.
 type RefreshConnector interface {
 	// Refresh is called when a client attempts to claim a refresh token. The
@@ -98,3 +197,11 @@ type RefreshConnector interface {
 	// changes since the token was last refreshed.
 	Refresh(ctx context.Context, s Scopes, identity Identity) (Identity, error)
 }
+
+// HealthChecker is a connector that can report whether its upstream identity
+// service is reachable. The server includes the result in its health checks.
+type HealthChecker interface {
+	// CheckHealth returns details about the reachability of the upstream
+	// service, and an error if it can't be used to log in.
+	CheckHealth(ctx context.Context) (details interface{}, err error)
+}
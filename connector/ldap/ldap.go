@@ -6,11 +6,17 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/dexidp/dex/connector"
 	"github.com/dexidp/dex/pkg/log"
@@ -26,6 +32,9 @@ import (
 //     type: ldap
 //     config:
 //       host: ldap.example.com:636
+//       # Optional additional hosts, connections are spread over all hosts.
+//       # hosts:
+//       # - ldap2.example.com:636
 //       # The following field is required if using port 389.
 //       # insecureNoSSL: true
 //       rootCA: /etc/dex/ldap.ca
@@ -54,6 +63,10 @@ import (
 //         - userAttr: DN
 //           groupAttr: member
 //         nameAttr: name
+//         # Optionally also return the groups containing these groups.
+//         nestedGroups:
+//           enabled: true
+//           maxDepth: 5
 //
 
 // UserMatcher holds information about user and group matching.
@@ -62,12 +75,79 @@ type UserMatcher struct {
 	GroupAttr string `json:"groupAttr"`
 }
 
+// Strategies for resolving nested groups.
+const (
+	nestedGroupsAuto      = "auto"
+	nestedGroupsInChain   = "inChain"
+	nestedGroupsIterative = "iterative"
+)
+
+// ldapMatchingRuleInChain is the OID of Active Directory's matching rule which
+// follows the DN valued attribute to any depth, e.g. to find the groups which
+// contain a group through other groups.
+const ldapMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+
+// ldapCapActiveDirectory is the OID of the supportedCapabilities value which
+// identifies an Active Directory server.
+const ldapCapActiveDirectory = "1.2.840.113556.1.4.800"
+
+// NestedGroups holds the configuration for resolving the groups which contain
+// the groups of a user, directly or through other groups.
+type NestedGroups struct {
+	// Also return the groups containing the groups found by the group search.
+	Enabled bool `json:"enabled"`
+
+	// Can either be:
+	// * "auto" - use "inChain" for Active Directory, "iterative" otherwise (default)
+	// * "inChain" - a single search using Active Directory's LDAP_MATCHING_RULE_IN_CHAIN
+	// * "iterative" - search the parents of the groups found, one level at a time
+	Strategy string `json:"strategy"`
+
+	// The attribute of a group holding the DNs of its members. Defaults to "member".
+	MemberAttr string `json:"memberAttr"`
+
+	// The maximum number of levels of nested groups the "iterative" strategy
+	// resolves. Defaults to 10.
+	MaxDepth int `json:"maxDepth"`
+}
+
+// PoolConfig holds the configuration of the pool of connections to the LDAP
+// servers.
+type PoolConfig struct {
+	// Maximum number of idle connections kept open for reuse. Defaults to 4.
+	// Set to -1 to open a new connection for every operation.
+	MaxIdleConns int `json:"maxIdleConns"`
+
+	// How long an idle connection is kept open. Defaults to "1m".
+	IdleTimeout string `json:"idleTimeout"`
+
+	// How long a host which couldn't be reached is skipped before it's tried
+	// again, unless no other host can be reached. Defaults to "30s".
+	HealthCheckInterval string `json:"healthCheckInterval"`
+}
+
 // Config holds configuration options for LDAP logins.
 type Config struct {
 	// The host and optional port of the LDAP server. If port isn't supplied, it will be
 	// guessed based on the TLS configuration. 389 or 636.
 	Host string `json:"host"`
 
+	// Additional hosts, in the same format as host. Connections are spread over
+	// all hosts round-robin, and hosts which can't be reached are skipped.
+	Hosts []string `json:"hosts"`
+
+	// Discover the hosts through the DNS SRV records of "_ldap._tcp.<srvDomain>"
+	// instead of configuring them, e.g. the domain controllers of an Active
+	// Directory domain. Unless insecureNoSSL or startTLS is set, port 636 of the
+	// discovered hosts is used.
+	SRVDomain string `json:"srvDomain"`
+
+	// Timeout for connecting to a host and for each LDAP operation. Defaults to "10s".
+	Timeout string `json:"timeout"`
+
+	// Connection pool configuration.
+	Pool PoolConfig `json:"pool"`
+
 	// Required if LDAP host does not use TLS.
 	InsecureNoSSL bool `json:"insecureNoSSL"`
 
@@ -98,6 +178,11 @@ type Config struct {
 	// "Username".
 	UsernamePrompt string `json:"usernamePrompt"`
 
+	// AllowPasswordChange lets users whose password has expired or must be
+	// changed choose a new password on the login page. Active Directory
+	// requires a TLS connection to change passwords.
+	AllowPasswordChange bool `json:"allowPasswordChange"`
+
 	// User entry search configuration.
 	UserSearch struct {
 		// BaseDN to start the search from. For example "cn=users,dc=example,dc=com"
@@ -155,6 +240,11 @@ type Config struct {
 
 		// The attribute of the group that represents its name.
 		NameAttr string `json:"nameAttr"`
+
+		// Resolution of nested groups. Groups are nested by listing the DN of
+		// the inner group as a member of the outer group. By default only the
+		// groups matching the user are returned.
+		NestedGroups NestedGroups `json:"nestedGroups"`
 	} `json:"groupSearch"`
 }
 
@@ -203,7 +293,7 @@ func userMatchers(c *Config, logger log.Logger) []UserMatcher {
 
 // Open returns an authentication strategy using LDAP.
 func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
-	conn, err := c.OpenConnector(logger)
+	conn, err := c.openConnector(id, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -221,15 +311,14 @@ func (c *Config) OpenConnector(logger log.Logger) (interface {
 	connector.PasswordConnector
 	connector.RefreshConnector
 }, error) {
-	return c.openConnector(logger)
+	return c.openConnector("", logger)
 }
 
-func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
+func (c *Config) openConnector(id string, logger log.Logger) (*ldapConnector, error) {
 	requiredFields := []struct {
 		name string
 		val  string
 	}{
-		{"host", c.Host},
 		{"userSearch.baseDN", c.UserSearch.BaseDN},
 		{"userSearch.username", c.UserSearch.Username},
 	}
@@ -240,20 +329,30 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 		}
 	}
 
-	var (
-		host string
-		err  error
-	)
-	if host, _, err = net.SplitHostPort(c.Host); err != nil {
-		host = c.Host
-		if c.InsecureNoSSL {
-			c.Host += ":389"
-		} else {
-			c.Host += ":636"
+	if c.Host == "" && len(c.Hosts) == 0 && c.SRVDomain == "" {
+		return nil, fmt.Errorf("ldap: missing required field %q", "host")
+	}
+
+	// Default to the port of the TLS configuration, 389 or 636.
+	defaultPort := "636"
+	if c.InsecureNoSSL {
+		defaultPort = "389"
+	}
+	var hosts []string
+	for _, host := range append([]string{c.Host}, c.Hosts...) {
+		if host == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, defaultPort)
 		}
+		hosts = append(hosts, host)
+	}
+	if len(hosts) != 0 {
+		c.Host = hosts[0]
 	}
 
-	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: c.InsecureSkipVerify}
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
 	if c.RootCA != "" || len(c.RootCAData) != 0 {
 		data := c.RootCAData
 		if len(data) == 0 {
@@ -285,9 +384,58 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 		return nil, fmt.Errorf("groupSearch.Scope unknown value %q", c.GroupSearch.Scope)
 	}
 
+	switch c.GroupSearch.NestedGroups.Strategy {
+	case "", nestedGroupsAuto, nestedGroupsInChain, nestedGroupsIterative:
+	default:
+		return nil, fmt.Errorf("groupSearch.nestedGroups.strategy unknown value %q", c.GroupSearch.NestedGroups.Strategy)
+	}
+	if c.GroupSearch.NestedGroups.MemberAttr == "" {
+		c.GroupSearch.NestedGroups.MemberAttr = "member"
+	}
+	if c.GroupSearch.NestedGroups.MaxDepth <= 0 {
+		c.GroupSearch.NestedGroups.MaxDepth = 10
+	}
+
+	timeout, err := parseDuration("timeout", c.Timeout, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	idleTimeout, err := parseDuration("pool.idleTimeout", c.Pool.IdleTimeout, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	healthCheckInterval, err := parseDuration("pool.healthCheckInterval", c.Pool.HealthCheckInterval, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO(nabokihms): remove it after deleting deprecated groupSearch options
 	c.GroupSearch.UserMatchers = userMatchers(c, logger)
-	return &ldapConnector{*c, userSearchScope, groupSearchScope, tlsConfig, logger}, nil
+	conn := &ldapConnector{
+		Config:           *c,
+		userSearchScope:  userSearchScope,
+		groupSearchScope: groupSearchScope,
+		tlsConfig:        tlsConfig,
+		timeout:          timeout,
+		logger:           logger,
+	}
+
+	hostsFunc := func(context.Context) ([]string, error) { return hosts, nil }
+	if c.SRVDomain != "" {
+		srv := &srvHosts{domain: c.SRVDomain, lookup: net.DefaultResolver.LookupSRV, now: time.Now}
+		if !c.InsecureNoSSL && !c.StartTLS {
+			srv.port = 636
+		}
+		hostsFunc = srv.get
+	}
+	conn.pool = newConnPool(id, conn.dial, hostsFunc)
+	conn.pool.maxIdle = c.Pool.MaxIdleConns
+	if conn.pool.maxIdle == 0 {
+		conn.pool.maxIdle = 4
+	}
+	conn.pool.idleTimeout = idleTimeout
+	conn.pool.healthCheckInterval = healthCheckInterval
+	return conn, nil
 }
 
 type ldapConnector struct {
@@ -297,53 +445,152 @@ type ldapConnector struct {
 	groupSearchScope int
 
 	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	pool *connPool
 
 	logger log.Logger
+
+	// Whether the server is Active Directory, once it has been checked.
+	mu              sync.Mutex
+	activeDirectory *bool
 }
 
 var (
 	_ connector.PasswordConnector = (*ldapConnector)(nil)
 	_ connector.RefreshConnector  = (*ldapConnector)(nil)
+	_ connector.PasswordChanger   = (*ldapConnector)(nil)
+	_ connector.HealthChecker     = (*ldapConnector)(nil)
+	_ prometheus.Collector        = (*ldapConnector)(nil)
+	_ io.Closer                   = (*ldapConnector)(nil)
 )
 
-// do initializes a connection to the LDAP directory and passes it to the
-// provided function. It then performs appropriate teardown or reuse before
-// returning.
-func (c *ldapConnector) do(_ context.Context, f func(c *ldap.Conn) error) error {
-	// TODO(ericchiang): support context here
-	var (
-		conn *ldap.Conn
-		err  error
-	)
+// parseDuration parses the duration of a config field, or returns def if the
+// field isn't set.
+func parseDuration(name, val string, def time.Duration) (time.Duration, error) {
+	if val == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("ldap: invalid %s %q: %v", name, val, err)
+	}
+	return d, nil
+}
+
+// do takes a connection to the LDAP directory from the pool, bound as the
+// service account, and passes it to the provided function. The connection is
+// returned to the pool unless the function fails. Operations on an idle
+// connection which turns out to be closed are retried on a new connection.
+func (c *ldapConnector) do(ctx context.Context, f func(c *ldap.Conn) error) error {
+	conn, reused, err := c.pool.get(ctx)
+	if err != nil {
+		return err
+	}
+	err = f(conn.Conn)
+	c.pool.put(conn, err == nil)
+
+	// The function may wrap the error of the failed operation.
+	var ldapErr *ldap.Error
+	if reused && errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.ErrorNetwork {
+		c.logger.Infof("ldap: idle connection to %s failed, retrying on a new connection: %v", conn.host, err)
+		if conn, err = c.pool.dialAny(ctx); err != nil {
+			return err
+		}
+		err = f(conn.Conn)
+		c.pool.put(conn, err == nil)
+	}
+	return err
+}
+
+// dial connects to the given host and binds as the service account.
+func (c *ldapConnector) dial(host string) (*ldap.Conn, error) {
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := c.tlsConfig.Clone()
+	tlsConfig.ServerName = hostname
+	dialer := &net.Dialer{Timeout: c.timeout}
+
+	var conn *ldap.Conn
 	switch {
 	case c.InsecureNoSSL:
-		conn, err = ldap.Dial("tcp", c.Host)
+		conn, err = ldap.DialURL("ldap://"+host, ldap.DialWithDialer(dialer))
 	case c.StartTLS:
-		conn, err = ldap.Dial("tcp", c.Host)
+		conn, err = ldap.DialURL("ldap://"+host, ldap.DialWithDialer(dialer))
 		if err != nil {
-			return fmt.Errorf("failed to connect: %v", err)
+			return nil, fmt.Errorf("failed to connect: %v", err)
 		}
-		if err := conn.StartTLS(c.tlsConfig); err != nil {
-			return fmt.Errorf("start TLS failed: %v", err)
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start TLS failed: %v", err)
 		}
 	default:
-		conn, err = ldap.DialTLS("tcp", c.Host, c.tlsConfig)
+		conn, err = ldap.DialURL("ldaps://"+host, ldap.DialWithTLSDialer(tlsConfig, dialer))
 	}
 	if err != nil {
-		return fmt.Errorf("failed to connect: %v", err)
+		return nil, fmt.Errorf("failed to connect: %v", err)
 	}
-	defer conn.Close()
+	conn.SetTimeout(c.timeout)
 
+	if err := c.bind(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// bind binds as the service account.
+func (c *ldapConnector) bind(conn *ldap.Conn) error {
 	// If bindDN and bindPW are empty this will default to an anonymous bind.
 	if c.BindDN == "" && c.BindPW == "" {
 		if err := conn.UnauthenticatedBind(""); err != nil {
-			return fmt.Errorf("ldap: initial anonymous bind failed: %v", err)
+			return fmt.Errorf("ldap: initial anonymous bind failed: %w", err)
 		}
 	} else if err := conn.Bind(c.BindDN, c.BindPW); err != nil {
-		return fmt.Errorf("ldap: initial bind for user %q failed: %v", c.BindDN, err)
+		return fmt.Errorf("ldap: initial bind for user %q failed: %w", c.BindDN, err)
+	}
+	return nil
+}
+
+// CheckHealth connects to every LDAP server. It fails if none of them can be
+// reached, and reports the servers which can't be reached in its details.
+func (c *ldapConnector) CheckHealth(ctx context.Context) (interface{}, error) {
+	results, err := c.pool.check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	details := make(map[string]string, len(results))
+	reachable := false
+	for host, err := range results {
+		if err != nil {
+			details[host] = err.Error()
+			continue
+		}
+		details[host] = "ok"
+		reachable = true
 	}
+	if !reachable {
+		return details, fmt.Errorf("ldap: no host reachable")
+	}
+	return details, nil
+}
+
+// Close closes the idle connections of the pool.
+func (c *ldapConnector) Close() error {
+	c.pool.close()
+	return nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *ldapConnector) Describe(ch chan<- *prometheus.Desc) {
+	c.pool.Describe(ch)
+}
 
-	return f(conn)
+// Collect implements prometheus.Collector.
+func (c *ldapConnector) Collect(ch chan<- prometheus.Metric) {
+	c.pool.Collect(ch)
 }
 
 func getAttrs(e ldap.Entry, name string) []string {
@@ -438,7 +685,7 @@ func (c *ldapConnector) userEntry(conn *ldap.Conn, username string) (user ldap.E
 		req.BaseDN, scopeString(req.Scope), req.Filter)
 	resp, err := conn.Search(req)
 	if err != nil {
-		return ldap.Entry{}, false, fmt.Errorf("ldap: search with filter %q failed: %v", req.Filter, err)
+		return ldap.Entry{}, false, fmt.Errorf("ldap: search with filter %q failed: %w", req.Filter, err)
 	}
 
 	switch n := len(resp.Entries); n {
@@ -465,6 +712,7 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 		// if there was an error.
 		incorrectPass = false
 		user          ldap.Entry
+		policyErr     *connector.PasswordPolicyError
 	)
 
 	err = c.do(ctx, func(conn *ldap.Conn) error {
@@ -479,7 +727,25 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 		user = entry
 
 		// Try to authenticate as the distinguished name.
-		if err := conn.Bind(user.DN, password); err != nil {
+		policyErr, err = bindUser(conn, user.DN, password)
+
+		// Bind as the service account again, so the connection can be reused.
+		if err := c.bind(conn); err != nil {
+			return err
+		}
+
+		if policyErr != nil {
+			c.logger.Infof("ldap: password policy restricts login of user %q: %v", user.DN, policyErr)
+			canChange, err := c.canChangePassword(conn, policyErr.Reason)
+			if err != nil {
+				return err
+			}
+			policyErr.CanChangePassword = canChange
+			if policyErr.Reason != connector.PasswordGraceLogin {
+				return nil
+			}
+		}
+		if err != nil {
 			// Detect a bad password through the LDAP error code.
 			if ldapErr, ok := err.(*ldap.Error); ok {
 				switch ldapErr.ResultCode {
@@ -493,7 +759,7 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 					return nil
 				}
 			} // will also catch all ldap.Error without a case statement above
-			return fmt.Errorf("ldap: failed to bind as dn %q: %v", user.DN, err)
+			return fmt.Errorf("ldap: failed to bind as dn %q: %w", user.DN, err)
 		}
 		return nil
 	})
@@ -503,6 +769,9 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 	if incorrectPass {
 		return connector.Identity{}, false, nil
 	}
+	if policyErr != nil && policyErr.Reason != connector.PasswordGraceLogin {
+		return connector.Identity{}, false, policyErr
+	}
 
 	if ident, err = c.identityFromEntry(user); err != nil {
 		return connector.Identity{}, false, err
@@ -528,6 +797,10 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 		}
 	}
 
+	if policyErr != nil {
+		// The user logged in with a grace login.
+		return ident, true, policyErr
+	}
 	return ident, true, nil
 }
 
@@ -572,6 +845,27 @@ func (c *ldapConnector) Refresh(ctx context.Context, s connector.Scopes, ident c
 	return newIdent, nil
 }
 
+// LookupGroups returns the groups of a user, for connectors which use the
+// directory as a source of groups.
+func (c *ldapConnector) LookupGroups(ctx context.Context, username string) ([]string, error) {
+	var user ldap.Entry
+	err := c.do(ctx, func(conn *ldap.Conn) error {
+		entry, found, err := c.userEntry(conn, username)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("ldap: user not found %q", username)
+		}
+		user = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.groups(ctx, user)
+}
+
 func (c *ldapConnector) groups(ctx context.Context, user ldap.Entry) ([]string, error) {
 	if c.GroupSearch.BaseDN == "" {
 		c.logger.Debugf("No groups returned for %q because no groups baseDN has been configured.", getAttr(user, c.UserSearch.NameAttr))
@@ -599,7 +893,7 @@ func (c *ldapConnector) groups(ctx context.Context, user ldap.Entry) ([]string,
 					req.BaseDN, scopeString(req.Scope), req.Filter)
 				resp, err := conn.Search(req)
 				if err != nil {
-					return fmt.Errorf("ldap: search failed: %v", err)
+					return fmt.Errorf("ldap: search failed: %w", err)
 				}
 				gotGroups = len(resp.Entries) != 0
 				groups = append(groups, resp.Entries...)
@@ -614,6 +908,14 @@ func (c *ldapConnector) groups(ctx context.Context, user ldap.Entry) ([]string,
 		}
 	}
 
+	if c.GroupSearch.NestedGroups.Enabled && len(groups) != 0 {
+		nested, err := c.nestedGroups(ctx, groups)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, nested...)
+	}
+
 	groupNames := make([]string, 0, len(groups))
 	for _, group := range groups {
 		name := getAttr(*group, c.GroupSearch.NameAttr)
@@ -631,6 +933,126 @@ func (c *ldapConnector) groups(ctx context.Context, user ldap.Entry) ([]string,
 	return groupNames, nil
 }
 
+// nestedGroups returns the groups which contain any of the given groups,
+// directly or through other groups, excluding the given groups themselves.
+func (c *ldapConnector) nestedGroups(ctx context.Context, groups []*ldap.Entry) ([]*ldap.Entry, error) {
+	// Groups are identified by their DN. Track the groups seen so far, both to
+	// skip duplicates and to stop at cycles.
+	seen := make(map[string]bool, len(groups))
+	var dns []string
+	for _, group := range groups {
+		if key := strings.ToLower(group.DN); !seen[key] {
+			seen[key] = true
+			dns = append(dns, group.DN)
+		}
+	}
+
+	var nested []*ldap.Entry
+	err := c.do(ctx, func(conn *ldap.Conn) error {
+		strategy, err := c.nestedGroupsStrategy(conn)
+		if err != nil {
+			return err
+		}
+
+		if strategy == nestedGroupsInChain {
+			// The server follows the chain of groups, including cycles.
+			entries, err := c.searchParentGroups(conn, dns, true)
+			if err != nil {
+				return err
+			}
+			for _, group := range entries {
+				if key := strings.ToLower(group.DN); !seen[key] {
+					seen[key] = true
+					nested = append(nested, group)
+				}
+			}
+			return nil
+		}
+
+		for depth := 1; len(dns) != 0; depth++ {
+			if depth > c.GroupSearch.NestedGroups.MaxDepth {
+				c.logger.Errorf("ldap: groups nested deeper than the max depth of %d are ignored, not resolving parents of %q",
+					c.GroupSearch.NestedGroups.MaxDepth, dns)
+				return nil
+			}
+
+			entries, err := c.searchParentGroups(conn, dns, false)
+			if err != nil {
+				return err
+			}
+			dns = nil
+			for _, group := range entries {
+				if key := strings.ToLower(group.DN); !seen[key] {
+					seen[key] = true
+					nested = append(nested, group)
+					dns = append(dns, group.DN)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+// nestedGroupsStrategy resolves the "auto" strategy by checking whether the
+// server is Active Directory, which supports LDAP_MATCHING_RULE_IN_CHAIN.
+func (c *ldapConnector) nestedGroupsStrategy(conn *ldap.Conn) (string, error) {
+	switch strategy := c.GroupSearch.NestedGroups.Strategy; strategy {
+	case nestedGroupsInChain, nestedGroupsIterative:
+		return strategy, nil
+	}
+
+	activeDirectory, err := c.isActiveDirectory(conn)
+	if err != nil {
+		return "", err
+	}
+	if activeDirectory {
+		return nestedGroupsInChain, nil
+	}
+	return nestedGroupsIterative, nil
+}
+
+// searchParentGroups returns the groups which have any of the given DNs as
+// a member. In chain, it returns the groups which contain them through other
+// groups as well.
+func (c *ldapConnector) searchParentGroups(conn *ldap.Conn, dns []string, inChain bool) ([]*ldap.Entry, error) {
+	req := &ldap.SearchRequest{
+		BaseDN:     c.GroupSearch.BaseDN,
+		Filter:     c.parentGroupsFilter(dns, inChain),
+		Scope:      c.groupSearchScope,
+		Attributes: []string{c.GroupSearch.NameAttr},
+	}
+	c.logger.Infof("performing ldap search %s %s %s",
+		req.BaseDN, scopeString(req.Scope), req.Filter)
+	resp, err := conn.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: search failed: %w", err)
+	}
+	return resp.Entries, nil
+}
+
+func (c *ldapConnector) parentGroupsFilter(dns []string, inChain bool) string {
+	attr := c.GroupSearch.NestedGroups.MemberAttr
+	if inChain {
+		attr += ":" + ldapMatchingRuleInChain + ":"
+	}
+
+	var filter string
+	for _, dn := range dns {
+		filter += fmt.Sprintf("(%s=%s)", attr, ldap.EscapeFilter(dn))
+	}
+	if len(dns) > 1 {
+		filter = "(|" + filter + ")"
+	}
+	if c.GroupSearch.Filter != "" {
+		filter = fmt.Sprintf("(&%s%s)", c.GroupSearch.Filter, filter)
+	}
+	return filter
+}
+
 func (c *ldapConnector) Prompt() string {
 	return c.UsernamePrompt
 }
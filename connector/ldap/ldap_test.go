@@ -417,6 +417,123 @@ func TestDeprecatedGroupToUserMatcher(t *testing.T) {
 	runTests(t, connectLDAP, c, tests)
 }
 
+func nestedGroupsConfig() *Config {
+	c := &Config{}
+	c.UserSearch.BaseDN = "ou=People,ou=TestNestedGroups,dc=example,dc=org"
+	c.UserSearch.NameAttr = "cn"
+	c.UserSearch.EmailAttr = "mail"
+	c.UserSearch.IDAttr = "DN"
+	c.UserSearch.Username = "cn"
+	c.GroupSearch.BaseDN = "ou=Groups,ou=TestNestedGroups,dc=example,dc=org"
+	c.GroupSearch.UserMatchers = []UserMatcher{
+		{
+			UserAttr:  "DN",
+			GroupAttr: "member",
+		},
+	}
+	c.GroupSearch.NameAttr = "cn"
+	c.GroupSearch.NestedGroups.Enabled = true
+	return c
+}
+
+func TestNestedGroups(t *testing.T) {
+	c := nestedGroupsConfig()
+
+	tests := []subtest{
+		{
+			name:     "nestedgroups",
+			username: "jane",
+			password: "foo",
+			groups:   true,
+			want: connector.Identity{
+				UserID:        "cn=jane,ou=People,ou=TestNestedGroups,dc=example,dc=org",
+				Username:      "jane",
+				Email:         "janedoe@example.com",
+				EmailVerified: true,
+				Groups:        []string{"developers", "cycle-a", "engineering", "cycle-b", "staff"},
+			},
+		},
+		{
+			name:     "nonestedgroups",
+			username: "john",
+			password: "bar",
+			groups:   true,
+			want: connector.Identity{
+				UserID:        "cn=john,ou=People,ou=TestNestedGroups,dc=example,dc=org",
+				Username:      "john",
+				Email:         "johndoe@example.com",
+				EmailVerified: true,
+				Groups:        []string{"staff"},
+			},
+		},
+	}
+
+	runTests(t, connectLDAP, c, tests)
+}
+
+func TestNestedGroupsMaxDepth(t *testing.T) {
+	c := nestedGroupsConfig()
+	c.GroupSearch.NestedGroups.Strategy = nestedGroupsIterative
+	c.GroupSearch.NestedGroups.MaxDepth = 1
+
+	tests := []subtest{
+		{
+			name:     "maxdepth",
+			username: "jane",
+			password: "foo",
+			groups:   true,
+			want: connector.Identity{
+				UserID:        "cn=jane,ou=People,ou=TestNestedGroups,dc=example,dc=org",
+				Username:      "jane",
+				Email:         "janedoe@example.com",
+				EmailVerified: true,
+				Groups:        []string{"developers", "cycle-a", "engineering", "cycle-b"},
+			},
+		},
+	}
+
+	runTests(t, connectLDAP, c, tests)
+}
+
+func TestParentGroupsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		dns     []string
+		inChain bool
+		want    string
+	}{
+		{
+			name: "single group",
+			dns:  []string{"cn=developers,dc=example,dc=org"},
+			want: "(member=cn=developers,dc=example,dc=org)",
+		},
+		{
+			name:   "multiple groups with filter",
+			dns:    []string{"cn=developers,dc=example,dc=org", "cn=admins (EU),dc=example,dc=org"},
+			filter: "(objectClass=group)",
+			want:   `(&(objectClass=group)(|(member=cn=developers,dc=example,dc=org)(member=cn=admins \28EU\29,dc=example,dc=org)))`,
+		},
+		{
+			name:    "in chain",
+			dns:     []string{"cn=developers,dc=example,dc=org"},
+			inChain: true,
+			want:    "(member:1.2.840.113556.1.4.1941:=cn=developers,dc=example,dc=org)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &ldapConnector{}
+			c.GroupSearch.Filter = tc.filter
+			c.GroupSearch.NestedGroups.MemberAttr = "member"
+			if got := c.parentGroupsFilter(tc.dns, tc.inChain); got != tc.want {
+				t.Errorf("expected filter %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestStartTLS(t *testing.T) {
 	c := &Config{}
 	c.UserSearch.BaseDN = "ou=People,ou=TestStartTLS,dc=example,dc=org"
@@ -557,7 +674,7 @@ func runTests(t *testing.T, connMethod connectionMethod, config *Config, tests [
 
 	l := &logrus.Logger{Out: io.Discard, Formatter: &logrus.TextFormatter{}}
 
-	conn, err := c.openConnector(l)
+	conn, err := c.openConnector("ldap", l)
 	if err != nil {
 		t.Errorf("open connector: %v", err)
 	}
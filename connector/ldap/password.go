@@ -0,0 +1,250 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// Active Directory reports why a bind failed in the diagnostic message, e.g.
+// "80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 532, v4563".
+//
+// See: https://ldapwiki.com/wiki/Common%20Active%20Directory%20Bind%20Errors
+var adBindErrorRe = regexp.MustCompile(`data ([0-9a-fA-F]+),`)
+
+const (
+	adPasswordExpired    = "532"
+	adPasswordMustChange = "773"
+	adAccountLocked      = "775"
+)
+
+// Active Directory reports why a password change failed in the diagnostic
+// message of a constraint violation, e.g. "0000052D: Constraint violation...".
+const (
+	adErrInvalidPassword   = "00000056"
+	adErrPasswordRestricts = "0000052D"
+)
+
+// passwordPolicyError returns the restriction the password policy put on a
+// bind, reported through the password policy controls of OpenLDAP and 389 DS,
+// or the diagnostic message of Active Directory. It returns nil if the policy
+// didn't restrict the bind.
+func passwordPolicyError(controls []ldap.Control, err error) *connector.PasswordPolicyError {
+	if c, ok := ldap.FindControl(controls, ldap.ControlTypeBeheraPasswordPolicy).(*ldap.ControlBeheraPasswordPolicy); ok {
+		switch c.Error {
+		case ldap.BeheraPasswordExpired:
+			return &connector.PasswordPolicyError{Reason: connector.PasswordExpired, Details: c.ErrorString}
+		case ldap.BeheraAccountLocked:
+			return &connector.PasswordPolicyError{Reason: connector.AccountLocked, Details: c.ErrorString}
+		case ldap.BeheraChangeAfterReset:
+			return &connector.PasswordPolicyError{Reason: connector.PasswordMustChange, Details: c.ErrorString}
+		case -1:
+		default:
+			// The remaining errors are returned when changing a password.
+			return &connector.PasswordPolicyError{Reason: connector.PasswordRejected, Details: c.ErrorString}
+		}
+		if err == nil && c.Grace >= 0 {
+			return &connector.PasswordPolicyError{Reason: connector.PasswordGraceLogin, GraceLoginsRemaining: int(c.Grace)}
+		}
+	}
+
+	if _, ok := ldap.FindControl(controls, ldap.ControlTypeVChuPasswordMustChange).(*ldap.ControlVChuPasswordMustChange); ok {
+		if err != nil {
+			return &connector.PasswordPolicyError{Reason: connector.PasswordExpired}
+		}
+		return &connector.PasswordPolicyError{Reason: connector.PasswordMustChange}
+	}
+
+	var ldapErr *ldap.Error
+	if errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultInvalidCredentials && ldapErr.Err != nil {
+		m := adBindErrorRe.FindStringSubmatch(ldapErr.Err.Error())
+		if m == nil {
+			return nil
+		}
+		switch m[1] {
+		case adPasswordExpired:
+			return &connector.PasswordPolicyError{Reason: connector.PasswordExpired, Details: ldapErr.Err.Error()}
+		case adPasswordMustChange:
+			return &connector.PasswordPolicyError{Reason: connector.PasswordMustChange, Details: ldapErr.Err.Error()}
+		case adAccountLocked:
+			return &connector.PasswordPolicyError{Reason: connector.AccountLocked, Details: ldapErr.Err.Error()}
+		}
+	}
+	return nil
+}
+
+// bindUser binds as a user, asking for the password policy state of the user.
+func bindUser(conn *ldap.Conn, dn, password string) (*connector.PasswordPolicyError, error) {
+	result, err := conn.SimpleBind(&ldap.SimpleBindRequest{
+		Username: dn,
+		Password: password,
+		Controls: []ldap.Control{ldap.NewControlBeheraPasswordPolicy()},
+	})
+	var controls []ldap.Control
+	if result != nil {
+		controls = result.Controls
+	}
+	return passwordPolicyError(controls, err), err
+}
+
+// ChangePassword changes the password of a user, if allowPasswordChange is set.
+//
+// On Active Directory the service account removes the old and adds the new
+// password in a single modify operation, which only requires the right to
+// change, not reset, passwords. Other servers let the user bind with a
+// password which must be changed, so the user binds and changes the password
+// through the password modify extended operation.
+func (c *ldapConnector) ChangePassword(ctx context.Context, username, oldPassword, newPassword string) (validPassword bool, err error) {
+	if !c.AllowPasswordChange {
+		return false, errors.New("ldap: password change not allowed")
+	}
+	if oldPassword == "" || newPassword == "" {
+		return false, nil
+	}
+
+	var (
+		incorrectPass = false
+		policyErr     *connector.PasswordPolicyError
+	)
+	err = c.do(ctx, func(conn *ldap.Conn) error {
+		user, found, err := c.userEntry(conn, username)
+		if err != nil {
+			return err
+		}
+		if !found {
+			incorrectPass = true
+			return nil
+		}
+
+		activeDirectory, err := c.isActiveDirectory(conn)
+		if err != nil {
+			return err
+		}
+		if activeDirectory {
+			req := ldap.NewModifyRequest(user.DN, nil)
+			req.Delete("unicodePwd", []string{encodeUnicodePwd(oldPassword)})
+			req.Add("unicodePwd", []string{encodeUnicodePwd(newPassword)})
+			err = conn.Modify(req)
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultConstraintViolation) {
+				switch msg := err.Error(); {
+				case strings.Contains(msg, adErrInvalidPassword):
+					incorrectPass = true
+					return nil
+				case strings.Contains(msg, adErrPasswordRestricts):
+					policyErr = &connector.PasswordPolicyError{Reason: connector.PasswordRejected, CanChangePassword: true, Details: msg}
+					return nil
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("ldap: change password of %q: %w", user.DN, err)
+			}
+			return nil
+		}
+
+		policyErr, err = bindUser(conn, user.DN, oldPassword)
+		if err == nil {
+			// Changing the password lifts the restrictions of the bind.
+			policyErr = nil
+			_, err = conn.PasswordModify(ldap.NewPasswordModifyRequest("", oldPassword, newPassword))
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultConstraintViolation) || ldap.IsErrorWithCode(err, ldap.LDAPResultUnwillingToPerform) {
+				policyErr = &connector.PasswordPolicyError{Reason: connector.PasswordRejected, CanChangePassword: true, Details: err.Error()}
+				err = nil
+			}
+		}
+
+		// Bind as the service account again, so the connection can be reused.
+		if err := c.bind(conn); err != nil {
+			return err
+		}
+
+		switch {
+		case policyErr != nil:
+			return nil
+		case ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials):
+			incorrectPass = true
+			return nil
+		case err != nil:
+			return fmt.Errorf("ldap: change password of %q: %w", user.DN, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if incorrectPass {
+		return false, nil
+	}
+	if policyErr != nil {
+		return true, policyErr
+	}
+	return true, nil
+}
+
+// canChangePassword reports whether a user whose login was restricted by the
+// password policy can change their password. Only Active Directory allows
+// changing a password which has expired, since the user has to bind on
+// other servers.
+func (c *ldapConnector) canChangePassword(conn *ldap.Conn, reason connector.PasswordPolicyReason) (bool, error) {
+	if !c.AllowPasswordChange {
+		return false, nil
+	}
+	switch reason {
+	case connector.PasswordMustChange, connector.PasswordGraceLogin:
+		return true, nil
+	case connector.PasswordExpired:
+		return c.isActiveDirectory(conn)
+	}
+	return false, nil
+}
+
+// encodeUnicodePwd encodes a password as the quoted UTF-16LE string expected
+// by the unicodePwd attribute of Active Directory.
+func encodeUnicodePwd(password string) string {
+	codes := utf16.Encode([]rune(`"` + password + `"`))
+	b := make([]byte, 0, 2*len(codes))
+	for _, code := range codes {
+		b = append(b, byte(code), byte(code>>8))
+	}
+	return string(b)
+}
+
+// isActiveDirectory reports whether the server is Active Directory, checking
+// the capabilities of the root DSE once.
+func (c *ldapConnector) isActiveDirectory(conn *ldap.Conn) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.activeDirectory != nil {
+		return *c.activeDirectory, nil
+	}
+
+	req := &ldap.SearchRequest{
+		BaseDN:     "",
+		Filter:     "(objectClass=*)",
+		Scope:      ldap.ScopeBaseObject,
+		Attributes: []string{"supportedCapabilities"},
+	}
+	resp, err := conn.Search(req)
+	if err != nil {
+		return false, fmt.Errorf("ldap: root DSE search failed: %w", err)
+	}
+
+	activeDirectory := false
+	if len(resp.Entries) == 1 {
+		for _, capability := range getAttrs(*resp.Entries[0], "supportedCapabilities") {
+			if capability == ldapCapActiveDirectory {
+				activeDirectory = true
+			}
+		}
+	}
+	c.logger.Infof("ldap: server is Active Directory: %t", activeDirectory)
+	c.activeDirectory = &activeDirectory
+	return activeDirectory, nil
+}
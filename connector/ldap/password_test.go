@@ -0,0 +1,71 @@
+package ldap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestPasswordPolicyError(t *testing.T) {
+	behera := func(grace int64, code int8) []ldap.Control {
+		c := ldap.NewControlBeheraPasswordPolicy()
+		c.Grace, c.Error = grace, code
+		return []ldap.Control{c}
+	}
+	invalidCredentials := ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	adError := func(data string) error {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials,
+			errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data "+data+", v4563"))
+	}
+
+	tests := []struct {
+		name      string
+		controls  []ldap.Control
+		err       error
+		want      connector.PasswordPolicyReason
+		wantGrace int
+	}{
+		{name: "no restriction"},
+		{name: "invalid credentials", err: invalidCredentials},
+		{name: "no behera restriction", controls: behera(-1, -1)},
+		{name: "behera expired", controls: behera(-1, ldap.BeheraPasswordExpired), err: invalidCredentials, want: connector.PasswordExpired},
+		{name: "behera locked", controls: behera(-1, ldap.BeheraAccountLocked), err: invalidCredentials, want: connector.AccountLocked},
+		{name: "behera must change", controls: behera(-1, ldap.BeheraChangeAfterReset), want: connector.PasswordMustChange},
+		{name: "behera grace login", controls: behera(2, -1), want: connector.PasswordGraceLogin, wantGrace: 2},
+		{name: "behera last grace login", controls: behera(0, -1), want: connector.PasswordGraceLogin},
+		{name: "behera insufficient quality", controls: behera(-1, ldap.BeheraInsufficientPasswordQuality), want: connector.PasswordRejected},
+		{name: "vchu must change", controls: []ldap.Control{&ldap.ControlVChuPasswordMustChange{MustChange: true}}, want: connector.PasswordMustChange},
+		{name: "vchu expired", controls: []ldap.Control{&ldap.ControlVChuPasswordMustChange{MustChange: true}}, err: invalidCredentials, want: connector.PasswordExpired},
+		{name: "active directory invalid password", err: adError("52e")},
+		{name: "active directory expired", err: adError("532"), want: connector.PasswordExpired},
+		{name: "active directory must change", err: adError("773"), want: connector.PasswordMustChange},
+		{name: "active directory locked", err: adError("775"), want: connector.AccountLocked},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := passwordPolicyError(tc.controls, tc.err)
+			if tc.want == "" {
+				if got != nil {
+					t.Fatalf("expected no restriction, got %v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("expected %s, got no restriction", tc.want)
+			}
+			if got.Reason != tc.want || got.GraceLoginsRemaining != tc.wantGrace {
+				t.Errorf("expected %s with %d grace logins, got %s with %d", tc.want, tc.wantGrace, got.Reason, got.GraceLoginsRemaining)
+			}
+		})
+	}
+}
+
+func TestEncodeUnicodePwd(t *testing.T) {
+	want := "\"\x00n\x00e\x00w\x00\"\x00"
+	if got := encodeUnicodePwd("new"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
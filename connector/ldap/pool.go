@@ -0,0 +1,288 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// srvCacheDuration is how long the hosts discovered through DNS SRV records
+// are used before they're looked up again.
+const srvCacheDuration = time.Minute
+
+// pooledConn is a connection bound as the service account.
+type pooledConn struct {
+	*ldap.Conn
+
+	host      string
+	idleSince time.Time
+}
+
+// hostStats counts the connections dialed to a host.
+type hostStats struct {
+	dials      uint64
+	dialErrors uint64
+}
+
+// connPool keeps idle connections bound as the service account for reuse by
+// later operations. New connections are spread over the hosts round-robin.
+// Hosts which can't be reached are skipped until the health check interval
+// has passed, unless no host can be reached.
+type connPool struct {
+	// dial connects to a host and binds as the service account.
+	dial func(host string) (*ldap.Conn, error)
+	// hosts returns the hosts to connect to, as host:port.
+	hosts func(ctx context.Context) ([]string, error)
+
+	maxIdle             int
+	idleTimeout         time.Duration
+	healthCheckInterval time.Duration
+	now                 func() time.Time
+
+	mu     sync.Mutex
+	closed bool
+	idle   []*pooledConn
+	open   int
+	reused uint64
+	next   int
+	// The hosts which couldn't be reached, and when.
+	down  map[string]time.Time
+	stats map[string]*hostStats
+
+	openDesc       *prometheus.Desc
+	idleDesc       *prometheus.Desc
+	reusedDesc     *prometheus.Desc
+	dialsDesc      *prometheus.Desc
+	dialErrorsDesc *prometheus.Desc
+	hostUpDesc     *prometheus.Desc
+}
+
+func newConnPool(connectorID string, dial func(host string) (*ldap.Conn, error), hosts func(ctx context.Context) ([]string, error)) *connPool {
+	labels := prometheus.Labels{"connector": connectorID}
+	return &connPool{
+		dial:  dial,
+		hosts: hosts,
+		now:   time.Now,
+		down:  make(map[string]time.Time),
+		stats: make(map[string]*hostStats),
+
+		openDesc: prometheus.NewDesc("dex_ldap_pool_open_connections",
+			"Connections to LDAP servers currently open, both idle and in use.", nil, labels),
+		idleDesc: prometheus.NewDesc("dex_ldap_pool_idle_connections",
+			"Idle connections to LDAP servers kept open for reuse.", nil, labels),
+		reusedDesc: prometheus.NewDesc("dex_ldap_pool_reused_connections_total",
+			"Operations which reused an idle connection.", nil, labels),
+		dialsDesc: prometheus.NewDesc("dex_ldap_pool_dials_total",
+			"Connections dialed to an LDAP server.", []string{"host"}, labels),
+		dialErrorsDesc: prometheus.NewDesc("dex_ldap_pool_dial_errors_total",
+			"Connections to an LDAP server which failed to dial or bind.", []string{"host"}, labels),
+		hostUpDesc: prometheus.NewDesc("dex_ldap_host_up",
+			"Whether the last connection to an LDAP server succeeded.", []string{"host"}, labels),
+	}
+}
+
+// get returns an idle connection, or dials a new one. reused reports whether
+// the connection was idle, and so may have been closed by the server.
+func (p *connPool) get(ctx context.Context) (conn *pooledConn, reused bool, err error) {
+	p.mu.Lock()
+	for len(p.idle) != 0 {
+		conn = p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if !conn.IsClosing() && p.now().Sub(conn.idleSince) < p.idleTimeout {
+			p.reused++
+			p.mu.Unlock()
+			return conn, true, nil
+		}
+		p.open--
+		conn.Close()
+	}
+	p.mu.Unlock()
+
+	conn, err = p.dialAny(ctx)
+	return conn, false, err
+}
+
+// put returns a connection to the pool, or closes it if it isn't reusable.
+func (p *connPool) put(conn *pooledConn, reusable bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !reusable || p.closed || conn.IsClosing() || len(p.idle) >= p.maxIdle {
+		p.open--
+		conn.Close()
+		return
+	}
+	conn.idleSince = p.now()
+	p.idle = append(p.idle, conn)
+}
+
+// dialAny dials the hosts round-robin, trying the hosts which are down last.
+func (p *connPool) dialAny(ctx context.Context) (*pooledConn, error) {
+	hosts, err := p.hosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("ldap: no hosts to connect to")
+	}
+
+	p.mu.Lock()
+	start := p.next % len(hosts)
+	p.next++
+	var up, down []string
+	for i := range hosts {
+		host := hosts[(start+i)%len(hosts)]
+		if since, ok := p.down[host]; ok && p.now().Sub(since) < p.healthCheckInterval {
+			down = append(down, host)
+		} else {
+			up = append(up, host)
+		}
+	}
+	p.mu.Unlock()
+
+	var errs []string
+	for _, host := range append(up, down...) {
+		conn, err := p.dialHost(host)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err.Error())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("ldap: no host reachable: %s", strings.Join(errs, "; "))
+}
+
+func (p *connPool) dialHost(host string) (*pooledConn, error) {
+	conn, err := p.dial(host)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats, ok := p.stats[host]
+	if !ok {
+		stats = new(hostStats)
+		p.stats[host] = stats
+	}
+	stats.dials++
+	if err != nil {
+		stats.dialErrors++
+		p.down[host] = p.now()
+		return nil, fmt.Errorf("%s: %v", host, err)
+	}
+	delete(p.down, host)
+	p.open++
+	return &pooledConn{Conn: conn, host: host}, nil
+}
+
+// check dials every host and returns the error of each host which couldn't
+// be reached. Reachable hosts map to nil.
+func (p *connPool) check(ctx context.Context) (map[string]error, error) {
+	hosts, err := p.hosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]error, len(hosts))
+	for _, host := range hosts {
+		conn, err := p.dialHost(host)
+		results[host] = err
+		if err == nil {
+			p.put(conn, false)
+		}
+	}
+	return results, nil
+}
+
+// close closes the idle connections. Connections in use are closed when
+// they're put back.
+func (p *connPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for _, conn := range p.idle {
+		p.open--
+		conn.Close()
+	}
+	p.idle = nil
+}
+
+// Describe implements prometheus.Collector.
+func (p *connPool) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.openDesc
+	ch <- p.idleDesc
+	ch <- p.reusedDesc
+	ch <- p.dialsDesc
+	ch <- p.dialErrorsDesc
+	ch <- p.hostUpDesc
+}
+
+// Collect implements prometheus.Collector.
+func (p *connPool) Collect(ch chan<- prometheus.Metric) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(p.openDesc, prometheus.GaugeValue, float64(p.open))
+	ch <- prometheus.MustNewConstMetric(p.idleDesc, prometheus.GaugeValue, float64(len(p.idle)))
+	ch <- prometheus.MustNewConstMetric(p.reusedDesc, prometheus.CounterValue, float64(p.reused))
+	for host, stats := range p.stats {
+		ch <- prometheus.MustNewConstMetric(p.dialsDesc, prometheus.CounterValue, float64(stats.dials), host)
+		ch <- prometheus.MustNewConstMetric(p.dialErrorsDesc, prometheus.CounterValue, float64(stats.dialErrors), host)
+		var up float64
+		if _, down := p.down[host]; !down {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(p.hostUpDesc, prometheus.GaugeValue, up, host)
+	}
+}
+
+// srvHosts looks up the hosts of an LDAP service through DNS SRV records,
+// ordered by priority and weight. If port isn't zero it replaces the ports of
+// the records, e.g. to use LDAPS on hosts advertising the plain LDAP port.
+type srvHosts struct {
+	domain string
+	port   uint16
+	lookup func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	now    func() time.Time
+
+	mu      sync.Mutex
+	hosts   []string
+	expires time.Time
+}
+
+func (s *srvHosts) get(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.hosts != nil && s.now().Before(s.expires) {
+		return s.hosts, nil
+	}
+
+	_, records, err := s.lookup(ctx, "ldap", "tcp", s.domain)
+	if err != nil {
+		if s.hosts != nil {
+			// Keep using the hosts from the last successful lookup.
+			return s.hosts, nil
+		}
+		return nil, fmt.Errorf("ldap: lookup SRV records of %q: %v", s.domain, err)
+	}
+
+	hosts := make([]string, 0, len(records))
+	for _, record := range records {
+		port := record.Port
+		if s.port != 0 {
+			port = s.port
+		}
+		hosts = append(hosts, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(port))))
+	}
+	s.hosts, s.expires = hosts, s.now().Add(srvCacheDuration)
+	return hosts, nil
+}
@@ -0,0 +1,314 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeDialer dials connections backed by in-memory pipes, failing for the
+// hosts which are down.
+type fakeDialer struct {
+	down  map[string]bool
+	dials []string
+}
+
+func (d *fakeDialer) dial(host string) (*ldap.Conn, error) {
+	d.dials = append(d.dials, host)
+	if d.down[host] {
+		return nil, errors.New("connection refused")
+	}
+	client, server := net.Pipe()
+	go func() {
+		// Discard everything the client sends until it closes the connection.
+		buf := make([]byte, 512)
+		for {
+			if _, err := server.Read(buf); err != nil {
+				server.Close()
+				return
+			}
+		}
+	}()
+	conn := ldap.NewConn(client, false)
+	conn.Start()
+	return conn, nil
+}
+
+func newTestPool(d *fakeDialer, hosts ...string) (*connPool, *time.Time) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := newConnPool("ldap", d.dial, func(context.Context) ([]string, error) { return hosts, nil })
+	p.maxIdle = 2
+	p.idleTimeout = time.Minute
+	p.healthCheckInterval = 30 * time.Second
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func TestConnPoolRoundRobin(t *testing.T) {
+	d := &fakeDialer{}
+	p, _ := newTestPool(d, "a:636", "b:636", "c:636")
+	p.maxIdle = -1
+	defer p.close()
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		conn, reused, err := p.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reused {
+			t.Errorf("expected a new connection with pooling disabled")
+		}
+		p.put(conn, true)
+	}
+
+	want := "a:636 b:636 c:636 a:636"
+	if got := strings.Join(d.dials, " "); got != want {
+		t.Errorf("expected dials %q, got %q", want, got)
+	}
+	if p.open != 0 {
+		t.Errorf("expected all connections to be closed, got %d open", p.open)
+	}
+}
+
+func TestConnPoolFailover(t *testing.T) {
+	d := &fakeDialer{down: map[string]bool{"a:636": true}}
+	p, now := newTestPool(d, "a:636", "b:636")
+	p.maxIdle = -1
+	defer p.close()
+
+	ctx := context.Background()
+	get := func() {
+		t.Helper()
+		conn, _, err := p.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conn.host != "b:636" {
+			t.Errorf("expected connection to b:636, got %s", conn.host)
+		}
+		p.put(conn, true)
+	}
+
+	// Fails over to b, then skips a while it's down.
+	get()
+	get()
+	get()
+	want := "a:636 b:636 b:636 b:636"
+	if got := strings.Join(d.dials, " "); got != want {
+		t.Errorf("expected dials %q, got %q", want, got)
+	}
+
+	// Tries a again once the health check interval has passed.
+	*now = now.Add(time.Minute)
+	d.down = nil
+	d.dials = nil
+	for i := 0; i < 2; i++ {
+		conn, _, err := p.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.put(conn, true)
+	}
+	if got := strings.Join(d.dials, " "); got != "b:636 a:636" {
+		t.Errorf("expected a:636 to be retried, got dials %q", got)
+	}
+
+	// Fails if no host can be reached.
+	d.down = map[string]bool{"a:636": true, "b:636": true}
+	if _, _, err := p.get(ctx); err == nil {
+		t.Errorf("expected error when no host is reachable")
+	}
+}
+
+func TestConnPoolReuse(t *testing.T) {
+	d := &fakeDialer{}
+	p, now := newTestPool(d, "a:636")
+	defer p.close()
+
+	ctx := context.Background()
+	var conns []*pooledConn
+	for i := 0; i < 3; i++ {
+		conn, _, err := p.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		p.put(conn, true)
+	}
+	if len(p.idle) != 2 || p.open != 2 {
+		t.Fatalf("expected 2 idle and open connections, got %d idle and %d open", len(p.idle), p.open)
+	}
+
+	conn, reused, err := p.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reused || conn != conns[1] {
+		t.Errorf("expected the most recently returned idle connection")
+	}
+
+	// Connections which failed aren't reused.
+	p.put(conn, false)
+	if len(p.idle) != 1 || p.open != 1 {
+		t.Errorf("expected 1 idle and open connection, got %d idle and %d open", len(p.idle), p.open)
+	}
+
+	// Expired idle connections are closed.
+	*now = now.Add(2 * time.Minute)
+	if _, reused, err = p.get(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if reused {
+		t.Errorf("expected expired idle connection not to be reused")
+	}
+	if len(d.dials) != 4 {
+		t.Errorf("expected 4 dials, got %d", len(d.dials))
+	}
+}
+
+func TestDoRetriesWrappedNetworkError(t *testing.T) {
+	d := &fakeDialer{}
+	p, _ := newTestPool(d, "a:636")
+	defer p.close()
+	c := &ldapConnector{pool: p, logger: &logrus.Logger{Out: io.Discard, Formatter: &logrus.TextFormatter{}}}
+
+	ctx := context.Background()
+	conn, _, err := p.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.put(conn, true)
+
+	calls := 0
+	err = c.do(ctx, func(conn *ldap.Conn) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("ldap: search failed: %w", ldap.NewError(ldap.ErrorNetwork, errors.New("connection closed")))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 || len(d.dials) != 2 {
+		t.Errorf("expected the operation to be retried on a new connection, got %d calls and %d dials", calls, len(d.dials))
+	}
+
+	// Other errors aren't retried.
+	calls = 0
+	err = c.do(ctx, func(conn *ldap.Conn) error {
+		calls++
+		return fmt.Errorf("ldap: search failed: %w", ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object")))
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected the operation to fail without a retry, got %d calls (%v)", calls, err)
+	}
+}
+
+func TestConnPoolMetrics(t *testing.T) {
+	d := &fakeDialer{down: map[string]bool{"b:636": true}}
+	p, _ := newTestPool(d, "a:636", "b:636")
+	defer p.close()
+
+	results, err := p.check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["a:636"] != nil || results["b:636"] == nil {
+		t.Errorf("unexpected health check results %v", results)
+	}
+
+	conn, _, err := p.get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.put(conn, true)
+
+	want := `
+# HELP dex_ldap_host_up Whether the last connection to an LDAP server succeeded.
+# TYPE dex_ldap_host_up gauge
+dex_ldap_host_up{connector="ldap",host="a:636"} 1
+dex_ldap_host_up{connector="ldap",host="b:636"} 0
+# HELP dex_ldap_pool_dial_errors_total Connections to an LDAP server which failed to dial or bind.
+# TYPE dex_ldap_pool_dial_errors_total counter
+dex_ldap_pool_dial_errors_total{connector="ldap",host="a:636"} 0
+dex_ldap_pool_dial_errors_total{connector="ldap",host="b:636"} 1
+# HELP dex_ldap_pool_dials_total Connections dialed to an LDAP server.
+# TYPE dex_ldap_pool_dials_total counter
+dex_ldap_pool_dials_total{connector="ldap",host="a:636"} 2
+dex_ldap_pool_dials_total{connector="ldap",host="b:636"} 1
+# HELP dex_ldap_pool_idle_connections Idle connections to LDAP servers kept open for reuse.
+# TYPE dex_ldap_pool_idle_connections gauge
+dex_ldap_pool_idle_connections{connector="ldap"} 1
+# HELP dex_ldap_pool_open_connections Connections to LDAP servers currently open, both idle and in use.
+# TYPE dex_ldap_pool_open_connections gauge
+dex_ldap_pool_open_connections{connector="ldap"} 1
+# HELP dex_ldap_pool_reused_connections_total Operations which reused an idle connection.
+# TYPE dex_ldap_pool_reused_connections_total counter
+dex_ldap_pool_reused_connections_total{connector="ldap"} 0
+`
+	if err := testutil.CollectAndCompare(p, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSRVHosts(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	lookups := 0
+	var lookupErr error
+	s := &srvHosts{
+		domain: "example.com",
+		port:   636,
+		lookup: func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			lookups++
+			if service != "ldap" || proto != "tcp" || name != "example.com" {
+				t.Errorf("unexpected lookup of _%s._%s.%s", service, proto, name)
+			}
+			if lookupErr != nil {
+				return "", nil, lookupErr
+			}
+			return "", []*net.SRV{
+				{Target: "dc1.example.com.", Port: 389},
+				{Target: "dc2.example.com.", Port: 389},
+			}, nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	want := "dc1.example.com:636 dc2.example.com:636"
+	for i := 0; i < 2; i++ {
+		hosts, err := s.get(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(hosts, " "); got != want {
+			t.Errorf("expected hosts %q, got %q", want, got)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected hosts to be cached, got %d lookups", lookups)
+	}
+
+	// Keeps the last hosts if the lookup fails.
+	now = now.Add(2 * srvCacheDuration)
+	lookupErr = errors.New("no such host")
+	hosts, err := s.get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(hosts, " "); got != want || lookups != 2 {
+		t.Errorf("expected the last hosts %q after %d lookups, got %q after %d", want, 2, got, lookups)
+	}
+}
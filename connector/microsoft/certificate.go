@@ -0,0 +1,34 @@
+package microsoft
+
+import (
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/pkg/clientassertion"
+)
+
+// Instead of a client secret, applications can authenticate with a JWT signed
+// with the private key of a certificate registered with the application.
+//
+// See: https://learn.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func newCertificateSigner(certFile, keyFile, clientID, tokenURL string) (*clientassertion.Signer, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %v", err)
+	}
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("client key must be an RSA private key")
+	}
+	// The certificate is identified by its SHA-1 thumbprint.
+	thumbprint := sha1.Sum(pair.Certificate[0])
+	return clientassertion.NewSigner(clientID, tokenURL,
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("x5t", base64.RawURLEncoding.EncodeToString(thumbprint[:])))
+}
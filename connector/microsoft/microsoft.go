@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/clientassertion"
 	groups_pkg "github.com/dexidp/dex/pkg/groups"
 	"github.com/dexidp/dex/pkg/log"
 )
@@ -57,6 +59,22 @@ type Config struct {
 	// PromptType is used for the prompt query parameter.
 	// For valid values, see https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow#request-an-authorization-code.
 	PromptType string `json:"promptType"`
+
+	// ClientCertificate and ClientKey are the PEM files of a certificate
+	// registered with the application and of its RSA private key. If they're
+	// set, the connector authenticates with a JWT signed by the key instead of
+	// the client secret.
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+
+	// UseTransitiveMemberOf lists the groups of the user with
+	// transitiveMemberOf instead of getMemberGroups. The memberships are listed
+	// with the names of the groups, so they don't have to be looked up.
+	UseTransitiveMemberOf bool `json:"useTransitiveMemberOf"`
+
+	// AppRoles adds the app roles of the application assigned to the user to
+	// the groups, by their value, or by their ID if groupNameFormat is "id".
+	AppRoles bool `json:"appRoles"`
 }
 
 // Open returns a strategy for logging in through Microsoft.
@@ -75,6 +93,8 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		logger:               logger,
 		emailToLowercase:     c.EmailToLowercase,
 		promptType:           c.PromptType,
+		transitiveMemberOf:   c.UseTransitiveMemberOf,
+		appRoles:             c.AppRoles,
 	}
 	// By default allow logins from both personal and business/school
 	// accounts.
@@ -91,6 +111,18 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		return nil, fmt.Errorf("invalid groupNameFormat: %s", m.groupNameFormat)
 	}
 
+	if c.ClientCertificate != "" || c.ClientKey != "" {
+		if c.ClientCertificate == "" || c.ClientKey == "" {
+			return nil, errors.New("clientCertificate and clientKey must be set together")
+		}
+		if c.ClientSecret != "" {
+			return nil, errors.New("clientSecret and clientCertificate are mutually exclusive")
+		}
+		if err := m.useCertificate(c.ClientCertificate, c.ClientKey); err != nil {
+			return nil, err
+		}
+	}
+
 	return &m, nil
 }
 
@@ -119,6 +151,34 @@ type microsoftConnector struct {
 	logger               log.Logger
 	emailToLowercase     bool
 	promptType           string
+	transitiveMemberOf   bool
+	appRoles             bool
+	// Authenticates requests to the token endpoint with a certificate.
+	httpClient *http.Client
+}
+
+// useCertificate authenticates the connector with a certificate instead of the
+// client secret.
+func (c *microsoftConnector) useCertificate(certFile, keyFile string) error {
+	signer, err := newCertificateSigner(certFile, keyFile, c.clientID, c.tokenURL())
+	if err != nil {
+		return err
+	}
+	c.httpClient = &http.Client{Transport: &clientassertion.Transport{Signer: signer}}
+	return nil
+}
+
+func (c *microsoftConnector) tokenURL() string {
+	return c.apiURL + "/" + c.tenant + "/oauth2/v2.0/token"
+}
+
+// clientContext returns a context for the requests to the token endpoint and
+// the Graph API.
+func (c *microsoftConnector) clientContext(ctx context.Context) context.Context {
+	if c.httpClient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
 }
 
 func (c *microsoftConnector) isOrgTenant() bool {
@@ -139,15 +199,22 @@ func (c *microsoftConnector) oauth2Config(scopes connector.Scopes) *oauth2.Confi
 		microsoftScopes = append(microsoftScopes, scopeOfflineAccess)
 	}
 
+	endpoint := oauth2.Endpoint{
+		AuthURL:  c.apiURL + "/" + c.tenant + "/oauth2/v2.0/authorize",
+		TokenURL: c.tokenURL(),
+	}
+	if c.httpClient != nil {
+		// The client is authenticated with the parameters added by the
+		// transport.
+		endpoint.AuthStyle = oauth2.AuthStyleInParams
+	}
+
 	return &oauth2.Config{
 		ClientID:     c.clientID,
 		ClientSecret: c.clientSecret,
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  c.apiURL + "/" + c.tenant + "/oauth2/v2.0/authorize",
-			TokenURL: c.apiURL + "/" + c.tenant + "/oauth2/v2.0/token",
-		},
-		Scopes:      microsoftScopes,
-		RedirectURL: c.redirectURI,
+		Endpoint:     endpoint,
+		Scopes:       microsoftScopes,
+		RedirectURL:  c.redirectURI,
 	}
 }
 
@@ -172,7 +239,7 @@ func (c *microsoftConnector) HandleCallback(s connector.Scopes, r *http.Request)
 
 	oauth2Config := c.oauth2Config(s)
 
-	ctx := r.Context()
+	ctx := c.clientContext(r.Context())
 
 	token, err := oauth2Config.Exchange(ctx, q.Get("code"))
 	if err != nil {
@@ -263,6 +330,7 @@ func (c *microsoftConnector) Refresh(ctx context.Context, s connector.Scopes, id
 		Expiry:       data.Expiry,
 	}
 
+	ctx = c.clientContext(ctx)
 	client := oauth2.NewClient(ctx, &notifyRefreshTokenSource{
 		new: c.oauth2Config(s).TokenSource(ctx, tok),
 		t:   tok,
@@ -356,16 +424,35 @@ type group struct {
 }
 
 func (c *microsoftConnector) getGroups(ctx context.Context, client *http.Client, userID string) ([]string, error) {
-	userGroups, err := c.getGroupIDs(ctx, client)
-	if err != nil {
-		return nil, err
+	var (
+		userGroups []string
+		err        error
+	)
+	if c.transitiveMemberOf {
+		userGroups, err = c.getTransitiveGroups(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		userGroups, err = c.getGroupIDs(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.groupNameFormat == GroupName {
+			userGroups, err = c.getGroupNames(ctx, client, userGroups)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	if c.groupNameFormat == GroupName {
-		userGroups, err = c.getGroupNames(ctx, client, userGroups)
+	if c.appRoles {
+		roles, err := c.getAppRoles(ctx, client)
 		if err != nil {
 			return nil, err
 		}
+		userGroups = append(userGroups, roles...)
 	}
 
 	// ensure that the user is in at least one required group
@@ -432,6 +519,140 @@ func (c *microsoftConnector) getGroupNames(ctx context.Context, client *http.Cli
 	}
 }
 
+// https://learn.microsoft.com/en-us/graph/api/resources/group
+// securityEnabled - Specifies whether the group is a security group.
+type memberGroup struct {
+	ID              string `json:"id"`
+	Name            string `json:"displayName"`
+	SecurityEnabled bool   `json:"securityEnabled"`
+}
+
+func (c *microsoftConnector) getTransitiveGroups(ctx context.Context, client *http.Client) (groups []string, err error) {
+	// https://learn.microsoft.com/en-us/graph/api/user-list-transitivememberof
+	// Casting to microsoft.graph.group leaves out directory roles and
+	// administrative units.
+	reqURL := c.graphURL + "/v1.0/me/transitiveMemberOf/microsoft.graph.group?$select=id,displayName,securityEnabled"
+	for {
+		var out []memberGroup
+		var next string
+
+		next, err = c.get(ctx, client, reqURL, &out)
+		if err != nil {
+			return groups, err
+		}
+
+		for _, g := range out {
+			if c.onlySecurityGroups && !g.SecurityEnabled {
+				continue
+			}
+			if c.groupNameFormat == GroupID {
+				groups = append(groups, g.ID)
+			} else {
+				groups = append(groups, g.Name)
+			}
+		}
+		if next == "" {
+			return
+		}
+		reqURL = next
+	}
+}
+
+// https://learn.microsoft.com/en-us/graph/api/resources/serviceprincipal
+// appRoles - The roles exposed by the application of the service principal.
+type servicePrincipal struct {
+	ID       string `json:"id"`
+	AppRoles []struct {
+		ID    string `json:"id"`
+		Value string `json:"value"`
+	} `json:"appRoles"`
+}
+
+// https://learn.microsoft.com/en-us/graph/api/resources/approleassignment
+type appRoleAssignment struct {
+	AppRoleID  string `json:"appRoleId"`
+	ResourceID string `json:"resourceId"`
+}
+
+func (c *microsoftConnector) getAppRoles(ctx context.Context, client *http.Client) (roles []string, err error) {
+	// https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list
+	// The service principal of the application in the tenant of the user
+	// defines the roles.
+	var sps []servicePrincipal
+	reqURL := c.graphURL + "/v1.0/servicePrincipals?$filter=" + url.QueryEscape("appId eq '"+c.clientID+"'") + "&$select=id,appRoles"
+	if _, err := c.get(ctx, client, reqURL, &sps); err != nil {
+		return nil, fmt.Errorf("get service principal: %v", err)
+	}
+	if len(sps) != 1 {
+		return nil, fmt.Errorf("expected one service principal for the application, got %d", len(sps))
+	}
+	sp := sps[0]
+	roleValues := make(map[string]string, len(sp.AppRoles))
+	for _, role := range sp.AppRoles {
+		roleValues[role.ID] = role.Value
+	}
+
+	// https://learn.microsoft.com/en-us/graph/api/user-list-approleassignments
+	reqURL = c.graphURL + "/v1.0/me/appRoleAssignments?$select=appRoleId,resourceId"
+	for {
+		var out []appRoleAssignment
+		var next string
+
+		next, err = c.get(ctx, client, reqURL, &out)
+		if err != nil {
+			return roles, err
+		}
+
+		for _, a := range out {
+			if a.ResourceID != sp.ID {
+				continue
+			}
+			// Assignments without a role, to the default access role, have no
+			// value.
+			value, ok := roleValues[a.AppRoleID]
+			if !ok || value == "" {
+				continue
+			}
+			if c.groupNameFormat == GroupID {
+				roles = append(roles, a.AppRoleID)
+			} else {
+				roles = append(roles, value)
+			}
+		}
+		if next == "" {
+			return
+		}
+		reqURL = next
+	}
+}
+
+func (c *microsoftConnector) get(ctx context.Context, client *http.Client, reqURL string, out interface{}) (string, error) {
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("new req: %v", err)
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("get URL %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", newGraphError(resp.Body)
+	}
+
+	var next string
+	if err = json.NewDecoder(resp.Body).Decode(&struct {
+		NextLink *string     `json:"@odata.nextLink"`
+		Value    interface{} `json:"value"`
+	}{&next, out}); err != nil {
+		return "", fmt.Errorf("JSON decode: %v", err)
+	}
+
+	return next, nil
+}
+
 func (c *microsoftConnector) post(ctx context.Context, client *http.Client, reqURL string, in interface{}, out interface{}) (string, error) {
 	var payload bytes.Buffer
 
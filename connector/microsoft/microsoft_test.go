@@ -1,19 +1,35 @@
 package microsoft
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/clientassertion"
 )
 
 type testResponse struct {
 	data interface{}
+	// check, if set, rejects the request if it returns an error.
+	check func(r *http.Request) error
 }
 
 const tenant = "9b1c3439-a67e-4e92-bb0d-0571d44ca965"
@@ -63,6 +79,226 @@ func TestUserGroupsFromGraphAPI(t *testing.T) {
 	expectEquals(t, identity.Groups, []string{"a", "b"})
 }
 
+func TestTransitiveGroupsFromGraphAPI(t *testing.T) {
+	responses := map[string]testResponse{
+		"/v1.0/me?$select=id,displayName,userPrincipalName": {data: user{}},
+		"/" + tenant + "/oauth2/v2.0/token":                 dummyToken,
+	}
+	s := newTestServer(responses)
+	defer s.Close()
+
+	responses["/v1.0/me/transitiveMemberOf/microsoft.graph.group?$select=id,displayName,securityEnabled"] = testResponse{data: map[string]interface{}{
+		"@odata.nextLink": s.URL + "/v1.0/me/transitiveMemberOf/microsoft.graph.group?$select=id,displayName,securityEnabled&$skiptoken=page2",
+		"value": []memberGroup{
+			{ID: "1", Name: "admins", SecurityEnabled: true},
+			{ID: "2", Name: "newsletter"},
+		},
+	}}
+	responses["/v1.0/me/transitiveMemberOf/microsoft.graph.group?$select=id,displayName,securityEnabled&$skiptoken=page2"] = testResponse{data: map[string]interface{}{
+		"value": []memberGroup{{ID: "3", Name: "developers", SecurityEnabled: true}},
+	}}
+
+	tests := []struct {
+		name               string
+		groupNameFormat    GroupNameFormat
+		onlySecurityGroups bool
+		expected           []string
+	}{
+		{name: "names", groupNameFormat: GroupName, expected: []string{"admins", "newsletter", "developers"}},
+		{name: "ids", groupNameFormat: GroupID, expected: []string{"1", "2", "3"}},
+		{name: "security groups", groupNameFormat: GroupName, onlySecurityGroups: true, expected: []string{"admins", "developers"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", s.URL, nil)
+
+			c := microsoftConnector{
+				apiURL:             s.URL,
+				graphURL:           s.URL,
+				tenant:             tenant,
+				transitiveMemberOf: true,
+				groupNameFormat:    tc.groupNameFormat,
+				onlySecurityGroups: tc.onlySecurityGroups,
+			}
+			identity, err := c.HandleCallback(connector.Scopes{Groups: true}, req)
+			expectNil(t, err)
+			expectEquals(t, identity.Groups, tc.expected)
+		})
+	}
+}
+
+func TestAppRolesFromGraphAPI(t *testing.T) {
+	const (
+		clientID      = "4d4bc57c-3bd0-4a5a-a7c3-6e7a3ba3b0d1"
+		principalID   = "e4a3a0c5-d2d6-4b43-9c63-02d3e5c1c4a1"
+		adminRoleID   = "7d2b0f7e-2c6a-4f1b-8d0b-0a6f4c7d9e11"
+		defaultRoleID = "00000000-0000-0000-0000-000000000000"
+	)
+
+	responses := map[string]testResponse{
+		"/v1.0/me?$select=id,displayName,userPrincipalName": {data: user{}},
+		"/v1.0/me/getMemberGroups": {data: map[string]interface{}{
+			"value": []string{"a"},
+		}},
+		"/v1.0/directoryObjects/getByIds": {data: map[string]interface{}{
+			"value": []group{{Name: "group-a"}},
+		}},
+		"/v1.0/servicePrincipals?$filter=appId+eq+%27" + clientID + "%27&$select=id,appRoles": {data: map[string]interface{}{
+			"value": []map[string]interface{}{{
+				"id": principalID,
+				"appRoles": []map[string]interface{}{
+					{"id": adminRoleID, "value": "Admin"},
+					{"id": "9a1f0b52-55d4-4d5e-8f5b-3c2a1e7b6d22", "value": "Reader"},
+				},
+			}},
+		}},
+		"/" + tenant + "/oauth2/v2.0/token": dummyToken,
+	}
+	s := newTestServer(responses)
+	defer s.Close()
+
+	responses["/v1.0/me/appRoleAssignments?$select=appRoleId,resourceId"] = testResponse{data: map[string]interface{}{
+		"@odata.nextLink": s.URL + "/v1.0/me/appRoleAssignments?$select=appRoleId,resourceId&$skiptoken=page2",
+		"value": []appRoleAssignment{
+			{AppRoleID: adminRoleID, ResourceID: principalID},
+			// Assigned to another application.
+			{AppRoleID: "5b0e1c9a-8d3f-4e2a-b6c7-1f9d8e7a6b33", ResourceID: "c0ffee00-0000-4000-8000-000000000000"},
+		},
+	}}
+	responses["/v1.0/me/appRoleAssignments?$select=appRoleId,resourceId&$skiptoken=page2"] = testResponse{data: map[string]interface{}{
+		"value": []appRoleAssignment{{AppRoleID: defaultRoleID, ResourceID: principalID}},
+	}}
+
+	tests := []struct {
+		name            string
+		groupNameFormat GroupNameFormat
+		expected        []string
+	}{
+		{name: "values", groupNameFormat: GroupName, expected: []string{"group-a", "Admin"}},
+		{name: "ids", groupNameFormat: GroupID, expected: []string{"a", adminRoleID}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", s.URL, nil)
+
+			c := microsoftConnector{
+				apiURL:          s.URL,
+				graphURL:        s.URL,
+				tenant:          tenant,
+				clientID:        clientID,
+				appRoles:        true,
+				groupNameFormat: tc.groupNameFormat,
+			}
+			identity, err := c.HandleCallback(connector.Scopes{Groups: true}, req)
+			expectNil(t, err)
+			expectEquals(t, identity.Groups, tc.expected)
+		})
+	}
+}
+
+func TestCertificateCredentials(t *testing.T) {
+	const clientID = "4d4bc57c-3bd0-4a5a-a7c3-6e7a3ba3b0d1"
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	expectNil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dex"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	expectNil(t, err)
+	thumbprint := sha1.Sum(der)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	expectNil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	expectNil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+
+	var (
+		s          *httptest.Server
+		grantTypes []string
+	)
+	checkAssertion := func(r *http.Request) error {
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		if _, _, ok := r.BasicAuth(); ok || r.PostForm.Get("client_secret") != "" {
+			return errors.New("unexpected client secret")
+		}
+		if got := r.PostForm.Get("client_assertion_type"); got != clientassertion.Type {
+			return fmt.Errorf("unexpected client_assertion_type %q", got)
+		}
+		tok, err := jwt.ParseSigned(r.PostForm.Get("client_assertion"))
+		if err != nil {
+			return err
+		}
+		if got := tok.Headers[0].ExtraHeaders["x5t"]; got != base64.RawURLEncoding.EncodeToString(thumbprint[:]) {
+			return fmt.Errorf("unexpected x5t header %v", got)
+		}
+		var claims jwt.Claims
+		if err := tok.Claims(&key.PublicKey, &claims); err != nil {
+			return err
+		}
+		if err := claims.Validate(jwt.Expected{
+			Issuer:   clientID,
+			Subject:  clientID,
+			Audience: jwt.Audience{s.URL + "/" + tenant + "/oauth2/v2.0/token"},
+			Time:     time.Now(),
+		}); err != nil {
+			return err
+		}
+		grantTypes = append(grantTypes, r.PostForm.Get("grant_type"))
+		return nil
+	}
+
+	s = newTestServer(map[string]testResponse{
+		"/v1.0/me?$select=id,displayName,userPrincipalName": {
+			data: user{ID: "S56767889", Name: "Jane Doe", Email: "jane.doe@example.com"},
+		},
+		"/" + tenant + "/oauth2/v2.0/token": {data: dummyToken.data, check: checkAssertion},
+	})
+	defer s.Close()
+
+	c := microsoftConnector{apiURL: s.URL, graphURL: s.URL, tenant: tenant, clientID: clientID}
+	expectNil(t, c.useCertificate(certFile, keyFile))
+
+	req, _ := http.NewRequest("GET", s.URL+"?code=code", nil)
+	identity, err := c.HandleCallback(connector.Scopes{}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.UserID, "S56767889")
+
+	data, err := json.Marshal(connectorData{
+		AccessToken:  "expired",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	})
+	expectNil(t, err)
+	identity.ConnectorData = data
+
+	_, err = c.Refresh(req.Context(), connector.Scopes{}, identity)
+	expectNil(t, err)
+	expectEquals(t, grantTypes, []string{"authorization_code", "refresh_token"})
+}
+
+func TestOpenCertificateErrors(t *testing.T) {
+	tests := map[string]Config{
+		"missing key":         {ClientID: "id", ClientCertificate: "client.crt"},
+		"missing certificate": {ClientID: "id", ClientKey: "client.key"},
+		"with secret":         {ClientID: "id", ClientSecret: "secret", ClientCertificate: "client.crt", ClientKey: "client.key"},
+		"missing files":       {ClientID: "id", ClientCertificate: "client.crt", ClientKey: "client.key"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := c.Open("microsoft", nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
 func newTestServer(responses map[string]testResponse) *httptest.Server {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response, found := responses[r.RequestURI]
@@ -71,6 +307,12 @@ func newTestServer(responses map[string]testResponse) *httptest.Server {
 			http.NotFound(w, r)
 			return
 		}
+		if response.check != nil {
+			if err := response.check(r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
 		w.Header().Add("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response.data)
 	}))
@@ -17,6 +17,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/claims"
 	"github.com/dexidp/dex/pkg/log"
 )
 
@@ -34,6 +35,7 @@ type oauthConnector struct {
 	emailKey             string
 	emailVerifiedKey     string
 	groupsKey            string
+	claimMapper          *claims.Mapper
 	httpClient           *http.Client
 	logger               log.Logger
 }
@@ -60,6 +62,11 @@ type Config struct {
 		EmailKey             string `json:"emailKey"`             // defaults to "email"
 		EmailVerifiedKey     string `json:"emailVerifiedKey"`     // defaults to "email_verified"
 	} `json:"claimMapping"`
+
+	// ClaimExpressions maps the userinfo claims, merged with the claims of the
+	// access token if it's a JWT, to the identity with selectors. Fields
+	// without a selector use the keys configured above.
+	ClaimExpressions *claims.Mapping `json:"claimExpressions"`
 }
 
 func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
@@ -112,6 +119,20 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		emailVerifiedKey:     emailVerifiedKey,
 	}
 
+	if c.ClaimExpressions != nil {
+		oauthConn.claimMapper, err = c.ClaimExpressions.Compile(claims.Mapping{
+			UserID:            claims.Keys(userIDKey),
+			Username:          claims.Keys(userNameKey),
+			PreferredUsername: claims.Keys(preferredUsernameKey),
+			Email:             claims.Keys(emailKey),
+			EmailVerified:     claims.Keys(emailVerifiedKey),
+			Groups:            claims.Keys(groupsKey),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid claimExpressions: %v", err)
+		}
+	}
+
 	oauthConn.httpClient, err = newHTTPClient(c.RootCAs, c.InsecureSkipVerify)
 	if err != nil {
 		return nil, err
@@ -209,25 +230,37 @@ func (c *oauthConnector) HandleCallback(s connector.Scopes, r *http.Request) (id
 		return identity, fmt.Errorf("OAuth Connector: failed to parse userinfo: %v", err)
 	}
 
-	userID, found := userInfoResult[c.userIDKey].(string)
-	if !found {
-		return identity, fmt.Errorf("OAuth Connector: not found %v claim", c.userIDKey)
-	}
+	if c.claimMapper != nil {
+		// Access tokens which aren't JWTs don't have claims.
+		tokenClaims, _ := decodeTokenClaims(token.AccessToken)
+		identity, err = c.claimMapper.Identity(claims.Merge(tokenClaims, userInfoResult))
+		if err != nil {
+			return identity, fmt.Errorf("OAuth Connector: %v", err)
+		}
+		if !s.Groups {
+			identity.Groups = nil
+		}
+	} else {
+		userID, found := userInfoResult[c.userIDKey].(string)
+		if !found {
+			return identity, fmt.Errorf("OAuth Connector: not found %v claim", c.userIDKey)
+		}
 
-	identity.UserID = userID
-	identity.Username, _ = userInfoResult[c.userNameKey].(string)
-	identity.PreferredUsername, _ = userInfoResult[c.preferredUsernameKey].(string)
-	identity.Email, _ = userInfoResult[c.emailKey].(string)
-	identity.EmailVerified, _ = userInfoResult[c.emailVerifiedKey].(bool)
+		identity.UserID = userID
+		identity.Username, _ = userInfoResult[c.userNameKey].(string)
+		identity.PreferredUsername, _ = userInfoResult[c.preferredUsernameKey].(string)
+		identity.Email, _ = userInfoResult[c.emailKey].(string)
+		identity.EmailVerified, _ = userInfoResult[c.emailVerifiedKey].(bool)
 
-	if s.Groups {
-		groups := map[string]struct{}{}
+		if s.Groups {
+			groups := map[string]struct{}{}
 
-		c.addGroupsFromMap(groups, userInfoResult)
-		c.addGroupsFromToken(groups, token.AccessToken)
+			c.addGroupsFromMap(groups, userInfoResult)
+			c.addGroupsFromToken(groups, token.AccessToken)
 
-		for groupName := range groups {
-			identity.Groups = append(identity.Groups, groupName)
+			for groupName := range groups {
+				identity.Groups = append(identity.Groups, groupName)
+			}
 		}
 	}
 
@@ -264,23 +297,32 @@ func (c *oauthConnector) addGroupsFromMap(groups map[string]struct{}, result map
 }
 
 func (c *oauthConnector) addGroupsFromToken(groups map[string]struct{}, token string) error {
+	claimsMap, err := decodeTokenClaims(token)
+	if err != nil {
+		return err
+	}
+
+	return c.addGroupsFromMap(groups, claimsMap)
+}
+
+func decodeTokenClaims(token string) (map[string]interface{}, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) < 2 {
-		return errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
 	decoded, err := decode(parts[1])
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var claimsMap map[string]interface{}
 	err = json.Unmarshal(decoded, &claimsMap)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return c.addGroupsFromMap(groups, claimsMap)
+	return claimsMap, nil
 }
 
 func decode(seg string) ([]byte, error) {
@@ -17,6 +17,7 @@ import (
 	jose "gopkg.in/square/go-jose.v2"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/claims"
 )
 
 func TestOpen(t *testing.T) {
@@ -168,6 +169,59 @@ func TestHandleCallBackForGroupsInToken(t *testing.T) {
 	assert.Equal(t, identity.EmailVerified, false)
 }
 
+func TestHandleCallBackWithClaimExpressions(t *testing.T) {
+	tokenClaims := map[string]interface{}{
+		"realm_access": map[string]interface{}{"roles": []string{"token-role"}},
+	}
+
+	userInfoClaims := map[string]interface{}{
+		"id":   12345,
+		"name": "test-name",
+		"profile": map[string]interface{}{
+			"login":  "test-login",
+			"emails": []map[string]interface{}{{"value": "test-email", "verified": true}},
+		},
+		"https://example.com/groups": []string{"admin-group"},
+	}
+
+	testServer := testSetup(t, tokenClaims, userInfoClaims)
+	defer testServer.Close()
+
+	conn := newConnectorWithClaimExpressions(t, testServer.URL, &claims.Mapping{
+		UserID:            claims.Paths("id"),
+		Username:          claims.Paths("name"),
+		PreferredUsername: &claims.Selector{Template: "{{ .profile.login }}@example.com"},
+		Email:             claims.Paths("profile.emails[0].value"),
+		EmailVerified:     claims.Paths("profile.emails[0].verified"),
+		Groups:            claims.Paths(`["https://example.com/groups"]`),
+	})
+	req := newRequestWithAuthCode(t, testServer.URL, "some-code")
+
+	identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	assert.Equal(t, err, nil)
+
+	assert.Equal(t, identity.UserID, "12345")
+	assert.Equal(t, identity.Username, "test-name")
+	assert.Equal(t, identity.PreferredUsername, "test-login@example.com")
+	assert.Equal(t, identity.Email, "test-email")
+	assert.Equal(t, identity.EmailVerified, true)
+	assert.Equal(t, identity.Groups, []string{"admin-group"})
+
+	// Claims of the access token are merged with the userinfo claims.
+	conn = newConnectorWithClaimExpressions(t, testServer.URL, &claims.Mapping{
+		UserID: claims.Paths("id"),
+		Groups: claims.Paths("realm_access.roles"),
+	})
+
+	identity, err = conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, identity.Groups, []string{"token-role"})
+
+	identity, err = conn.HandleCallback(connector.Scopes{}, req)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(identity.Groups), 0)
+}
+
 func testSetup(t *testing.T, tokenClaims map[string]interface{}, userInfoClaims map[string]interface{}) *httptest.Server {
 	key, err := rsa.GenerateKey(rand.Reader, 1024)
 	if err != nil {
@@ -226,6 +280,10 @@ func newToken(key *jose.JSONWebKey, claims map[string]interface{}) (string, erro
 }
 
 func newConnector(t *testing.T, serverURL string) *oauthConnector {
+	return newConnectorWithClaimExpressions(t, serverURL, nil)
+}
+
+func newConnectorWithClaimExpressions(t *testing.T, serverURL string, expressions *claims.Mapping) *oauthConnector {
 	testConfig := Config{
 		ClientID:         "testClient",
 		ClientSecret:     "testSecret",
@@ -241,6 +299,7 @@ func newConnector(t *testing.T, serverURL string) *oauthConnector {
 	testConfig.ClaimMapping.GroupsKey = "groups_key"
 	testConfig.ClaimMapping.EmailKey = "mail"
 	testConfig.ClaimMapping.EmailVerifiedKey = "has_verified_email"
+	testConfig.ClaimExpressions = expressions
 
 	log := logrus.New()
 
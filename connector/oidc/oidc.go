@@ -3,6 +3,9 @@ package oidc
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +18,8 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/claims"
+	"github.com/dexidp/dex/pkg/clientassertion"
 	"github.com/dexidp/dex/pkg/log"
 )
 
@@ -32,6 +37,17 @@ type Config struct {
 	// https://tools.ietf.org/html/rfc6749#section-2.3.1
 	BasicAuthUnsupported *bool `json:"basicAuthUnsupported"`
 
+	// PrivateKeyJWT authenticates to the token endpoint with a JWT signed by a
+	// private key, instead of the client secret.
+	PrivateKeyJWT *PrivateKeyJWTConfig `json:"privateKeyJWT"`
+
+	// PKCEChallenge is the method of the PKCE code challenges sent with the
+	// authentication requests. Only "S256" is supported, PKCE isn't used if it's
+	// empty. The code verifier is kept with the auth request.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc7636
+	PKCEChallenge string `json:"pkceChallenge"`
+
 	Scopes []string `json:"scopes"` // defaults to "profile" and "email"
 
 	// Optional list of whitelisted domains when using Google
@@ -51,7 +67,8 @@ type Config struct {
 
 	// GetUserInfo uses the userinfo endpoint to get additional claims for
 	// the token. This is especially useful where upstreams return "thin"
-	// id tokens
+	// id tokens. The claims are fetched again on refresh, which also allows
+	// refresh responses without an ID token.
 	GetUserInfo bool `json:"getUserInfo"`
 
 	UserIDKey string `json:"userIDKey"`
@@ -76,6 +93,70 @@ type Config struct {
 		// Configurable key which contains the groups claims
 		GroupsKey string `json:"groups"` // defaults to "groups"
 	} `json:"claimMapping"`
+
+	// ClaimExpressions maps the claims of the ID token, merged with the
+	// userinfo claims if getUserInfo is set, to the identity with selectors,
+	// e.g. "realm_access.roles" for nested claims. Fields without a selector
+	// use the claims configured above, and fail like without expressions if
+	// the name, or the email and email_verified claims with the email scope,
+	// are missing. Groups are also mapped without insecureEnableGroups if a
+	// selector is configured.
+	ClaimExpressions *claims.Mapping `json:"claimExpressions"`
+}
+
+// claimKeys returns a selector for the standard claim, falling back to the
+// configured claim, or only for the configured claim if overrideClaimMapping
+// is set.
+func (c *Config) claimKeys(standard, configured string) *claims.Selector {
+	if configured != "" && c.OverrideClaimMapping {
+		return claims.Keys(configured)
+	}
+	return claims.Keys(standard, configured)
+}
+
+// defaultClaimMapping returns the mapping of the claims without expressions.
+// Like without expressions, the name is required, and so are the email and
+// whether it's verified if the email scope is requested.
+func (c *Config) defaultClaimMapping() claims.Mapping {
+	m := claims.Mapping{
+		UserID:            claims.Keys("sub"),
+		Username:          claims.Keys("name"),
+		PreferredUsername: c.claimKeys("preferred_username", c.ClaimMapping.PreferredUsernameKey),
+		Email:             c.claimKeys("email", c.ClaimMapping.EmailKey),
+		EmailVerified:     claims.Keys("email_verified"),
+	}
+	if c.UserIDKey != "" {
+		m.UserID = claims.Keys(c.UserIDKey)
+	}
+	if c.UserNameKey != "" {
+		m.Username = claims.Keys(c.UserNameKey)
+	}
+	m.Username.Required = true
+	if c.hasEmailScope() {
+		m.Email.Required = true
+		m.EmailVerified.Required = true
+	}
+	if c.InsecureSkipEmailVerified {
+		m.EmailVerified.Value = true
+	}
+	if c.InsecureEnableGroups {
+		m.Groups = c.claimKeys("groups", c.ClaimMapping.GroupsKey)
+	}
+	return m
+}
+
+// hasEmailScope returns whether the email scope is requested, which it is by
+// default.
+func (c *Config) hasEmailScope() bool {
+	if len(c.Scopes) == 0 {
+		return true
+	}
+	for _, s := range c.Scopes {
+		if s == "email" {
+			return true
+		}
+	}
+	return false
 }
 
 // Domains that don't support basic auth. golang.org/x/oauth2 has an internal
@@ -89,8 +170,28 @@ var brokenAuthHeaderDomains = []string{
 // connectorData stores information for sessions authenticated by this connector
 type connectorData struct {
 	RefreshToken []byte
+
+	// Subject of the ID token, to verify the userinfo of refreshed identities
+	// against when the provider doesn't issue a new ID token.
+	Subject string `json:",omitempty"`
 }
 
+// loginState is kept with the auth request between the redirect to the
+// provider and the callback.
+type loginState struct {
+	CodeVerifier string `json:"codeVerifier"`
+}
+
+const pkceMethodS256 = "S256"
+
+// caller is who creates an identity from a token response.
+type caller uint
+
+const (
+	createCaller caller = iota
+	refreshCaller
+)
+
 // Detect auth header provider issues for known providers. This lets users
 // avoid having to explicitly set "basicAuthUnsupported" in their config.
 //
@@ -109,6 +210,19 @@ func knownBrokenAuthHeaderProvider(issuerURL string) bool {
 // Open returns a connector which can be used to login users through an upstream
 // OpenID Connect provider.
 func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, err error) {
+	var claimMapper *claims.Mapper
+	if c.ClaimExpressions != nil {
+		if claimMapper, err = c.ClaimExpressions.Compile(c.defaultClaimMapping()); err != nil {
+			return nil, fmt.Errorf("invalid claimExpressions: %v", err)
+		}
+	}
+	if c.PKCEChallenge != "" && c.PKCEChallenge != pkceMethodS256 {
+		return nil, fmt.Errorf("unsupported pkceChallenge %q, only %q is supported", c.PKCEChallenge, pkceMethodS256)
+	}
+	if c.PrivateKeyJWT != nil && c.ClientSecret != "" {
+		return nil, errors.New("clientSecret and privateKeyJWT are mutually exclusive")
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	provider, err := oidc.NewProvider(ctx, c.Issuer)
@@ -128,6 +242,19 @@ func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, e
 		endpoint.AuthStyle = oauth2.AuthStyleInParams
 	}
 
+	var httpClient *http.Client
+	if c.PrivateKeyJWT != nil {
+		signer, err := newClientAssertionSigner(c.PrivateKeyJWT, c.ClientID, endpoint.TokenURL)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("privateKeyJWT: %v", err)
+		}
+		// The client is authenticated with the parameters added by the
+		// transport.
+		endpoint.AuthStyle = oauth2.AuthStyleInParams
+		httpClient = &http.Client{Transport: &clientassertion.Transport{Signer: signer}}
+	}
+
 	scopes := []string{oidc.ScopeOpenID}
 	if len(c.Scopes) > 0 {
 		scopes = append(scopes, c.Scopes...)
@@ -168,12 +295,16 @@ func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, e
 		preferredUsernameKey:      c.ClaimMapping.PreferredUsernameKey,
 		emailKey:                  c.ClaimMapping.EmailKey,
 		groupsKey:                 c.ClaimMapping.GroupsKey,
+		claimMapper:               claimMapper,
+		pkceChallenge:             c.PKCEChallenge,
+		httpClient:                httpClient,
 	}, nil
 }
 
 var (
-	_ connector.CallbackConnector = (*oidcConnector)(nil)
-	_ connector.RefreshConnector  = (*oidcConnector)(nil)
+	_ connector.CallbackConnector      = (*oidcConnector)(nil)
+	_ connector.CallbackStateConnector = (*oidcConnector)(nil)
+	_ connector.RefreshConnector       = (*oidcConnector)(nil)
 )
 
 type oidcConnector struct {
@@ -195,6 +326,9 @@ type oidcConnector struct {
 	preferredUsernameKey      string
 	emailKey                  string
 	groupsKey                 string
+	claimMapper               *claims.Mapper
+	pkceChallenge             string
+	httpClient                *http.Client
 }
 
 func (c *oidcConnector) Close() error {
@@ -203,8 +337,18 @@ func (c *oidcConnector) Close() error {
 }
 
 func (c *oidcConnector) LoginURL(s connector.Scopes, callbackURL, state string) (string, error) {
+	if c.pkceChallenge != "" {
+		return "", errors.New("oidc: PKCE requires keeping the code verifier with the auth request")
+	}
+	loginURL, _, err := c.LoginURLWithState(s, callbackURL, state)
+	return loginURL, err
+}
+
+// LoginURLWithState returns the login URL and, if PKCE is used, the code
+// verifier to keep until the callback.
+func (c *oidcConnector) LoginURLWithState(s connector.Scopes, callbackURL, state string) (string, []byte, error) {
 	if c.redirectURI != callbackURL {
-		return "", fmt.Errorf("expected callback URL %q did not match the URL in the config %q", callbackURL, c.redirectURI)
+		return "", nil, fmt.Errorf("expected callback URL %q did not match the URL in the config %q", callbackURL, c.redirectURI)
 	}
 
 	var opts []oauth2.AuthCodeOption
@@ -224,7 +368,33 @@ func (c *oidcConnector) LoginURL(s connector.Scopes, callbackURL, state string)
 	if s.OfflineAccess {
 		opts = append(opts, oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("prompt", c.promptType))
 	}
-	return c.oauth2Config.AuthCodeURL(state, opts...), nil
+
+	var connData []byte
+	if c.pkceChallenge != "" {
+		verifier, err := newCodeVerifier()
+		if err != nil {
+			return "", nil, fmt.Errorf("oidc: failed to generate code verifier: %v", err)
+		}
+		if connData, err = json.Marshal(loginState{CodeVerifier: verifier}); err != nil {
+			return "", nil, fmt.Errorf("oidc: failed to encode login state: %v", err)
+		}
+		challenge := sha256.Sum256([]byte(verifier))
+		opts = append(opts,
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", pkceMethodS256),
+		)
+	}
+	return c.oauth2Config.AuthCodeURL(state, opts...), connData, nil
+}
+
+// newCodeVerifier returns a PKCE code verifier of 43 characters, from 256
+// random bits.
+func newCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 type oauth2Error struct {
@@ -240,19 +410,46 @@ func (e *oauth2Error) Error() string {
 }
 
 func (c *oidcConnector) HandleCallback(s connector.Scopes, r *http.Request) (identity connector.Identity, err error) {
+	return c.HandleCallbackWithState(s, nil, r)
+}
+
+// HandleCallbackWithState exchanges the code with the code verifier kept with
+// the auth request, if PKCE is used.
+func (c *oidcConnector) HandleCallbackWithState(s connector.Scopes, connData []byte, r *http.Request) (identity connector.Identity, err error) {
 	q := r.URL.Query()
 	if errType := q.Get("error"); errType != "" {
 		return identity, &oauth2Error{errType, q.Get("error_description")}
 	}
-	token, err := c.oauth2Config.Exchange(r.Context(), q.Get("code"))
+
+	var opts []oauth2.AuthCodeOption
+	if c.pkceChallenge != "" {
+		var state loginState
+		if err := json.Unmarshal(connData, &state); err != nil || state.CodeVerifier == "" {
+			return identity, errors.New("oidc: no PKCE code verifier kept with the auth request")
+		}
+		opts = append(opts, oauth2.SetAuthURLParam("code_verifier", state.CodeVerifier))
+	}
+
+	ctx := c.clientContext(r.Context())
+	token, err := c.oauth2Config.Exchange(ctx, q.Get("code"), opts...)
 	if err != nil {
 		return identity, fmt.Errorf("oidc: failed to get token: %v", err)
 	}
 
-	return c.createIdentity(r.Context(), identity, token)
+	return c.createIdentity(ctx, identity, token, createCaller)
 }
 
-// Refresh is used to refresh a session with the refresh token provided by the IdP
+// clientContext returns a context for the requests to the provider.
+func (c *oidcConnector) clientContext(ctx context.Context) context.Context {
+	if c.httpClient == nil {
+		return ctx
+	}
+	return oidc.ClientContext(ctx, c.httpClient)
+}
+
+// Refresh is used to refresh a session with the refresh token provided by the IdP.
+// The userinfo is fetched again, if the provider supports it, so changes of the
+// claims are picked up even if the provider doesn't issue a new ID token.
 func (c *oidcConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
 	cd := connectorData{}
 	err := json.Unmarshal(identity.ConnectorData, &cd)
@@ -264,40 +461,65 @@ func (c *oidcConnector) Refresh(ctx context.Context, s connector.Scopes, identit
 		RefreshToken: string(cd.RefreshToken),
 		Expiry:       time.Now().Add(-time.Hour),
 	}
+	ctx = c.clientContext(ctx)
 	token, err := c.oauth2Config.TokenSource(ctx, t).Token()
 	if err != nil {
 		return identity, fmt.Errorf("oidc: failed to get refresh token: %v", err)
 	}
 
-	return c.createIdentity(ctx, identity, token)
+	return c.createIdentity(ctx, identity, token, refreshCaller)
 }
 
-func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.Identity, token *oauth2.Token) (connector.Identity, error) {
-	rawIDToken, ok := token.Extra("id_token").(string)
-	if !ok {
-		return identity, errors.New("oidc: no id_token in token response")
-	}
-	idToken, err := c.verifier.Verify(ctx, rawIDToken)
-	if err != nil {
-		return identity, fmt.Errorf("oidc: failed to verify ID Token: %v", err)
-	}
-
-	var claims map[string]interface{}
-	if err := idToken.Claims(&claims); err != nil {
-		return identity, fmt.Errorf("oidc: failed to decode claims: %v", err)
+func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.Identity, token *oauth2.Token, caller caller) (connector.Identity, error) {
+	var (
+		claims  map[string]interface{}
+		subject string
+	)
+	if rawIDToken, ok := token.Extra("id_token").(string); ok {
+		idToken, err := c.verifier.Verify(ctx, rawIDToken)
+		if err != nil {
+			return identity, fmt.Errorf("oidc: failed to verify ID Token: %v", err)
+		}
+		if err := idToken.Claims(&claims); err != nil {
+			return identity, fmt.Errorf("oidc: failed to decode claims: %v", err)
+		}
+		subject = idToken.Subject
+	} else {
+		// Providers don't have to issue new ID tokens when refreshing, the
+		// identity is refreshed from the userinfo then.
+		if caller != refreshCaller || !c.getUserInfo {
+			return identity, errors.New("oidc: no id_token in token response")
+		}
+		var cd connectorData
+		if err := json.Unmarshal(identity.ConnectorData, &cd); err != nil || cd.Subject == "" {
+			return identity, errors.New("oidc: no id_token in token response and no subject to verify the userinfo against, the user has to log in again")
+		}
+		claims = map[string]interface{}{}
+		subject = cd.Subject
 	}
 
 	// We immediately want to run getUserInfo if configured before we validate the claims
+	var userInfoClaims map[string]interface{}
 	if c.getUserInfo {
 		userInfo, err := c.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
 		if err != nil {
 			return identity, fmt.Errorf("oidc: error loading userinfo: %v", err)
 		}
-		if err := userInfo.Claims(&claims); err != nil {
+		if userInfo.Subject != subject {
+			return identity, fmt.Errorf("oidc: userinfo subject %q does not match subject %q", userInfo.Subject, subject)
+		}
+		if err := userInfo.Claims(&userInfoClaims); err != nil {
 			return identity, fmt.Errorf("oidc: failed to decode userinfo claims: %v", err)
 		}
 	}
 
+	if c.claimMapper != nil {
+		return c.mapIdentity(claims, userInfoClaims, token, subject)
+	}
+	for k, v := range userInfoClaims {
+		claims[k] = v
+	}
+
 	userNameKey := "name"
 	if c.userNameKey != "" {
 		userNameKey = c.userNameKey
@@ -361,32 +583,17 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 		}
 	}
 
-	hostedDomain, _ := claims["hd"].(string)
-	if len(c.hostedDomains) > 0 {
-		found := false
-		for _, domain := range c.hostedDomains {
-			if hostedDomain == domain {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			return identity, fmt.Errorf("oidc: unexpected hd claim %v", hostedDomain)
-		}
+	if err := c.checkHostedDomain(claims); err != nil {
+		return identity, err
 	}
 
-	cd := connectorData{
-		RefreshToken: []byte(token.RefreshToken),
-	}
-
-	connData, err := json.Marshal(&cd)
+	connData, err := newConnectorData(token, subject)
 	if err != nil {
-		return identity, fmt.Errorf("oidc: failed to encode connector data: %v", err)
+		return identity, err
 	}
 
 	identity = connector.Identity{
-		UserID:            idToken.Subject,
+		UserID:            subject,
 		Username:          name,
 		PreferredUsername: preferredUsername,
 		Email:             email,
@@ -405,3 +612,52 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 
 	return identity, nil
 }
+
+// mapIdentity maps the claims of the ID token, merged with the userinfo
+// claims, to an identity with the claim expressions.
+func (c *oidcConnector) mapIdentity(idTokenClaims, userInfoClaims map[string]interface{}, token *oauth2.Token, subject string) (connector.Identity, error) {
+	merged := claims.Merge(idTokenClaims, userInfoClaims)
+	if err := c.checkHostedDomain(merged); err != nil {
+		return connector.Identity{}, err
+	}
+
+	identity, err := c.claimMapper.Identity(merged)
+	if err != nil {
+		return identity, fmt.Errorf("oidc: %v", err)
+	}
+	if identity.ConnectorData, err = newConnectorData(token, subject); err != nil {
+		return identity, err
+	}
+	return identity, nil
+}
+
+func (c *oidcConnector) checkHostedDomain(claims map[string]interface{}) error {
+	hostedDomain, _ := claims["hd"].(string)
+	if len(c.hostedDomains) > 0 {
+		found := false
+		for _, domain := range c.hostedDomains {
+			if hostedDomain == domain {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("oidc: unexpected hd claim %v", hostedDomain)
+		}
+	}
+	return nil
+}
+
+func newConnectorData(token *oauth2.Token, subject string) ([]byte, error) {
+	cd := connectorData{
+		RefreshToken: []byte(token.RefreshToken),
+		Subject:      subject,
+	}
+
+	connData, err := json.Marshal(&cd)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to encode connector data: %v", err)
+	}
+	return connData, nil
+}
@@ -2,15 +2,24 @@ package oidc
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -18,8 +27,10 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 
 	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/claims"
 )
 
 func TestKnownBrokenAuthHeaderProvider(t *testing.T) {
@@ -275,7 +286,7 @@ func TestHandleCallback(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			testServer, err := setupServer(tc.token)
+			testServer, err := setupServer(tc.token, nil)
 			if err != nil {
 				t.Fatal("failed to setup test server", err)
 			}
@@ -331,7 +342,519 @@ func TestHandleCallback(t *testing.T) {
 	}
 }
 
-func setupServer(tok map[string]interface{}) (*httptest.Server, error) {
+func TestHandleCallbackClaimExpressions(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":                "subvalue",
+		"name":               "namevalue",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+		"email":              "emailvalue",
+		"realm_access":       map[string]interface{}{"roles": []string{"role1", "role2"}},
+		"https://corp/roles": []string{"corp1"},
+	}
+	userInfo := map[string]interface{}{
+		"sub":            "subvalue",
+		"email":          "userinfoemailvalue",
+		"email_verified": true,
+		"realm_access":   map[string]interface{}{"groups": []string{"group1"}},
+	}
+	testServer, err := setupServer(token, userInfo)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	tests := []struct {
+		name        string
+		expressions claims.Mapping
+		expect      connector.Identity
+	}{
+		{
+			name:        "defaults",
+			expressions: claims.Mapping{},
+			expect: connector.Identity{
+				UserID:        "subvalue",
+				Username:      "namevalue",
+				Email:         "userinfoemailvalue",
+				EmailVerified: true,
+			},
+		},
+		{
+			name: "nested and merged claims",
+			expressions: claims.Mapping{
+				Username: &claims.Selector{Paths: []string{"display_name"}, Template: "{{ .given_name }} {{ .family_name }}"},
+				Groups:   claims.Paths("realm_access.roles"),
+			},
+			expect: connector.Identity{
+				UserID:        "subvalue",
+				Username:      "Jane Doe",
+				Email:         "userinfoemailvalue",
+				EmailVerified: true,
+				Groups:        []string{"role1", "role2"},
+			},
+		},
+		{
+			name: "namespaced claims and values",
+			expressions: claims.Mapping{
+				UserID:            claims.Paths("email"),
+				PreferredUsername: &claims.Selector{Paths: []string{"preferred_username"}, Value: "unknown"},
+				Groups:            claims.Paths(`["https://corp/roles"]`, "realm_access.groups"),
+			},
+			expect: connector.Identity{
+				UserID:            "userinfoemailvalue",
+				Username:          "namevalue",
+				PreferredUsername: "unknown",
+				Email:             "userinfoemailvalue",
+				EmailVerified:     true,
+				Groups:            []string{"corp1"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			basicAuth := true
+			expressions := tc.expressions
+			conn, err := newConnector(Config{
+				Issuer:               testServer.URL,
+				ClientID:             "clientID",
+				ClientSecret:         "clientSecret",
+				Scopes:               []string{"email"},
+				RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+				GetUserInfo:          true,
+				BasicAuthUnsupported: &basicAuth,
+				ClaimExpressions:     &expressions,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			identity.ConnectorData = nil
+			expectEquals(t, identity, tc.expect)
+		})
+	}
+
+	config := Config{
+		Issuer:           testServer.URL,
+		ClaimExpressions: &claims.Mapping{Groups: claims.Paths("realm_access..roles")},
+	}
+	if _, err := config.Open("id", logrus.New()); err == nil {
+		t.Errorf("expected error opening a connector with an invalid path")
+	}
+}
+
+func TestHandleCallbackClaimExpressionsMissingClaims(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":        "subvalue",
+		"given_name": "Jane",
+		"email":      "emailvalue",
+	}
+	testServer, err := setupServer(token, nil)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	tests := []struct {
+		name                      string
+		expressions               *claims.Mapping
+		insecureSkipEmailVerified bool
+		expectErr                 string
+		expect                    connector.Identity
+	}{
+		{
+			name:      "without expressions",
+			expectErr: `missing "name" claim`,
+		},
+		{
+			name:        "default selectors",
+			expressions: &claims.Mapping{},
+			expectErr:   `missing claim ["name"]`,
+		},
+		{
+			name:        "default email_verified selector",
+			expressions: &claims.Mapping{Username: claims.Paths("given_name")},
+			expectErr:   `missing claim ["email_verified"]`,
+		},
+		{
+			name:                      "default email_verified selector skipped",
+			expressions:               &claims.Mapping{Username: claims.Paths("given_name")},
+			insecureSkipEmailVerified: true,
+			expect: connector.Identity{
+				UserID:        "subvalue",
+				Username:      "Jane",
+				Email:         "emailvalue",
+				EmailVerified: true,
+			},
+		},
+		{
+			name: "configured selectors",
+			expressions: &claims.Mapping{
+				Username:      claims.Paths("nickname"),
+				EmailVerified: claims.Paths("email_verified"),
+			},
+			expect: connector.Identity{
+				UserID: "subvalue",
+				Email:  "emailvalue",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			basicAuth := true
+			conn, err := newConnector(Config{
+				Issuer:                    testServer.URL,
+				ClientID:                  "clientID",
+				ClientSecret:              "clientSecret",
+				Scopes:                    []string{"email"},
+				RedirectURI:               fmt.Sprintf("%s/callback", testServer.URL),
+				BasicAuthUnsupported:      &basicAuth,
+				InsecureSkipEmailVerified: tc.insecureSkipEmailVerified,
+				ClaimExpressions:          tc.expressions,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			identity.ConnectorData = nil
+			expectEquals(t, identity, tc.expect)
+		})
+	}
+}
+
+func TestPKCE(t *testing.T) {
+	var verifier string
+	testServer, err := setupServerWithTokenHook(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	}, nil, func(r *http.Request, resp map[string]string) error {
+		verifier = r.PostFormValue("code_verifier")
+		return nil
+	})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	basicAuth := true
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		Scopes:               []string{"email"},
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		BasicAuthUnsupported: &basicAuth,
+		PKCEChallenge:        "S256",
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	if _, err := conn.LoginURL(connector.Scopes{}, conn.redirectURI, "state"); err == nil {
+		t.Errorf("expected error creating a login URL without keeping the code verifier")
+	}
+	loginURL, connData, err := conn.LoginURLWithState(connector.Scopes{}, conn.redirectURI, "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state loginState
+	if err := json.Unmarshal(connData, &state); err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge := sha256.Sum256([]byte(state.CodeVerifier))
+	expectEquals(t, u.Query().Get("code_challenge"), base64.RawURLEncoding.EncodeToString(challenge[:]))
+	expectEquals(t, u.Query().Get("code_challenge_method"), "S256")
+	if strings.Contains(loginURL, state.CodeVerifier) {
+		t.Errorf("expected the code verifier not to be sent with the login URL")
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+	if _, err := conn.HandleCallbackWithState(connector.Scopes{}, nil, req); err == nil {
+		t.Errorf("expected error handling the callback without the code verifier")
+	}
+	identity, err := conn.HandleCallbackWithState(connector.Scopes{}, connData, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	expectEquals(t, identity.UserID, "subvalue")
+	expectEquals(t, verifier, state.CodeVerifier)
+
+	config := Config{Issuer: testServer.URL, PKCEChallenge: "plain"}
+	if _, err := config.Open("id", logrus.New()); err == nil {
+		t.Errorf("expected error opening a connector with the plain PKCE method")
+	}
+}
+
+func TestPrivateKeyJWT(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var grantTypes []string
+	testServer, err := setupServerWithTokenHook(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	}, map[string]interface{}{
+		"sub": "subvalue",
+	}, func(r *http.Request, resp map[string]string) error {
+		if _, _, ok := r.BasicAuth(); ok || r.PostFormValue("client_secret") != "" {
+			return errors.New("unexpected client secret")
+		}
+		if r.PostFormValue("client_assertion_type") != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+			return errors.New("unexpected client assertion type")
+		}
+		assertion, err := jwt.ParseSigned(r.PostFormValue("client_assertion"))
+		if err != nil {
+			return err
+		}
+		if len(assertion.Headers) != 1 || assertion.Headers[0].KeyID != "key-1" || assertion.Headers[0].Algorithm != "ES256" {
+			return fmt.Errorf("unexpected headers %+v", assertion.Headers)
+		}
+		var claims jwt.Claims
+		if err := assertion.Claims(&key.PublicKey, &claims); err != nil {
+			return err
+		}
+		err = claims.Validate(jwt.Expected{
+			Issuer:   "clientID",
+			Subject:  "clientID",
+			Audience: jwt.Audience{fmt.Sprintf("http://%s/token", r.Host)},
+			Time:     time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		grantTypes = append(grantTypes, r.PostFormValue("grant_type"))
+		resp["refresh_token"] = "refreshtoken"
+		return nil
+	})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:        testServer.URL,
+		ClientID:      "clientID",
+		Scopes:        []string{"email"},
+		RedirectURI:   fmt.Sprintf("%s/callback", testServer.URL),
+		PrivateKeyJWT: &PrivateKeyJWTConfig{PrivateKey: keyFile, KeyID: "key-1"},
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+	identity, err := conn.HandleCallback(connector.Scopes{OfflineAccess: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	if _, err := conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true}, identity); err != nil {
+		t.Fatal("refresh failed", err)
+	}
+	expectEquals(t, grantTypes, []string{"authorization_code", "refresh_token"})
+
+	config := Config{
+		Issuer:        testServer.URL,
+		ClientSecret:  "clientSecret",
+		PrivateKeyJWT: &PrivateKeyJWTConfig{PrivateKey: keyFile},
+	}
+	if _, err := config.Open("id", logrus.New()); err == nil {
+		t.Errorf("expected error opening a connector with a client secret and a private key")
+	}
+}
+
+func TestRefreshUserInfo(t *testing.T) {
+	idTokenOnRefresh := true
+	userInfo := map[string]interface{}{
+		"sub":    "subvalue",
+		"groups": []string{"group1"},
+	}
+	testServer, err := setupServerWithTokenHook(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"groups":         []string{"group1"},
+	}, userInfo, func(r *http.Request, resp map[string]string) error {
+		resp["refresh_token"] = "refreshtoken"
+		if r.PostFormValue("grant_type") == "refresh_token" && !idTokenOnRefresh {
+			delete(resp, "id_token")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	basicAuth := true
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		Scopes:               []string{"email", "groups"},
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		BasicAuthUnsupported: &basicAuth,
+		InsecureEnableGroups: true,
+		GetUserInfo:          true,
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+	identity, err := conn.HandleCallback(connector.Scopes{OfflineAccess: true, Groups: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	expectEquals(t, identity.Groups, []string{"group1"})
+
+	// The groups of the userinfo take precedence over the ID token claims.
+	userInfo["groups"] = []string{"group1", "group2"}
+	refreshed, err := conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, identity)
+	if err != nil {
+		t.Fatal("refresh failed", err)
+	}
+	expectEquals(t, refreshed.Groups, []string{"group1", "group2"})
+
+	// Without a new ID token the identity is refreshed from the userinfo.
+	idTokenOnRefresh = false
+	userInfo["name"] = "namevalue"
+	userInfo["email"] = "emailvalue"
+	userInfo["email_verified"] = true
+	userInfo["groups"] = []string{"group3"}
+	refreshed, err = conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, refreshed)
+	if err != nil {
+		t.Fatal("refresh failed", err)
+	}
+	expectEquals(t, refreshed.UserID, "subvalue")
+	expectEquals(t, refreshed.Groups, []string{"group3"})
+
+	userInfo["sub"] = "othersubvalue"
+	if _, err := conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, refreshed); err == nil {
+		t.Errorf("expected error refreshing with the userinfo of another subject")
+	}
+}
+
+func TestRefreshWithoutUserInfo(t *testing.T) {
+	idTokenOnRefresh := true
+	testServer, err := setupServerWithTokenHook(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"groups":         []string{"group1"},
+	}, map[string]interface{}{
+		"sub":    "subvalue",
+		"groups": []string{"group2"},
+	}, func(r *http.Request, resp map[string]string) error {
+		resp["refresh_token"] = "refreshtoken"
+		if r.PostFormValue("grant_type") == "refresh_token" && !idTokenOnRefresh {
+			delete(resp, "id_token")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	basicAuth := true
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		Scopes:               []string{"email", "groups"},
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		BasicAuthUnsupported: &basicAuth,
+		InsecureEnableGroups: true,
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+	identity, err := conn.HandleCallback(connector.Scopes{OfflineAccess: true, Groups: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+
+	// Without getUserInfo the identity is refreshed from the ID token only,
+	// like at login.
+	refreshed, err := conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, identity)
+	if err != nil {
+		t.Fatal("refresh failed", err)
+	}
+	expectEquals(t, refreshed.Groups, []string{"group1"})
+
+	idTokenOnRefresh = false
+	if _, err := conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, refreshed); err == nil {
+		t.Errorf("expected error refreshing without an ID token")
+	}
+}
+
+func setupServer(tok, userInfo map[string]interface{}) (*httptest.Server, error) {
+	return setupServerWithTokenHook(tok, userInfo, nil)
+}
+
+// tokenHook checks a token request and modifies the response.
+type tokenHook func(r *http.Request, resp map[string]string) error
+
+func setupServerWithTokenHook(tok, userInfo map[string]interface{}, hook tokenHook) (*httptest.Server, error) {
 	key, err := rsa.GenerateKey(rand.Reader, 1024)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate rsa key: %v", err)
@@ -367,12 +890,25 @@ func setupServer(tok map[string]interface{}) (*httptest.Server, error) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 
-		w.Header().Add("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(&map[string]string{
+		resp := map[string]string{
 			"access_token": token,
 			"id_token":     token,
 			"token_type":   "Bearer",
-		})
+		}
+		if hook != nil {
+			if err := hook(r, resp); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&resp)
+	})
+
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(userInfo)
 	})
 
 	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
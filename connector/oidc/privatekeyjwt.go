@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/pkg/clientassertion"
+)
+
+// PrivateKeyJWTConfig configures authenticating to the token endpoint with a
+// JWT signed by a private key, instead of a client secret.
+//
+// See: https://openid.net/specs/openid-connect-core-1_0.html#ClientAuthentication
+type PrivateKeyJWTConfig struct {
+	// Path to a PEM encoded RSA or ECDSA private key.
+	PrivateKey string `json:"privateKey"`
+
+	// ID of the key registered with the provider, sent in the "kid" header.
+	KeyID string `json:"keyID"`
+
+	// Algorithm to sign the JWT with. Defaults to RS256 for RSA keys, and to
+	// ES256, ES384 or ES512 for ECDSA keys depending on the curve.
+	SigningAlgorithm string `json:"signingAlgorithm"`
+}
+
+func newClientAssertionSigner(c *PrivateKeyJWTConfig, clientID, tokenURL string) (*clientassertion.Signer, error) {
+	if c.PrivateKey == "" {
+		return nil, errors.New("missing required field \"privateKeyJWT.privateKey\"")
+	}
+	data, err := os.ReadFile(c.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %v", err)
+	}
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key %s: %v", c.PrivateKey, err)
+	}
+
+	alg := jose.SignatureAlgorithm(c.SigningAlgorithm)
+	if alg == "" {
+		if alg, err = defaultSigningAlgorithm(key); err != nil {
+			return nil, err
+		}
+	}
+	return clientassertion.NewSigner(clientID, tokenURL,
+		jose.SigningKey{Algorithm: alg, Key: jose.JSONWebKey{Key: key, KeyID: c.KeyID}}, nil)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case *ecdsa.PrivateKey:
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+func defaultSigningAlgorithm(key crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+	}
+	return "", errors.New("no default signing algorithm for the private key, set \"privateKeyJWT.signingAlgorithm\"")
+}
@@ -0,0 +1,269 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha1" // for the hashes of RSA-OAEP
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
+)
+
+// Identity providers can encrypt assertions for the service provider. The
+// content is encrypted with a random key, which is encrypted with the public
+// key of the service provider.
+//
+// See: https://www.w3.org/TR/xmlenc-core1/
+
+const (
+	samlAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	// Key transport algorithms.
+	keyTransportRSA15        = "http://www.w3.org/2001/04/xmlenc#rsa-1_5"
+	keyTransportRSAOAEPMGF1P = "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"
+	keyTransportRSAOAEP      = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+
+	// Block encryption algorithms.
+	encryptionAES128CBC = "http://www.w3.org/2001/04/xmlenc#aes128-cbc"
+	encryptionAES192CBC = "http://www.w3.org/2001/04/xmlenc#aes192-cbc"
+	encryptionAES256CBC = "http://www.w3.org/2001/04/xmlenc#aes256-cbc"
+	encryptionAES128GCM = "http://www.w3.org/2009/xmlenc11#aes128-gcm"
+	encryptionAES192GCM = "http://www.w3.org/2009/xmlenc11#aes192-gcm"
+	encryptionAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+
+	// GCM uses a 96 bit IV and a 128 bit authentication tag.
+	gcmNonceSize = 12
+)
+
+// blockEncryptions maps the block encryption algorithms to their key size.
+var blockEncryptions = map[string]int{
+	encryptionAES128CBC: 16,
+	encryptionAES192CBC: 24,
+	encryptionAES256CBC: 32,
+	encryptionAES128GCM: 16,
+	encryptionAES192GCM: 24,
+	encryptionAES256GCM: 32,
+}
+
+// oaepDigests maps the digest and mask generation function algorithms of
+// RSA-OAEP to their hash.
+var oaepDigests = map[string]crypto.Hash{
+	"http://www.w3.org/2000/09/xmldsig#sha1":     crypto.SHA1,
+	"http://www.w3.org/2001/04/xmlenc#sha256":    crypto.SHA256,
+	"http://www.w3.org/2001/04/xmlenc#sha512":    crypto.SHA512,
+	"http://www.w3.org/2009/xmlenc11#mgf1sha1":   crypto.SHA1,
+	"http://www.w3.org/2009/xmlenc11#mgf1sha256": crypto.SHA256,
+	"http://www.w3.org/2009/xmlenc11#mgf1sha512": crypto.SHA512,
+}
+
+// decryptResponse decrypts the assertion of a response whose signature isn't
+// verified.
+func decryptResponse(data []byte, key *rsa.PrivateKey) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("parse document: %v", err)
+	}
+	if doc.Root() == nil {
+		return nil, errors.New("response does not contain an element")
+	}
+	if err := decryptAssertion(doc.Root(), key); err != nil {
+		return nil, err
+	}
+	return doc.WriteToBytes()
+}
+
+// decryptAssertion replaces the EncryptedAssertion element of a response with
+// the Assertion element it contains. It does nothing if the assertion isn't
+// encrypted.
+func decryptAssertion(response *etree.Element, key *rsa.PrivateKey) error {
+	var encrypted *etree.Element
+	for _, el := range response.ChildElements() {
+		if el.Tag == "EncryptedAssertion" && el.NamespaceURI() == samlAssertionNamespace {
+			encrypted = el
+			break
+		}
+	}
+	if encrypted == nil {
+		return nil
+	}
+	if key == nil {
+		return errors.New("response contains an encrypted assertion, set 'spCert' and 'spKey' to decrypt it")
+	}
+
+	plaintext, err := decryptElement(encrypted, key)
+	if err != nil {
+		return fmt.Errorf("decrypt assertion: %v", err)
+	}
+	if err := xrv.Validate(bytes.NewReader(plaintext)); err != nil {
+		return fmt.Errorf("validating decrypted assertion: %v", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(plaintext); err != nil {
+		return fmt.Errorf("parse decrypted assertion: %v", err)
+	}
+	assertion := doc.Root()
+	if assertion == nil || assertion.Tag != "Assertion" {
+		return errors.New("encrypted assertion does not contain an Assertion element")
+	}
+
+	index := encrypted.Index()
+	response.RemoveChildAt(index)
+	response.InsertChildAt(index, assertion)
+	return nil
+}
+
+// decryptElement decrypts the EncryptedData element of an element, with the
+// key of the EncryptedKey element of its key info or of the element itself.
+func decryptElement(el *etree.Element, key *rsa.PrivateKey) ([]byte, error) {
+	data := el.FindElement("./EncryptedData")
+	if data == nil {
+		return nil, errors.New("no EncryptedData element")
+	}
+	algorithm := encryptionMethod(data)
+	keySize, ok := blockEncryptions[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", algorithm)
+	}
+	ciphertext, err := cipherValue(data)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey := data.FindElement("./KeyInfo/EncryptedKey")
+	if encryptedKey == nil {
+		// The key can also follow the encrypted data, referenced by a
+		// RetrievalMethod element.
+		path := "./EncryptedKey"
+		if rm := data.FindElement("./KeyInfo/RetrievalMethod"); rm != nil {
+			if id := strings.TrimPrefix(rm.SelectAttrValue("URI", ""), "#"); id != "" {
+				path = "./EncryptedKey[@Id='" + id + "']"
+			}
+		}
+		if encryptedKey = el.FindElement(path); encryptedKey == nil {
+			return nil, errors.New("no EncryptedKey element")
+		}
+	}
+	contentKey, err := decryptKey(encryptedKey, key, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	switch algorithm {
+	case encryptionAES128GCM, encryptionAES192GCM, encryptionAES256GCM:
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		if len(ciphertext) < gcmNonceSize+gcm.Overhead() {
+			return nil, errors.New("ciphertext too short")
+		}
+		plaintext, err := gcm.Open(nil, ciphertext[:gcmNonceSize], ciphertext[gcmNonceSize:], nil)
+		if err != nil {
+			return nil, errors.New("decrypt data: message authentication failed")
+		}
+		return plaintext, nil
+	default:
+		if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+			return nil, errors.New("invalid ciphertext length")
+		}
+		plaintext := make([]byte, len(ciphertext)-aes.BlockSize)
+		cipher.NewCBCDecrypter(block, ciphertext[:aes.BlockSize]).CryptBlocks(plaintext, ciphertext[aes.BlockSize:])
+		// Only the last byte of the padding is defined, which is the length
+		// of the padding.
+		padding := int(plaintext[len(plaintext)-1])
+		if padding == 0 || padding > aes.BlockSize {
+			return nil, errors.New("invalid padding")
+		}
+		return plaintext[:len(plaintext)-padding], nil
+	}
+}
+
+// decryptKey decrypts the content encryption key of an EncryptedKey element.
+func decryptKey(el *etree.Element, key *rsa.PrivateKey, keySize int) ([]byte, error) {
+	ciphertext, err := cipherValue(el)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algorithm := encryptionMethod(el); algorithm {
+	case keyTransportRSA15:
+		// Decrypt into a random key on errors, so a padding oracle doesn't
+		// reveal whether the key was decrypted, only the content fails to
+		// decrypt.
+		contentKey := make([]byte, keySize)
+		if _, err := rand.Read(contentKey); err != nil {
+			return nil, err
+		}
+		if err := rsa.DecryptPKCS1v15SessionKey(rand.Reader, key, ciphertext, contentKey); err != nil {
+			return nil, errors.New("decrypt key failed")
+		}
+		return contentKey, nil
+	case keyTransportRSAOAEPMGF1P, keyTransportRSAOAEP:
+		method := el.FindElement("./EncryptionMethod")
+		digest, mgf := crypto.SHA1, crypto.SHA1
+		if dm := method.FindElement("./DigestMethod"); dm != nil {
+			var ok bool
+			if digest, ok = oaepDigests[dm.SelectAttrValue("Algorithm", "")]; !ok {
+				return nil, fmt.Errorf("unsupported digest algorithm %q", dm.SelectAttrValue("Algorithm", ""))
+			}
+		}
+		if m := method.FindElement("./MGF"); m != nil && algorithm == keyTransportRSAOAEP {
+			var ok bool
+			if mgf, ok = oaepDigests[m.SelectAttrValue("Algorithm", "")]; !ok {
+				return nil, fmt.Errorf("unsupported mask generation function %q", m.SelectAttrValue("Algorithm", ""))
+			}
+		}
+		if digest != mgf {
+			return nil, errors.New("RSA-OAEP with different digest and mask generation function hashes is not supported")
+		}
+		var label []byte
+		if params := method.FindElement("./OAEPparams"); params != nil {
+			if label, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(params.Text()), "")); err != nil {
+				return nil, fmt.Errorf("decode OAEPparams: %v", err)
+			}
+		}
+		contentKey, err := rsa.DecryptOAEP(digest.New(), rand.Reader, key, ciphertext, label)
+		if err != nil {
+			return nil, errors.New("decrypt key failed")
+		}
+		if len(contentKey) != keySize {
+			return nil, fmt.Errorf("expected a %d byte key, got %d bytes", keySize, len(contentKey))
+		}
+		return contentKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported key transport algorithm %q", algorithm)
+	}
+}
+
+func encryptionMethod(el *etree.Element) string {
+	if method := el.FindElement("./EncryptionMethod"); method != nil {
+		return method.SelectAttrValue("Algorithm", "")
+	}
+	return ""
+}
+
+func cipherValue(el *etree.Element) ([]byte, error) {
+	value := el.FindElement("./CipherData/CipherValue")
+	if value == nil {
+		return nil, fmt.Errorf("%s does not contain a CipherValue element", el.Tag)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value.Text()), ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode CipherValue: %v", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,297 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+var encryptedAssertionIdent = connector.Identity{
+	UserID:        "eric.chiang+okta@coreos.com",
+	Username:      "Eric",
+	Email:         "eric.chiang+okta@coreos.com",
+	EmailVerified: true,
+}
+
+func TestEncryptedAssertion(t *testing.T) {
+	for _, respFile := range []string{
+		"testdata/encrypted-assertion-oaep-aes256-cbc.xml",
+		"testdata/encrypted-assertion-rsa1_5-aes128-cbc.xml",
+	} {
+		t.Run(respFile, func(t *testing.T) {
+			test := responseTest{
+				caFile:       "testdata/ca.crt",
+				respFile:     respFile,
+				spCert:       "testdata/sp.crt",
+				spKey:        "testdata/sp.key",
+				now:          "2017-04-04T04:34:59.330Z",
+				usernameAttr: "Name",
+				emailAttr:    "email",
+				inResponseTo: "6zmm5mguyebwvajyf2sdwwcw6m",
+				redirectURI:  "http://127.0.0.1:5556/dex/callback",
+				wantIdent:    encryptedAssertionIdent,
+			}
+			test.run(t)
+		})
+	}
+}
+
+// The Okta responses are real captures, taken from the crewjam/saml test data
+// (BSD 2-Clause license, Copyright (c) 2015, Ross Kinder). Okta references the
+// EncryptedKey from the EncryptedData with a RetrievalMethod and sets the Type
+// of the encrypted data, unlike the responses of gen.sh.
+func TestOktaEncryptedAssertion(t *testing.T) {
+	tests := []struct {
+		respFile     string
+		now          string
+		inResponseTo string
+	}{
+		{
+			respFile:     "testdata/okta-encrypted-assertion-signed-response.xml",
+			now:          "2020-03-03T19:24:29.213Z",
+			inResponseTo: "id-a7364d1e4432aa9085a7a8bd824ea2fa8fa8f684",
+		},
+		{
+			respFile:     "testdata/okta-encrypted-signed-assertion.xml",
+			now:          "2020-03-03T19:31:55.895Z",
+			inResponseTo: "id-6d976cdde8e76df5df0a8ff58148fc0b7ec6796d",
+		},
+		{
+			respFile:     "testdata/okta-encrypted-signed-assertion-signed-response.xml",
+			now:          "2020-03-03T19:40:54.699Z",
+			inResponseTo: "id-953d4cab69ff475c5901d12e585b0bb15a7b85fe",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.respFile, func(t *testing.T) {
+			test := responseTest{
+				caFile:   "testdata/okta-encrypted-ca.pem",
+				respFile: tc.respFile,
+				spCert:   "testdata/okta-sp.crt",
+				spKey:    "testdata/okta-sp.key",
+				now:      tc.now,
+				// The assertions only contain a Username attribute.
+				usernameAttr: "Username",
+				emailAttr:    "Username",
+				inResponseTo: tc.inResponseTo,
+				redirectURI:  "http://localhost:8000/saml/acs",
+				entityIssuer: "http://localhost:8000/saml/metadata",
+				wantIdent: connector.Identity{
+					UserID:        "testuser@testrsc.com",
+					Username:      "FixedValue",
+					Email:         "FixedValue",
+					EmailVerified: true,
+				},
+			}
+			test.run(t)
+		})
+	}
+}
+
+func TestEncryptedAssertionWithoutKey(t *testing.T) {
+	test := responseTest{
+		caFile:       "testdata/ca.crt",
+		respFile:     "testdata/encrypted-assertion-oaep-aes256-cbc.xml",
+		now:          "2017-04-04T04:34:59.330Z",
+		usernameAttr: "Name",
+		emailAttr:    "email",
+		inResponseTo: "6zmm5mguyebwvajyf2sdwwcw6m",
+		redirectURI:  "http://127.0.0.1:5556/dex/callback",
+		wantErr:      true,
+	}
+	test.run(t)
+}
+
+func TestEncryptedAssertionWrongKey(t *testing.T) {
+	test := responseTest{
+		caFile:       "testdata/ca.crt",
+		respFile:     "testdata/encrypted-assertion-oaep-aes256-cbc.xml",
+		spCert:       "testdata/ca.crt",
+		spKey:        "testdata/ca.key",
+		now:          "2017-04-04T04:34:59.330Z",
+		usernameAttr: "Name",
+		emailAttr:    "email",
+		inResponseTo: "6zmm5mguyebwvajyf2sdwwcw6m",
+		redirectURI:  "http://127.0.0.1:5556/dex/callback",
+		wantErr:      true,
+	}
+	test.run(t)
+}
+
+// encryptedAssertionTest encrypts the signed assertion of the testdata
+// directory for the service provider.
+type encryptedAssertionTest struct {
+	keyTransport string
+	digest       string
+	encryption   string
+	// Put the encrypted key after the encrypted data, instead of into its key
+	// info.
+	detachedKey bool
+	// Modify the assertion before it's encrypted.
+	tamper func(assertion string) string
+}
+
+func (e encryptedAssertionTest) response(t *testing.T) string {
+	data, err := os.ReadFile("testdata/assertion-signed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := string(data)
+	start := strings.Index(resp, "<saml2:Assertion ")
+	end := strings.Index(resp, "</saml2:Assertion>") + len("</saml2:Assertion>")
+	assertion := resp[start:end]
+	if e.tamper != nil {
+		assertion = e.tamper(assertion)
+	}
+
+	contentKey := make([]byte, blockEncryptions[e.encryption])
+	if _, err := rand.Read(contentKey); err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ciphertext []byte
+	if strings.HasSuffix(e.encryption, "gcm") {
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce := make([]byte, gcmNonceSize)
+		if _, err := rand.Read(nonce); err != nil {
+			t.Fatal(err)
+		}
+		ciphertext = gcm.Seal(nonce, nonce, []byte(assertion), nil)
+	} else {
+		padding := aes.BlockSize - len(assertion)%aes.BlockSize
+		plaintext := append([]byte(assertion), make([]byte, padding)...)
+		plaintext[len(plaintext)-1] = byte(padding)
+		ciphertext = make([]byte, aes.BlockSize+len(plaintext))
+		if _, err := rand.Read(ciphertext[:aes.BlockSize]); err != nil {
+			t.Fatal(err)
+		}
+		cipher.NewCBCEncrypter(block, ciphertext[:aes.BlockSize]).CryptBlocks(ciphertext[aes.BlockSize:], plaintext)
+	}
+
+	sp, err := loadCert("testdata/sp.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := sp.PublicKey.(*rsa.PublicKey)
+	var encryptedKey []byte
+	digestMethod := ""
+	switch e.keyTransport {
+	case keyTransportRSA15:
+		encryptedKey, err = rsa.EncryptPKCS1v15(rand.Reader, pub, contentKey)
+	default:
+		hash := crypto.SHA1
+		if e.digest != "" {
+			hash = oaepDigests[e.digest]
+			digestMethod = `<ds:DigestMethod Algorithm="` + e.digest + `"/>` +
+				`<xenc11:MGF xmlns:xenc11="http://www.w3.org/2009/xmlenc11#" Algorithm="http://www.w3.org/2009/xmlenc11#mgf1sha256"/>`
+		}
+		encryptedKey, err = rsa.EncryptOAEP(hash.New(), rand.Reader, pub, contentKey, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := `<xenc:EncryptedKey Id="_key"><xenc:EncryptionMethod Algorithm="` + e.keyTransport + `">` + digestMethod + `</xenc:EncryptionMethod>` +
+		`<xenc:CipherData><xenc:CipherValue>` + base64.StdEncoding.EncodeToString(encryptedKey) + `</xenc:CipherValue></xenc:CipherData></xenc:EncryptedKey>`
+	keyInfo, detached := key, ""
+	if e.detachedKey {
+		keyInfo = `<ds:RetrievalMethod URI="#_key" Type="http://www.w3.org/2001/04/xmlenc#EncryptedKey"/>`
+		detached = key
+	}
+	encrypted := `<saml2:EncryptedAssertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion" xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` +
+		`<xenc:EncryptedData Type="http://www.w3.org/2001/04/xmlenc#Element"><xenc:EncryptionMethod Algorithm="` + e.encryption + `"/>` +
+		`<ds:KeyInfo>` + keyInfo + `</ds:KeyInfo>` +
+		`<xenc:CipherData><xenc:CipherValue>` + base64.StdEncoding.EncodeToString(ciphertext) + `</xenc:CipherValue></xenc:CipherData></xenc:EncryptedData>` +
+		detached + `</saml2:EncryptedAssertion>`
+	return base64.StdEncoding.EncodeToString([]byte(resp[:start] + encrypted + resp[end:]))
+}
+
+func TestDecryptAssertion(t *testing.T) {
+	tests := []struct {
+		name    string
+		test    encryptedAssertionTest
+		wantErr bool
+	}{
+		{
+			name: "RSA-OAEP AES-128-GCM",
+			test: encryptedAssertionTest{keyTransport: keyTransportRSAOAEPMGF1P, encryption: encryptionAES128GCM},
+		},
+		{
+			name: "RSA-OAEP SHA-256 AES-256-GCM",
+			test: encryptedAssertionTest{keyTransport: keyTransportRSAOAEP, digest: "http://www.w3.org/2001/04/xmlenc#sha256", encryption: encryptionAES256GCM},
+		},
+		{
+			name: "RSA 1.5 AES-192-CBC",
+			test: encryptedAssertionTest{keyTransport: keyTransportRSA15, encryption: encryptionAES192CBC},
+		},
+		{
+			name: "detached key",
+			test: encryptedAssertionTest{keyTransport: keyTransportRSAOAEPMGF1P, encryption: encryptionAES256CBC, detachedKey: true},
+		},
+		{
+			name: "tampered assertion",
+			test: encryptedAssertionTest{
+				keyTransport: keyTransportRSAOAEPMGF1P,
+				encryption:   encryptionAES256GCM,
+				tamper: func(assertion string) string {
+					return strings.Replace(assertion, ">Eric<", ">Mallory<", 1)
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	c := Config{
+		CA:           "testdata/ca.crt",
+		SPCert:       "testdata/sp.crt",
+		SPKey:        "testdata/sp.key",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+		SSOURL:       "http://foo.bar/",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now, err := time.Parse(timeFormat, "2017-04-04T04:34:59.330Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.now = func() time.Time { return now }
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ident, err := p.HandlePOST(connector.Scopes{}, tc.test.response(t), "6zmm5mguyebwvajyf2sdwwcw6m")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got identity %+v", ident)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ident.Username != encryptedAssertionIdent.Username || ident.Email != encryptedAssertionIdent.Email {
+				t.Errorf("unexpected identity %+v", ident)
+			}
+		})
+	}
+}
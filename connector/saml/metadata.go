@@ -0,0 +1,289 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// The metadata of an identity provider describes its endpoints and signing
+// certificates.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf
+
+const (
+	// defaultMetadataRefreshInterval is how often the metadata is loaded again
+	// unless metadataRefreshInterval is set.
+	defaultMetadataRefreshInterval = time.Hour
+
+	// maxMetadataSize limits the size of the metadata downloaded.
+	maxMetadataSize = 10 << 20
+)
+
+type entitiesDescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+
+	ValidUntil string `xml:"validUntil,attr,omitempty"`
+
+	EntitiesDescriptors []entitiesDescriptor `xml:"EntitiesDescriptor"`
+	EntityDescriptors   []entityDescriptor   `xml:"EntityDescriptor"`
+}
+
+type entityDescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+
+	EntityID   string `xml:"entityID,attr"`
+	ValidUntil string `xml:"validUntil,attr,omitempty"`
+
+	IDPSSODescriptors []idpSSODescriptor `xml:"IDPSSODescriptor"`
+}
+
+type idpSSODescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+
+	ValidUntil              string `xml:"validUntil,attr,omitempty"`
+	WantAuthnRequestsSigned bool   `xml:"WantAuthnRequestsSigned,attr,omitempty"`
+
+	KeyDescriptors       []keyDescriptor `xml:"KeyDescriptor"`
+	SingleSignOnServices []endpoint      `xml:"SingleSignOnService"`
+}
+
+type keyDescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+
+	// Either "signing", "encryption" or empty if the key is used for both.
+	Use string `xml:"use,attr,omitempty"`
+
+	KeyInfo keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+}
+
+type keyInfo struct {
+	X509Data x509Data `xml:"http://www.w3.org/2000/09/xmldsig# X509Data"`
+}
+
+type x509Data struct {
+	Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# X509Certificate"`
+}
+
+type endpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// idpMetadata is the configuration of an identity provider read from its
+// metadata.
+type idpMetadata struct {
+	entityID string
+	// The location of the SingleSignOnService of each binding.
+	ssoURLs map[string]string
+	// The certificates the identity provider signs with.
+	certs []*x509.Certificate
+
+	wantAuthnRequestsSigned bool
+}
+
+// parseMetadata parses the metadata of an identity provider. If the metadata
+// describes several entities, entityID selects the identity provider.
+func parseMetadata(data []byte, entityID string, now time.Time) (*idpMetadata, error) {
+	if err := xrv.Validate(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("validating XML metadata: %v", err)
+	}
+
+	var entities []entityDescriptor
+	var entity entityDescriptor
+	var group entitiesDescriptor
+	switch err := xml.Unmarshal(data, &entity); {
+	case err == nil:
+		entities = []entityDescriptor{entity}
+	case xml.Unmarshal(data, &group) == nil:
+		if entities, err = group.idpEntities(now); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unmarshal metadata: %v", err)
+	}
+
+	var candidates []entityDescriptor
+	for _, e := range entities {
+		if len(e.IDPSSODescriptors) != 0 && (entityID == "" || e.EntityID == entityID) {
+			candidates = append(candidates, e)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		if entityID != "" {
+			return nil, fmt.Errorf("metadata does not describe identity provider %q", entityID)
+		}
+		return nil, fmt.Errorf("metadata does not describe an identity provider")
+	case 1:
+	default:
+		return nil, fmt.Errorf("metadata describes %d identity providers, set metadataEntityID to select one", len(candidates))
+	}
+	entity = candidates[0]
+	if err := checkValidUntil(entity.ValidUntil, now); err != nil {
+		return nil, fmt.Errorf("entity %q: %v", entity.EntityID, err)
+	}
+
+	md := &idpMetadata{
+		entityID: entity.EntityID,
+		ssoURLs:  make(map[string]string),
+	}
+	for _, d := range entity.IDPSSODescriptors {
+		if err := checkValidUntil(d.ValidUntil, now); err != nil {
+			return nil, fmt.Errorf("entity %q: IDPSSODescriptor: %v", entity.EntityID, err)
+		}
+		md.wantAuthnRequestsSigned = md.wantAuthnRequestsSigned || d.WantAuthnRequestsSigned
+		for _, sso := range d.SingleSignOnServices {
+			if _, ok := md.ssoURLs[sso.Binding]; !ok && sso.Location != "" {
+				md.ssoURLs[sso.Binding] = sso.Location
+			}
+		}
+		for _, key := range d.KeyDescriptors {
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, data := range key.KeyInfo.X509Data.Certificates {
+				cert, err := parseBase64Cert(data)
+				if err != nil {
+					return nil, fmt.Errorf("entity %q: parse signing certificate: %v", entity.EntityID, err)
+				}
+				md.certs = append(md.certs, cert)
+			}
+		}
+	}
+	if len(md.ssoURLs) == 0 {
+		return nil, fmt.Errorf("entity %q does not have a SingleSignOnService", entity.EntityID)
+	}
+	return md, nil
+}
+
+// idpEntities returns the entities of a group of entities and its nested
+// groups, skipping groups which are no longer valid.
+func (g entitiesDescriptor) idpEntities(now time.Time) ([]entityDescriptor, error) {
+	if err := checkValidUntil(g.ValidUntil, now); err != nil {
+		return nil, err
+	}
+	entities := g.EntityDescriptors
+	for _, nested := range g.EntitiesDescriptors {
+		nestedEntities, err := nested.idpEntities(now)
+		if err != nil {
+			continue
+		}
+		entities = append(entities, nestedEntities...)
+	}
+	return entities, nil
+}
+
+// checkValidUntil returns an error if the validUntil attribute of a metadata
+// element has passed.
+func checkValidUntil(validUntil string, now time.Time) error {
+	if validUntil == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, validUntil)
+	if err != nil {
+		return fmt.Errorf("parse validUntil: %v", err)
+	}
+	if after(now, t) {
+		return fmt.Errorf("metadata expired at %s", t)
+	}
+	return nil
+}
+
+func parseBase64Cert(data string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// verifyMetadataSig verifies the signature of the root element of the
+// metadata, and returns the signed element.
+func verifyMetadataSig(validator *dsig.ValidationContext, data []byte) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("parse document: %v", err)
+	}
+	if doc.Root() == nil {
+		return nil, fmt.Errorf("metadata does not contain an element")
+	}
+	transformed, err := validator.Validate(doc.Root())
+	if err != nil {
+		return nil, fmt.Errorf("metadata does not contain a valid signature element: %v", err)
+	}
+	doc.SetRoot(transformed)
+	return doc.WriteToBytes()
+}
+
+// metadataLoader loads the metadata of the identity provider from a URL or a
+// file. Metadata fetched from a URL is only downloaded again if it changed.
+type metadataLoader struct {
+	url    string
+	file   string
+	client *http.Client
+
+	etag         string
+	lastModified string
+}
+
+// load returns the metadata, or nil if it hasn't changed since it was last
+// loaded.
+func (l *metadataLoader) load(ctx context.Context) ([]byte, error) {
+	if l.file != "" {
+		data, err := os.ReadFile(l.file)
+		if err != nil {
+			return nil, fmt.Errorf("read metadata file: %v", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new metadata request: %v", err)
+	}
+	if l.etag != "" {
+		req.Header.Set("If-None-Match", l.etag)
+	}
+	if l.lastModified != "" {
+		req.Header.Set("If-Modified-Since", l.lastModified)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch metadata: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("fetch metadata: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("read metadata: %v", err)
+	}
+	l.etag = resp.Header.Get("ETag")
+	l.lastModified = resp.Header.Get("Last-Modified")
+	return data, nil
+}
+
+// forget makes the next load download the metadata even if it hasn't
+// changed, e.g. because the metadata last downloaded was rejected.
+func (l *metadataLoader) forget() {
+	l.etag, l.lastModified = "", ""
+}
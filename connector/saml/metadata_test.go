@@ -0,0 +1,290 @@
+package saml
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+const testMetadataEntityID = "http://www.okta.com/exk91cb99lKkKSYoy0h7"
+
+// loadKeyPair loads a key pair of the testdata directory to sign metadata
+// with.
+func loadKeyPair(t *testing.T, name string) dsig.X509KeyStore {
+	certData, err := os.ReadFile("testdata/" + name + ".crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := os.ReadFile("testdata/" + name + ".key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dsig.TLSCertKeyStore(pair)
+}
+
+// signMetadata signs metadata with a key pair of the testdata directory.
+func signMetadata(t *testing.T, data []byte, keyPair string) []byte {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := dsig.NewDefaultSigningContext(loadKeyPair(t, keyPair)).SignEnveloped(doc.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.SetRoot(signed)
+	out, err := doc.WriteToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// testMetadata returns the metadata of the testdata directory, listing the
+// signing certificates of the given key pairs.
+func testMetadata(t *testing.T, keyPairs ...string) []byte {
+	data, err := os.ReadFile("testdata/idp-metadata.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, name := range keyPairs {
+		certData, err := os.ReadFile("testdata/" + name + ".crt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(certData)
+		keys = append(keys, `<md:KeyDescriptor use="signing"><ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data><ds:X509Certificate>`+
+			base64.StdEncoding.EncodeToString(block.Bytes)+`</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>`)
+	}
+	start := strings.Index(string(data), "<md:KeyDescriptor")
+	end := strings.Index(string(data), "</md:KeyDescriptor>") + len("</md:KeyDescriptor>")
+	return []byte(string(data[:start]) + strings.Join(keys, "\n") + string(data[end:]))
+}
+
+func TestParseMetadata(t *testing.T) {
+	data, err := os.ReadFile("testdata/idp-metadata.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, err := parseMetadata(data, "", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.entityID != testMetadataEntityID {
+		t.Errorf("expected entity ID %q, got %q", testMetadataEntityID, md.entityID)
+	}
+	if got := md.ssoURLs[bindingPOST]; got != "https://idp.example.com/sso/saml" {
+		t.Errorf("unexpected HTTP-POST SSO URL %q", got)
+	}
+	if got := md.ssoURLs[bindingRedirect]; got != "https://idp.example.com/sso/saml/redirect" {
+		t.Errorf("unexpected HTTP-Redirect SSO URL %q", got)
+	}
+	ca, err := loadCert("testdata/ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(md.certs) != 1 || !md.certs[0].Equal(ca) {
+		t.Errorf("expected the signing certificate of testdata/ca.crt")
+	}
+}
+
+func TestParseMetadataEntities(t *testing.T) {
+	data, err := os.ReadFile("testdata/idp-metadata.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity := string(data[strings.Index(string(data), "<md:EntityDescriptor"):])
+	other := strings.Replace(entity, testMetadataEntityID, "https://other.example.com", 1)
+	group := `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">` + entity + other + `</md:EntitiesDescriptor>`
+
+	if _, err := parseMetadata([]byte(group), "", time.Now()); err == nil {
+		t.Errorf("expected error selecting one of several identity providers")
+	}
+	md, err := parseMetadata([]byte(group), "https://other.example.com", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.entityID != "https://other.example.com" {
+		t.Errorf("expected selected entity, got %q", md.entityID)
+	}
+	if _, err := parseMetadata([]byte(group), "https://unknown.example.com", time.Now()); err == nil {
+		t.Errorf("expected error selecting unknown identity provider")
+	}
+}
+
+func TestParseMetadataValidUntil(t *testing.T) {
+	data, err := os.ReadFile("testdata/idp-metadata.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiring := strings.Replace(string(data), `entityID=`, `validUntil="2022-01-01T00:00:00.000Z" entityID=`, 1)
+
+	if _, err := parseMetadata([]byte(expiring), "", time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("expected valid metadata, got %v", err)
+	}
+	if _, err := parseMetadata([]byte(expiring), "", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("expected expired metadata to be rejected")
+	}
+}
+
+// metadataServer serves metadata with an ETag.
+type metadataServer struct {
+	mu     sync.Mutex
+	data   []byte
+	etag   string
+	notMod int
+}
+
+func (s *metadataServer) set(data []byte, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data, s.etag = data, etag
+}
+
+func (s *metadataServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("If-None-Match") == s.etag {
+		s.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Write(s.data)
+}
+
+func TestMetadataURL(t *testing.T) {
+	ms := &metadataServer{}
+	ms.set(signMetadata(t, testMetadata(t, "ca"), "ca"), `"1"`)
+	s := httptest.NewServer(ms)
+	defer s.Close()
+
+	c := Config{
+		MetadataURL:  s.URL,
+		CA:           "testdata/ca.crt",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	idp := p.idpConfig()
+	if idp.ssoURL != "https://idp.example.com/sso/saml" || idp.ssoIssuer != testMetadataEntityID {
+		t.Errorf("unexpected SSO URL %q and issuer %q", idp.ssoURL, idp.ssoIssuer)
+	}
+
+	// Unchanged metadata isn't downloaded again.
+	if err := p.refreshMetadata(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ms.notMod != 1 || p.idpConfig() != idp {
+		t.Errorf("expected metadata not to be downloaded again")
+	}
+
+	// Metadata not signed by a trusted certificate is rejected, and the
+	// last good metadata is kept.
+	ms.set(signMetadata(t, testMetadata(t, "bad-ca"), "bad-ca"), `"2"`)
+	if err := p.refreshMetadata(context.Background()); err == nil {
+		t.Errorf("expected metadata signed by an untrusted certificate to be rejected")
+	}
+	if p.idpConfig() != idp {
+		t.Errorf("expected the last good metadata to be kept")
+	}
+
+	// The identity provider rotates its certificate: the new certificate is
+	// announced by metadata signed with the old one, and then signs the
+	// metadata itself.
+	ms.set(signMetadata(t, testMetadata(t, "ca", "bad-ca"), "ca"), `"3"`)
+	if err := p.refreshMetadata(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ms.set(signMetadata(t, testMetadata(t, "bad-ca"), "bad-ca"), `"4"`)
+	if err := p.refreshMetadata(context.Background()); err != nil {
+		t.Fatalf("expected metadata signed by the rotated certificate to be accepted: %v", err)
+	}
+	badCA, err := loadCert("testdata/bad-ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certs := p.md.certs; len(certs) != 1 || !certs[0].Equal(badCA) {
+		t.Errorf("expected the rotated certificate to be used")
+	}
+}
+
+func TestMetadataURLUnsigned(t *testing.T) {
+	ms := &metadataServer{}
+	ms.set(testMetadata(t, "ca"), `"1"`)
+	s := httptest.NewServer(ms)
+	defer s.Close()
+
+	c := Config{
+		MetadataURL:  s.URL,
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+	}
+	if _, err := c.openConnector(logrus.New()); err == nil || !strings.Contains(err.Error(), "must use https") {
+		t.Errorf("expected unsigned metadata over http to be rejected, got %v", err)
+	}
+	c.InsecureSkipSignatureValidation = true
+	c.CA = "testdata/ca.crt"
+	if _, err := c.openConnector(logrus.New()); err == nil || !strings.Contains(err.Error(), "must use https") {
+		t.Errorf("expected unverified metadata over http to be rejected, got %v", err)
+	}
+}
+
+func TestMetadataFile(t *testing.T) {
+	c := Config{
+		MetadataFile: "testdata/idp-metadata.xml",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	now, err := time.Parse(timeFormat, "2017-04-04T04:34:59.330Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.now = func() time.Time { return now }
+
+	resp, err := os.ReadFile("testdata/good-resp.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ident, err := p.HandlePOST(connector.Scopes{}, base64.StdEncoding.EncodeToString(resp), "6zmm5mguyebwvajyf2sdwwcw6m")
+	if err != nil {
+		t.Fatalf("expected response signed by the certificate of the metadata to be accepted: %v", err)
+	}
+	if ident.Email != "eric.chiang+okta@coreos.com" {
+		t.Errorf("unexpected identity %+v", ident)
+	}
+
+}
@@ -0,0 +1,262 @@
+package saml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/connector/ldap"
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// SAML has no way to ask the identity provider about a user without the user
+// being present, so the identity validated at login is kept in the connector
+// data and restored when it's refreshed. The groups can be looked up again in
+// an LDAP directory or a SCIM service provider.
+
+// RefreshGroupsConfig configures looking up the groups of a user again when
+// the identity is refreshed.
+type RefreshGroupsConfig struct {
+	// Claim of the identity to look up the user by, either "nameID", "username"
+	// or "email". Defaults to "username".
+	UserClaim string `json:"userClaim"`
+
+	// LDAP directory to look up the groups in, configured like the LDAP
+	// connector. Only the user and group searches are used.
+	LDAP *ldap.Config `json:"ldap"`
+
+	// SCIM service provider to look up the groups in.
+	SCIM *SCIMConfig `json:"scim"`
+}
+
+// SCIMConfig configures looking up groups through the SCIM 2.0 API.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2
+type SCIMConfig struct {
+	// Base URL of the API, e.g. "https://scim.example.com/scim/v2".
+	URL string `json:"url"`
+
+	// Token sent as bearer token in the Authorization header.
+	BearerToken string `json:"bearerToken"`
+
+	// User attribute to filter the users by. Defaults to "userName".
+	UserAttr string `json:"userAttr"`
+}
+
+// groupsLookup looks up the groups of a user.
+type groupsLookup interface {
+	LookupGroups(ctx context.Context, user string) ([]string, error)
+}
+
+func (c *RefreshGroupsConfig) open(id string, logger log.Logger) (groupsLookup, error) {
+	switch c.UserClaim {
+	case "", "nameID", "username", "email":
+	default:
+		return nil, fmt.Errorf("invalid refreshGroups.userClaim %q", c.UserClaim)
+	}
+	switch {
+	case c.LDAP != nil && c.SCIM != nil:
+		return nil, fmt.Errorf("must provide either 'refreshGroups.ldap' or 'refreshGroups.scim'")
+	case c.LDAP != nil:
+		conn, err := c.LDAP.Open(id, logger)
+		if err != nil {
+			return nil, fmt.Errorf("refreshGroups.ldap: %v", err)
+		}
+		lookup, ok := conn.(groupsLookup)
+		if !ok {
+			return nil, fmt.Errorf("refreshGroups.ldap: connector cannot look up groups")
+		}
+		return lookup, nil
+	case c.SCIM != nil:
+		if c.SCIM.URL == "" {
+			return nil, fmt.Errorf("missing required field %q", "refreshGroups.scim.url")
+		}
+		lookup := &scimGroupsLookup{
+			url:         strings.TrimSuffix(c.SCIM.URL, "/"),
+			bearerToken: c.SCIM.BearerToken,
+			userAttr:    c.SCIM.UserAttr,
+			client:      &http.Client{Timeout: 30 * time.Second},
+		}
+		if lookup.userAttr == "" {
+			lookup.userAttr = "userName"
+		}
+		return lookup, nil
+	default:
+		return nil, fmt.Errorf("must provide either 'refreshGroups.ldap' or 'refreshGroups.scim'")
+	}
+}
+
+// refreshData is the connector data of an identity.
+type refreshData struct {
+	Identity cachedIdentity `json:"identity"`
+
+	// The NameID of the subject of the assertion.
+	NameID string `json:"nameID"`
+
+	// When the session at the identity provider ends, zero if the identity
+	// provider didn't limit it.
+	SessionNotOnOrAfter time.Time `json:"sessionNotOnOrAfter,omitempty"`
+}
+
+// cachedIdentity is the identity validated at login.
+type cachedIdentity struct {
+	UserID            string   `json:"userID"`
+	Username          string   `json:"username"`
+	PreferredUsername string   `json:"preferredUsername,omitempty"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"emailVerified"`
+	Groups            []string `json:"groups,omitempty"`
+}
+
+// newRefreshData returns the connector data of an identity validated from an
+// assertion.
+func newRefreshData(ident connector.Identity, assertion *assertion) ([]byte, error) {
+	data := refreshData{
+		Identity: cachedIdentity{
+			UserID:            ident.UserID,
+			Username:          ident.Username,
+			PreferredUsername: ident.PreferredUsername,
+			Email:             ident.Email,
+			EmailVerified:     ident.EmailVerified,
+			Groups:            ident.Groups,
+		},
+		NameID: ident.UserID,
+	}
+	if assertion.AuthnStatement != nil {
+		data.SessionNotOnOrAfter = time.Time(assertion.AuthnStatement.SessionNotOnOrAfter)
+	}
+	connData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("saml: marshal connector data: %v", err)
+	}
+	return connData, nil
+}
+
+// Refresh restores the identity validated at login, until the session at the
+// identity provider ends. If refreshGroups is set, the groups of the user are
+// looked up again.
+//
+// Sessions of logins before the connector refreshed identities have no
+// connector data. Their claims are kept, like they were before.
+func (p *provider) Refresh(ctx context.Context, s connector.Scopes, ident connector.Identity) (connector.Identity, error) {
+	if len(ident.ConnectorData) == 0 {
+		return ident, nil
+	}
+	var data refreshData
+	if err := json.Unmarshal(ident.ConnectorData, &data); err != nil {
+		return ident, fmt.Errorf("saml: failed to unmarshal internal data: %v", err)
+	}
+	if !data.SessionNotOnOrAfter.IsZero() && !p.now().Before(data.SessionNotOnOrAfter) {
+		return ident, fmt.Errorf("saml: session of %q ended at %s", data.NameID, data.SessionNotOnOrAfter)
+	}
+
+	newIdent := connector.Identity{
+		UserID:            data.Identity.UserID,
+		Username:          data.Identity.Username,
+		PreferredUsername: data.Identity.PreferredUsername,
+		Email:             data.Identity.Email,
+		EmailVerified:     data.Identity.EmailVerified,
+		Groups:            data.Identity.Groups,
+		ConnectorData:     ident.ConnectorData,
+	}
+	if p.groupsLookup == nil || !s.Groups {
+		return newIdent, nil
+	}
+
+	var user string
+	switch p.refreshGroupsUserClaim {
+	case "nameID":
+		user = data.NameID
+	case "email":
+		user = newIdent.Email
+	default:
+		user = newIdent.Username
+	}
+	userGroups, err := p.groupsLookup.LookupGroups(ctx, user)
+	if err != nil {
+		return ident, fmt.Errorf("saml: failed to look up groups of %q: %v", user, err)
+	}
+	if newIdent.Groups, err = p.allowGroups(userGroups); err != nil {
+		return ident, err
+	}
+
+	data.Identity.Groups = newIdent.Groups
+	if newIdent.ConnectorData, err = json.Marshal(data); err != nil {
+		return ident, fmt.Errorf("saml: marshal connector data: %v", err)
+	}
+	return newIdent, nil
+}
+
+// scimGroupsLookup looks up the groups of a user through the SCIM API.
+type scimGroupsLookup struct {
+	url         string
+	bearerToken string
+	userAttr    string
+	client      *http.Client
+}
+
+type scimListResponse struct {
+	TotalResults int `json:"totalResults"`
+	Resources    []struct {
+		Groups []struct {
+			Value   string `json:"value"`
+			Display string `json:"display"`
+		} `json:"groups"`
+	} `json:"Resources"`
+}
+
+func (l *scimGroupsLookup) LookupGroups(ctx context.Context, user string) ([]string, error) {
+	value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(user)
+	query := url.Values{
+		"filter":     {fmt.Sprintf(`%s eq "%s"`, l.userAttr, value)},
+		"attributes": {"groups"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url+"/Users?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("scim: new request: %v", err)
+	}
+	req.Header.Set("Accept", "application/scim+json")
+	if l.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+l.bearerToken)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scim: query users: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("scim: read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scim: query users: %s: %s", resp.Status, body)
+	}
+	var list scimListResponse
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("scim: unmarshal response: %v", err)
+	}
+	switch len(list.Resources) {
+	case 0:
+		return nil, fmt.Errorf("scim: user not found %q", user)
+	case 1:
+	default:
+		return nil, fmt.Errorf("scim: filter for user %q matched %d users", user, len(list.Resources))
+	}
+
+	var groups []string
+	for _, g := range list.Resources[0].Groups {
+		if g.Display != "" {
+			groups = append(groups, g.Display)
+		} else {
+			groups = append(groups, g.Value)
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,175 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestRefresh(t *testing.T) {
+	c := Config{
+		CA:           "testdata/ca.crt",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		GroupsAttr:   "groups",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+		SSOURL:       "http://foo.bar/",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now, err := time.Parse(timeFormat, "2017-04-04T04:34:59.330Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.now = func() time.Time { return now }
+
+	resp, err := os.ReadFile("testdata/good-resp.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopes := connector.Scopes{OfflineAccess: true, Groups: true}
+	ident, err := p.HandlePOST(scopes, base64.StdEncoding.EncodeToString(resp), "6zmm5mguyebwvajyf2sdwwcw6m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ident.ConnectorData) == 0 {
+		t.Fatal("expected connector data with offline access")
+	}
+
+	// The server only keeps the claims and the connector data.
+	refreshed, err := p.Refresh(context.Background(), scopes, connector.Identity{UserID: ident.UserID, ConnectorData: ident.ConnectorData})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(refreshed, ident); diff != "" {
+		t.Errorf("expected the identity of the login: %s", diff)
+	}
+}
+
+func TestRefreshLegacySession(t *testing.T) {
+	p := &provider{now: time.Now}
+
+	// Sessions of logins before the connector refreshed identities have no
+	// connector data, their claims are kept as they are.
+	ident := connector.Identity{
+		UserID:        "eric.chiang+okta@coreos.com",
+		Username:      "Eric",
+		Email:         "eric.chiang+okta@coreos.com",
+		EmailVerified: true,
+		Groups:        []string{"Admins", "Everyone"},
+	}
+	refreshed, err := p.Refresh(context.Background(), connector.Scopes{OfflineAccess: true, Groups: true}, ident)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(refreshed, ident); diff != "" {
+		t.Errorf("expected the identity to be unchanged: %s", diff)
+	}
+}
+
+func TestRefreshSessionNotOnOrAfter(t *testing.T) {
+	var a assertion
+	err := xml.Unmarshal([]byte(`<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">
+  <saml2:AuthnStatement AuthnInstant="2017-04-04T04:34:59.330Z" SessionNotOnOrAfter="2017-04-04T12:34:59.330Z"/>
+</saml2:Assertion>`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := newRefreshData(connector.Identity{UserID: "jane", Username: "jane"}, &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2017, 4, 4, 12, 0, 0, 0, time.UTC)
+	p := &provider{now: func() time.Time { return now }}
+	ident := connector.Identity{UserID: "jane", ConnectorData: data}
+	if _, err := p.Refresh(context.Background(), connector.Scopes{}, ident); err != nil {
+		t.Errorf("expected refresh during the session to succeed: %v", err)
+	}
+	now = now.Add(time.Hour)
+	if _, err := p.Refresh(context.Background(), connector.Scopes{}, ident); err == nil {
+		t.Errorf("expected refresh after the session ended to fail")
+	}
+}
+
+func TestRefreshSCIMGroups(t *testing.T) {
+	var filters []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scim/v2/Users" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		filter := r.URL.Query().Get("filter")
+		filters = append(filters, filter)
+		if filter != `userName eq "jane"` {
+			w.Write([]byte(`{"totalResults": 0, "Resources": []}`))
+			return
+		}
+		w.Write([]byte(`{"totalResults": 1, "Resources": [{"groups": [
+			{"value": "1", "display": "admins"},
+			{"value": "2", "display": "developers"},
+			{"value": "3"}
+		]}]}`))
+	}))
+	defer s.Close()
+
+	c := RefreshGroupsConfig{SCIM: &SCIMConfig{URL: s.URL + "/scim/v2/", BearerToken: "secret"}}
+	lookup, err := c.open("saml", logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &provider{now: time.Now, groupsLookup: lookup, allowedGroups: []string{"admins", "operators"}}
+
+	data, err := json.Marshal(refreshData{
+		Identity: cachedIdentity{UserID: "jane-id", Username: "jane", Groups: []string{"admins"}},
+		NameID:   "jane-id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ident, err := p.Refresh(context.Background(), connector.Scopes{Groups: true}, connector.Identity{ConnectorData: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(ident.Groups, " "); got != "admins developers 3" {
+		t.Errorf("unexpected groups %q", got)
+	}
+
+	// The groups looked up are kept in the connector data.
+	var newData refreshData
+	if err := json.Unmarshal(ident.ConnectorData, &newData); err != nil {
+		t.Fatal(err)
+	}
+	if len(newData.Identity.Groups) != 3 {
+		t.Errorf("expected the groups to be stored, got %q", newData.Identity.Groups)
+	}
+
+	// Users who left the allowed groups can't refresh.
+	p.allowedGroups = []string{"operators"}
+	if _, err := p.Refresh(context.Background(), connector.Scopes{Groups: true}, connector.Identity{ConnectorData: data}); err == nil {
+		t.Errorf("expected error refreshing a user who isn't a member of the allowed groups")
+	}
+
+	// Looks up the user by the configured claim.
+	p.refreshGroupsUserClaim = "nameID"
+	if _, err := p.Refresh(context.Background(), connector.Scopes{Groups: true}, connector.Identity{ConnectorData: data}); err == nil {
+		t.Errorf("expected error refreshing a user who isn't found")
+	}
+	if got := filters[len(filters)-1]; got != `userName eq "jane-id"` {
+		t.Errorf("expected user to be looked up by NameID, got filter %q", got)
+	}
+}
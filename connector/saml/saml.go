@@ -3,11 +3,17 @@ package saml
 
 import (
 	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -26,8 +32,9 @@ import (
 
 // nolint
 const (
-	bindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
-	bindingPOST     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	bindingPrefix   = "urn:oasis:names:tc:SAML:2.0:bindings:"
+	bindingRedirect = bindingPrefix + "HTTP-Redirect"
+	bindingPOST     = bindingPrefix + "HTTP-POST"
 
 	nameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
 	nameIDFormatUnspecified  = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
@@ -68,11 +75,6 @@ var (
 
 // Config represents configuration options for the SAML provider.
 type Config struct {
-	// TODO(ericchiang): A bunch of these fields could be auto-filled if
-	// we supported SAML metadata discovery.
-	//
-	// https://www.oasis-open.org/committees/download.php/35391/sstc-saml-metadata-errata-2.0-wd-04-diff.pdf
-
 	EntityIssuer string `json:"entityIssuer"`
 	SSOIssuer    string `json:"ssoIssuer"`
 	SSOURL       string `json:"ssoURL"`
@@ -81,6 +83,25 @@ type Config struct {
 	CA     string `json:"ca"`
 	CAData []byte `json:"caData"`
 
+	// URL or file of the metadata of the identity provider. The SSO URL, the
+	// issuer and the signing certificates are read from the metadata unless
+	// ssoURL or ssoIssuer are set, and the metadata is loaded again
+	// periodically to pick up rotated certificates. If ca or caData are set,
+	// the metadata must be signed by one of their certificates or a signing
+	// certificate of the metadata loaded before. Otherwise the metadata URL
+	// must use https.
+	//
+	// https://www.oasis-open.org/committees/download.php/35391/sstc-saml-metadata-errata-2.0-wd-04-diff.pdf
+	MetadataURL  string `json:"metadataURL"`
+	MetadataFile string `json:"metadataFile"`
+
+	// Entity ID of the identity provider if the metadata describes several
+	// entities.
+	MetadataEntityID string `json:"metadataEntityID"`
+
+	// How often the metadata is loaded again. Defaults to "1h".
+	MetadataRefreshInterval string `json:"metadataRefreshInterval"`
+
 	InsecureSkipSignatureValidation bool `json:"insecureSkipSignatureValidation"`
 
 	// Assertion attribute names to lookup various claims with.
@@ -108,6 +129,25 @@ type Config struct {
 	//		urn:oasis:names:tc:SAML:2.0:nameid-format:persistent
 	//
 	NameIDPolicyFormat string `json:"nameIDPolicyFormat"`
+
+	// PEM encoded certificate and RSA private key files of dex as a service
+	// provider. If set, authentication requests are signed, encrypted
+	// assertions are decrypted, and the certificate is published in the
+	// service provider metadata served at "/saml/{connector}/metadata".
+	SPCert string `json:"spCert"`
+	SPKey  string `json:"spKey"`
+
+	// Binding to send authentication requests through, either "HTTP-POST" or
+	// "HTTP-Redirect". This can also be the full URI of the binding.
+	//
+	// Defaults to HTTP-POST, or HTTP-Redirect if the metadata of the identity
+	// provider doesn't have a SingleSignOnService with the HTTP-POST binding.
+	SSOBinding string `json:"ssoBinding"`
+
+	// Look up the groups of a user again when the identity is refreshed.
+	// Otherwise the groups of the assertion are kept until the session at the
+	// identity provider ends.
+	RefreshGroups *RefreshGroupsConfig `json:"refreshGroups"`
 }
 
 type certStore struct {
@@ -119,20 +159,41 @@ func (c certStore) Certificates() (roots []*x509.Certificate, err error) {
 }
 
 // Open validates the config and returns a connector. It does not actually
-// validate connectivity with the provider.
+// validate connectivity with the provider, unless it has to load its metadata.
 func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
-	return c.openConnector(logger)
+	p, err := c.openConnector(logger)
+	if err != nil {
+		return nil, err
+	}
+	if c.RefreshGroups != nil {
+		if p.groupsLookup, err = c.RefreshGroups.open(id, logger); err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.refreshGroupsUserClaim = c.RefreshGroups.UserClaim
+	}
+	return p, nil
 }
 
 func (c *Config) openConnector(logger log.Logger) (*provider, error) {
+	useMetadata := c.MetadataURL != "" || c.MetadataFile != ""
+	if c.MetadataURL != "" && c.MetadataFile != "" {
+		return nil, errors.New("must provide either 'metadataURL' or 'metadataFile'")
+	}
+	if (c.SPCert == "") != (c.SPKey == "") {
+		return nil, errors.New("must provide both 'spCert' and 'spKey'")
+	}
+
 	requiredFields := []struct {
 		name, val string
 	}{
-		{"ssoURL", c.SSOURL},
 		{"usernameAttr", c.UsernameAttr},
 		{"emailAttr", c.EmailAttr},
 		{"redirectURI", c.RedirectURI},
 	}
+	if !useMetadata {
+		requiredFields = append(requiredFields, struct{ name, val string }{"ssoURL", c.SSOURL})
+	}
 	var missing []string
 	for _, f := range requiredFields {
 		if f.val == "" {
@@ -147,11 +208,26 @@ func (c *Config) openConnector(logger log.Logger) (*provider, error) {
 		return nil, fmt.Errorf("missing required fields %q", missing)
 	}
 
+	// Metadata which isn't verified with the CA is only trusted if it's loaded
+	// over TLS, since it provides the certificates assertions are verified with.
+	if c.MetadataURL != "" && (c.InsecureSkipSignatureValidation || (c.CA == "" && c.CAData == nil)) {
+		u, err := url.Parse(c.MetadataURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse metadataURL: %v", err)
+		}
+		if u.Scheme != "https" {
+			return nil, errors.New("'metadataURL' must use https unless the metadata signature is verified with 'ca' or 'caData'")
+		}
+	}
+
 	p := &provider{
-		entityIssuer:  c.EntityIssuer,
-		ssoIssuer:     c.SSOIssuer,
-		ssoURL:        c.SSOURL,
-		now:           time.Now,
+		entityIssuer: c.EntityIssuer,
+		ssoIssuer:    c.SSOIssuer,
+		ssoURL:       c.SSOURL,
+		now:          time.Now,
+
+		skipSignatureValidation: c.InsecureSkipSignatureValidation,
+
 		usernameAttr:  c.UsernameAttr,
 		emailAttr:     c.EmailAttr,
 		groupsAttr:    c.GroupsAttr,
@@ -187,7 +263,23 @@ func (c *Config) openConnector(logger log.Logger) (*provider, error) {
 		}
 	}
 
-	if !c.InsecureSkipSignatureValidation {
+	if c.SSOBinding != "" {
+		binding, err := parseBinding(c.SSOBinding)
+		if err != nil {
+			return nil, err
+		}
+		p.ssoBinding = binding
+	}
+
+	if c.SPCert != "" {
+		keyPair, err := loadSPKeyPair(c.SPCert, c.SPKey)
+		if err != nil {
+			return nil, err
+		}
+		p.spKeyPair = keyPair
+	}
+
+	if !c.InsecureSkipSignatureValidation && (!useMetadata || c.CA != "" || c.CAData != nil) {
 		if (c.CA == "") == (c.CAData == nil) {
 			return nil, errors.New("must provide either 'ca' or 'caData'")
 		}
@@ -225,20 +317,71 @@ func (c *Config) openConnector(logger log.Logger) (*provider, error) {
 		if len(certs) == 0 {
 			return nil, errors.New("no certificates found in ca data")
 		}
-		p.validator = dsig.NewDefaultValidationContext(certStore{certs})
+		p.caCerts = certs
 	}
+
+	if !useMetadata {
+		idp, err := p.newIDPConfig(nil)
+		if err != nil {
+			return nil, err
+		}
+		p.idp = idp
+		return p, nil
+	}
+
+	p.metadata = &metadataLoader{
+		url:    c.MetadataURL,
+		file:   c.MetadataFile,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	p.metadataEntityID = c.MetadataEntityID
+	refreshInterval := defaultMetadataRefreshInterval
+	if c.MetadataRefreshInterval != "" {
+		var err error
+		if refreshInterval, err = time.ParseDuration(c.MetadataRefreshInterval); err != nil {
+			return nil, fmt.Errorf("invalid metadataRefreshInterval %q: %v", c.MetadataRefreshInterval, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := p.refreshMetadata(ctx); err != nil {
+		return nil, fmt.Errorf("load metadata: %v", err)
+	}
+
+	ctx, p.cancel = context.WithCancel(context.Background())
+	go p.refreshMetadataLoop(ctx, refreshInterval)
 	return p, nil
 }
 
 type provider struct {
 	entityIssuer string
-	ssoIssuer    string
-	ssoURL       string
 
-	now func() time.Time
+	// Configured values, which take precedence over the metadata.
+	ssoIssuer  string
+	ssoURL     string
+	ssoBinding string
+	caCerts    []*x509.Certificate
 
-	// If nil, don't do signature validation.
-	validator *dsig.ValidationContext
+	skipSignatureValidation bool
+
+	// The key pair of the service provider to sign authentication requests
+	// with, nil if they aren't signed.
+	spKeyPair *tls.Certificate
+
+	// The configuration of the identity provider. Replaced when its metadata
+	// is refreshed.
+	mu  sync.Mutex
+	idp *idpConfig
+	md  *idpMetadata
+
+	// Loads the metadata of the identity provider, nil if it's configured
+	// statically.
+	metadata         *metadataLoader
+	metadataEntityID string
+	cancel           context.CancelFunc
+
+	now func() time.Time
 
 	// Attribute mappings
 	usernameAttr  string
@@ -252,15 +395,178 @@ type provider struct {
 
 	nameIDPolicyFormat string
 
+	// Looks up the groups of a user when the identity is refreshed, nil if
+	// the groups of the assertion are kept.
+	groupsLookup           groupsLookup
+	refreshGroupsUserClaim string
+
 	logger log.Logger
 }
 
+// idpConfig is the configuration of the identity provider used to log in.
+type idpConfig struct {
+	ssoIssuer  string
+	ssoURL     string
+	ssoBinding string
+
+	// If nil, don't do signature validation.
+	validator *dsig.ValidationContext
+}
+
+// newIDPConfig combines the configuration of the connector with the metadata
+// of the identity provider, which is nil if it's configured statically.
+func (p *provider) newIDPConfig(md *idpMetadata) (*idpConfig, error) {
+	idp := &idpConfig{ssoIssuer: p.ssoIssuer, ssoURL: p.ssoURL, ssoBinding: p.ssoBinding}
+	certs := p.caCerts
+	if md != nil {
+		if idp.ssoIssuer == "" {
+			idp.ssoIssuer = md.entityID
+		}
+		if idp.ssoURL == "" {
+			if idp.ssoBinding == "" {
+				idp.ssoBinding = bindingPOST
+				if _, ok := md.ssoURLs[bindingPOST]; !ok {
+					idp.ssoBinding = bindingRedirect
+				}
+			}
+			if idp.ssoURL = md.ssoURLs[idp.ssoBinding]; idp.ssoURL == "" {
+				return nil, fmt.Errorf("entity %q does not have a SingleSignOnService with the %s binding",
+					md.entityID, strings.TrimPrefix(idp.ssoBinding, bindingPrefix))
+			}
+		}
+		if md.wantAuthnRequestsSigned && p.spKeyPair == nil {
+			return nil, fmt.Errorf("entity %q wants signed authentication requests, set 'spCert' and 'spKey'", md.entityID)
+		}
+		certs = append(append([]*x509.Certificate(nil), certs...), md.certs...)
+	}
+	if idp.ssoBinding == "" {
+		idp.ssoBinding = bindingPOST
+	}
+	if !p.skipSignatureValidation {
+		if len(certs) == 0 {
+			return nil, errors.New("no certificates to verify signatures with")
+		}
+		idp.validator = dsig.NewDefaultValidationContext(certStore{certs})
+	}
+	return idp, nil
+}
+
+// idpConfig returns the current configuration of the identity provider.
+func (p *provider) idpConfig() *idpConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.idp
+}
+
+// refreshMetadata loads the metadata of the identity provider and replaces
+// its configuration. On errors the last good metadata is kept.
+func (p *provider) refreshMetadata(ctx context.Context) error {
+	data, err := p.metadata.load(ctx)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		// Not modified.
+		return nil
+	}
+
+	p.mu.Lock()
+	last := p.md
+	p.mu.Unlock()
+
+	md, idp, err := p.parseMetadata(data, last)
+	if err != nil {
+		p.metadata.forget()
+		return err
+	}
+
+	p.mu.Lock()
+	p.md, p.idp = md, idp
+	p.mu.Unlock()
+	return nil
+}
+
+// parseMetadata verifies and parses metadata loaded after the last good
+// metadata, which is nil when it's loaded for the first time.
+func (p *provider) parseMetadata(data []byte, last *idpMetadata) (*idpMetadata, *idpConfig, error) {
+	if !p.skipSignatureValidation && len(p.caCerts) != 0 {
+		// Trust the certificates of the last good metadata as well, so the
+		// identity provider can rotate the certificate it signs with.
+		roots := p.caCerts
+		if last != nil {
+			roots = append(append([]*x509.Certificate(nil), roots...), last.certs...)
+		}
+		var err error
+		if data, err = verifyMetadataSig(dsig.NewDefaultValidationContext(certStore{roots}), data); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	md, err := parseMetadata(data, p.metadataEntityID, p.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	idp, err := p.newIDPConfig(md)
+	if err != nil {
+		return nil, nil, err
+	}
+	return md, idp, nil
+}
+
+func (p *provider) refreshMetadataLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		refreshCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		if err := p.refreshMetadata(refreshCtx); err != nil {
+			p.logger.Errorf("saml: failed to refresh metadata, keeping the last good metadata: %v", err)
+		}
+		cancel()
+	}
+}
+
+// Close stops refreshing the metadata of the identity provider.
+func (p *provider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if closer, ok := p.groupsLookup.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func (p *provider) POSTData(s connector.Scopes, id string) (action, value string, err error) {
+	idp := p.idpConfig()
+	data, err := p.authnRequest(idp, id)
+	if err != nil {
+		return "", "", err
+	}
+	if p.spKeyPair != nil {
+		if data, err = p.signRequest(data); err != nil {
+			return "", "", fmt.Errorf("sign authn request: %v", err)
+		}
+	}
+
+	// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+	// "3.5.4 Message Encoding"
+	return idp.ssoURL, base64.StdEncoding.EncodeToString(data), nil
+}
+
+// authnRequest returns the authentication request sent to the identity
+// provider.
+func (p *provider) authnRequest(idp *idpConfig, id string) ([]byte, error) {
 	r := &authnRequest{
 		ProtocolBinding: bindingPOST,
 		ID:              id,
 		IssueInstant:    xmlTime(p.now()),
-		Destination:     p.ssoURL,
+		Destination:     idp.ssoURL,
 		NameIDPolicy: &nameIDPolicy{
 			AllowCreate: true,
 			Format:      p.nameIDPolicyFormat,
@@ -271,16 +577,17 @@ func (p *provider) POSTData(s connector.Scopes, id string) (action, value string
 		// Issuer for the request is optional. For example, okta always ignores
 		// this value.
 		r.Issuer = &issuer{Issuer: p.entityIssuer}
+	} else if p.spKeyPair != nil {
+		// The identity provider needs the issuer to look up the certificate
+		// to verify a signed request with.
+		r.Issuer = &issuer{Issuer: p.spEntityID()}
 	}
 
 	data, err := xml.MarshalIndent(r, "", "  ")
 	if err != nil {
-		return "", "", fmt.Errorf("marshal authn request: %v", err)
+		return nil, fmt.Errorf("marshal authn request: %v", err)
 	}
-
-	// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
-	// "3.5.4 Message Encoding"
-	return p.ssoURL, base64.StdEncoding.EncodeToString(data), nil
+	return data, nil
 }
 
 // HandlePOST interprets a request from a SAML provider attempting to verify a
@@ -290,6 +597,7 @@ func (p *provider) POSTData(s connector.Scopes, id string) (action, value string
 //
 // * Validate XML document does not contain malicious inputs.
 // * Verify signature on XML document (or verify sig on assertion elements).
+// * Decrypt the assertion element if it's encrypted, before verifying its sig.
 // * Verify various parts of the Assertion element. Conditions, audience, etc.
 // * Map the Assertion's attribute elements to user info.
 //
@@ -304,13 +612,23 @@ func (p *provider) HandlePOST(s connector.Scopes, samlResponse, inResponseTo str
 		return ident, errors.Wrap(xrvErr, "validating XML response")
 	}
 
+	idp := p.idpConfig()
+
+	// Encrypted assertions are decrypted with the key of the service provider.
+	var key *rsa.PrivateKey
+	if p.spKeyPair != nil {
+		key = p.spKeyPair.PrivateKey.(*rsa.PrivateKey)
+	}
+
 	// Root element is allowed to not be signed if the Assertion element is.
 	rootElementSigned := true
-	if p.validator != nil {
-		rawResp, rootElementSigned, err = verifyResponseSig(p.validator, rawResp)
+	if idp.validator != nil {
+		rawResp, rootElementSigned, err = verifyResponseSig(idp.validator, rawResp, key)
 		if err != nil {
 			return ident, fmt.Errorf("verify signature: %v", err)
 		}
+	} else if rawResp, err = decryptResponse(rawResp, key); err != nil {
+		return ident, err
 	}
 
 	var resp response
@@ -321,8 +639,8 @@ func (p *provider) HandlePOST(s connector.Scopes, samlResponse, inResponseTo str
 	// If the root element isn't signed, there's no reason to inspect these
 	// elements. They're not verified.
 	if rootElementSigned {
-		if p.ssoIssuer != "" && resp.Issuer != nil && resp.Issuer.Issuer != p.ssoIssuer {
-			return ident, fmt.Errorf("expected Issuer value %s, got %s", p.ssoIssuer, resp.Issuer.Issuer)
+		if idp.ssoIssuer != "" && resp.Issuer != nil && resp.Issuer.Issuer != idp.ssoIssuer {
+			return ident, fmt.Errorf("expected Issuer value %s, got %s", idp.ssoIssuer, resp.Issuer.Issuer)
 		}
 
 		// Verify InResponseTo value matches the expected ID associated with
@@ -351,6 +669,15 @@ func (p *provider) HandlePOST(s connector.Scopes, samlResponse, inResponseTo str
 		return ident, fmt.Errorf("response did not contain an assertion")
 	}
 
+	if s.OfflineAccess {
+		// Keep the identity once it's validated, so it can be refreshed.
+		defer func() {
+			if err == nil {
+				ident.ConnectorData, err = newRefreshData(ident, assertion)
+			}
+		}()
+	}
+
 	// Subject is usually optional, but we need it for the user ID, so complain
 	// if it's not present.
 	subject := assertion.Subject
@@ -430,25 +757,34 @@ func (p *provider) HandlePOST(s connector.Scopes, samlResponse, inResponseTo str
 		ident.Groups = groups
 	}
 
+	if ident.Groups, err = p.allowGroups(ident.Groups); err != nil {
+		return ident, err
+	}
+
+	// Otherwise, we're good
+	return ident, nil
+}
+
+// allowGroups checks that a user is a member of one of the allowed groups, if
+// allowedGroups is set, and returns the groups of the user to use.
+func (p *provider) allowGroups(userGroups []string) ([]string, error) {
 	if len(p.allowedGroups) == 0 {
-		// No allowed groups set, just return the ident
-		return ident, nil
+		// No allowed groups set, use all groups.
+		return userGroups, nil
 	}
 
 	// Look for membership in one of the allowed groups
-	groupMatches := groups.Filter(ident.Groups, p.allowedGroups)
+	groupMatches := groups.Filter(userGroups, p.allowedGroups)
 
 	if len(groupMatches) == 0 {
 		// No group membership matches found, disallowing
-		return ident, fmt.Errorf("user not a member of allowed groups")
+		return nil, fmt.Errorf("user not a member of allowed groups")
 	}
 
 	if p.filterGroups {
-		ident.Groups = groupMatches
+		return groupMatches, nil
 	}
-
-	// Otherwise, we're good
-	return ident, nil
+	return userGroups, nil
 }
 
 // validateStatus verifies that the response has a good status code or
@@ -591,7 +927,7 @@ func (p *provider) validateConditions(conditions *conditions) error {
 //
 // Note: we still don't support multiple <Assertion> tags. If there are
 // multiple present this code will only process the first.
-func verifyResponseSig(validator *dsig.ValidationContext, data []byte) (signed []byte, rootVerified bool, err error) {
+func verifyResponseSig(validator *dsig.ValidationContext, data []byte, key *rsa.PrivateKey) (signed []byte, rootVerified bool, err error) {
 	doc := etree.NewDocument()
 	if err = doc.ReadFromBytes(data); err != nil {
 		return nil, false, fmt.Errorf("parse document: %v", err)
@@ -600,12 +936,20 @@ func verifyResponseSig(validator *dsig.ValidationContext, data []byte) (signed [
 	response := doc.Root()
 	transformedResponse, err := validator.Validate(response)
 	if err == nil {
-		// Root element is verified, return it.
+		// Root element is verified, decrypt its assertion and return it.
+		if err := decryptAssertion(transformedResponse, key); err != nil {
+			return nil, false, err
+		}
 		doc.SetRoot(transformedResponse)
 		signed, err = doc.WriteToBytes()
 		return signed, true, err
 	}
 
+	// The signature of an encrypted assertion covers the decrypted assertion.
+	if err := decryptAssertion(response, key); err != nil {
+		return nil, false, err
+	}
+
 	// Ensures xmlns are copied down to the assertion element when they are defined in the root
 	//
 	// TODO: Only select from child elements of the root.
@@ -48,6 +48,10 @@ type responseTest struct {
 	redirectURI  string
 	entityIssuer string
 
+	// Key pair of the service provider to decrypt assertions with.
+	spCert string
+	spKey  string
+
 	// Attribute customization.
 	usernameAttr  string
 	emailAttr     string
@@ -413,6 +417,8 @@ func (r responseTest) run(t *testing.T) {
 		EntityIssuer:  r.entityIssuer,
 		AllowedGroups: r.allowedGroups,
 		FilterGroups:  r.filterGroups,
+		SPCert:        r.spCert,
+		SPKey:         r.spKey,
 		// Never logging in, don't need this.
 		SSOURL: "http://foo.bar/",
 	}
@@ -556,7 +562,7 @@ func runVerify(t *testing.T, ca string, resp string, shouldSucceed bool) {
 		t.Fatal(err)
 	}
 
-	if _, _, err := verifyResponseSig(validator, data); err != nil {
+	if _, _, err := verifyResponseSig(validator, data, nil); err != nil {
 		if shouldSucceed {
 			t.Fatal(err)
 		}
@@ -0,0 +1,204 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// The metadata of a service provider describes where the identity provider
+// sends responses to, and the certificate authentication requests are signed
+// with.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf
+// "2.4.4 Element <SPSSODescriptor>"
+
+const (
+	protocolSAML20 = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	// Signature algorithm of requests sent through the HTTP Redirect binding.
+	sigAlgRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+)
+
+type spEntityDescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+
+	EntityID string `xml:"entityID,attr"`
+
+	SPSSODescriptor spSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+
+	AuthnRequestsSigned        bool   `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool   `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string `xml:"protocolSupportEnumeration,attr"`
+
+	KeyDescriptors            []keyDescriptor   `xml:"KeyDescriptor"`
+	NameIDFormats             []string          `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+	AssertionConsumerServices []indexedEndpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type indexedEndpoint struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr,omitempty"`
+}
+
+// parseBinding resolves the name of a binding, which can be abbreviated to the
+// last component of its URI.
+func parseBinding(name string) (string, error) {
+	for _, binding := range []string{bindingPOST, bindingRedirect} {
+		if name == binding || name == strings.TrimPrefix(binding, bindingPrefix) {
+			return binding, nil
+		}
+	}
+	return "", fmt.Errorf("invalid ssoBinding: %q", name)
+}
+
+// loadSPKeyPair loads the certificate and RSA private key of the service
+// provider.
+func loadSPKeyPair(certFile, keyFile string) (*tls.Certificate, error) {
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load service provider key pair: %v", err)
+	}
+	if _, ok := keyPair.PrivateKey.(*rsa.PrivateKey); !ok {
+		return nil, fmt.Errorf("service provider key must be an RSA key, got %T", keyPair.PrivateKey)
+	}
+	if keyPair.Leaf, err = x509.ParseCertificate(keyPair.Certificate[0]); err != nil {
+		return nil, fmt.Errorf("parse service provider certificate: %v", err)
+	}
+	return &keyPair, nil
+}
+
+// spEntityID returns the entity ID of dex as a service provider.
+func (p *provider) spEntityID() string {
+	if p.entityIssuer != "" {
+		return p.entityIssuer
+	}
+	// The audience expected by HandlePOST if entityIssuer isn't set.
+	return p.redirectURI
+}
+
+// signRequest signs an authentication request sent through the HTTP POST
+// binding with an enveloped signature.
+func (p *provider) signRequest(data []byte) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	req := doc.Root()
+
+	ctx := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(*p.spKeyPair))
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	sig, err := ctx.ConstructSignature(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schema requires the signature to follow the issuer.
+	//
+	// See: https://www.oasis-open.org/committees/download.php/35711/sstc-saml-core-errata-2.0-wd-06-diff.pdf
+	// "3.2.1 Complex Type RequestAbstractType"
+	index := 0
+	if issuer := req.SelectElement("Issuer"); issuer != nil {
+		index = issuer.Index() + 1
+	}
+	req.InsertChildAt(index, sig)
+	return doc.WriteToBytes()
+}
+
+// RedirectURL returns the SSO URL with the authentication request if the
+// identity provider is sent requests through the HTTP Redirect binding.
+// Instead of an enveloped signature, the request is signed by the Signature
+// query parameter.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+// "3.4.4 Message Encoding"
+func (p *provider) RedirectURL(s connector.Scopes, id string) (string, error) {
+	idp := p.idpConfig()
+	if idp.ssoBinding != bindingRedirect {
+		return "", nil
+	}
+	data, err := p.authnRequest(idp, id)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("deflate authn request: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("deflate authn request: %v", err)
+	}
+
+	// The signature covers the query parameters in this order, as they're
+	// encoded in the URL.
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(buf.Bytes())) +
+		"&RelayState=" + url.QueryEscape(id)
+	if p.spKeyPair != nil {
+		query += "&SigAlg=" + url.QueryEscape(sigAlgRSASHA256)
+		digest := sha256.Sum256([]byte(query))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, p.spKeyPair.PrivateKey.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("sign authn request: %v", err)
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+
+	sep := "?"
+	if strings.Contains(idp.ssoURL, "?") {
+		sep = "&"
+	}
+	return idp.ssoURL + sep + query, nil
+}
+
+// Metadata returns the metadata of dex as a service provider.
+func (p *provider) Metadata() ([]byte, error) {
+	d := spSSODescriptor{
+		AuthnRequestsSigned:        p.spKeyPair != nil,
+		WantAssertionsSigned:       !p.skipSignatureValidation,
+		ProtocolSupportEnumeration: protocolSAML20,
+		NameIDFormats:              []string{p.nameIDPolicyFormat},
+		AssertionConsumerServices: []indexedEndpoint{
+			{Binding: bindingPOST, Location: p.redirectURI, Index: 0, IsDefault: true},
+		},
+	}
+	if p.spKeyPair != nil {
+		cert := base64.StdEncoding.EncodeToString(p.spKeyPair.Leaf.Raw)
+		for _, use := range []string{"signing", "encryption"} {
+			key := keyDescriptor{Use: use}
+			key.KeyInfo.X509Data.Certificates = []string{cert}
+			d.KeyDescriptors = append(d.KeyDescriptors, key)
+		}
+	}
+
+	data, err := xml.MarshalIndent(spEntityDescriptor{EntityID: p.spEntityID(), SPSSODescriptor: d}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %v", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
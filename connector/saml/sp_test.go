@@ -0,0 +1,207 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func newSPTestProvider(t *testing.T, c Config) *provider {
+	c.SSOURL = "https://idp.example.com/sso/saml"
+	c.CA = "testdata/ca.crt"
+	c.UsernameAttr = "Name"
+	c.EmailAttr = "email"
+	c.RedirectURI = "http://127.0.0.1:5556/dex/callback"
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestSPMetadata(t *testing.T) {
+	p := newSPTestProvider(t, Config{SPCert: "testdata/ca.crt", SPKey: "testdata/ca.key"})
+
+	data, err := p.Metadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var md spEntityDescriptor
+	if err := xml.Unmarshal(data, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.EntityID != "http://127.0.0.1:5556/dex/callback" {
+		t.Errorf("expected the redirect URI as entity ID, got %q", md.EntityID)
+	}
+	d := md.SPSSODescriptor
+	if !d.AuthnRequestsSigned || !d.WantAssertionsSigned {
+		t.Errorf("expected signed requests and assertions")
+	}
+	if len(d.AssertionConsumerServices) != 1 || d.AssertionConsumerServices[0].Location != p.redirectURI ||
+		d.AssertionConsumerServices[0].Binding != bindingPOST {
+		t.Errorf("unexpected assertion consumer services %+v", d.AssertionConsumerServices)
+	}
+	if len(d.NameIDFormats) != 1 || d.NameIDFormats[0] != nameIDFormatPersistent {
+		t.Errorf("unexpected NameID formats %q", d.NameIDFormats)
+	}
+
+	// The metadata can be read back like the metadata of an identity
+	// provider.
+	ca, err := loadCert("testdata/ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uses []string
+	for _, key := range d.KeyDescriptors {
+		uses = append(uses, key.Use)
+		certs := key.KeyInfo.X509Data.Certificates
+		if len(certs) != 1 {
+			t.Fatalf("expected one certificate, got %d", len(certs))
+		}
+		cert, err := parseBase64Cert(certs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cert.Equal(ca) {
+			t.Errorf("expected the service provider certificate")
+		}
+	}
+	if got := strings.Join(uses, " "); got != "signing encryption" {
+		t.Errorf("unexpected key uses %q", got)
+	}
+}
+
+func TestPOSTDataSigned(t *testing.T) {
+	p := newSPTestProvider(t, Config{SPCert: "testdata/ca.crt", SPKey: "testdata/ca.key"})
+
+	action, value, err := p.POSTData(connector.Scopes{}, "_6zmm5mguyebwvajyf2sdwwcw6m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action != "https://idp.example.com/sso/saml" {
+		t.Errorf("unexpected action %q", action)
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []string
+	for _, el := range doc.Root().ChildElements() {
+		tags = append(tags, el.Tag)
+	}
+	if got := strings.Join(tags, " "); got != "Issuer Signature NameIDPolicy" {
+		t.Errorf("expected the signature to follow the issuer, got elements %q", got)
+	}
+	if issuer := doc.Root().SelectElement("Issuer"); issuer == nil || issuer.Text() != p.redirectURI {
+		t.Errorf("expected the entity ID of the service provider as issuer")
+	}
+
+	ca, err := loadCert("testdata/ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dsig.NewDefaultValidationContext(certStore{[]*x509.Certificate{ca}}).Validate(doc.Root()); err != nil {
+		t.Errorf("expected a valid signature: %v", err)
+	}
+}
+
+func TestRedirectURL(t *testing.T) {
+	p := newSPTestProvider(t, Config{})
+	if redirectURL, err := p.RedirectURL(connector.Scopes{}, "id"); err != nil || redirectURL != "" {
+		t.Errorf("expected the HTTP-POST binding by default, got %q, %v", redirectURL, err)
+	}
+
+	p = newSPTestProvider(t, Config{SSOBinding: "HTTP-Redirect", SPCert: "testdata/ca.crt", SPKey: "testdata/ca.key"})
+	redirectURL, err := p.RedirectURL(connector.Scopes{}, "_6zmm5mguyebwvajyf2sdwwcw6m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "idp.example.com" || u.Path != "/sso/saml" {
+		t.Errorf("unexpected redirect URL %q", redirectURL)
+	}
+	q := u.Query()
+	if q.Get("RelayState") != "_6zmm5mguyebwvajyf2sdwwcw6m" || q.Get("SigAlg") != sigAlgRSASHA256 {
+		t.Errorf("unexpected query %v", q)
+	}
+
+	deflated, err := base64.StdEncoding.DecodeString(q.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req authnRequest
+	if err := xml.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req.ID != "_6zmm5mguyebwvajyf2sdwwcw6m" || req.Destination != "https://idp.example.com/sso/saml" {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	// The signature covers the query as sent, without the signature.
+	signed := u.RawQuery[:strings.Index(u.RawQuery, "&Signature=")]
+	sig, err := base64.StdEncoding.DecodeString(q.Get("Signature"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(signed))
+	ca, err := loadCert("testdata/ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsa.VerifyPKCS1v15(ca.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], sig); err != nil {
+		t.Errorf("expected a valid signature: %v", err)
+	}
+}
+
+func TestSSOBindingFromMetadata(t *testing.T) {
+	md := &idpMetadata{
+		entityID: testMetadataEntityID,
+		ssoURLs:  map[string]string{bindingRedirect: "https://idp.example.com/sso/saml/redirect"},
+	}
+	p := &provider{skipSignatureValidation: true}
+	idp, err := p.newIDPConfig(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idp.ssoBinding != bindingRedirect || idp.ssoURL != "https://idp.example.com/sso/saml/redirect" {
+		t.Errorf("expected the HTTP-Redirect binding, got %q at %q", idp.ssoBinding, idp.ssoURL)
+	}
+
+	p.ssoBinding = bindingPOST
+	if _, err := p.newIDPConfig(md); err == nil {
+		t.Errorf("expected error when the configured binding isn't supported")
+	}
+
+	p.ssoBinding = ""
+	md.wantAuthnRequestsSigned = true
+	if _, err := p.newIDPConfig(md); err == nil {
+		t.Errorf("expected error when signed requests are wanted without a key")
+	}
+}
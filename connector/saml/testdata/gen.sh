@@ -22,6 +22,14 @@ cd "$(dirname "$0")"
 #     -extensions v3_ca \
 #     -out bad-ca.crt \
 #     -subj "/O=BAD/CN=coreos.com"
+#
+# openssl req \
+#     -nodes \
+#     -newkey rsa:2048 \
+#     -keyout sp.key \
+#     -new -x509 -days 7300 \
+#     -out sp.crt \
+#     -subj "/O=DEX/CN=dex service provider"
 
 # Sign these files using xmlsec1.
 #
@@ -45,3 +53,54 @@ xmlsec1 --sign --privkey-pem ca.key,ca.crt \
     --output two-assertions-first-signed.xml \
     two-assertions-first-signed.tmpl
 
+# Encrypt the signed assertion for the service provider, like identity
+# providers configured to encrypt assertions do. The content is encrypted with
+# a random AES key, which is encrypted with the certificate of the service
+# provider.
+#
+# The okta-encrypted-*.xml responses, okta-sp.crt and okta-sp.key aren't
+# generated. They're captures of Okta from the crewjam/saml test data.
+#
+# See: https://www.w3.org/TR/xmlenc-core1/
+encrypt_assertion() {
+    local out=$1 key_transport=$2 padding=$3 cipher=$4 key_size=$5
+    local tmp
+    tmp=$(mktemp -d)
+
+    sed -n '/<saml2:Assertion /,/<\/saml2:Assertion>/p' assertion-signed.xml > "$tmp/assertion.xml"
+    openssl rand "$key_size" > "$tmp/key"
+    openssl rand 16 > "$tmp/iv"
+    openssl enc "-${cipher/aes/aes-}" -K "$(xxd -p -c 64 "$tmp/key")" -iv "$(xxd -p "$tmp/iv")" \
+        -in "$tmp/assertion.xml" -out "$tmp/data"
+    local encrypted_data encrypted_key
+    encrypted_data=$(cat "$tmp/iv" "$tmp/data" | openssl base64 -A)
+    encrypted_key=$(openssl pkeyutl -encrypt -certin -inkey sp.crt \
+        -pkeyopt "rsa_padding_mode:$padding" -in "$tmp/key" | openssl base64 -A)
+
+    {
+        sed '/<saml2:Assertion /,$d' assertion-signed.xml
+        cat <<EOT
+  <saml2:EncryptedAssertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">
+    <xenc:EncryptedData xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" Id="_b6d2f1e4a3c5" Type="http://www.w3.org/2001/04/xmlenc#Element">
+      <xenc:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#$cipher"/>
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <xenc:EncryptedKey Id="_c1e5a7d9b2f4">
+          <xenc:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#$key_transport"/>
+          <xenc:CipherData>
+            <xenc:CipherValue>$encrypted_key</xenc:CipherValue>
+          </xenc:CipherData>
+        </xenc:EncryptedKey>
+      </ds:KeyInfo>
+      <xenc:CipherData>
+        <xenc:CipherValue>$encrypted_data</xenc:CipherValue>
+      </xenc:CipherData>
+    </xenc:EncryptedData>
+  </saml2:EncryptedAssertion>
+</saml2p:Response>
+EOT
+    } > "$out"
+    rm -r "$tmp"
+}
+
+encrypt_assertion encrypted-assertion-oaep-aes256-cbc.xml rsa-oaep-mgf1p oaep aes256-cbc 32
+encrypt_assertion encrypted-assertion-rsa1_5-aes128-cbc.xml rsa-1_5 pkcs1 aes128-cbc 16
@@ -191,9 +191,19 @@ type assertion struct {
 
 	Conditions *conditions `xml:"Conditions"`
 
+	AuthnStatement *authnStatement `xml:"AuthnStatement,omitempty"`
+
 	AttributeStatement *attributeStatement `xml:"AttributeStatement,omitempty"`
 }
 
+type authnStatement struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion AuthnStatement"`
+
+	AuthnInstant        xmlTime `xml:"AuthnInstant,attr,omitempty"`
+	SessionIndex        string  `xml:"SessionIndex,attr,omitempty"`
+	SessionNotOnOrAfter xmlTime `xml:"SessionNotOnOrAfter,attr,omitempty"`
+}
+
 type attributeStatement struct {
 	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement"`
 
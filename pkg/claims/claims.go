@@ -0,0 +1,548 @@
+// Package claims maps the claims of ID tokens, access tokens and userinfo
+// responses to identities.
+package claims
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// Selector selects the value of a claim. The paths are tried in order, the
+// first one that selects a value is used. If none does, the template is
+// rendered, and if it fails or renders an empty string, the constant value is
+// used.
+//
+// A selector can also be configured as a single path:
+//
+//	groups: realm_access.roles
+//
+// Paths are similar to JSONPath: keys are separated by dots, and keys that
+// contain dots or other special characters are quoted in brackets. Array
+// elements are selected by index, and all elements of an array or values of an
+// object by a wildcard.
+//
+//	email
+//	realm_access.roles
+//	["https://example.com/roles"]
+//	$.resource_access.*.roles
+//	emails[0].value
+type Selector struct {
+	// Paths of the claims to select the value from.
+	Paths []string `json:"paths"`
+
+	// Template rendered with the claims if no path selects a value, e.g.
+	// "{{ .given_name }} {{ .family_name }}". Claims that aren't valid
+	// identifiers can be accessed with index, e.g.
+	// `{{ index . "https://example.com/login" }}`.
+	Template string `json:"template"`
+
+	// Value used if neither the paths nor the template select a value.
+	Value interface{} `json:"value"`
+
+	// Required fails the mapping if nothing selects a value.
+	Required bool `json:"required"`
+}
+
+// UnmarshalJSON accepts a single path as well as a selector object.
+func (s *Selector) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*s = Selector{Paths: []string{path}}
+		return nil
+	}
+	type selector Selector
+	return json.Unmarshal(data, (*selector)(s))
+}
+
+// Paths returns a selector for paths.
+func Paths(paths ...string) *Selector {
+	return &Selector{Paths: paths}
+}
+
+// Keys returns a selector for top level claims. Unlike paths, the names of
+// the claims aren't parsed, so they may contain dots or brackets.
+func Keys(names ...string) *Selector {
+	s := &Selector{}
+	for _, name := range names {
+		if name != "" {
+			s.Paths = append(s.Paths, `["`+strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name)+`"]`)
+		}
+	}
+	return s
+}
+
+// Mapping configures how the claims are mapped to the fields of an identity.
+type Mapping struct {
+	UserID            *Selector `json:"userID"`
+	Username          *Selector `json:"username"`
+	PreferredUsername *Selector `json:"preferredUsername"`
+	Email             *Selector `json:"email"`
+	EmailVerified     *Selector `json:"emailVerified"`
+
+	// Groups can be selected from strings, arrays of strings, or arrays of
+	// objects with a "name".
+	Groups *Selector `json:"groups"`
+}
+
+// Mapper maps claims to identities.
+type Mapper struct {
+	userID            *selector
+	username          *selector
+	preferredUsername *selector
+	email             *selector
+	emailVerified     *selector
+	groups            *selector
+}
+
+// Compile parses the selectors of the mapping. Fields the mapping doesn't
+// configure use the selectors of the defaults, or aren't mapped if neither
+// does.
+func (m *Mapping) Compile(defaults Mapping) (*Mapper, error) {
+	if m == nil {
+		m = &Mapping{}
+	}
+	var (
+		mapper Mapper
+		err    error
+	)
+	fields := []struct {
+		name       string
+		configured *Selector
+		fallback   *Selector
+		check      func(interface{}) error
+		compiled   **selector
+	}{
+		{"userID", m.UserID, defaults.UserID, checkString, &mapper.userID},
+		{"username", m.Username, defaults.Username, checkString, &mapper.username},
+		{"preferredUsername", m.PreferredUsername, defaults.PreferredUsername, checkString, &mapper.preferredUsername},
+		{"email", m.Email, defaults.Email, checkString, &mapper.email},
+		{"emailVerified", m.EmailVerified, defaults.EmailVerified, checkBool, &mapper.emailVerified},
+		{"groups", m.Groups, defaults.Groups, checkStrings, &mapper.groups},
+	}
+	for _, f := range fields {
+		s := f.configured
+		if s == nil {
+			s = f.fallback
+		}
+		if s == nil {
+			continue
+		}
+		if *f.compiled, err = compileSelector(f.name, s, f.check); err != nil {
+			return nil, err
+		}
+	}
+	return &mapper, nil
+}
+
+// Identity maps the claims to an identity. It fails if no user ID is
+// selected, or a claim has the wrong type.
+func (m *Mapper) Identity(claims map[string]interface{}) (connector.Identity, error) {
+	var (
+		ident connector.Identity
+		err   error
+	)
+	if ident.UserID, err = m.userID.string(claims); err != nil {
+		return ident, err
+	}
+	if ident.UserID == "" {
+		return ident, errors.New("claims: no user ID selected")
+	}
+	if ident.Username, err = m.username.string(claims); err != nil {
+		return ident, err
+	}
+	if ident.PreferredUsername, err = m.preferredUsername.string(claims); err != nil {
+		return ident, err
+	}
+	if ident.Email, err = m.email.string(claims); err != nil {
+		return ident, err
+	}
+	if ident.EmailVerified, err = m.emailVerified.bool(claims); err != nil {
+		return ident, err
+	}
+	if ident.Groups, err = m.groups.strings(claims); err != nil {
+		return ident, err
+	}
+	return ident, nil
+}
+
+// Merge merges claims, e.g. the claims of an ID token and a userinfo
+// response. The claims of later maps take precedence, objects are merged
+// recursively.
+func Merge(maps ...map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for _, m := range maps {
+		mergeInto(merged, m)
+	}
+	return merged
+}
+
+func mergeInto(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcObj, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dstObj, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dstObj = map[string]interface{}{}
+			dst[k] = dstObj
+		}
+		mergeInto(dstObj, srcObj)
+	}
+}
+
+type selector struct {
+	field    string
+	paths    []path
+	rawPaths []string
+	template *template.Template
+	value    interface{}
+	required bool
+}
+
+func compileSelector(field string, s *Selector, check func(interface{}) error) (*selector, error) {
+	c := &selector{field: field, rawPaths: s.Paths, value: s.Value, required: s.Required}
+	for _, p := range s.Paths {
+		parsed, err := parsePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("claims: %s: invalid path %q: %v", field, p, err)
+		}
+		c.paths = append(c.paths, parsed)
+	}
+	if s.Template != "" {
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(s.Template)
+		if err != nil {
+			return nil, fmt.Errorf("claims: %s: invalid template: %v", field, err)
+		}
+		c.template = tmpl
+	}
+	if s.Value != nil {
+		if err := check(s.Value); err != nil {
+			return nil, fmt.Errorf("claims: %s: invalid value: %v", field, err)
+		}
+	}
+	return c, nil
+}
+
+// missing returns the error of a required selector which selects nothing.
+func (s *selector) missing() error {
+	if len(s.rawPaths) == 0 {
+		return fmt.Errorf("claims: %s: no value selected", s.field)
+	}
+	return fmt.Errorf("claims: %s: missing claim %s", s.field, strings.Join(s.rawPaths, " or "))
+}
+
+// find returns the values selected from the claims, if any.
+func (s *selector) find(claims map[string]interface{}) (values []interface{}, source string) {
+	for i, p := range s.paths {
+		if values := p.eval(claims); len(values) > 0 {
+			return values, fmt.Sprintf("path %q", s.rawPaths[i])
+		}
+	}
+	if s.template != nil {
+		var b strings.Builder
+		// Templates referring to missing claims fail, and fall back to the
+		// value.
+		if err := s.template.Execute(&b, claims); err == nil && b.Len() > 0 {
+			return []interface{}{b.String()}, "template"
+		}
+	}
+	if s.value != nil {
+		return []interface{}{s.value}, "value"
+	}
+	return nil, ""
+}
+
+func (s *selector) string(claims map[string]interface{}) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	values, source := s.find(claims)
+	if len(values) == 0 {
+		if s.required {
+			return "", s.missing()
+		}
+		return "", nil
+	}
+	// Paths with wildcards can select several values, the first one is used.
+	v := values[0]
+	str, ok := toString(v)
+	if !ok {
+		return "", fmt.Errorf("claims: %s: %s selects a %s, expected a string", s.field, source, typeName(v))
+	}
+	return str, nil
+}
+
+func (s *selector) bool(claims map[string]interface{}) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	values, source := s.find(claims)
+	if len(values) == 0 {
+		if s.required {
+			return false, s.missing()
+		}
+		return false, nil
+	}
+	v := values[0]
+	b, ok := toBool(v)
+	if !ok {
+		return false, fmt.Errorf("claims: %s: %s selects a %s, expected a boolean", s.field, source, typeName(v))
+	}
+	return b, nil
+}
+
+func (s *selector) strings(claims map[string]interface{}) ([]string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	values, source := s.find(claims)
+	if len(values) == 0 && s.required {
+		return nil, s.missing()
+	}
+	var groups []string
+	// The values selected by a path with wildcards are combined, e.g. the
+	// roles of all clients.
+	for _, v := range values {
+		strs, err := toStrings(v)
+		if err != nil {
+			return nil, fmt.Errorf("claims: %s: %s %v", s.field, source, err)
+		}
+		groups = append(groups, strs...)
+	}
+	return groups, nil
+}
+
+func toString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		// Numeric IDs, decoded by encoding/json.
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	}
+	return "", false
+}
+
+func toBool(v interface{}) (bool, bool) {
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		// Some providers return "true" and "false" strings.
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func toStrings(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		strs := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				strs = append(strs, s)
+				continue
+			}
+			if obj, ok := e.(map[string]interface{}); ok {
+				if name, ok := obj["name"].(string); ok {
+					strs = append(strs, name)
+					continue
+				}
+			}
+			return nil, fmt.Errorf("selects an array containing a %s, expected strings", typeName(e))
+		}
+		return strs, nil
+	}
+	return nil, fmt.Errorf("selects a %s, expected a string or an array of strings", typeName(v))
+}
+
+func checkString(v interface{}) error {
+	if _, ok := toString(v); !ok {
+		return fmt.Errorf("expected a string, got a %s", typeName(v))
+	}
+	return nil
+}
+
+func checkBool(v interface{}) error {
+	if _, ok := toBool(v); !ok {
+		return fmt.Errorf("expected a boolean, got a %s", typeName(v))
+	}
+	return nil
+}
+
+func checkStrings(v interface{}) error {
+	_, err := toStrings(v)
+	return err
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}, []string:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+type segmentKind int
+
+const (
+	segmentKey segmentKind = iota
+	segmentIndex
+	segmentWildcard
+)
+
+type segment struct {
+	kind  segmentKind
+	key   string
+	index int
+}
+
+// path is a parsed path of a claim.
+type path []segment
+
+func parsePath(p string) (path, error) {
+	s := strings.TrimSpace(p)
+	switch {
+	case s == "":
+		return nil, errors.New("empty path")
+	case s[0] == '$':
+		s = s[1:]
+	case s[0] != '[':
+		s = "." + s
+	}
+
+	var segs path
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			key := s[:end]
+			s = s[end:]
+			switch key {
+			case "":
+				return nil, errors.New("empty key")
+			case "*":
+				segs = append(segs, segment{kind: segmentWildcard})
+			default:
+				segs = append(segs, segment{kind: segmentKey, key: key})
+			}
+		case '[':
+			seg, rest, err := parseBracket(s[1:])
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+			s = rest
+		default:
+			return nil, fmt.Errorf("unexpected %q", s[0])
+		}
+	}
+	return segs, nil
+}
+
+// parseBracket parses the segment following an opening bracket.
+func parseBracket(s string) (segment, string, error) {
+	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+		quote := s[0]
+		var key strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+				if i == len(s) {
+					return segment{}, "", errors.New("unterminated key")
+				}
+				key.WriteByte(s[i])
+			case quote:
+				if !strings.HasPrefix(s[i+1:], "]") {
+					return segment{}, "", errors.New("expected ] after quoted key")
+				}
+				return segment{kind: segmentKey, key: key.String()}, s[i+2:], nil
+			default:
+				key.WriteByte(s[i])
+			}
+		}
+		return segment{}, "", errors.New("unterminated key")
+	}
+
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return segment{}, "", errors.New("missing ]")
+	}
+	inner, rest := strings.TrimSpace(s[:end]), s[end+1:]
+	if inner == "*" {
+		return segment{kind: segmentWildcard}, rest, nil
+	}
+	index, err := strconv.Atoi(inner)
+	if err != nil || index < 0 {
+		return segment{}, "", fmt.Errorf("invalid index %q", inner)
+	}
+	return segment{kind: segmentIndex, index: index}, rest, nil
+}
+
+// eval returns the values the path selects. Wildcards select the elements of
+// arrays in order and the values of objects ordered by key.
+func (p path) eval(claims map[string]interface{}) []interface{} {
+	values := []interface{}{claims}
+	for _, seg := range p {
+		var next []interface{}
+		for _, v := range values {
+			switch seg.kind {
+			case segmentKey:
+				if obj, ok := v.(map[string]interface{}); ok {
+					if e, ok := obj[seg.key]; ok && e != nil {
+						next = append(next, e)
+					}
+				}
+			case segmentIndex:
+				if arr, ok := v.([]interface{}); ok && seg.index < len(arr) {
+					next = append(next, arr[seg.index])
+				}
+			case segmentWildcard:
+				switch v := v.(type) {
+				case []interface{}:
+					next = append(next, v...)
+				case map[string]interface{}:
+					keys := make([]string, 0, len(v))
+					for k := range v {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						next = append(next, v[k])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
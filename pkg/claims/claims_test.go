@@ -0,0 +1,202 @@
+package claims_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/claims"
+)
+
+const testClaims = `{
+	"sub": "1234",
+	"id": 42,
+	"name": "jane",
+	"given_name": "Jane",
+	"family_name": "Doe",
+	"email": "jane@example.com",
+	"email_verified": "true",
+	"emails": [{"value": "jane.doe@example.com"}, {"value": "jdoe@example.com"}],
+	"realm_access": {"roles": ["admin", "user"]},
+	"resource_access": {
+		"b-client": {"roles": ["writer"]},
+		"a-client": {"roles": ["reader"]}
+	},
+	"https://example.com/roles": ["ops"],
+	"teams": [{"name": "red", "id": 1}, {"name": "blue", "id": 2}],
+	"team": "red"
+}`
+
+func mapIdentity(t *testing.T, mapping string, defaults claims.Mapping) (connector.Identity, error) {
+	var m claims.Mapping
+	require.NoError(t, json.Unmarshal([]byte(mapping), &m))
+	mapper, err := m.Compile(defaults)
+	require.NoError(t, err)
+
+	var c map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(testClaims), &c))
+	return mapper.Identity(c)
+}
+
+func TestIdentity(t *testing.T) {
+	cases := map[string]struct {
+		mapping  string
+		defaults claims.Mapping
+		expected connector.Identity
+	}{
+		"paths": {
+			mapping: `{
+				"userID": "sub",
+				"username": {"paths": ["nickname", "name"]},
+				"email": "$.email",
+				"emailVerified": "email_verified",
+				"groups": "realm_access.roles"
+			}`,
+			expected: connector.Identity{UserID: "1234", Username: "jane", Email: "jane@example.com", EmailVerified: true, Groups: []string{"admin", "user"}},
+		},
+		"quoted keys": {
+			mapping:  `{"userID": "['sub']", "groups": "[\"https://example.com/roles\"]"}`,
+			expected: connector.Identity{UserID: "1234", Groups: []string{"ops"}},
+		},
+		"numbers": {
+			mapping:  `{"userID": "id"}`,
+			expected: connector.Identity{UserID: "42"},
+		},
+		"indexes and wildcards": {
+			mapping: `{
+				"userID": "sub",
+				"email": "emails[*].value",
+				"preferredUsername": "emails[1].value",
+				"groups": "resource_access.*.roles"
+			}`,
+			expected: connector.Identity{UserID: "1234", Email: "jane.doe@example.com", PreferredUsername: "jdoe@example.com", Groups: []string{"reader", "writer"}},
+		},
+		"group objects": {
+			mapping:  `{"userID": "sub", "groups": "teams"}`,
+			expected: connector.Identity{UserID: "1234", Groups: []string{"red", "blue"}},
+		},
+		"single group": {
+			mapping:  `{"userID": "sub", "groups": "team"}`,
+			expected: connector.Identity{UserID: "1234", Groups: []string{"red"}},
+		},
+		"template": {
+			mapping:  `{"userID": "sub", "username": {"paths": ["display_name"], "template": "{{ .given_name }} {{ .family_name }}"}}`,
+			expected: connector.Identity{UserID: "1234", Username: "Jane Doe"},
+		},
+		"template with missing claims falls back to value": {
+			mapping:  `{"userID": "sub", "username": {"template": "{{ .nickname }}", "value": "unknown"}}`,
+			expected: connector.Identity{UserID: "1234", Username: "unknown"},
+		},
+		"values": {
+			mapping:  `{"userID": "sub", "emailVerified": {"paths": ["verified"], "value": true}, "groups": {"value": ["everyone"]}}`,
+			expected: connector.Identity{UserID: "1234", EmailVerified: true, Groups: []string{"everyone"}},
+		},
+		"defaults": {
+			mapping: `{"username": "given_name"}`,
+			defaults: claims.Mapping{
+				UserID:   claims.Keys("sub"),
+				Username: claims.Keys("name"),
+				Email:    claims.Keys("email"),
+			},
+			expected: connector.Identity{UserID: "1234", Username: "Jane", Email: "jane@example.com"},
+		},
+		"required defaults": {
+			mapping: `{"userID": "sub", "username": "nickname"}`,
+			defaults: claims.Mapping{
+				Username:      &claims.Selector{Paths: []string{"name"}, Required: true},
+				EmailVerified: &claims.Selector{Paths: []string{"verified"}, Value: true, Required: true},
+			},
+			expected: connector.Identity{UserID: "1234", EmailVerified: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ident, err := mapIdentity(t, tc.mapping, tc.defaults)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ident)
+		})
+	}
+}
+
+func TestIdentityErrors(t *testing.T) {
+	cases := map[string]string{
+		"no user ID":         `{"userID": "oid"}`,
+		"object as string":   `{"userID": "sub", "username": "realm_access"}`,
+		"array as boolean":   `{"userID": "sub", "emailVerified": "emails"}`,
+		"numbers as groups":  `{"userID": "sub", "groups": "teams[*].id"}`,
+		"objects as strings": `{"userID": "sub", "groups": "resource_access"}`,
+		"missing required":   `{"userID": "sub", "username": {"paths": ["nickname"], "required": true}}`,
+		"required groups":    `{"userID": "sub", "groups": {"paths": ["roles"], "required": true}}`,
+	}
+	for name, mapping := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := mapIdentity(t, mapping, claims.Mapping{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	cases := map[string]claims.Mapping{
+		"empty path":          {UserID: claims.Paths("")},
+		"empty key":           {UserID: claims.Paths("realm_access..roles")},
+		"unterminated key":    {UserID: claims.Paths(`["sub]`)},
+		"invalid index":       {UserID: claims.Paths("emails[first]")},
+		"invalid template":    {Username: &claims.Selector{Template: "{{ .name"}},
+		"invalid value":       {EmailVerified: &claims.Selector{Value: "yes please"}},
+		"invalid groups":      {Groups: &claims.Selector{Value: 1.0}},
+		"missing quote end ]": {UserID: claims.Paths(`["sub"`)},
+	}
+	for name, m := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.Compile(claims.Mapping{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	m := claims.Mapping{UserID: claims.Keys("user.id"), Groups: claims.Keys("cognito:groups")}
+	mapper, err := m.Compile(claims.Mapping{})
+	require.NoError(t, err)
+
+	ident, err := mapper.Identity(map[string]interface{}{
+		"user":           map[string]interface{}{"id": "nested"},
+		"user.id":        "literal",
+		"cognito:groups": []interface{}{"admins"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "literal", ident.UserID)
+	assert.Equal(t, []string{"admins"}, ident.Groups)
+}
+
+func TestMerge(t *testing.T) {
+	idToken := map[string]interface{}{
+		"sub":          "1234",
+		"name":         "jane",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"user"}},
+	}
+	userInfo := map[string]interface{}{
+		"name":         "Jane Doe",
+		"email":        "jane@example.com",
+		"realm_access": map[string]interface{}{"groups": []interface{}{"devs"}},
+	}
+	merged := claims.Merge(idToken, userInfo)
+	assert.Equal(t, map[string]interface{}{
+		"sub":   "1234",
+		"name":  "Jane Doe",
+		"email": "jane@example.com",
+		"realm_access": map[string]interface{}{
+			"roles":  []interface{}{"user"},
+			"groups": []interface{}{"devs"},
+		},
+	}, merged)
+
+	// The merged claims don't share objects with the merged maps.
+	merged["realm_access"].(map[string]interface{})["roles"] = nil
+	assert.Equal(t, []interface{}{"user"}, idToken["realm_access"].(map[string]interface{})["roles"])
+}
@@ -0,0 +1,120 @@
+// Package clientassertion authenticates clients at the token endpoint of an
+// OAuth 2.0 provider with signed JWTs, instead of client secrets.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+package clientassertion
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// Type is the client_assertion_type of JWT assertions.
+const Type = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// Lifetime is how long the signed JWTs are valid.
+const Lifetime = 5 * time.Minute
+
+// Signer signs the JWTs authenticating a client.
+type Signer struct {
+	clientID string
+	tokenURL string
+	signer   jose.Signer
+	now      func() time.Time
+}
+
+// NewSigner returns a signer of JWTs for the client, valid at the token URL.
+// The options can add headers identifying the key, such as "kid" or "x5t".
+func NewSigner(clientID, tokenURL string, key jose.SigningKey, opts *jose.SignerOptions) (*Signer, error) {
+	if opts == nil {
+		opts = &jose.SignerOptions{}
+	}
+	signer, err := jose.NewSigner(key, opts.WithType("JWT"))
+	if err != nil {
+		return nil, fmt.Errorf("new signer: %v", err)
+	}
+	return &Signer{
+		clientID: clientID,
+		tokenURL: tokenURL,
+		signer:   signer,
+		now:      time.Now,
+	}, nil
+}
+
+// Assertion returns a new signed JWT, for a single request.
+func (s *Signer) Assertion() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	now := s.now()
+	claims := jwt.Claims{
+		Issuer:    s.clientID,
+		Subject:   s.clientID,
+		Audience:  jwt.Audience{s.tokenURL},
+		ID:        base64.RawURLEncoding.EncodeToString(id),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(Lifetime)),
+	}
+	return jwt.Signed(s.signer).Claims(claims).CompactSerialize()
+}
+
+// Transport adds a signed JWT to the requests to the token endpoint.
+// golang.org/x/oauth2 can't add parameters to refresh requests, so the client
+// is authenticated at the transport. The oauth2.Config must send the client ID
+// in the parameters, with an empty client secret.
+type Transport struct {
+	Signer *Signer
+
+	// Base is the transport sending the requests, http.DefaultTransport if
+	// nil.
+	Base http.RoundTripper
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodPost || r.Body == nil || r.URL.String() != t.Signer.tokenURL {
+		return t.base().RoundTrip(r)
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("clientassertion: parse token request: %v", err)
+	}
+	assertion, err := t.Signer.Assertion()
+	if err != nil {
+		return nil, fmt.Errorf("clientassertion: sign client assertion: %v", err)
+	}
+	form.Set("client_assertion_type", Type)
+	form.Set("client_assertion", assertion)
+	encoded := form.Encode()
+
+	// Requests must not be modified by round trippers.
+	req := r.Clone(r.Context())
+	req.Body = io.NopCloser(strings.NewReader(encoded))
+	req.ContentLength = int64(len(encoded))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(encoded)), nil
+	}
+	return t.base().RoundTrip(req)
+}
@@ -0,0 +1,101 @@
+package clientassertion
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestTransport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var form url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if form, err = url.ParseQuery(string(body)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer s.Close()
+
+	tokenURL := s.URL + "/token"
+	signer, err := NewSigner("client", tokenURL, jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &Transport{Signer: signer}}
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		wantAssertion bool
+	}{
+		{name: "token request", method: http.MethodPost, url: tokenURL, wantAssertion: true},
+		{name: "other endpoint", method: http.MethodPost, url: s.URL + "/userinfo"},
+		{name: "not a post", method: http.MethodGet, url: tokenURL},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			form = nil
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader("grant_type=refresh_token"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if got := form.Get("grant_type"); got != "refresh_token" {
+				t.Errorf("expected grant_type to be kept, got %q", got)
+			}
+			assertion := form.Get("client_assertion")
+			if !tc.wantAssertion {
+				if assertion != "" || form.Get("client_assertion_type") != "" {
+					t.Errorf("expected no client assertion, got %v", form)
+				}
+				return
+			}
+			if got := form.Get("client_assertion_type"); got != Type {
+				t.Errorf("expected client_assertion_type %q, got %q", Type, got)
+			}
+
+			token, err := jwt.ParseSigned(assertion)
+			if err != nil {
+				t.Fatalf("parse client assertion: %v", err)
+			}
+			if typ := token.Headers[0].ExtraHeaders[jose.HeaderType]; typ != "JWT" {
+				t.Errorf("expected typ JWT, got %v", typ)
+			}
+			var claims jwt.Claims
+			if err := token.Claims(&key.PublicKey, &claims); err != nil {
+				t.Fatalf("verify client assertion: %v", err)
+			}
+			if claims.ID == "" {
+				t.Error("expected a jti claim")
+			}
+			expected := jwt.Expected{Issuer: "client", Subject: "client", Audience: jwt.Audience{tokenURL}}
+			if err := claims.ValidateWithLeeway(expected.WithTime(time.Now()), 0); err != nil {
+				t.Errorf("validate client assertion: %v", err)
+			}
+		})
+	}
+}
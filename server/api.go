@@ -15,7 +15,7 @@ import (
 
 // apiVersion increases every time a new call is added to the API. Clients should use this info
 // to determine if the server supports specific features.
-const apiVersion = 2
+const apiVersion = 3
 
 const (
 	// recCost is the recommended bcrypt cost, which balances hash strength and
@@ -45,6 +45,33 @@ type dexAPI struct {
 	version string
 }
 
+func (d dexAPI) GetClient(ctx context.Context, req *api.GetClientReq) (*api.GetClientResp, error) {
+	c, err := d.s.GetClient(req.Id)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return &api.GetClientResp{NotFound: true}, nil
+		}
+		d.logger.Errorf("api: failed to get client: %v", err)
+		return nil, fmt.Errorf("get client: %v", err)
+	}
+
+	return &api.GetClientResp{
+		Client: &api.Client{
+			Id:           c.ID,
+			Secret:       c.Secret,
+			RedirectUris: c.RedirectURIs,
+			TrustedPeers: c.TrustedPeers,
+			Public:       c.Public,
+			Name:         c.Name,
+			LogoUrl:      c.LogoURL,
+			TokenPolicy:  toAPIClientTokenPolicy(c.TokenPolicy),
+			Restrictions: toAPIClientRestrictions(c),
+
+			BackchannelNotificationEndpoint: c.BackchannelNotificationEndpoint,
+		},
+	}, nil
+}
+
 func (d dexAPI) CreateClient(ctx context.Context, req *api.CreateClientReq) (*api.CreateClientResp, error) {
 	if req.Client == nil {
 		return nil, errors.New("no client supplied")
@@ -65,6 +92,16 @@ func (d dexAPI) CreateClient(ctx context.Context, req *api.CreateClientReq) (*ap
 		Public:       req.Client.Public,
 		Name:         req.Client.Name,
 		LogoURL:      req.Client.LogoUrl,
+		TokenPolicy:  toStorageClientTokenPolicy(req.Client.TokenPolicy),
+
+		BackchannelNotificationEndpoint: req.Client.BackchannelNotificationEndpoint,
+	}
+	setClientRestrictions(&c, req.Client.Restrictions)
+	if err := ValidateClientRestrictions(c); err != nil {
+		return nil, fmt.Errorf("create client: invalid restrictions: %v", err)
+	}
+	if err := c.TokenPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("create client: invalid token policy: %v", err)
 	}
 	if err := d.s.CreateClient(c); err != nil {
 		if err == storage.ErrAlreadyExists {
@@ -83,6 +120,19 @@ func (d dexAPI) UpdateClient(ctx context.Context, req *api.UpdateClientReq) (*ap
 	if req.Id == "" {
 		return nil, errors.New("update client: no client ID supplied")
 	}
+	if req.TokenPolicy != nil {
+		if err := toStorageClientTokenPolicy(req.TokenPolicy).Validate(); err != nil {
+			return nil, fmt.Errorf("update client: invalid token policy: %v", err)
+		}
+	}
+
+	if req.Restrictions != nil {
+		var c storage.Client
+		setClientRestrictions(&c, req.Restrictions)
+		if err := ValidateClientRestrictions(c); err != nil {
+			return nil, fmt.Errorf("update client: invalid restrictions: %v", err)
+		}
+	}
 
 	err := d.s.UpdateClient(req.Id, func(old storage.Client) (storage.Client, error) {
 		if req.RedirectUris != nil {
@@ -97,6 +147,15 @@ func (d dexAPI) UpdateClient(ctx context.Context, req *api.UpdateClientReq) (*ap
 		if req.LogoUrl != "" {
 			old.LogoURL = req.LogoUrl
 		}
+		if req.BackchannelNotificationEndpoint != "" {
+			old.BackchannelNotificationEndpoint = req.BackchannelNotificationEndpoint
+		}
+		if req.TokenPolicy != nil {
+			old.TokenPolicy = toStorageClientTokenPolicy(req.TokenPolicy)
+		}
+		if req.Restrictions != nil {
+			setClientRestrictions(&old, req.Restrictions)
+		}
 		return old, nil
 	})
 	if err != nil {
@@ -109,6 +168,66 @@ func (d dexAPI) UpdateClient(ctx context.Context, req *api.UpdateClientReq) (*ap
 	return &api.UpdateClientResp{}, nil
 }
 
+func toStorageClientTokenPolicy(p *api.ClientTokenPolicy) storage.ClientTokenPolicy {
+	if p == nil {
+		return storage.ClientTokenPolicy{}
+	}
+	policy := storage.ClientTokenPolicy{
+		IDTokensValidFor:              p.IdTokensValidFor,
+		AccessTokensValidFor:          p.AccessTokensValidFor,
+		AuthCodesValidFor:             p.AuthCodesValidFor,
+		RefreshTokenAbsoluteLifetime:  p.RefreshTokenAbsoluteLifetime,
+		RefreshTokenValidIfNotUsedFor: p.RefreshTokenValidIfNotUsedFor,
+	}
+	switch p.RefreshTokenRotation {
+	case api.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_ENABLED:
+		disable := false
+		policy.DisableRefreshTokenRotation = &disable
+	case api.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_DISABLED:
+		disable := true
+		policy.DisableRefreshTokenRotation = &disable
+	}
+	return policy
+}
+
+func toAPIClientTokenPolicy(p storage.ClientTokenPolicy) *api.ClientTokenPolicy {
+	policy := &api.ClientTokenPolicy{
+		IdTokensValidFor:              p.IDTokensValidFor,
+		AccessTokensValidFor:          p.AccessTokensValidFor,
+		AuthCodesValidFor:             p.AuthCodesValidFor,
+		RefreshTokenAbsoluteLifetime:  p.RefreshTokenAbsoluteLifetime,
+		RefreshTokenValidIfNotUsedFor: p.RefreshTokenValidIfNotUsedFor,
+	}
+	if p.DisableRefreshTokenRotation != nil {
+		if *p.DisableRefreshTokenRotation {
+			policy.RefreshTokenRotation = api.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_DISABLED
+		} else {
+			policy.RefreshTokenRotation = api.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_ENABLED
+		}
+	}
+	return policy
+}
+
+func setClientRestrictions(c *storage.Client, r *api.ClientRestrictions) {
+	c.AllowedConnectors = r.GetAllowedConnectors()
+	c.AllowedGrantTypes = r.GetAllowedGrantTypes()
+	c.AllowedScopes = r.GetAllowedScopes()
+	c.AllowedResponseTypes = r.GetAllowedResponseTypes()
+}
+
+func toAPIClientRestrictions(c storage.Client) *api.ClientRestrictions {
+	if len(c.AllowedConnectors) == 0 && len(c.AllowedGrantTypes) == 0 &&
+		len(c.AllowedScopes) == 0 && len(c.AllowedResponseTypes) == 0 {
+		return nil
+	}
+	return &api.ClientRestrictions{
+		AllowedConnectors:    c.AllowedConnectors,
+		AllowedGrantTypes:    c.AllowedGrantTypes,
+		AllowedScopes:        c.AllowedScopes,
+		AllowedResponseTypes: c.AllowedResponseTypes,
+	}
+}
+
 func (d dexAPI) DeleteClient(ctx context.Context, req *api.DeleteClientReq) (*api.DeleteClientResp, error) {
 	err := d.s.DeleteClient(req.Id)
 	if err != nil {
@@ -230,8 +349,14 @@ func (d dexAPI) GetVersion(ctx context.Context, req *api.VersionReq) (*api.Versi
 }
 
 func (d dexAPI) ListPasswords(ctx context.Context, req *api.ListPasswordReq) (*api.ListPasswordResp, error) {
-	passwordList, err := d.s.ListPasswords()
+	passwordList, next, err := d.s.ListPasswordsPage(storage.ListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidPageToken) {
+			return nil, err
+		}
 		d.logger.Errorf("api: failed to list passwords: %v", err)
 		return nil, fmt.Errorf("list passwords: %v", err)
 	}
@@ -247,7 +372,8 @@ func (d dexAPI) ListPasswords(ctx context.Context, req *api.ListPasswordReq) (*a
 	}
 
 	return &api.ListPasswordResp{
-		Passwords: passwords,
+		Passwords:     passwords,
+		NextPageToken: next,
 	}, nil
 }
 
@@ -289,30 +415,33 @@ func (d dexAPI) ListRefresh(ctx context.Context, req *api.ListRefreshReq) (*api.
 		return nil, err
 	}
 
-	offlineSessions, err := d.s.GetOfflineSessions(id.UserId, id.ConnId)
+	refreshTokens, next, err := d.s.ListRefreshTokensPage(storage.ListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		Filter:    storage.ListFilter{UserID: id.UserId, ConnectorID: id.ConnId},
+	})
 	if err != nil {
-		if err == storage.ErrNotFound {
-			// This means that this user-client pair does not have a refresh token yet.
-			// An empty list should be returned instead of an error.
-			return &api.ListRefreshResp{}, nil
+		if errors.Is(err, storage.ErrInvalidPageToken) {
+			return nil, err
 		}
-		d.logger.Errorf("api: failed to list refresh tokens %t here : %v", err == storage.ErrNotFound, err)
+		d.logger.Errorf("api: failed to list refresh tokens: %v", err)
 		return nil, err
 	}
 
-	refreshTokenRefs := make([]*api.RefreshTokenRef, 0, len(offlineSessions.Refresh))
-	for _, session := range offlineSessions.Refresh {
+	refreshTokenRefs := make([]*api.RefreshTokenRef, 0, len(refreshTokens))
+	for _, token := range refreshTokens {
 		r := api.RefreshTokenRef{
-			Id:        session.ID,
-			ClientId:  session.ClientID,
-			CreatedAt: session.CreatedAt.Unix(),
-			LastUsed:  session.LastUsed.Unix(),
+			Id:        token.ID,
+			ClientId:  token.ClientID,
+			CreatedAt: token.CreatedAt.Unix(),
+			LastUsed:  token.LastUsed.Unix(),
 		}
 		refreshTokenRefs = append(refreshTokenRefs, &r)
 	}
 
 	return &api.ListRefreshResp{
 		RefreshTokens: refreshTokenRefs,
+		NextPageToken: next,
 	}, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/dexidp/dex/api/v2"
 	"github.com/dexidp/dex/pkg/log"
@@ -227,6 +228,57 @@ func TestCheckCost(t *testing.T) {
 	}
 }
 
+// Lists passwords page by page.
+func TestListPasswordsPage(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	s := memory.New(logger)
+	client := newAPI(s, logger, t)
+	defer client.Close()
+
+	ctx := context.Background()
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if err := s.CreatePassword(storage.Password{Email: email, Hash: []byte("hash"), Username: email, UserID: email}); err != nil {
+			t.Fatalf("create password: %v", err)
+		}
+	}
+
+	var (
+		emails []string
+		req    = api.ListPasswordReq{PageSize: 2}
+	)
+	for i := 0; ; i++ {
+		if i > 3 {
+			t.Fatalf("listing did not terminate")
+		}
+		resp, err := client.ListPasswords(ctx, &req)
+		if err != nil {
+			t.Fatalf("list passwords: %v", err)
+		}
+		if len(resp.Passwords) > 2 {
+			t.Errorf("expected at most 2 passwords per page, got %d", len(resp.Passwords))
+		}
+		for _, p := range resp.Passwords {
+			emails = append(emails, p.Email)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if len(emails) != 3 || emails[0] != "a@example.com" || emails[2] != "c@example.com" {
+		t.Errorf("unexpected passwords listed: %v", emails)
+	}
+
+	if _, err := client.ListPasswords(ctx, &api.ListPasswordReq{PageToken: "not a page token"}); err == nil {
+		t.Errorf("expected an invalid page token to be rejected")
+	}
+}
+
 // Attempts to list and revoke an existing refresh token.
 func TestRefreshToken(t *testing.T) {
 	logger := &logrus.Logger{
@@ -430,6 +482,51 @@ func TestUpdateClient(t *testing.T) {
 				NotFound: false,
 			},
 		},
+		"update client restrictions": {
+			setup:   createClient,
+			cleanup: deleteClient,
+			req: &api.UpdateClientReq{
+				Id:      "test",
+				Name:    "test",
+				LogoUrl: "https://logout",
+				Restrictions: &api.ClientRestrictions{
+					AllowedConnectors: []string{"github"},
+					AllowedGrantTypes: []string{"urn:ietf:params:oauth:grant-type:device_code"},
+				},
+			},
+			wantErr: false,
+			want: &api.UpdateClientResp{
+				NotFound: false,
+			},
+		},
+		"update client backchannel notification endpoint": {
+			setup:   createClient,
+			cleanup: deleteClient,
+			req: &api.UpdateClientReq{
+				Id:                              "test",
+				Name:                            "test",
+				LogoUrl:                         "https://logout",
+				BackchannelNotificationEndpoint: "https://client.example.com/ciba",
+			},
+			wantErr: false,
+			want: &api.UpdateClientResp{
+				NotFound: false,
+			},
+		},
+		"update client with unknown grant type": {
+			setup:   createClient,
+			cleanup: deleteClient,
+			req: &api.UpdateClientReq{
+				Id: "test",
+				Restrictions: &api.ClientRestrictions{
+					AllowedGrantTypes: []string{"client_credentials"},
+				},
+			},
+			wantErr: true,
+			want: &api.UpdateClientResp{
+				NotFound: false,
+			},
+		},
 		"update client which not exists ": {
 			req: &api.UpdateClientReq{
 				Id:           "test",
@@ -490,6 +587,19 @@ func TestUpdateClient(t *testing.T) {
 						t.Errorf("expected trusted peer: %s", peer)
 					}
 				}
+				if tc.req.BackchannelNotificationEndpoint != client.BackchannelNotificationEndpoint {
+					t.Errorf("expected stored client with BackchannelNotificationEndpoint: %s, found %s", tc.req.BackchannelNotificationEndpoint, client.BackchannelNotificationEndpoint)
+				}
+				for _, connector := range tc.req.Restrictions.GetAllowedConnectors() {
+					if !find(connector, client.AllowedConnectors) {
+						t.Errorf("expected allowed connector: %s", connector)
+					}
+				}
+				for _, grantType := range tc.req.Restrictions.GetAllowedGrantTypes() {
+					if !find(grantType, client.AllowedGrantTypes) {
+						t.Errorf("expected allowed grant type: %s", grantType)
+					}
+				}
 			}
 
 			if tc.cleanup != nil {
@@ -499,6 +609,89 @@ func TestUpdateClient(t *testing.T) {
 	}
 }
 
+func TestGetClient(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	s := memory.New(logger)
+	client := newAPI(s, logger, t)
+	defer client.Close()
+	ctx := context.Background()
+
+	want := &api.Client{
+		Id:           "test",
+		Secret:       "secret",
+		RedirectUris: []string{"https://client.example.com/callback"},
+		Name:         "test",
+		TokenPolicy: &api.ClientTokenPolicy{
+			IdTokensValidFor:             "10m",
+			RefreshTokenRotation:         api.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_DISABLED,
+			RefreshTokenAbsoluteLifetime: "720h",
+		},
+		Restrictions: &api.ClientRestrictions{
+			AllowedConnectors: []string{"mock"},
+			AllowedScopes:     []string{"openid", "email"},
+		},
+		BackchannelNotificationEndpoint: "https://client.example.com/ciba",
+	}
+	if _, err := client.CreateClient(ctx, &api.CreateClientReq{Client: want}); err != nil {
+		t.Fatalf("unable to create the client: %v", err)
+	}
+
+	resp, err := client.GetClient(ctx, &api.GetClientReq{Id: "test"})
+	if err != nil {
+		t.Fatalf("unable to get the client: %v", err)
+	}
+	if resp.NotFound {
+		t.Fatal("expected client to be found")
+	}
+	if !proto.Equal(want, resp.Client) {
+		t.Errorf("expected client %v, got %v", want, resp.Client)
+	}
+
+	resp, err = client.GetClient(ctx, &api.GetClientReq{Id: "unknown"})
+	if err != nil {
+		t.Fatalf("unable to get the client: %v", err)
+	}
+	if !resp.NotFound {
+		t.Error("expected unknown client not to be found")
+	}
+}
+
+func TestCreateClientBackchannelNotificationEndpoint(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	s := memory.New(logger)
+	client := newAPI(s, logger, t)
+	defer client.Close()
+
+	_, err := client.CreateClient(context.Background(), &api.CreateClientReq{
+		Client: &api.Client{
+			Id:                              "ping",
+			RedirectUris:                    []string{"https://client.example.com/callback"},
+			BackchannelNotificationEndpoint: "https://client.example.com/ciba",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to create the client: %v", err)
+	}
+
+	c, err := s.GetClient("ping")
+	if err != nil {
+		t.Fatalf("no client found in the storage: %v", err)
+	}
+	if c.BackchannelNotificationEndpoint != "https://client.example.com/ciba" {
+		t.Errorf("expected stored client with BackchannelNotificationEndpoint, found %q", c.BackchannelNotificationEndpoint)
+	}
+}
+
 func find(item string, items []string) bool {
 	for _, i := range items {
 		if item == i {
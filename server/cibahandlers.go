@@ -0,0 +1,524 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+const (
+	cibaDeliveryModePoll = "poll"
+	cibaDeliveryModePing = "ping"
+)
+
+// The maximum length of a binding message. It is meant to be short enough to be
+// displayed on both devices, see https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.1
+const cibaMaxBindingMessageLength = 64
+
+const errInvalidBindingMessage = "invalid_binding_message"
+
+type cibaAuthResponse struct {
+	// The unique identifier of the backchannel authentication request
+	AuthReqID string `json:"auth_req_id"`
+	// The lifetime of the auth_req_id in seconds
+	ExpiresIn int `json:"expires_in"`
+	// How often the client is allowed to poll the token endpoint, omitted in ping mode
+	Interval int `json:"interval,omitempty"`
+}
+
+// handleBackchannelAuthorize handles the backchannel authentication endpoint.
+// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#auth_request
+func (s *Server) handleBackchannelAuthorize(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodPost {
+		s.tokenErrHelper(w, errInvalidRequest, "method not allowed", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.logger.Errorf("Could not parse backchannel authentication request body: %v", err)
+		s.tokenErrHelper(w, errInvalidRequest, "", http.StatusBadRequest)
+		return
+	}
+
+	s.withClientFromStorage(w, r, s.withAllowedGrantType(grantTypeCIBA, s.handleBackchannelAuthRequest))
+}
+
+func (s *Server) handleBackchannelAuthRequest(w http.ResponseWriter, r *http.Request, client storage.Client) {
+	pollIntervalSeconds := 5
+
+	scopes := strings.Fields(r.PostFormValue("scope"))
+	hasOpenIDScope := false
+	for _, scope := range scopes {
+		if scope == scopeOpenID {
+			hasOpenIDScope = true
+		}
+	}
+	if !hasOpenIDScope {
+		s.tokenErrHelper(w, errInvalidScope, `Missing required scope(s) ["openid"].`, http.StatusBadRequest)
+		return
+	}
+	if disallowed := disallowedScopes(client, scopes); len(disallowed) > 0 {
+		s.tokenErrHelper(w, errInvalidScope, fmt.Sprintf("Client can't request scope(s) %q", disallowed), http.StatusBadRequest)
+		return
+	}
+
+	loginHint := r.PostFormValue("login_hint")
+	if loginHint == "" {
+		if r.PostFormValue("login_hint_token") != "" || r.PostFormValue("id_token_hint") != "" {
+			s.tokenErrHelper(w, errInvalidRequest, "Only login_hint is supported to identify the user.", http.StatusBadRequest)
+			return
+		}
+		s.tokenErrHelper(w, errInvalidRequest, "Required param: login_hint.", http.StatusBadRequest)
+		return
+	}
+
+	bindingMessage := r.PostFormValue("binding_message")
+	if len(bindingMessage) > cibaMaxBindingMessageLength {
+		s.tokenErrHelper(w, errInvalidBindingMessage, "Binding message is too long.", http.StatusBadRequest)
+		return
+	}
+
+	expiresIn := s.cibaRequestsValidFor
+	if requestedExpiry := r.PostFormValue("requested_expiry"); requestedExpiry != "" {
+		seconds, err := strconv.Atoi(requestedExpiry)
+		if err != nil || seconds <= 0 {
+			s.tokenErrHelper(w, errInvalidRequest, "Invalid requested_expiry.", http.StatusBadRequest)
+			return
+		}
+		if d := time.Duration(seconds) * time.Second; d < expiresIn {
+			expiresIn = d
+		}
+	}
+
+	// Clients registering a notification endpoint use the ping mode, all others poll.
+	notificationToken := r.PostFormValue("client_notification_token")
+	switch {
+	case client.BackchannelNotificationEndpoint != "" && notificationToken == "":
+		s.tokenErrHelper(w, errInvalidRequest, "Required param: client_notification_token.", http.StatusBadRequest)
+		return
+	case client.BackchannelNotificationEndpoint == "" && notificationToken != "":
+		s.tokenErrHelper(w, errInvalidRequest, "Client has no backchannel notification endpoint registered.", http.StatusBadRequest)
+		return
+	}
+
+	// The user is sent through the regular login flow, which redirects back to dex once they are done.
+	if !validateRedirectURI(client, cibaCallbackURI) {
+		s.tokenErrHelper(w, errUnauthorizedClient, "Client is not registered for backchannel authentication.", http.StatusBadRequest)
+		return
+	}
+
+	s.logger.Infof("Received backchannel authentication request for client %v with scopes %v", client.ID, scopes)
+
+	now := s.now()
+	expiry := now.Add(expiresIn)
+
+	cibaReq := storage.CIBARequest{
+		ApprovalCode:   storage.NewDeviceCode(),
+		AuthReqID:      storage.NewDeviceCode(),
+		ClientID:       client.ID,
+		Scopes:         scopes,
+		LoginHint:      loginHint,
+		BindingMessage: bindingMessage,
+		Expiry:         expiry,
+	}
+	if err := s.storage.CreateCIBARequest(cibaReq); err != nil {
+		s.logger.Errorf("Failed to store backchannel authentication request: %v", err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+
+	cibaToken := storage.CIBAToken{
+		AuthReqID:         cibaReq.AuthReqID,
+		ClientID:          client.ID,
+		Status:            cibaTokenPending,
+		NotificationToken: notificationToken,
+		Expiry:            expiry,
+		LastRequestTime:   now,
+	}
+	if err := s.storage.CreateCIBAToken(cibaToken); err != nil {
+		s.logger.Errorf("Failed to store backchannel authentication token: %v", err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+
+	u, err := url.Parse(s.absURL("/bc-authorize/approve"))
+	if err != nil {
+		s.logger.Errorf("Could not parse issuer URL %v", err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+	q := u.Query()
+	q.Set("code", cibaReq.ApprovalCode)
+	u.RawQuery = q.Encode()
+
+	notification := CIBANotification{
+		LoginHint:      loginHint,
+		BindingMessage: bindingMessage,
+		ClientID:       client.ID,
+		ClientName:     client.Name,
+		Scopes:         scopes,
+		ApprovalURL:    u.String(),
+		Expiry:         expiry,
+	}
+	if err := s.cibaNotifier.Notify(r.Context(), notification); err != nil {
+		s.logger.Errorf("Failed to notify user of backchannel authentication request: %v", err)
+		s.tokenErrHelper(w, errServerError, "Failed to notify user.", http.StatusInternalServerError)
+		return
+	}
+
+	resp := cibaAuthResponse{
+		AuthReqID: cibaReq.AuthReqID,
+		ExpiresIn: int(expiresIn.Seconds()),
+	}
+	if notificationToken == "" {
+		resp.Interval = pollIntervalSeconds
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "   ")
+	enc.Encode(resp)
+}
+
+// handleBackchannelApproval renders the page where the user approves or denies a
+// backchannel authentication request. Approving sends the user through the regular
+// login flow before the tokens are issued.
+func (s *Server) handleBackchannelApproval(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		s.renderError(r, w, http.StatusBadRequest, "Requested resource does not exist.")
+		return
+	}
+
+	approvalCode := r.FormValue("code")
+	if approvalCode == "" {
+		s.renderError(r, w, http.StatusBadRequest, "Request was missing parameters")
+		return
+	}
+
+	cibaReq, err := s.storage.GetCIBARequest(approvalCode)
+	if err != nil || s.now().After(cibaReq.Expiry) {
+		errCode := http.StatusBadRequest
+		if err != nil && err != storage.ErrNotFound {
+			s.logger.Errorf("failed to get backchannel authentication request: %v", err)
+			errCode = http.StatusInternalServerError
+		}
+		s.renderError(r, w, errCode, "Invalid or expired authentication request.")
+		return
+	}
+
+	cibaToken, err := s.storage.GetCIBAToken(cibaReq.AuthReqID)
+	if err != nil {
+		s.logger.Errorf("failed to get backchannel authentication token: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "")
+		return
+	}
+	if cibaToken.Status != cibaTokenPending {
+		s.renderError(r, w, http.StatusBadRequest, "This authentication request has already been answered.")
+		return
+	}
+
+	client, err := s.storage.GetClient(cibaReq.ClientID)
+	if err != nil {
+		s.logger.Errorf("failed to get client: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		if err := s.templates.ciba(r, w, cibaReq.ApprovalCode, client.Name, cibaReq.BindingMessage, cibaReq.Scopes, false); err != nil {
+			s.logger.Errorf("Server template error: %v", err)
+		}
+		return
+	}
+
+	if r.FormValue("approval") != "approve" {
+		if err := s.denyBackchannelAuthRequest(cibaReq, client); err != nil {
+			s.logger.Errorf("failed to deny backchannel authentication request: %v", err)
+			s.renderError(r, w, http.StatusBadRequest, "This authentication request has already been answered.")
+			return
+		}
+		if err := s.templates.ciba(r, w, cibaReq.ApprovalCode, client.Name, cibaReq.BindingMessage, cibaReq.Scopes, true); err != nil {
+			s.logger.Errorf("Server template error: %v", err)
+		}
+		return
+	}
+
+	// Redirect to Dex Auth Endpoint
+	u, err := url.Parse(path.Join(s.issuerURL.Path, "/auth"))
+	if err != nil {
+		s.renderError(r, w, http.StatusInternalServerError, "Invalid auth URI.")
+		return
+	}
+	q := u.Query()
+	q.Set("client_id", cibaReq.ClientID)
+	q.Set("state", cibaReq.ApprovalCode)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", cibaCallbackURI)
+	q.Set("scope", strings.Join(cibaReq.Scopes, " "))
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *Server) denyBackchannelAuthRequest(cibaReq storage.CIBARequest, client storage.Client) error {
+	var notificationToken string
+	updater := func(old storage.CIBAToken) (storage.CIBAToken, error) {
+		if old.Status != cibaTokenPending {
+			return old, errors.New("backchannel authentication token is not pending")
+		}
+		old.Status = cibaTokenDenied
+		notificationToken = old.NotificationToken
+		return old, nil
+	}
+	if err := s.storage.UpdateCIBAToken(cibaReq.AuthReqID, updater); err != nil {
+		return err
+	}
+	s.pingBackchannelClient(client, cibaReq.AuthReqID, notificationToken)
+	return nil
+}
+
+// handleBackchannelCallback finishes a backchannel authentication request once the user
+// has logged in. The tokens are stored for the client to retrieve from the token endpoint.
+func (s *Server) handleBackchannelCallback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("method not implemented: %s", r.Method), http.StatusBadRequest)
+		return
+	}
+
+	// Authorization redirect callback from OAuth2 auth flow.
+	if errMsg := r.FormValue("error"); errMsg != "" {
+		http.Error(w, errMsg+": "+r.FormValue("error_description"), http.StatusBadRequest)
+		return
+	}
+
+	approvalCode := r.FormValue("state")
+	code := r.FormValue("code")
+	if approvalCode == "" || code == "" {
+		s.renderError(r, w, http.StatusBadRequest, "Request was missing parameters")
+		return
+	}
+
+	authCode, err := s.storage.GetAuthCode(code)
+	if err != nil || s.now().After(authCode.Expiry) {
+		errCode := http.StatusBadRequest
+		if err != nil && err != storage.ErrNotFound {
+			s.logger.Errorf("failed to get auth code: %v", err)
+			errCode = http.StatusInternalServerError
+		}
+		s.renderError(r, w, errCode, "Invalid or expired auth code.")
+		return
+	}
+
+	cibaReq, err := s.storage.GetCIBARequest(approvalCode)
+	if err != nil || s.now().After(cibaReq.Expiry) {
+		errCode := http.StatusBadRequest
+		if err != nil && err != storage.ErrNotFound {
+			s.logger.Errorf("failed to get backchannel authentication request: %v", err)
+			errCode = http.StatusInternalServerError
+		}
+		s.renderError(r, w, errCode, "Invalid or expired authentication request.")
+		return
+	}
+
+	if authCode.ClientID != cibaReq.ClientID {
+		s.renderError(r, w, http.StatusBadRequest, "Invalid auth code.")
+		return
+	}
+
+	// Only the user the client asked for may approve the request.
+	if !loginHintMatches(cibaReq.LoginHint, authCode.Claims) {
+		s.logger.Errorf("backchannel authentication request for %q approved by user %q, rejecting", cibaReq.LoginHint, authCode.Claims.UserID)
+		if err := s.storage.DeleteAuthCode(authCode.ID); err != nil {
+			s.logger.Errorf("failed to delete auth code: %v", err)
+		}
+		s.renderError(r, w, http.StatusForbidden, "The authentication request is meant for another user.")
+		return
+	}
+
+	client, err := s.storage.GetClient(cibaReq.ClientID)
+	if err != nil {
+		s.logger.Errorf("failed to get client: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "")
+		return
+	}
+
+	resp, err := s.exchangeAuthCode(w, authCode, client)
+	if err != nil {
+		s.logger.Errorf("Could not exchange auth code for client %q: %v", cibaReq.ClientID, err)
+		s.renderError(r, w, http.StatusInternalServerError, "Failed to exchange auth code.")
+		return
+	}
+
+	var notificationToken string
+	updater := func(old storage.CIBAToken) (storage.CIBAToken, error) {
+		if old.Status != cibaTokenPending {
+			return old, errors.New("backchannel authentication token is not pending")
+		}
+		respStr, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return old, err
+		}
+
+		old.Token = string(respStr)
+		old.Status = cibaTokenComplete
+		notificationToken = old.NotificationToken
+		return old, nil
+	}
+
+	// Store the token and mark the request as complete
+	if err := s.storage.UpdateCIBAToken(cibaReq.AuthReqID, updater); err != nil {
+		s.logger.Errorf("failed to update backchannel authentication token: %v", err)
+		s.renderError(r, w, http.StatusBadRequest, "")
+		return
+	}
+
+	s.pingBackchannelClient(client, cibaReq.AuthReqID, notificationToken)
+
+	if err := s.templates.deviceSuccess(r, w, client.Name); err != nil {
+		s.logger.Errorf("Server template error: %v", err)
+		s.renderError(r, w, http.StatusNotFound, "Page not found")
+	}
+}
+
+// handleCIBAToken handles a token request using the CIBA grant.
+// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#token_request
+func (s *Server) handleCIBAToken(w http.ResponseWriter, r *http.Request, client storage.Client) {
+	authReqID := r.PostFormValue("auth_req_id")
+	if authReqID == "" {
+		s.tokenErrHelper(w, errInvalidRequest, "Required param: auth_req_id.", http.StatusBadRequest)
+		return
+	}
+
+	now := s.now()
+
+	cibaToken, err := s.storage.GetCIBAToken(authReqID)
+	if err != nil || cibaToken.ClientID != client.ID {
+		if err != nil && err != storage.ErrNotFound {
+			s.logger.Errorf("failed to get backchannel authentication token: %v", err)
+			s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+			return
+		}
+		s.tokenErrHelper(w, errInvalidGrant, "Invalid auth_req_id.", http.StatusBadRequest)
+		return
+	} else if now.After(cibaToken.Expiry) {
+		s.tokenErrHelper(w, deviceTokenExpired, "", http.StatusBadRequest)
+		return
+	}
+
+	switch cibaToken.Status {
+	case cibaTokenPending:
+		// Rate Limiting check
+		slowDown := false
+		pollInterval := cibaToken.PollIntervalSeconds
+		minRequestTime := cibaToken.LastRequestTime.Add(time.Second * time.Duration(pollInterval))
+		if now.Before(minRequestTime) {
+			slowDown = true
+			// Continually increase the poll interval until the client waits the proper time
+			pollInterval += 5
+		} else {
+			pollInterval = 5
+		}
+
+		updater := func(old storage.CIBAToken) (storage.CIBAToken, error) {
+			old.PollIntervalSeconds = pollInterval
+			old.LastRequestTime = now
+			return old, nil
+		}
+		if err := s.storage.UpdateCIBAToken(authReqID, updater); err != nil {
+			s.logger.Errorf("failed to update backchannel authentication token: %v", err)
+			s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+			return
+		}
+		if slowDown {
+			s.tokenErrHelper(w, deviceTokenSlowDown, "", http.StatusBadRequest)
+		} else {
+			s.tokenErrHelper(w, cibaTokenPending, "", http.StatusBadRequest)
+		}
+	case cibaTokenDenied:
+		s.tokenErrHelper(w, errAccessDenied, "The user denied the authentication request.", http.StatusBadRequest)
+	case cibaTokenComplete:
+		// Tokens are handed out only once.
+		var token string
+		updater := func(old storage.CIBAToken) (storage.CIBAToken, error) {
+			if old.Status != cibaTokenComplete {
+				return old, errors.New("backchannel authentication token already redeemed")
+			}
+			token = old.Token
+			old.Token = ""
+			old.Status = cibaTokenRedeemed
+			return old, nil
+		}
+		if err := s.storage.UpdateCIBAToken(authReqID, updater); err != nil {
+			s.logger.Errorf("failed to redeem backchannel authentication token: %v", err)
+			s.tokenErrHelper(w, errInvalidGrant, "Invalid auth_req_id.", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(token))
+	default:
+		s.tokenErrHelper(w, errInvalidGrant, "Invalid auth_req_id.", http.StatusBadRequest)
+	}
+}
+
+// pingBackchannelClient notifies a client using the ping mode that the result of a
+// backchannel authentication request can be retrieved from the token endpoint.
+// Clients using the poll mode have no notification token and aren't notified.
+func (s *Server) pingBackchannelClient(client storage.Client, authReqID, notificationToken string) {
+	if notificationToken == "" || client.BackchannelNotificationEndpoint == "" {
+		return
+	}
+
+	body, err := json.Marshal(map[string]string{"auth_req_id": authReqID})
+	if err != nil {
+		s.logger.Errorf("failed to marshal backchannel notification: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.BackchannelNotificationEndpoint, bytes.NewReader(body))
+	if err != nil {
+		s.logger.Errorf("failed to create backchannel notification for client %q: %v", client.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+notificationToken)
+
+	resp, err := s.cibaHTTPClient.Do(req)
+	if err != nil {
+		s.logger.Errorf("failed to notify client %q of backchannel authentication result: %v", client.ID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		s.logger.Errorf("failed to notify client %q of backchannel authentication result: unexpected status %s", client.ID, resp.Status)
+	}
+}
+
+// loginHintMatches reports whether the hint provided by the client identifies the
+// logged in user. Hints are compared to the email, the usernames and the user ID.
+func loginHintMatches(hint string, claims storage.Claims) bool {
+	switch {
+	case claims.Email != "" && strings.EqualFold(hint, claims.Email):
+		return true
+	case claims.PreferredUsername != "" && hint == claims.PreferredUsername:
+		return true
+	case claims.Username != "" && hint == claims.Username:
+		return true
+	default:
+		return hint == claims.UserID
+	}
+}
@@ -0,0 +1,650 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+type fakeCIBANotifier struct {
+	notifications []CIBANotification
+	err           error
+}
+
+func (n *fakeCIBANotifier) Notify(_ context.Context, notification CIBANotification) error {
+	n.notifications = append(n.notifications, notification)
+	return n.err
+}
+
+func TestHandleBackchannelAuthorize(t *testing.T) {
+	t0 := time.Now()
+	now := func() time.Time { return t0 }
+
+	client := storage.Client{
+		ID:           "testclient",
+		Secret:       "testclientsecret",
+		RedirectURIs: []string{cibaCallbackURI},
+	}
+
+	tests := []struct {
+		testName             string
+		client               storage.Client
+		values               url.Values
+		notifierErr          error
+		expectedResponseCode int
+		expectedError        string
+	}{
+		{
+			testName: "Poll Mode",
+			client:   client,
+			values: url.Values{
+				"scope":           {"openid email"},
+				"login_hint":      {"jane.doe@example.com"},
+				"binding_message": {"W4SCT"},
+			},
+			expectedResponseCode: http.StatusOK,
+		},
+		{
+			testName: "Ping Mode",
+			client: storage.Client{
+				ID:                              "testclient",
+				Secret:                          "testclientsecret",
+				RedirectURIs:                    []string{cibaCallbackURI},
+				BackchannelNotificationEndpoint: "https://client.example.com/ciba",
+			},
+			values: url.Values{
+				"scope":                     {"openid"},
+				"login_hint":                {"jane.doe@example.com"},
+				"client_notification_token": {"notificationtoken"},
+			},
+			expectedResponseCode: http.StatusOK,
+		},
+		{
+			testName: "Ping Mode Without Notification Token",
+			client: storage.Client{
+				ID:                              "testclient",
+				Secret:                          "testclientsecret",
+				RedirectURIs:                    []string{cibaCallbackURI},
+				BackchannelNotificationEndpoint: "https://client.example.com/ciba",
+			},
+			values: url.Values{
+				"scope":      {"openid"},
+				"login_hint": {"jane.doe@example.com"},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidRequest,
+		},
+		{
+			testName: "Notification Token Without Endpoint",
+			client:   client,
+			values: url.Values{
+				"scope":                     {"openid"},
+				"login_hint":                {"jane.doe@example.com"},
+				"client_notification_token": {"notificationtoken"},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidRequest,
+		},
+		{
+			testName: "Missing OpenID Scope",
+			client:   client,
+			values: url.Values{
+				"scope":      {"email"},
+				"login_hint": {"jane.doe@example.com"},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidScope,
+		},
+		{
+			testName: "Missing Login Hint",
+			client:   client,
+			values: url.Values{
+				"scope": {"openid"},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidRequest,
+		},
+		{
+			testName: "Binding Message Too Long",
+			client:   client,
+			values: url.Values{
+				"scope":           {"openid"},
+				"login_hint":      {"jane.doe@example.com"},
+				"binding_message": {strings.Repeat("x", cibaMaxBindingMessageLength+1)},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidBindingMessage,
+		},
+		{
+			testName: "Client Without Callback",
+			client: storage.Client{
+				ID:           "testclient",
+				Secret:       "testclientsecret",
+				RedirectURIs: []string{"https://client.example.com/callback"},
+			},
+			values: url.Values{
+				"scope":      {"openid"},
+				"login_hint": {"jane.doe@example.com"},
+			},
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errUnauthorizedClient,
+		},
+		{
+			testName: "Notifier Failure",
+			client:   client,
+			values: url.Values{
+				"scope":      {"openid"},
+				"login_hint": {"jane.doe@example.com"},
+			},
+			notifierErr:          errors.New("unreachable"),
+			expectedResponseCode: http.StatusInternalServerError,
+			expectedError:        errServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			notifier := &fakeCIBANotifier{err: tc.notifierErr}
+
+			// Setup a dex server.
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.Issuer += "/non-root-path"
+				c.Now = now
+				c.CIBANotifier = notifier
+			})
+			defer httpServer.Close()
+
+			if err := s.storage.CreateClient(tc.client); err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			u, err := url.Parse(s.issuerURL.String())
+			if err != nil {
+				t.Fatalf("Could not parse issuer URL %v", err)
+			}
+			u.Path = u.Path + "/bc-authorize"
+
+			req, _ := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(tc.values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth("testclient", "testclientsecret")
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedResponseCode {
+				t.Fatalf("Unexpected Response Type.  Expected %v got %v: %s", tc.expectedResponseCode, rr.Code, rr.Body.String())
+			}
+
+			if tc.expectedError != "" {
+				var errResp struct {
+					Error string `json:"error"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &errResp); err != nil {
+					t.Fatalf("Unexpected response body: %v", err)
+				}
+				if errResp.Error != tc.expectedError {
+					t.Errorf("Expected error %q got %q", tc.expectedError, errResp.Error)
+				}
+				return
+			}
+
+			var resp cibaAuthResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Unexpected response body: %v", err)
+			}
+			pingMode := tc.values.Get("client_notification_token") != ""
+			if pingMode != (resp.Interval == 0) {
+				t.Errorf("Unexpected interval %d for ping mode %v", resp.Interval, pingMode)
+			}
+
+			token, err := s.storage.GetCIBAToken(resp.AuthReqID)
+			if err != nil {
+				t.Fatalf("failed to get ciba token: %v", err)
+			}
+			if token.Status != cibaTokenPending || token.NotificationToken != tc.values.Get("client_notification_token") {
+				t.Errorf("Unexpected ciba token %#v", token)
+			}
+
+			if len(notifier.notifications) != 1 {
+				t.Fatalf("Expected one notification, got %d", len(notifier.notifications))
+			}
+			n := notifier.notifications[0]
+			if n.LoginHint != tc.values.Get("login_hint") || n.BindingMessage != tc.values.Get("binding_message") {
+				t.Errorf("Unexpected notification %#v", n)
+			}
+			approvalURL, err := url.Parse(n.ApprovalURL)
+			if err != nil {
+				t.Fatalf("Invalid approval URL %q: %v", n.ApprovalURL, err)
+			}
+			cibaReq, err := s.storage.GetCIBARequest(approvalURL.Query().Get("code"))
+			if err != nil {
+				t.Fatalf("failed to get ciba request: %v", err)
+			}
+			if cibaReq.AuthReqID != resp.AuthReqID {
+				t.Errorf("Expected auth_req_id %q got %q", resp.AuthReqID, cibaReq.AuthReqID)
+			}
+		})
+	}
+}
+
+func TestHandleCIBAToken(t *testing.T) {
+	t0 := time.Now()
+	now := func() time.Time { return t0 }
+
+	baseToken := storage.CIBAToken{
+		AuthReqID:       "authreqid",
+		ClientID:        "testclient",
+		Status:          cibaTokenPending,
+		Expiry:          now().Add(5 * time.Minute),
+		LastRequestTime: now().Add(-time.Minute),
+	}
+
+	tests := []struct {
+		testName             string
+		token                func(t storage.CIBAToken) storage.CIBAToken
+		authReqID            string
+		expectedResponseCode int
+		expectedError        string
+		expectedBody         string
+	}{
+		{
+			testName:             "Pending",
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        cibaTokenPending,
+		},
+		{
+			testName: "Slow Down",
+			token: func(t storage.CIBAToken) storage.CIBAToken {
+				t.LastRequestTime = now()
+				t.PollIntervalSeconds = 5
+				return t
+			},
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        deviceTokenSlowDown,
+		},
+		{
+			testName: "Denied",
+			token: func(t storage.CIBAToken) storage.CIBAToken {
+				t.Status = cibaTokenDenied
+				return t
+			},
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errAccessDenied,
+		},
+		{
+			testName: "Expired",
+			token: func(t storage.CIBAToken) storage.CIBAToken {
+				t.Expiry = now().Add(-time.Minute)
+				return t
+			},
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        deviceTokenExpired,
+		},
+		{
+			testName: "Other Client",
+			token: func(t storage.CIBAToken) storage.CIBAToken {
+				t.ClientID = "otherclient"
+				return t
+			},
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidGrant,
+		},
+		{
+			testName:             "Unknown Auth Request",
+			authReqID:            "unknown",
+			expectedResponseCode: http.StatusBadRequest,
+			expectedError:        errInvalidGrant,
+		},
+		{
+			testName: "Complete",
+			token: func(t storage.CIBAToken) storage.CIBAToken {
+				t.Status = cibaTokenComplete
+				t.Token = `{"access_token": "foo"}`
+				return t
+			},
+			authReqID:            "authreqid",
+			expectedResponseCode: http.StatusOK,
+			expectedBody:         `{"access_token": "foo"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Setup a dex server.
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.Issuer += "/non-root-path"
+				c.Now = now
+				c.CIBANotifier = &fakeCIBANotifier{}
+			})
+			defer httpServer.Close()
+
+			if err := s.storage.CreateClient(storage.Client{ID: "testclient", Secret: "testclientsecret"}); err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			token := baseToken
+			if tc.token != nil {
+				token = tc.token(token)
+			}
+			if err := s.storage.CreateCIBAToken(token); err != nil {
+				t.Fatalf("failed to create ciba token: %v", err)
+			}
+
+			u, err := url.Parse(s.issuerURL.String())
+			if err != nil {
+				t.Fatalf("Could not parse issuer URL %v", err)
+			}
+			u.Path = u.Path + "/token"
+
+			data := url.Values{
+				"grant_type":  {grantTypeCIBA},
+				"auth_req_id": {tc.authReqID},
+			}
+
+			doRequest := func() *httptest.ResponseRecorder {
+				req, _ := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(data.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("testclient", "testclientsecret")
+				rr := httptest.NewRecorder()
+				s.ServeHTTP(rr, req)
+				return rr
+			}
+
+			rr := doRequest()
+			if rr.Code != tc.expectedResponseCode {
+				t.Fatalf("Unexpected Response Type.  Expected %v got %v: %s", tc.expectedResponseCode, rr.Code, rr.Body.String())
+			}
+
+			if tc.expectedError != "" {
+				var errResp struct {
+					Error string `json:"error"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &errResp); err != nil {
+					t.Fatalf("Unexpected response body: %v", err)
+				}
+				if errResp.Error != tc.expectedError {
+					t.Errorf("Expected error %q got %q", tc.expectedError, errResp.Error)
+				}
+				return
+			}
+
+			if rr.Body.String() != tc.expectedBody {
+				t.Errorf("Unexpected response body. Expected %q got %q", tc.expectedBody, rr.Body.String())
+			}
+
+			// Tokens can only be redeemed once.
+			if rr := doRequest(); rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected redeemed token to be rejected, got %v", rr.Code)
+			}
+		})
+	}
+}
+
+func TestHandleBackchannelCallback(t *testing.T) {
+	t0 := time.Now()
+	now := func() time.Time { return t0 }
+
+	tests := []struct {
+		testName             string
+		loginHint            string
+		claims               storage.Claims
+		expectedResponseCode int
+		expectedStatus       string
+	}{
+		{
+			testName:             "Matching Email",
+			loginHint:            "Jane.Doe@example.com",
+			claims:               storage.Claims{UserID: "1", Email: "jane.doe@example.com"},
+			expectedResponseCode: http.StatusOK,
+			expectedStatus:       cibaTokenComplete,
+		},
+		{
+			testName:             "Matching Username",
+			loginHint:            "jane",
+			claims:               storage.Claims{UserID: "1", PreferredUsername: "jane"},
+			expectedResponseCode: http.StatusOK,
+			expectedStatus:       cibaTokenComplete,
+		},
+		{
+			testName:             "Other User",
+			loginHint:            "jane.doe@example.com",
+			claims:               storage.Claims{UserID: "2", Email: "john.doe@example.com"},
+			expectedResponseCode: http.StatusForbidden,
+			expectedStatus:       cibaTokenPending,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var pinged []string
+			clientServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					AuthReqID string `json:"auth_req_id"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				pinged = append(pinged, r.Header.Get("Authorization")+" "+body.AuthReqID)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer clientServer.Close()
+
+			// Setup a dex server.
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.Issuer += "/non-root-path"
+				c.Now = now
+				c.CIBANotifier = &fakeCIBANotifier{}
+			})
+			defer httpServer.Close()
+
+			client := storage.Client{
+				ID:                              "testclient",
+				Secret:                          "testclientsecret",
+				RedirectURIs:                    []string{cibaCallbackURI},
+				BackchannelNotificationEndpoint: clientServer.URL,
+			}
+			if err := s.storage.CreateClient(client); err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			authCode := storage.AuthCode{
+				ID:          "somecode",
+				ClientID:    "testclient",
+				RedirectURI: cibaCallbackURI,
+				Scopes:      []string{"openid", "email"},
+				ConnectorID: "mock",
+				Claims:      tc.claims,
+				Expiry:      now().Add(5 * time.Minute),
+			}
+			if err := s.storage.CreateAuthCode(authCode); err != nil {
+				t.Fatalf("failed to create auth code: %v", err)
+			}
+			if err := s.storage.CreateCIBARequest(storage.CIBARequest{
+				ApprovalCode: "approvalcode",
+				AuthReqID:    "authreqid",
+				ClientID:     "testclient",
+				Scopes:       []string{"openid", "email"},
+				LoginHint:    tc.loginHint,
+				Expiry:       now().Add(5 * time.Minute),
+			}); err != nil {
+				t.Fatalf("failed to create ciba request: %v", err)
+			}
+			if err := s.storage.CreateCIBAToken(storage.CIBAToken{
+				AuthReqID:         "authreqid",
+				ClientID:          "testclient",
+				Status:            cibaTokenPending,
+				NotificationToken: "notificationtoken",
+				Expiry:            now().Add(5 * time.Minute),
+			}); err != nil {
+				t.Fatalf("failed to create ciba token: %v", err)
+			}
+
+			u, err := url.Parse(s.issuerURL.String())
+			if err != nil {
+				t.Fatalf("Could not parse issuer URL %v", err)
+			}
+			u.Path = u.Path + cibaCallbackURI
+			u.RawQuery = url.Values{"state": {"approvalcode"}, "code": {"somecode"}}.Encode()
+
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, u.String(), nil))
+			if rr.Code != tc.expectedResponseCode {
+				t.Fatalf("Unexpected Response Type.  Expected %v got %v: %s", tc.expectedResponseCode, rr.Code, rr.Body.String())
+			}
+
+			token, err := s.storage.GetCIBAToken("authreqid")
+			if err != nil {
+				t.Fatalf("failed to get ciba token: %v", err)
+			}
+			if token.Status != tc.expectedStatus {
+				t.Errorf("Expected ciba token status %q got %q", tc.expectedStatus, token.Status)
+			}
+
+			if tc.expectedStatus == cibaTokenComplete {
+				var resp accessTokenResponse
+				if err := json.Unmarshal([]byte(token.Token), &resp); err != nil || resp.IDToken == "" {
+					t.Errorf("Expected stored token response, got %q", token.Token)
+				}
+				if len(pinged) != 1 || pinged[0] != "Bearer notificationtoken authreqid" {
+					t.Errorf("Unexpected client notifications %v", pinged)
+				}
+			} else {
+				if len(pinged) != 0 {
+					t.Errorf("Unexpected client notifications %v", pinged)
+				}
+				if _, err := s.storage.GetAuthCode("somecode"); err != storage.ErrNotFound {
+					t.Errorf("Expected auth code to be deleted, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleBackchannelApproval(t *testing.T) {
+	t0 := time.Now()
+	now := func() time.Time { return t0 }
+
+	tests := []struct {
+		testName             string
+		method               string
+		approval             string
+		expectedResponseCode int
+		expectedStatus       string
+	}{
+		{
+			testName:             "Show Approval Page",
+			method:               http.MethodGet,
+			expectedResponseCode: http.StatusOK,
+			expectedStatus:       cibaTokenPending,
+		},
+		{
+			testName:             "Approve",
+			method:               http.MethodPost,
+			approval:             "approve",
+			expectedResponseCode: http.StatusFound,
+			expectedStatus:       cibaTokenPending,
+		},
+		{
+			testName:             "Deny",
+			method:               http.MethodPost,
+			approval:             "deny",
+			expectedResponseCode: http.StatusOK,
+			expectedStatus:       cibaTokenDenied,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Setup a dex server.
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.Issuer += "/non-root-path"
+				c.Now = now
+				c.CIBANotifier = &fakeCIBANotifier{}
+			})
+			defer httpServer.Close()
+
+			if err := s.storage.CreateClient(storage.Client{
+				ID:           "testclient",
+				Secret:       "testclientsecret",
+				RedirectURIs: []string{cibaCallbackURI},
+				Name:         "Call Center",
+			}); err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if err := s.storage.CreateCIBARequest(storage.CIBARequest{
+				ApprovalCode:   "approvalcode",
+				AuthReqID:      "authreqid",
+				ClientID:       "testclient",
+				Scopes:         []string{"openid", "email"},
+				LoginHint:      "jane.doe@example.com",
+				BindingMessage: "W4SCT",
+				Expiry:         now().Add(5 * time.Minute),
+			}); err != nil {
+				t.Fatalf("failed to create ciba request: %v", err)
+			}
+			if err := s.storage.CreateCIBAToken(storage.CIBAToken{
+				AuthReqID: "authreqid",
+				ClientID:  "testclient",
+				Status:    cibaTokenPending,
+				Expiry:    now().Add(5 * time.Minute),
+			}); err != nil {
+				t.Fatalf("failed to create ciba token: %v", err)
+			}
+
+			u, err := url.Parse(s.issuerURL.String())
+			if err != nil {
+				t.Fatalf("Could not parse issuer URL %v", err)
+			}
+			u.Path = u.Path + "/bc-authorize/approve"
+			u.RawQuery = url.Values{"code": {"approvalcode"}}.Encode()
+
+			data := url.Values{"code": {"approvalcode"}, "approval": {tc.approval}}
+			req := httptest.NewRequest(tc.method, u.String(), strings.NewReader(data.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedResponseCode {
+				t.Fatalf("Unexpected Response Type.  Expected %v got %v: %s", tc.expectedResponseCode, rr.Code, rr.Body.String())
+			}
+
+			switch tc.expectedResponseCode {
+			case http.StatusOK:
+				if tc.method == http.MethodGet && !strings.Contains(rr.Body.String(), "W4SCT") {
+					t.Errorf("Expected binding message on the approval page")
+				}
+			case http.StatusFound:
+				loc, err := url.Parse(rr.Header().Get("Location"))
+				if err != nil {
+					t.Fatalf("Invalid redirect: %v", err)
+				}
+				if loc.Query().Get("redirect_uri") != cibaCallbackURI || loc.Query().Get("state") != "approvalcode" {
+					t.Errorf("Unexpected redirect %q", loc)
+				}
+			}
+
+			token, err := s.storage.GetCIBAToken("authreqid")
+			if err != nil {
+				t.Fatalf("failed to get ciba token: %v", err)
+			}
+			if token.Status != tc.expectedStatus {
+				t.Errorf("Expected ciba token status %q got %q", tc.expectedStatus, token.Status)
+			}
+		})
+	}
+}
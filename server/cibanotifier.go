@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CIBANotification is the message delivered to a user when a client initiates
+// a backchannel authentication request on their behalf.
+type CIBANotification struct {
+	// LoginHint identifies the user the request is for, as provided by the client.
+	LoginHint string `json:"login_hint"`
+	// BindingMessage is displayed on both the consumption and the authentication
+	// device, so the user can make sure they approve the right request.
+	BindingMessage string `json:"binding_message,omitempty"`
+
+	ClientID   string   `json:"client_id"`
+	ClientName string   `json:"client_name,omitempty"`
+	Scopes     []string `json:"scopes"`
+
+	// ApprovalURL is the dex hosted page where the user approves or denies the request.
+	ApprovalURL string    `json:"approval_url"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// CIBANotifier tells a user that a client is waiting for them to approve a
+// backchannel authentication request.
+type CIBANotifier interface {
+	Notify(ctx context.Context, n CIBANotification) error
+}
+
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookNotifier returns a CIBANotifier which POSTs every notification as a
+// JSON document to the provided URL. The receiving service is responsible for
+// reaching the user, for example through a push notification or a text message.
+func NewWebhookNotifier(url string, headers map[string]string, client *http.Client) CIBANotifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &webhookNotifier{url: url, headers: headers, client: client}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, notification CIBANotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post notification: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("post notification: unexpected status %s", resp.Status)
+	}
+	return nil
+}
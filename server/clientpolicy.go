@@ -0,0 +1,176 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// defaultAuthCodesValidFor is the lifetime of authorization codes for clients
+// which don't override it.
+const defaultAuthCodesValidFor = 30 * time.Minute
+
+// clientTokenPolicy holds the token lifetimes and the refresh token policy in
+// effect for a single client.
+type clientTokenPolicy struct {
+	idTokensValidFor     time.Duration
+	accessTokensValidFor time.Duration
+	authCodesValidFor    time.Duration
+
+	refreshTokens *RefreshTokenPolicy
+}
+
+// tokenPolicy applies the overrides of the client on top of the server
+// configuration. Overrides are validated when a client is created, values
+// which still fail to parse are logged and ignored.
+func (s *Server) tokenPolicy(client storage.Client) clientTokenPolicy {
+	p := client.TokenPolicy
+	policy := clientTokenPolicy{
+		idTokensValidFor:  s.clientDuration(client.ID, "idTokensValidFor", p.IDTokensValidFor, s.idTokensValidFor),
+		authCodesValidFor: s.clientDuration(client.ID, "authCodesValidFor", p.AuthCodesValidFor, defaultAuthCodesValidFor),
+		refreshTokens:     s.refreshTokenPolicy,
+	}
+	// Access tokens are signed like ID tokens and share their lifetime unless
+	// the client sets its own.
+	policy.accessTokensValidFor = s.clientDuration(client.ID, "accessTokensValidFor", p.AccessTokensValidFor, policy.idTokensValidFor)
+
+	if p.DisableRefreshTokenRotation == nil && p.RefreshTokenAbsoluteLifetime == "" && p.RefreshTokenValidIfNotUsedFor == "" {
+		return policy
+	}
+
+	var refreshTokens RefreshTokenPolicy
+	if s.refreshTokenPolicy != nil {
+		refreshTokens = *s.refreshTokenPolicy
+	} else {
+		refreshTokens = RefreshTokenPolicy{now: time.Now, logger: s.logger}
+	}
+	if p.DisableRefreshTokenRotation != nil {
+		refreshTokens.rotateRefreshTokens = !*p.DisableRefreshTokenRotation
+	}
+	refreshTokens.absoluteLifetime = s.clientDuration(client.ID, "refreshTokenAbsoluteLifetime", p.RefreshTokenAbsoluteLifetime, refreshTokens.absoluteLifetime)
+	refreshTokens.validIfNotUsedFor = s.clientDuration(client.ID, "refreshTokenValidIfNotUsedFor", p.RefreshTokenValidIfNotUsedFor, refreshTokens.validIfNotUsedFor)
+	policy.refreshTokens = &refreshTokens
+	return policy
+}
+
+func (s *Server) clientDuration(clientID, name, value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		s.logger.Errorf("client %q: ignoring invalid %s %q", clientID, name, value)
+		return defaultValue
+	}
+	return d
+}
+
+// gcOptions returns the lifetimes garbage collection uses to prune refresh
+// tokens. Since tokens aren't collected per client, the longest lifetime of the
+// server configuration and all client overrides is used. Tokens of clients with
+// shorter lifetimes are rejected on use and collected later.
+func (s *Server) gcOptions() storage.GCOptions {
+	opts := s.refreshTokenPolicy.gcOptions()
+	if opts.RefreshTokenAbsoluteLifetime == 0 && opts.RefreshTokenValidIfNotUsedFor == 0 {
+		return opts
+	}
+
+	clients, err := s.storage.ListClients()
+	if err != nil {
+		s.logger.Errorf("failed to list clients, skipping refresh token garbage collection: %v", err)
+		return storage.GCOptions{}
+	}
+	for _, client := range clients {
+		refreshTokens := s.tokenPolicy(client).refreshTokens.gcOptions()
+		opts.RefreshTokenAbsoluteLifetime = maxLifetime(opts.RefreshTokenAbsoluteLifetime, refreshTokens.RefreshTokenAbsoluteLifetime)
+		opts.RefreshTokenValidIfNotUsedFor = maxLifetime(opts.RefreshTokenValidIfNotUsedFor, refreshTokens.RefreshTokenValidIfNotUsedFor)
+	}
+	return opts
+}
+
+// maxLifetime returns the longer of two lifetimes where zero means unlimited.
+func maxLifetime(a, b time.Duration) time.Duration {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// clientAllows reports whether value is part of a client allow list. An empty
+// list doesn't restrict the client.
+func clientAllows(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, v := range allowed {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// disallowedScopes returns the scopes the client isn't allowed to request. The
+// "openid" scope is required by every flow and always allowed.
+func disallowedScopes(client storage.Client, scopes []string) []string {
+	var disallowed []string
+	for _, scope := range scopes {
+		if scope != scopeOpenID && !clientAllows(client.AllowedScopes, scope) {
+			disallowed = append(disallowed, scope)
+		}
+	}
+	return disallowed
+}
+
+// allowedConnectors filters connectors down to the ones the client may use.
+func allowedConnectors(client storage.Client, connectors []storage.Connector) []storage.Connector {
+	if len(client.AllowedConnectors) == 0 {
+		return connectors
+	}
+	var allowed []storage.Connector
+	for _, c := range connectors {
+		if clientAllows(client.AllowedConnectors, c.ID) {
+			allowed = append(allowed, c)
+		}
+	}
+	return allowed
+}
+
+// withAllowedGrantType rejects token requests of clients which aren't allowed
+// to use the grant type.
+func (s *Server) withAllowedGrantType(grantType string, handler func(http.ResponseWriter, *http.Request, storage.Client)) func(http.ResponseWriter, *http.Request, storage.Client) {
+	return func(w http.ResponseWriter, r *http.Request, client storage.Client) {
+		if !clientAllows(client.AllowedGrantTypes, grantType) {
+			s.tokenErrHelper(w, errUnauthorizedClient, fmt.Sprintf("Client can't use grant type %q.", grantType), http.StatusBadRequest)
+			return
+		}
+		handler(w, r, client)
+	}
+}
+
+// ValidateClientRestrictions rejects grant and response types dex doesn't
+// know, which would silently lock the client out. Clients created through the
+// API and static clients are validated alike.
+func ValidateClientRestrictions(client storage.Client) error {
+	for _, grantType := range client.AllowedGrantTypes {
+		switch grantType {
+		case grantTypeAuthorizationCode, grantTypeRefreshToken, grantTypeImplicit,
+			grantTypePassword, grantTypeDeviceCode, grantTypeCIBA:
+		default:
+			return fmt.Errorf("unknown grant type %q", grantType)
+		}
+	}
+	for _, responseType := range client.AllowedResponseTypes {
+		switch responseType {
+		case responseTypeCode, responseTypeToken, responseTypeIDToken:
+		default:
+			return fmt.Errorf("unknown response type %q", responseType)
+		}
+	}
+	return nil
+}
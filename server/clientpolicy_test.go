@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+func TestClientTokenPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.IDTokensValidFor = time.Hour
+	})
+	defer httpServer.Close()
+
+	var err error
+	s.refreshTokenPolicy, err = NewRefreshTokenPolicy(logger, false, "24h", "72h", "", false)
+	if err != nil {
+		t.Fatalf("failed to prepare rotation policy: %v", err)
+	}
+
+	disabled := true
+	tests := []struct {
+		name   string
+		policy storage.ClientTokenPolicy
+
+		wantIDTokens      time.Duration
+		wantAccessTokens  time.Duration
+		wantAuthCodes     time.Duration
+		wantRotation      bool
+		wantAbsolute      time.Duration
+		wantIfNotUsedFor  time.Duration
+		wantServerRefresh bool
+	}{
+		{
+			name:              "server defaults",
+			wantIDTokens:      time.Hour,
+			wantAccessTokens:  time.Hour,
+			wantAuthCodes:     defaultAuthCodesValidFor,
+			wantRotation:      true,
+			wantAbsolute:      72 * time.Hour,
+			wantIfNotUsedFor:  24 * time.Hour,
+			wantServerRefresh: true,
+		},
+		{
+			name: "token lifetimes",
+			policy: storage.ClientTokenPolicy{
+				IDTokensValidFor:  "15m",
+				AuthCodesValidFor: "1m",
+			},
+			wantIDTokens:      15 * time.Minute,
+			wantAccessTokens:  15 * time.Minute,
+			wantAuthCodes:     time.Minute,
+			wantRotation:      true,
+			wantAbsolute:      72 * time.Hour,
+			wantIfNotUsedFor:  24 * time.Hour,
+			wantServerRefresh: true,
+		},
+		{
+			name: "access token lifetime",
+			policy: storage.ClientTokenPolicy{
+				AccessTokensValidFor: "5m",
+			},
+			wantIDTokens:      time.Hour,
+			wantAccessTokens:  5 * time.Minute,
+			wantAuthCodes:     defaultAuthCodesValidFor,
+			wantRotation:      true,
+			wantAbsolute:      72 * time.Hour,
+			wantIfNotUsedFor:  24 * time.Hour,
+			wantServerRefresh: true,
+		},
+		{
+			name: "refresh token overrides",
+			policy: storage.ClientTokenPolicy{
+				DisableRefreshTokenRotation:   &disabled,
+				RefreshTokenValidIfNotUsedFor: "2h",
+			},
+			wantIDTokens:     time.Hour,
+			wantAccessTokens: time.Hour,
+			wantAuthCodes:    defaultAuthCodesValidFor,
+			wantRotation:     false,
+			wantAbsolute:     72 * time.Hour,
+			wantIfNotUsedFor: 2 * time.Hour,
+		},
+		{
+			name: "invalid values are ignored",
+			policy: storage.ClientTokenPolicy{
+				IDTokensValidFor:             "forever",
+				RefreshTokenAbsoluteLifetime: "-1h",
+			},
+			wantIDTokens:     time.Hour,
+			wantAccessTokens: time.Hour,
+			wantAuthCodes:    defaultAuthCodesValidFor,
+			wantRotation:     true,
+			wantAbsolute:     72 * time.Hour,
+			wantIfNotUsedFor: 24 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := s.tokenPolicy(storage.Client{ID: "test", TokenPolicy: tc.policy})
+
+			if policy.idTokensValidFor != tc.wantIDTokens {
+				t.Errorf("expected ID tokens valid for %s, got %s", tc.wantIDTokens, policy.idTokensValidFor)
+			}
+			if policy.accessTokensValidFor != tc.wantAccessTokens {
+				t.Errorf("expected access tokens valid for %s, got %s", tc.wantAccessTokens, policy.accessTokensValidFor)
+			}
+			if policy.authCodesValidFor != tc.wantAuthCodes {
+				t.Errorf("expected auth codes valid for %s, got %s", tc.wantAuthCodes, policy.authCodesValidFor)
+			}
+			if got := policy.refreshTokens.RotationEnabled(); got != tc.wantRotation {
+				t.Errorf("expected rotation enabled %t, got %t", tc.wantRotation, got)
+			}
+			if policy.refreshTokens.absoluteLifetime != tc.wantAbsolute {
+				t.Errorf("expected absolute lifetime %s, got %s", tc.wantAbsolute, policy.refreshTokens.absoluteLifetime)
+			}
+			if policy.refreshTokens.validIfNotUsedFor != tc.wantIfNotUsedFor {
+				t.Errorf("expected valid if not used for %s, got %s", tc.wantIfNotUsedFor, policy.refreshTokens.validIfNotUsedFor)
+			}
+			if (policy.refreshTokens == s.refreshTokenPolicy) != tc.wantServerRefresh {
+				t.Errorf("expected server refresh token policy to be shared: %t", tc.wantServerRefresh)
+			}
+		})
+	}
+}
+
+func TestClientTokenPolicyGCOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	var err error
+	s.refreshTokenPolicy, err = NewRefreshTokenPolicy(logger, false, "24h", "72h", "", false)
+	if err != nil {
+		t.Fatalf("failed to prepare rotation policy: %v", err)
+	}
+
+	opts := s.gcOptions()
+	if opts.RefreshTokenAbsoluteLifetime != 72*time.Hour || opts.RefreshTokenValidIfNotUsedFor != 24*time.Hour {
+		t.Errorf("unexpected gc options without clients: %+v", opts)
+	}
+
+	if err := s.storage.CreateClient(storage.Client{
+		ID: "long-lived",
+		TokenPolicy: storage.ClientTokenPolicy{
+			RefreshTokenValidIfNotUsedFor: "48h",
+		},
+	}); err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	opts = s.gcOptions()
+	if opts.RefreshTokenAbsoluteLifetime != 72*time.Hour || opts.RefreshTokenValidIfNotUsedFor != 48*time.Hour {
+		t.Errorf("expected the longest client lifetime to be used, got %+v", opts)
+	}
+}
+
+func TestClientRestrictionsGrantType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	if err := s.storage.CreateClient(storage.Client{
+		ID:                "ci",
+		Secret:            "secret",
+		AllowedGrantTypes: []string{grantTypeDeviceCode},
+	}); err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for _, grantType := range []string{grantTypeAuthorizationCode, grantTypeRefreshToken, grantTypePassword} {
+		t.Run(grantType, func(t *testing.T) {
+			data := url.Values{}
+			data.Set("grant_type", grantType)
+			data.Set("client_id", "ci")
+			data.Set("client_secret", "secret")
+
+			req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(data.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+			var resp struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Error != errUnauthorizedClient {
+				t.Errorf("expected error %q, got %q", errUnauthorizedClient, resp.Error)
+			}
+		})
+	}
+}
+
+func TestClientRestrictionsConnectorList(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServerMultipleConnectors(ctx, t, func(c *Config) {
+		c.Storage = storage.WithStaticClients(c.Storage, []storage.Client{
+			{
+				ID:                "contractors",
+				RedirectURIs:      []string{"https://example.com/callback"},
+				AllowedConnectors: []string{"mock2"},
+			},
+		})
+	})
+	defer httpServer.Close()
+
+	params := url.Values{}
+	params.Set("client_id", "contractors")
+	params.Set("redirect_uri", "https://example.com/callback")
+	params.Set("response_type", "code")
+	params.Set("scope", "openid")
+
+	req := httptest.NewRequest(http.MethodGet, "/auth?"+params.Encode(), nil)
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+
+	// Only one connector is left, so the user is sent straight to it.
+	if rr.Code != http.StatusFound {
+		t.Fatalf("expected status %d, got %d", http.StatusFound, rr.Code)
+	}
+	location, err := url.Parse(rr.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("failed to parse redirect: %v", err)
+	}
+	if location.Path != "/auth/mock2" {
+		t.Errorf("expected redirect to the allowed connector, got %q", location.Path)
+	}
+
+	// Logging in through a connector the client can't use is rejected.
+	req = httptest.NewRequest(http.MethodGet, "/auth/mock?"+params.Encode(), nil)
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestValidateClientRestrictions(t *testing.T) {
+	tests := map[string]struct {
+		client  storage.Client
+		wantErr bool
+	}{
+		"no restrictions": {client: storage.Client{}},
+		"known types": {client: storage.Client{
+			AllowedGrantTypes:    []string{grantTypeAuthorizationCode, grantTypeRefreshToken},
+			AllowedResponseTypes: []string{responseTypeCode, responseTypeIDToken},
+		}},
+		"unknown grant type": {
+			client:  storage.Client{AllowedGrantTypes: []string{"authorisation_code"}},
+			wantErr: true,
+		},
+		"unknown response type": {
+			client:  storage.Client{AllowedResponseTypes: []string{"code id_token"}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateClientRestrictions(tc.client)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -74,6 +74,19 @@ func (s *Server) handleDeviceCode(w http.ResponseWriter, r *http.Request) {
 
 		s.logger.Infof("Received device request for client %v with scopes %v", clientID, scopes)
 
+		// Reject clients restricted to other flows early, the authorization
+		// request checks the client again once the user logs in.
+		if client, err := s.storage.GetClient(clientID); err == nil {
+			if !clientAllows(client.AllowedGrantTypes, grantTypeDeviceCode) {
+				s.tokenErrHelper(w, errUnauthorizedClient, fmt.Sprintf("Client can't use grant type %q.", grantTypeDeviceCode), http.StatusBadRequest)
+				return
+			}
+			if disallowed := disallowedScopes(client, scopes); len(disallowed) > 0 {
+				s.tokenErrHelper(w, errInvalidScope, fmt.Sprintf("Client can't request scope(s) %q", disallowed), http.StatusBadRequest)
+				return
+			}
+		}
+
 		// Make device code
 		deviceCode := storage.NewDeviceCode()
 
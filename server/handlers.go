@@ -5,6 +5,7 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -75,6 +76,8 @@ type discovery struct {
 	Keys              string   `json:"jwks_uri"`
 	UserInfo          string   `json:"userinfo_endpoint"`
 	DeviceEndpoint    string   `json:"device_authorization_endpoint"`
+	CIBAEndpoint      string   `json:"backchannel_authentication_endpoint,omitempty"`
+	CIBADeliveryModes []string `json:"backchannel_token_delivery_modes_supported,omitempty"`
 	GrantTypes        []string `json:"grant_types_supported"`
 	ResponseTypes     []string `json:"response_types_supported"`
 	Subjects          []string `json:"subject_types_supported"`
@@ -111,6 +114,11 @@ func (s *Server) discoveryHandler() (http.HandlerFunc, error) {
 
 	d.GrantTypes = s.supportedGrantTypes
 
+	if s.cibaNotifier != nil {
+		d.CIBAEndpoint = s.absURL("/bc-authorize")
+		d.CIBADeliveryModes = []string{cibaDeliveryModePoll, cibaDeliveryModePing}
+	}
+
 	data, err := json.MarshalIndent(d, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal discovery data: %v", err)
@@ -142,6 +150,16 @@ func (s *Server) handleAuthorization(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only offer the connectors the client may use. Unknown clients are
+	// rejected once the authorization request is parsed.
+	if client, err := s.storage.GetClient(r.Form.Get("client_id")); err == nil {
+		connectors = allowedConnectors(client, connectors)
+	} else if err != storage.ErrNotFound {
+		s.logger.Errorf("Failed to get client: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "Database error.")
+		return
+	}
+
 	// We don't need connector_id any more
 	r.Form.Del("connector_id")
 
@@ -217,6 +235,18 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	client, err := s.storage.GetClient(authReq.ClientID)
+	if err != nil {
+		s.logger.Errorf("Failed to get client: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "Database error.")
+		return
+	}
+	if !clientAllows(client.AllowedConnectors, connID) {
+		s.logger.Errorf("Client %q isn't allowed to use connector %q", client.ID, connID)
+		s.renderError(r, w, http.StatusBadRequest, "Bad connector ID")
+		return
+	}
+
 	authReq.ConnectorID = connID
 
 	// Actually create the auth request
@@ -246,12 +276,32 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 			// Use the auth request ID as the "state" token.
 			//
 			// TODO(ericchiang): Is this appropriate or should we also be using a nonce?
-			callbackURL, err := conn.LoginURL(scopes, s.absURL("/callback"), authReq.ID)
+			var (
+				callbackURL string
+				connData    []byte
+			)
+			if stateConn, ok := conn.(connector.CallbackStateConnector); ok {
+				callbackURL, connData, err = stateConn.LoginURLWithState(scopes, s.absURL("/callback"), authReq.ID)
+			} else {
+				callbackURL, err = conn.LoginURL(scopes, s.absURL("/callback"), authReq.ID)
+			}
 			if err != nil {
 				s.logger.Errorf("Connector %q returned error when creating callback: %v", connID, err)
 				s.renderError(r, w, http.StatusInternalServerError, "Login error.")
 				return
 			}
+			if len(connData) > 0 {
+				// Keep the data of the connector until the callback.
+				updater := func(a storage.AuthRequest) (storage.AuthRequest, error) {
+					a.ConnectorData = connData
+					return a, nil
+				}
+				if err := s.storage.UpdateAuthRequest(authReq.ID, updater); err != nil {
+					s.logger.Errorf("Failed to update auth request: %v", err)
+					s.renderError(r, w, http.StatusInternalServerError, "Database error.")
+					return
+				}
+			}
 			http.Redirect(w, r, callbackURL, http.StatusFound)
 		case connector.PasswordConnector:
 			loginURL := url.URL{
@@ -264,6 +314,19 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 
 			http.Redirect(w, r, loginURL.String(), http.StatusFound)
 		case connector.SAMLConnector:
+			if redirectConn, ok := conn.(connector.SAMLRedirectConnector); ok {
+				redirectURL, err := redirectConn.RedirectURL(scopes, authReq.ID)
+				if err != nil {
+					s.logger.Errorf("Creating SAML redirect URL: %v", err)
+					s.renderError(r, w, http.StatusInternalServerError, "Connector Login Error")
+					return
+				}
+				if redirectURL != "" {
+					http.Redirect(w, r, redirectURL, http.StatusFound)
+					return
+				}
+			}
+
 			action, value, err := conn.POSTData(scopes, authReq.ID)
 			if err != nil {
 				s.logger.Errorf("Creating SAML data: %v", err)
@@ -296,6 +359,31 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleSAMLMetadata serves the metadata of the server as a service provider
+// of a SAML connector.
+func (s *Server) handleSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	connID := mux.Vars(r)["connector"]
+	conn, err := s.getConnector(connID)
+	if err != nil {
+		s.logger.Errorf("Failed to get connector with id %q : %v", connID, err)
+		s.renderError(r, w, http.StatusNotFound, "Requested resource does not exist.")
+		return
+	}
+	metadataConn, ok := conn.Connector.(connector.SAMLMetadataConnector)
+	if !ok {
+		s.renderError(r, w, http.StatusNotFound, "Requested resource does not exist.")
+		return
+	}
+	data, err := metadataConn.Metadata()
+	if err != nil {
+		s.logger.Errorf("Creating SAML metadata: %v", err)
+		s.renderError(r, w, http.StatusInternalServerError, "Internal server error.")
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(data)
+}
+
 func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 	authID := r.URL.Query().Get("state")
 	if authID == "" {
@@ -339,7 +427,7 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
-		if err := s.templates.password(r, w, r.URL.String(), "", usernamePrompt(pwConn), false, backLink); err != nil {
+		if err := s.templates.password(r, w, r.URL.String(), "", usernamePrompt(pwConn), false, backLink, passwordNotice{}); err != nil {
 			s.logger.Errorf("Server template error: %v", err)
 		}
 	case http.MethodPost:
@@ -347,16 +435,67 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 		password := r.FormValue("password")
 		scopes := parseScopes(authReq.Scopes)
 
+		renderNotice := func(invalid bool, notice passwordNotice) {
+			if err := s.templates.password(r, w, r.URL.String(), username, usernamePrompt(pwConn), invalid, backLink, notice); err != nil {
+				s.logger.Errorf("Server template error: %v", err)
+			}
+		}
+
+		// Change the password first if the user submitted the password change form.
+		if newPassword := r.FormValue("new_password"); newPassword != "" {
+			changer, ok := pwConn.(connector.PasswordChanger)
+			if !ok {
+				s.renderError(r, w, http.StatusBadRequest, "Password change not supported.")
+				return
+			}
+			if newPassword != r.FormValue("confirm_password") {
+				renderNotice(false, passwordNotice{Message: "The new passwords don't match.", ChangePassword: true})
+				return
+			}
+			ok, err := changer.ChangePassword(r.Context(), username, password, newPassword)
+			var policyErr *connector.PasswordPolicyError
+			if errors.As(err, &policyErr) {
+				renderNotice(false, s.passwordPolicyNotice(pwConn, policyErr))
+				return
+			}
+			if err != nil {
+				s.logger.Errorf("Failed to change password of user: %v", err)
+				s.renderError(r, w, http.StatusInternalServerError, "Password change error.")
+				return
+			}
+			if !ok {
+				renderNotice(true, passwordNotice{ChangePassword: true})
+				return
+			}
+			s.logger.Infof("password of user %q of connector %q changed", username, authReq.ConnectorID)
+			password = newPassword
+		}
+
 		identity, ok, err := pwConn.Login(r.Context(), scopes, username, password)
+		var policyErr *connector.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			notice := s.passwordPolicyNotice(pwConn, policyErr)
+			if ok {
+				// The login succeeded regardless, let the user choose between
+				// changing the password and continuing.
+				redirectURL, err := s.finalizeLogin(identity, authReq, conn.Connector)
+				if err != nil {
+					s.logger.Errorf("Failed to finalize login: %v", err)
+					s.renderError(r, w, http.StatusInternalServerError, "Login error.")
+					return
+				}
+				notice.ContinueURL = redirectURL
+			}
+			renderNotice(false, notice)
+			return
+		}
 		if err != nil {
 			s.logger.Errorf("Failed to login user: %v", err)
 			s.renderError(r, w, http.StatusInternalServerError, fmt.Sprintf("Login error: %v", err))
 			return
 		}
 		if !ok {
-			if err := s.templates.password(r, w, r.URL.String(), username, usernamePrompt(pwConn), true, backLink); err != nil {
-				s.logger.Errorf("Server template error: %v", err)
-			}
+			renderNotice(true, passwordNotice{})
 			return
 		}
 		redirectURL, err := s.finalizeLogin(identity, authReq, conn.Connector)
@@ -372,6 +511,42 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// passwordPolicyNotice explains to the user how the password policy of the
+// connector restricted their login, and offers to change the password if the
+// connector supports it.
+func (s *Server) passwordPolicyNotice(pwConn connector.PasswordConnector, policyErr *connector.PasswordPolicyError) passwordNotice {
+	_, canChange := pwConn.(connector.PasswordChanger)
+	canChange = canChange && policyErr.CanChangePassword
+
+	var notice passwordNotice
+	switch policyErr.Reason {
+	case connector.PasswordExpired:
+		notice.Message = "Your password has expired."
+	case connector.PasswordMustChange:
+		notice.Message = "Your password must be changed before you can log in."
+	case connector.PasswordGraceLogin:
+		notice.Message = fmt.Sprintf("Your password has expired. You can log in %d more times before you must change it.", policyErr.GraceLoginsRemaining)
+	case connector.AccountLocked:
+		notice.Message = "Your account is locked. Try again later or contact your administrator."
+		return notice
+	case connector.PasswordRejected:
+		notice.Message = "The new password doesn't satisfy the password policy."
+	default:
+		notice.Message = "Your login was refused by the password policy."
+		return notice
+	}
+
+	if canChange {
+		notice.ChangePassword = true
+		if policyErr.Reason != connector.PasswordRejected {
+			notice.Message += " Please choose a new password."
+		}
+	} else if policyErr.Reason != connector.PasswordGraceLogin {
+		notice.Message += " Please contact your administrator to change it."
+	}
+	return notice
+}
+
 func (s *Server) handleConnectorCallback(w http.ResponseWriter, r *http.Request) {
 	var authID string
 	switch r.Method {
@@ -423,7 +598,11 @@ func (s *Server) handleConnectorCallback(w http.ResponseWriter, r *http.Request)
 			s.renderError(r, w, http.StatusBadRequest, "Invalid request")
 			return
 		}
-		identity, err = conn.HandleCallback(parseScopes(authReq.Scopes), r)
+		if stateConn, ok := conn.(connector.CallbackStateConnector); ok {
+			identity, err = stateConn.HandleCallbackWithState(parseScopes(authReq.Scopes), authReq.ConnectorData, r)
+		} else {
+			identity, err = conn.HandleCallback(parseScopes(authReq.Scopes), r)
+		}
 	case connector.SAMLConnector:
 		if r.Method != http.MethodPost {
 			s.logger.Errorf("OAuth2 request mapped to SAML connector")
@@ -569,6 +748,14 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 		return
 	}
 
+	client, err := s.storage.GetClient(authReq.ClientID)
+	if err != nil {
+		s.logger.Errorf("Failed to get client %q: %v", authReq.ClientID, err)
+		s.renderError(r, w, http.StatusInternalServerError, "Failed to retrieve client.")
+		return
+	}
+	policy := s.tokenPolicy(client)
+
 	if err := s.storage.DeleteAuthRequest(authReq.ID); err != nil {
 		if err != storage.ErrNotFound {
 			s.logger.Errorf("Failed to delete authorization request: %v", err)
@@ -594,11 +781,11 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 
 		// ID token returned immediately if the response_type includes "id_token".
 		// Only valid for implicit and hybrid flows.
-		idToken       string
-		idTokenExpiry time.Time
+		idToken string
 
 		// Access token
-		accessToken string
+		accessToken       string
+		accessTokenExpiry time.Time
 	)
 
 	for _, responseType := range authReq.ResponseTypes {
@@ -611,7 +798,7 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 				Nonce:         authReq.Nonce,
 				Scopes:        authReq.Scopes,
 				Claims:        authReq.Claims,
-				Expiry:        s.now().Add(time.Minute * 30),
+				Expiry:        s.now().Add(policy.authCodesValidFor),
 				RedirectURI:   authReq.RedirectURI,
 				ConnectorData: authReq.ConnectorData,
 				PKCE:          authReq.PKCE,
@@ -636,14 +823,14 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 			implicitOrHybrid = true
 			var err error
 
-			accessToken, err = s.newAccessToken(authReq.ClientID, authReq.Claims, authReq.Scopes, authReq.Nonce, authReq.ConnectorID)
+			accessToken, accessTokenExpiry, err = s.newAccessToken(authReq.ClientID, policy, authReq.Claims, authReq.Scopes, authReq.Nonce, authReq.ConnectorID)
 			if err != nil {
 				s.logger.Errorf("failed to create new access token: %v", err)
 				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 				return
 			}
 
-			idToken, idTokenExpiry, err = s.newIDToken(authReq.ClientID, authReq.Claims, authReq.Scopes, authReq.Nonce, accessToken, code.ID, authReq.ConnectorID)
+			idToken, _, err = s.newIDToken(authReq.ClientID, policy, authReq.Claims, authReq.Scopes, authReq.Nonce, accessToken, code.ID, authReq.ConnectorID)
 			if err != nil {
 				s.logger.Errorf("failed to create ID token: %v", err)
 				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -665,7 +852,7 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 			//
 			// https://openid.net/specs/openid-connect-core-1_0.html#HybridAuthResponse
 			if code.ID == "" {
-				v.Set("expires_in", strconv.Itoa(int(idTokenExpiry.Sub(s.now()).Seconds())))
+				v.Set("expires_in", strconv.Itoa(int(accessTokenExpiry.Sub(s.now()).Seconds())))
 			}
 		}
 		if code.ID != "" {
@@ -759,12 +946,18 @@ func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
 	switch grantType {
 	case grantTypeDeviceCode:
 		s.handleDeviceToken(w, r)
+	case grantTypeCIBA:
+		if s.cibaNotifier == nil {
+			s.tokenErrHelper(w, errUnsupportedGrantType, "", http.StatusBadRequest)
+			return
+		}
+		s.withClientFromStorage(w, r, s.withAllowedGrantType(grantType, s.handleCIBAToken))
 	case grantTypeAuthorizationCode:
-		s.withClientFromStorage(w, r, s.handleAuthCode)
+		s.withClientFromStorage(w, r, s.withAllowedGrantType(grantType, s.handleAuthCode))
 	case grantTypeRefreshToken:
-		s.withClientFromStorage(w, r, s.handleRefreshToken)
+		s.withClientFromStorage(w, r, s.withAllowedGrantType(grantType, s.handleRefreshToken))
 	case grantTypePassword:
-		s.withClientFromStorage(w, r, s.handlePasswordGrant)
+		s.withClientFromStorage(w, r, s.withAllowedGrantType(grantType, s.handlePasswordGrant))
 	default:
 		s.tokenErrHelper(w, errUnsupportedGrantType, "", http.StatusBadRequest)
 	}
@@ -843,14 +1036,16 @@ func (s *Server) handleAuthCode(w http.ResponseWriter, r *http.Request, client s
 }
 
 func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCode, client storage.Client) (*accessTokenResponse, error) {
-	accessToken, err := s.newAccessToken(client.ID, authCode.Claims, authCode.Scopes, authCode.Nonce, authCode.ConnectorID)
+	policy := s.tokenPolicy(client)
+
+	accessToken, expiry, err := s.newAccessToken(client.ID, policy, authCode.Claims, authCode.Scopes, authCode.Nonce, authCode.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create new access token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 		return nil, err
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, authCode.Claims, authCode.Scopes, authCode.Nonce, accessToken, authCode.ID, authCode.ConnectorID)
+	idToken, _, err := s.newIDToken(client.ID, policy, authCode.Claims, authCode.Scopes, authCode.Nonce, accessToken, authCode.ID, authCode.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create ID token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -962,18 +1157,12 @@ func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCo
 				return nil, err
 			}
 		} else {
-			if oldTokenRef, ok := session.Refresh[tokenRef.ClientID]; ok {
-				// Delete old refresh token from storage.
-				if err := s.storage.DeleteRefresh(oldTokenRef.ID); err != nil && err != storage.ErrNotFound {
-					s.logger.Errorf("failed to delete refresh token: %v", err)
-					s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
-					deleteToken = true
-					return nil, err
-				}
-			}
-
-			// Update existing OfflineSession obj with new RefreshTokenRef.
+			// Update existing OfflineSession obj with new RefreshTokenRef. The
+			// old refresh token is deleted afterwards, so the session never
+			// looks orphaned to the garbage collection.
+			var oldTokenRef *storage.RefreshTokenRef
 			if err := s.storage.UpdateOfflineSessions(session.UserID, session.ConnID, func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+				oldTokenRef = old.Refresh[tokenRef.ClientID]
 				old.Refresh[tokenRef.ClientID] = &tokenRef
 				return old, nil
 			}); err != nil {
@@ -982,6 +1171,16 @@ func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCo
 				deleteToken = true
 				return nil, err
 			}
+
+			if oldTokenRef != nil {
+				// Delete old refresh token from storage.
+				if err := s.storage.DeleteRefresh(oldTokenRef.ID); err != nil && err != storage.ErrNotFound {
+					s.logger.Errorf("failed to delete refresh token: %v", err)
+					s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+					deleteToken = true
+					return nil, err
+				}
+			}
 		}
 	}
 	return s.toAccessTokenResponse(idToken, accessToken, refreshToken, expiry), nil
@@ -1067,9 +1266,17 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		s.tokenErrHelper(w, errInvalidRequest, fmt.Sprintf("Client can't request scope(s) %q", invalidScopes), http.StatusBadRequest)
 		return
 	}
+	if disallowed := disallowedScopes(client, scopes); len(disallowed) > 0 {
+		s.tokenErrHelper(w, errInvalidScope, fmt.Sprintf("Client can't request scope(s) %q", disallowed), http.StatusBadRequest)
+		return
+	}
 
 	// Which connector
 	connID := s.passwordConnector
+	if !clientAllows(client.AllowedConnectors, connID) {
+		s.tokenErrHelper(w, errUnauthorizedClient, "Client can't use the password connector.", http.StatusBadRequest)
+		return
+	}
 	conn, err := s.getConnector(connID)
 	if err != nil {
 		s.tokenErrHelper(w, errInvalidRequest, "Requested connector does not exist.", http.StatusBadRequest)
@@ -1086,6 +1293,16 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 	username := q.Get("username")
 	password := q.Get("password")
 	identity, ok, err := passwordConnector.Login(r.Context(), parseScopes(scopes), username, password)
+	var policyErr *connector.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		if !ok {
+			s.logger.Infof("Password policy refused login of user %q: %v", username, policyErr)
+			s.tokenErrHelper(w, errAccessDenied, "Login refused by password policy", http.StatusUnauthorized)
+			return
+		}
+		s.logger.Infof("Password policy warning for user %q: %v", username, policyErr)
+		err = nil
+	}
 	if err != nil {
 		s.logger.Errorf("Failed to login user: %v", err)
 		s.tokenErrHelper(w, errInvalidRequest, "Could not login user", http.StatusBadRequest)
@@ -1106,14 +1323,16 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		Groups:            identity.Groups,
 	}
 
-	accessToken, err := s.newAccessToken(client.ID, claims, scopes, nonce, connID)
+	policy := s.tokenPolicy(client)
+
+	accessToken, expiry, err := s.newAccessToken(client.ID, policy, claims, scopes, nonce, connID)
 	if err != nil {
 		s.logger.Errorf("password grant failed to create new access token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 		return
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, claims, scopes, nonce, accessToken, "", connID)
+	idToken, _, err := s.newIDToken(client.ID, policy, claims, scopes, nonce, accessToken, "", connID)
 	if err != nil {
 		s.logger.Errorf("password grant failed to create new ID token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -1213,7 +1432,23 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 				return
 			}
 		} else {
-			if oldTokenRef, ok := session.Refresh[tokenRef.ClientID]; ok {
+			// Update existing OfflineSession obj with new RefreshTokenRef. The
+			// old refresh token is deleted afterwards, so the session never
+			// looks orphaned to the garbage collection.
+			var oldTokenRef *storage.RefreshTokenRef
+			if err := s.storage.UpdateOfflineSessions(session.UserID, session.ConnID, func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+				oldTokenRef = old.Refresh[tokenRef.ClientID]
+				old.Refresh[tokenRef.ClientID] = &tokenRef
+				old.ConnectorData = identity.ConnectorData
+				return old, nil
+			}); err != nil {
+				s.logger.Errorf("failed to update offline session: %v", err)
+				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+				deleteToken = true
+				return
+			}
+
+			if oldTokenRef != nil {
 				// Delete old refresh token from storage.
 				if err := s.storage.DeleteRefresh(oldTokenRef.ID); err != nil {
 					if err == storage.ErrNotFound {
@@ -1226,18 +1461,6 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 					}
 				}
 			}
-
-			// Update existing OfflineSession obj with new RefreshTokenRef.
-			if err := s.storage.UpdateOfflineSessions(session.UserID, session.ConnID, func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
-				old.Refresh[tokenRef.ClientID] = &tokenRef
-				old.ConnectorData = identity.ConnectorData
-				return old, nil
-			}); err != nil {
-				s.logger.Errorf("failed to update offline session: %v", err)
-				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
-				deleteToken = true
-				return
-			}
 		}
 	}
 
@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
+	"github.com/dexidp/dex/connector"
 	"github.com/dexidp/dex/storage"
 )
 
@@ -301,3 +304,239 @@ func TestPasswordConnectorDataNotEmpty(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `{"test": "true"}`, string(newSess.ConnectorData))
 }
+
+// passwordPolicyConnector only lets its user log in after changing the
+// expired password.
+type passwordPolicyConnector struct {
+	password string
+}
+
+func (p *passwordPolicyConnector) Prompt() string { return "" }
+
+func (p *passwordPolicyConnector) Login(_ context.Context, _ connector.Scopes, username, password string) (connector.Identity, bool, error) {
+	if username != "jane" || password != p.password {
+		return connector.Identity{}, false, nil
+	}
+	if password == "expired" {
+		return connector.Identity{}, false, &connector.PasswordPolicyError{Reason: connector.PasswordExpired, CanChangePassword: true}
+	}
+	return connector.Identity{UserID: "jane", Username: "jane"}, true, nil
+}
+
+func (p *passwordPolicyConnector) ChangePassword(_ context.Context, username, oldPassword, newPassword string) (bool, error) {
+	if username != "jane" || oldPassword != p.password {
+		return false, nil
+	}
+	if len(newPassword) < 8 {
+		return true, &connector.PasswordPolicyError{Reason: connector.PasswordRejected, CanChangePassword: true}
+	}
+	p.password = newPassword
+	return true, nil
+}
+
+func TestHandlePasswordLoginPasswordPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	conn := &passwordPolicyConnector{password: "expired"}
+	require.NoError(t, s.storage.CreateConnector(storage.Connector{ID: "policy", Type: "mockPassword", ResourceVersion: "1"}))
+	s.mu.Lock()
+	s.connectors["policy"] = Connector{ResourceVersion: "1", Connector: conn}
+	s.mu.Unlock()
+
+	require.NoError(t, s.storage.CreateAuthRequest(storage.AuthRequest{
+		ID:          "policy-request",
+		ClientID:    "test",
+		ConnectorID: "policy",
+		RedirectURI: "https://example.com/callback",
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/policy/login?state=policy-request", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, req)
+		return rr
+	}
+
+	tests := []struct {
+		name     string
+		form     url.Values
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "expired password",
+			form:     url.Values{"login": {"jane"}, "password": {"expired"}},
+			wantCode: http.StatusOK,
+			wantBody: "Your password has expired. Please choose a new password.",
+		},
+		{
+			name:     "mismatched new passwords",
+			form:     url.Values{"login": {"jane"}, "password": {"expired"}, "new_password": {"correct horse"}, "confirm_password": {"battery staple"}},
+			wantCode: http.StatusOK,
+			wantBody: "The new passwords don&#39;t match.",
+		},
+		{
+			name:     "wrong old password",
+			form:     url.Values{"login": {"jane"}, "password": {"wrong"}, "new_password": {"correct horse"}, "confirm_password": {"correct horse"}},
+			wantCode: http.StatusOK,
+			wantBody: "Invalid Username and password.",
+		},
+		{
+			name:     "rejected new password",
+			form:     url.Values{"login": {"jane"}, "password": {"expired"}, "new_password": {"short"}, "confirm_password": {"short"}},
+			wantCode: http.StatusOK,
+			wantBody: "The new password doesn&#39;t satisfy the password policy.",
+		},
+		{
+			name:     "changed password",
+			form:     url.Values{"login": {"jane"}, "password": {"expired"}, "new_password": {"correct horse"}, "confirm_password": {"correct horse"}},
+			wantCode: http.StatusSeeOther,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := post(tc.form)
+			require.Equal(t, tc.wantCode, rr.Code, rr.Body.String())
+			require.Contains(t, rr.Body.String(), tc.wantBody)
+			if tc.wantCode == http.StatusOK {
+				require.Contains(t, rr.Body.String(), `name="new_password"`)
+			}
+		})
+	}
+	require.Equal(t, "correct horse", conn.password)
+}
+
+// redirectSAMLConnector sends SAML requests through the HTTP Redirect binding
+// and serves service provider metadata.
+type redirectSAMLConnector struct{}
+
+func (redirectSAMLConnector) POSTData(_ connector.Scopes, requestID string) (string, string, error) {
+	return "https://idp.example.com/sso", "request", nil
+}
+
+func (redirectSAMLConnector) HandlePOST(connector.Scopes, string, string) (connector.Identity, error) {
+	return connector.Identity{}, errors.New("not implemented")
+}
+
+func (redirectSAMLConnector) RedirectURL(_ connector.Scopes, requestID string) (string, error) {
+	return "https://idp.example.com/sso?SAMLRequest=request&RelayState=" + requestID, nil
+}
+
+func (redirectSAMLConnector) Metadata() ([]byte, error) {
+	return []byte("<EntityDescriptor/>"), nil
+}
+
+func TestHandleSAMLRedirectAndMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.Storage = storage.WithStaticClients(c.Storage, []storage.Client{
+			{ID: "saml-client", RedirectURIs: []string{"https://example.com/callback"}},
+		})
+	})
+	defer httpServer.Close()
+
+	require.NoError(t, s.storage.CreateConnector(storage.Connector{ID: "saml", Type: "saml", ResourceVersion: "1"}))
+	s.mu.Lock()
+	s.connectors["saml"] = Connector{ResourceVersion: "1", Connector: redirectSAMLConnector{}}
+	s.mu.Unlock()
+
+	params := url.Values{}
+	params.Set("client_id", "saml-client")
+	params.Set("redirect_uri", "https://example.com/callback")
+	params.Set("response_type", "code")
+	params.Set("scope", "openid")
+
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/saml?"+params.Encode(), nil))
+	require.Equal(t, http.StatusFound, rr.Code)
+	location, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "idp.example.com", location.Host)
+	require.NotEmpty(t, location.Query().Get("RelayState"))
+
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/saml/saml/metadata", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/samlmetadata+xml", rr.Header().Get("Content-Type"))
+	require.Equal(t, "<EntityDescriptor/>", rr.Body.String())
+
+	// Other connectors don't have metadata.
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/saml/mock/metadata", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// stateCallbackConnector keeps a code verifier with the auth request.
+type stateCallbackConnector struct{}
+
+func (stateCallbackConnector) LoginURL(connector.Scopes, string, string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (stateCallbackConnector) HandleCallback(connector.Scopes, *http.Request) (connector.Identity, error) {
+	return connector.Identity{}, errors.New("not implemented")
+}
+
+func (stateCallbackConnector) LoginURLWithState(_ connector.Scopes, callbackURL, state string) (string, []byte, error) {
+	return "https://idp.example.com/authorize?state=" + state, []byte("verifier-" + state), nil
+}
+
+func (stateCallbackConnector) HandleCallbackWithState(_ connector.Scopes, connData []byte, r *http.Request) (connector.Identity, error) {
+	if string(connData) != "verifier-"+r.URL.Query().Get("state") {
+		return connector.Identity{}, fmt.Errorf("unexpected connector data %q", connData)
+	}
+	return connector.Identity{UserID: "1", Username: "jane", Email: "jane@example.com", EmailVerified: true}, nil
+}
+
+func TestHandleCallbackWithState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.Storage = storage.WithStaticClients(c.Storage, []storage.Client{
+			{ID: "state-client", RedirectURIs: []string{"https://example.com/callback"}},
+		})
+	})
+	defer httpServer.Close()
+
+	require.NoError(t, s.storage.CreateConnector(storage.Connector{ID: "state", Type: "oidc", ResourceVersion: "1"}))
+	s.mu.Lock()
+	s.connectors["state"] = Connector{ResourceVersion: "1", Connector: stateCallbackConnector{}}
+	s.mu.Unlock()
+
+	params := url.Values{}
+	params.Set("client_id", "state-client")
+	params.Set("redirect_uri", "https://example.com/callback")
+	params.Set("response_type", "code")
+	params.Set("scope", "openid")
+
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/state?"+params.Encode(), nil))
+	require.Equal(t, http.StatusFound, rr.Code)
+	location, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	state := location.Query().Get("state")
+	require.NotEmpty(t, state)
+
+	// The data of the connector is kept with the auth request.
+	authReq, err := s.storage.GetAuthRequest(state)
+	require.NoError(t, err)
+	require.Equal(t, "verifier-"+state, string(authReq.ConnectorData))
+
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+state, nil))
+	require.Equal(t, http.StatusSeeOther, rr.Code, rr.Body.String())
+
+	authReq, err = s.storage.GetAuthRequest(state)
+	require.NoError(t, err)
+	require.True(t, authReq.LoggedIn)
+	require.Empty(t, authReq.ConnectorData)
+}
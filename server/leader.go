@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+)
+
+// leaderLeaseName is the name of the lease held by the leader.
+const leaderLeaseName = "dex-leader"
+
+// LeaderElectionConfig configures the election of the instance which rotates
+// keys and collects garbage among the instances sharing a storage.
+type LeaderElectionConfig struct {
+	// Leases is usually the storage of the server, before wrapping it with
+	// static clients, passwords or connectors.
+	Leases storage.LeaseStorage
+
+	// Identity identifies this instance in the lease, e.g. the hostname. It
+	// must differ between instances.
+	Identity string
+
+	// LeaseDuration is how long other instances wait for the leader to renew
+	// the lease before they take it over. Defaults to 15 seconds.
+	LeaseDuration time.Duration
+}
+
+// leaderElector campaigns for the leader lease in the background. The leader
+// renews the lease three times per lease duration, other instances check as
+// often whether it has expired.
+type leaderElector struct {
+	leases   storage.LeaseStorage
+	identity string
+	duration time.Duration
+	logger   log.Logger
+
+	mu     sync.RWMutex
+	lease  storage.Lease
+	leader bool
+
+	leaderDesc *prometheus.Desc
+	isLeader   *prometheus.Desc
+}
+
+func newLeaderElector(c LeaderElectionConfig, logger log.Logger) *leaderElector {
+	return &leaderElector{
+		leases:   c.Leases,
+		identity: c.Identity,
+		duration: value(c.LeaseDuration, 15*time.Second),
+		logger:   logger,
+		leaderDesc: prometheus.NewDesc("dex_leader_election_leader",
+			"The instance currently holding the leader lease, as seen by this instance.",
+			[]string{"identity"}, nil),
+		isLeader: prometheus.NewDesc("dex_leader_election_is_leader",
+			"Whether this instance is the leader running key rotation and garbage collection.",
+			nil, nil),
+	}
+}
+
+// start campaigns once before returning, so the leader knows about it before
+// it starts the background loops, then keeps campaigning until ctx is done.
+func (e *leaderElector) start(ctx context.Context) {
+	e.campaign()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				e.resign()
+				return
+			case <-time.After(e.duration / 3):
+				e.campaign()
+			}
+		}
+	}()
+}
+
+func (e *leaderElector) campaign() {
+	lease, err := e.leases.AcquireLease(leaderLeaseName, e.identity, e.duration)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.logger.Errorf("failed to acquire leader lease: %v", err)
+		// No other instance can take over a lease before it expires.
+		if e.leader && !e.lease.Held(time.Now()) {
+			e.logger.Infof("leader lease expired, stopping key rotation and garbage collection")
+			e.leader = false
+		}
+		return
+	}
+
+	leader := lease.Holder == e.identity
+	switch {
+	case leader && !e.leader:
+		e.logger.Infof("acquired leader lease as %q, starting key rotation and garbage collection", e.identity)
+	case !leader && e.leader:
+		e.logger.Infof("lost leader lease to %q, stopping key rotation and garbage collection", lease.Holder)
+	case !leader && lease.Holder != e.lease.Holder:
+		e.logger.Infof("leader lease held by %q", lease.Holder)
+	}
+	e.lease, e.leader = lease, leader
+}
+
+// resign releases the lease so another instance can take over before it
+// expires.
+func (e *leaderElector) resign() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.leader {
+		return
+	}
+	e.leader = false
+	if err := e.leases.ReleaseLease(leaderLeaseName, e.identity); err != nil {
+		e.logger.Errorf("failed to release leader lease: %v", err)
+	}
+}
+
+// current returns the holder of the lease when it was last seen, and whether
+// this instance is the leader.
+func (e *leaderElector) current() (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.lease.Held(time.Now()) {
+		return "", e.leader
+	}
+	return e.lease.Holder, e.leader
+}
+
+// Describe implements prometheus.Collector.
+func (e *leaderElector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.leaderDesc
+	ch <- e.isLeader
+}
+
+// Collect implements prometheus.Collector.
+func (e *leaderElector) Collect(ch chan<- prometheus.Metric) {
+	leader, isLeader := e.current()
+	if leader != "" {
+		ch <- prometheus.MustNewConstMetric(e.leaderDesc, prometheus.GaugeValue, 1, leader)
+	}
+	var v float64
+	if isLeader {
+		v = 1
+	}
+	ch <- prometheus.MustNewConstMetric(e.isLeader, prometheus.GaugeValue, v)
+}
+
+// Leader returns the identity of the instance holding the leader lease, and
+// whether it's this server. Without leader election every server acts as the
+// leader and the identity is empty.
+func (s *Server) Leader() (identity string, isLeader bool) {
+	if s.leaderElector == nil {
+		return "", true
+	}
+	return s.leaderElector.current()
+}
+
+// isLeader reports whether this server should rotate keys and collect garbage.
+func (s *Server) isLeader() bool {
+	_, leader := s.Leader()
+	return leader
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
+)
+
+func TestLeaderElection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := memory.New(logger)
+	leases := s.(storage.LeaseStorage)
+
+	// Another instance leads, this one doesn't create keys.
+	_, err := leases.AcquireLease(leaderLeaseName, "other", time.Minute)
+	require.NoError(t, err)
+
+	httpServer, server := newTestServer(ctx, t, func(c *Config) {
+		c.Storage = s
+		c.LeaderElection = &LeaderElectionConfig{Leases: leases, Identity: "this"}
+	})
+	defer httpServer.Close()
+
+	leader, isLeader := server.Leader()
+	require.Equal(t, "other", leader)
+	require.False(t, isLeader)
+	keys, err := s.GetKeys()
+	require.NoError(t, err)
+	require.Nil(t, keys.SigningKey)
+
+	// Once the lease is released this instance takes over.
+	require.NoError(t, leases.ReleaseLease(leaderLeaseName, "other"))
+	server.leaderElector.campaign()
+	leader, isLeader = server.Leader()
+	require.Equal(t, "this", leader)
+	require.True(t, isLeader)
+
+	expected := `
+# HELP dex_leader_election_is_leader Whether this instance is the leader running key rotation and garbage collection.
+# TYPE dex_leader_election_is_leader gauge
+dex_leader_election_is_leader 1
+# HELP dex_leader_election_leader The instance currently holding the leader lease, as seen by this instance.
+# TYPE dex_leader_election_leader gauge
+dex_leader_election_leader{identity="this"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(server.leaderElector, strings.NewReader(expected)))
+
+	// The leader releases the lease when it stops.
+	server.leaderElector.resign()
+	lease, err := leases.AcquireLease(leaderLeaseName, "other", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "other", lease.Holder)
+}
@@ -119,6 +119,7 @@ const (
 
 const (
 	deviceCallbackURI = "/device/callback"
+	cibaCallbackURI   = "/bc-authorize/callback"
 )
 
 const (
@@ -131,6 +132,7 @@ const (
 	grantTypeImplicit          = "implicit"
 	grantTypePassword          = "password"
 	grantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	grantTypeCIBA              = "urn:openid:params:grant-type:ciba"
 )
 
 const (
@@ -146,6 +148,13 @@ const (
 	deviceTokenExpired  = "expired_token"
 )
 
+const (
+	cibaTokenPending  = "authorization_pending"
+	cibaTokenComplete = "complete"
+	cibaTokenDenied   = "access_denied"
+	cibaTokenRedeemed = "redeemed"
+)
+
 func parseScopes(scopes []string) connector.Scopes {
 	var s connector.Scopes
 	for _, scope := range scopes {
@@ -287,12 +296,15 @@ type federatedIDClaims struct {
 	UserID      string `json:"user_id,omitempty"`
 }
 
-func (s *Server) newAccessToken(clientID string, claims storage.Claims, scopes []string, nonce, connID string) (accessToken string, err error) {
-	idToken, _, err := s.newIDToken(clientID, claims, scopes, nonce, storage.NewID(), "", connID)
-	return idToken, err
+func (s *Server) newAccessToken(clientID string, policy clientTokenPolicy, claims storage.Claims, scopes []string, nonce, connID string) (accessToken string, expiry time.Time, err error) {
+	return s.newToken(policy.accessTokensValidFor, clientID, claims, scopes, nonce, storage.NewID(), "", connID)
 }
 
-func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
+func (s *Server) newIDToken(clientID string, policy clientTokenPolicy, claims storage.Claims, scopes []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
+	return s.newToken(policy.idTokensValidFor, clientID, claims, scopes, nonce, accessToken, code, connID)
+}
+
+func (s *Server) newToken(validFor time.Duration, clientID string, claims storage.Claims, scopes []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
 	keys, err := s.storage.GetKeys()
 	if err != nil {
 		s.logger.Errorf("Failed to get keys: %v", err)
@@ -309,7 +321,7 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 	}
 
 	issuedAt := s.now()
-	expiry = issuedAt.Add(s.idTokensValidFor)
+	expiry = issuedAt.Add(validFor)
 
 	sub := &internal.IDTokenSubject{
 		UserId: claims.UserID,
@@ -449,6 +461,9 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 	if redirectURI == deviceCallbackURI && client.Public {
 		redirectURI = s.issuerURL.Path + deviceCallbackURI
 	}
+	if redirectURI == cibaCallbackURI {
+		redirectURI = s.issuerURL.Path + cibaCallbackURI
+	}
 
 	// From here on out, we want to redirect back to the client with an error.
 	newRedirectedErr := func(typ, format string, a ...interface{}) *redirectedAuthErr {
@@ -464,6 +479,9 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		if !validateConnectorID(connectors, connectorID) {
 			return nil, newRedirectedErr(errInvalidRequest, "Invalid ConnectorID")
 		}
+		if !clientAllows(client.AllowedConnectors, connectorID) {
+			return nil, newRedirectedErr(errInvalidRequest, "Client can't use connector %q", connectorID)
+		}
 	}
 
 	// dex doesn't support request parameter and must return request_not_supported error
@@ -512,6 +530,9 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 	if len(invalidScopes) > 0 {
 		return nil, newRedirectedErr(errInvalidScope, "Client can't request scope(s) %q", invalidScopes)
 	}
+	if disallowed := disallowedScopes(client, scopes); len(disallowed) > 0 {
+		return nil, newRedirectedErr(errInvalidScope, "Client can't request scope(s) %q", disallowed)
+	}
 
 	var rt struct {
 		code    bool
@@ -534,6 +555,9 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		if !s.supportedResponseTypes[responseType] {
 			return nil, newRedirectedErr(errUnsupportedResponseType, "Unsupported response type %q", responseType)
 		}
+		if !clientAllows(client.AllowedResponseTypes, responseType) {
+			return nil, newRedirectedErr(errUnauthorizedClient, "Client can't use response type %q", responseType)
+		}
 	}
 
 	if len(responseTypes) == 0 {
@@ -562,6 +586,21 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		}
 	}
 
+	// The device and backchannel flows drive the code flow internally, check
+	// the grant type the client actually uses.
+	grantType := grantTypeImplicit
+	switch {
+	case redirectURI == deviceCallbackURI, redirectURI == s.issuerURL.Path+deviceCallbackURI:
+		grantType = grantTypeDeviceCode
+	case redirectURI == s.issuerURL.Path+cibaCallbackURI:
+		grantType = grantTypeCIBA
+	case rt.code:
+		grantType = grantTypeAuthorizationCode
+	}
+	if !clientAllows(client.AllowedGrantTypes, grantType) {
+		return nil, newRedirectedErr(errUnauthorizedClient, "Client can't use grant type %q", grantType)
+	}
+
 	return &storage.AuthRequest{
 		ID:                  storage.NewID(),
 		ClientID:            client.ID,
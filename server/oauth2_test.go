@@ -287,6 +287,100 @@ func TestParseAuthorizationRequest(t *testing.T) {
 			},
 			expectedError: &redirectedAuthErr{Type: errInvalidRequest},
 		},
+		{
+			name: "allowed connector, scopes and response type",
+			clients: []storage.Client{
+				{
+					ID:                   "bar",
+					RedirectURIs:         []string{"https://example.com/bar"},
+					AllowedConnectors:    []string{"mock2"},
+					AllowedGrantTypes:    []string{"authorization_code"},
+					AllowedScopes:        []string{"email"},
+					AllowedResponseTypes: []string{"code"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"connector_id":  "mock2",
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email",
+			},
+		},
+		{
+			name: "connector not allowed for client",
+			clients: []storage.Client{
+				{
+					ID:                "bar",
+					RedirectURIs:      []string{"https://example.com/bar"},
+					AllowedConnectors: []string{"mock2"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"connector_id":  "mock",
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email profile",
+			},
+			expectedError: &redirectedAuthErr{Type: errInvalidRequest},
+		},
+		{
+			name: "scope not allowed for client",
+			clients: []storage.Client{
+				{
+					ID:            "bar",
+					RedirectURIs:  []string{"https://example.com/bar"},
+					AllowedScopes: []string{"email"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email groups",
+			},
+			expectedError: &redirectedAuthErr{Type: errInvalidScope},
+		},
+		{
+			name: "response type not allowed for client",
+			clients: []storage.Client{
+				{
+					ID:                   "bar",
+					RedirectURIs:         []string{"https://example.com/bar"},
+					AllowedResponseTypes: []string{"code"},
+				},
+			},
+			supportedResponseTypes: []string{"code", "id_token"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code id_token",
+				"scope":         "openid email profile",
+			},
+			expectedError: &redirectedAuthErr{Type: errUnauthorizedClient},
+		},
+		{
+			name: "grant type not allowed for client",
+			clients: []storage.Client{
+				{
+					ID:                "bar",
+					RedirectURIs:      []string{"https://example.com/bar"},
+					AllowedGrantTypes: []string{"urn:ietf:params:oauth:grant-type:device_code"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email profile",
+			},
+			expectedError: &redirectedAuthErr{Type: errUnauthorizedClient},
+		},
 	}
 
 	for _, tc := range tests {
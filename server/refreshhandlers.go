@@ -61,7 +61,7 @@ func (s *Server) extractRefreshTokenFromRequest(r *http.Request) (*internal.Refr
 }
 
 // getRefreshTokenFromStorage checks that refresh token is valid and exists in the storage and gets its info
-func (s *Server) getRefreshTokenFromStorage(clientID string, token *internal.RefreshToken) (*storage.RefreshToken, *refreshError) {
+func (s *Server) getRefreshTokenFromStorage(clientID string, token *internal.RefreshToken, policy *RefreshTokenPolicy) (*storage.RefreshToken, *refreshError) {
 	invalidErr := newBadRequestError("Refresh token is invalid or has already been claimed by another client.")
 
 	refresh, err := s.storage.GetRefresh(token.RefreshId)
@@ -81,24 +81,29 @@ func (s *Server) getRefreshTokenFromStorage(clientID string, token *internal.Ref
 	}
 
 	if refresh.Token != token.Token {
-		switch {
-		case !s.refreshTokenPolicy.AllowedToReuse(refresh.LastUsed):
-			fallthrough
-		case refresh.ObsoleteToken != token.Token:
-			fallthrough
-		case refresh.ObsoleteToken == "":
+		// Only a replayed obsolete token proves the token leaked. Any other
+		// secret could be forged by anyone who knows the ID, so it must not
+		// revoke the token.
+		if refresh.ObsoleteToken == "" || refresh.ObsoleteToken != token.Token {
+			s.logger.Errorf("refresh token with id %s claimed with an invalid token", refresh.ID)
+			return nil, invalidErr
+		}
+		if !policy.AllowedToReuse(refresh.LastUsed) {
 			s.logger.Errorf("refresh token with id %s claimed twice", refresh.ID)
+			if policy.RevokeOnReuse() {
+				s.revokeReusedRefreshToken(refresh)
+			}
 			return nil, invalidErr
 		}
 	}
 
 	expiredErr := newBadRequestError("Refresh token expired.")
-	if s.refreshTokenPolicy.CompletelyExpired(refresh.CreatedAt) {
+	if policy.CompletelyExpired(refresh.CreatedAt) {
 		s.logger.Errorf("refresh token with id %s expired", refresh.ID)
 		return nil, expiredErr
 	}
 
-	if s.refreshTokenPolicy.ExpiredBecauseUnused(refresh.LastUsed) {
+	if policy.ExpiredBecauseUnused(refresh.LastUsed) {
 		s.logger.Errorf("refresh token with id %s expired due to inactivity", refresh.ID)
 		return nil, expiredErr
 	}
@@ -106,6 +111,35 @@ func (s *Server) getRefreshTokenFromStorage(clientID string, token *internal.Ref
 	return &refresh, nil
 }
 
+// revokeReusedRefreshToken deletes a refresh token whose obsolete value has been
+// replayed, along with its entry in the user's offline session. Either the
+// client or an attacker holds a stale copy, so neither of them can be trusted
+// with the current one.
+//
+// https://datatracker.ietf.org/doc/html/rfc6819#section-5.2.2.3
+func (s *Server) revokeReusedRefreshToken(refresh storage.RefreshToken) {
+	s.logger.Warnf("refresh token with id %s reused, revoking it for client %q and user %q",
+		refresh.ID, refresh.ClientID, refresh.Claims.UserID)
+	if s.refreshTokenReuseCounter != nil {
+		s.refreshTokenReuseCounter.Inc()
+	}
+
+	updater := func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+		if ref, ok := old.Refresh[refresh.ClientID]; ok && ref.ID == refresh.ID {
+			delete(old.Refresh, refresh.ClientID)
+		}
+		return old, nil
+	}
+	err := s.storage.UpdateOfflineSessions(refresh.Claims.UserID, refresh.ConnectorID, updater)
+	if err != nil && err != storage.ErrNotFound {
+		s.logger.Errorf("failed to update offline session of reused refresh token: %v", err)
+	}
+
+	if err := s.storage.DeleteRefresh(refresh.ID); err != nil && err != storage.ErrNotFound {
+		s.logger.Errorf("failed to delete reused refresh token: %v", err)
+	}
+}
+
 func (s *Server) getRefreshScopes(r *http.Request, refresh *storage.RefreshToken) ([]string, *refreshError) {
 	// Per the OAuth2 spec, if the client has omitted the scopes, default to the original
 	// authorized scopes.
@@ -138,7 +172,7 @@ func (s *Server) getRefreshScopes(r *http.Request, refresh *storage.RefreshToken
 	return requestedScopes, nil
 }
 
-func (s *Server) refreshWithConnector(ctx context.Context, token *internal.RefreshToken, refresh *storage.RefreshToken, scopes []string) (connector.Identity, *refreshError) {
+func (s *Server) refreshWithConnector(ctx context.Context, token *internal.RefreshToken, refresh *storage.RefreshToken, scopes []string, policy *RefreshTokenPolicy) (connector.Identity, *refreshError) {
 	var connectorData []byte
 
 	session, err := s.storage.GetOfflineSessions(refresh.Claims.UserID, refresh.ConnectorID)
@@ -173,7 +207,7 @@ func (s *Server) refreshWithConnector(ctx context.Context, token *internal.Refre
 
 	// user's token was previously updated by a connector and is allowed to reuse
 	// it is excessive to refresh identity in upstream
-	if s.refreshTokenPolicy.AllowedToReuse(refresh.LastUsed) && token.Token == refresh.ObsoleteToken {
+	if policy.AllowedToReuse(refresh.LastUsed) && token.Token == refresh.ObsoleteToken {
 		return ident, nil
 	}
 
@@ -217,9 +251,9 @@ func (s *Server) updateOfflineSession(refresh *storage.RefreshToken, ident conne
 }
 
 // updateRefreshToken updates refresh token and offline session in the storage
-func (s *Server) updateRefreshToken(token *internal.RefreshToken, refresh *storage.RefreshToken, ident connector.Identity) (*internal.RefreshToken, *refreshError) {
+func (s *Server) updateRefreshToken(token *internal.RefreshToken, refresh *storage.RefreshToken, ident connector.Identity, policy *RefreshTokenPolicy) (*internal.RefreshToken, *refreshError) {
 	newToken := token
-	if s.refreshTokenPolicy.RotationEnabled() {
+	if policy.RotationEnabled() {
 		newToken = &internal.RefreshToken{
 			RefreshId: refresh.ID,
 			Token:     storage.NewID(),
@@ -229,9 +263,9 @@ func (s *Server) updateRefreshToken(token *internal.RefreshToken, refresh *stora
 	lastUsed := s.now()
 
 	refreshTokenUpdater := func(old storage.RefreshToken) (storage.RefreshToken, error) {
-		if s.refreshTokenPolicy.RotationEnabled() {
+		if policy.RotationEnabled() {
 			if old.Token != token.Token {
-				if s.refreshTokenPolicy.AllowedToReuse(old.LastUsed) && old.ObsoleteToken == token.Token {
+				if policy.AllowedToReuse(old.LastUsed) && old.ObsoleteToken == token.Token {
 					newToken.Token = old.Token
 					// Do not update last used time for offline session if token is allowed to be reused
 					lastUsed = old.LastUsed
@@ -283,7 +317,11 @@ func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, clie
 		return
 	}
 
-	refresh, rerr := s.getRefreshTokenFromStorage(client.ID, token)
+	// Clients may override the refresh token policy of the server.
+	clientPolicy := s.tokenPolicy(client)
+	policy := clientPolicy.refreshTokens
+
+	refresh, rerr := s.getRefreshTokenFromStorage(client.ID, token, policy)
 	if rerr != nil {
 		s.refreshTokenErrHelper(w, rerr)
 		return
@@ -295,7 +333,7 @@ func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, clie
 		return
 	}
 
-	ident, rerr := s.refreshWithConnector(r.Context(), token, refresh, scopes)
+	ident, rerr := s.refreshWithConnector(r.Context(), token, refresh, scopes, policy)
 	if rerr != nil {
 		s.refreshTokenErrHelper(w, rerr)
 		return
@@ -310,21 +348,21 @@ func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, clie
 		Groups:            ident.Groups,
 	}
 
-	accessToken, err := s.newAccessToken(client.ID, claims, scopes, refresh.Nonce, refresh.ConnectorID)
+	accessToken, expiry, err := s.newAccessToken(client.ID, clientPolicy, claims, scopes, refresh.Nonce, refresh.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create new access token: %v", err)
 		s.refreshTokenErrHelper(w, newInternalServerError())
 		return
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, claims, scopes, refresh.Nonce, accessToken, "", refresh.ConnectorID)
+	idToken, _, err := s.newIDToken(client.ID, clientPolicy, claims, scopes, refresh.Nonce, accessToken, "", refresh.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create ID token: %v", err)
 		s.refreshTokenErrHelper(w, newInternalServerError())
 		return
 	}
 
-	newToken, rerr := s.updateRefreshToken(token, refresh, ident)
+	newToken, rerr := s.updateRefreshToken(token, refresh, ident, policy)
 	if rerr != nil {
 		s.refreshTokenErrHelper(w, rerr)
 		return
@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dexidp/dex/server/internal"
@@ -210,3 +212,86 @@ func TestRefreshTokenExpirationScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenReuseDetection(t *testing.T) {
+	t0 := time.Now()
+	tests := []struct {
+		name          string
+		revokeOnReuse bool
+		secret        string
+		revoked       bool
+	}{
+		{
+			name:          "Reuse is rejected",
+			revokeOnReuse: false,
+			secret:        "bar",
+		},
+		{
+			name:          "Reuse revokes the token",
+			revokeOnReuse: true,
+			secret:        "bar",
+			revoked:       true,
+		},
+		{
+			name:          "Invalid secret does not revoke the token",
+			revokeOnReuse: true,
+			secret:        "forged",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Setup a dex server.
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.RefreshTokenPolicy = &RefreshTokenPolicy{
+					rotateRefreshTokens: true,
+					reuseInterval:       time.Second * 30,
+					revokeOnReuse:       tc.revokeOnReuse,
+					now:                 func() time.Time { return t0.Add(time.Minute) },
+				}
+				c.Now = func() time.Time { return t0 }
+				c.PrometheusRegistry = prometheus.NewRegistry()
+			})
+			defer httpServer.Close()
+
+			mockRefreshTokenTestStorage(t, s.storage, true)
+
+			u, err := url.Parse(s.issuerURL.String())
+			require.NoError(t, err)
+
+			tokenData, err := internal.Marshal(&internal.RefreshToken{RefreshId: "test", Token: tc.secret})
+			require.NoError(t, err)
+
+			u.Path = path.Join(u.Path, "/token")
+			v := url.Values{}
+			v.Add("grant_type", "refresh_token")
+			v.Add("refresh_token", tokenData)
+
+			req, _ := http.NewRequest("POST", u.String(), bytes.NewBufferString(v.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
+			req.SetBasicAuth("test", "barfoo")
+
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+
+			require.Equal(t, `{"error":"invalid_request","error_description":"Refresh token is invalid or has already been claimed by another client."}`, rr.Body.String())
+
+			_, err = s.storage.GetRefresh("test")
+			session, sessionErr := s.storage.GetOfflineSessions("1", "test")
+			require.NoError(t, sessionErr)
+
+			if tc.revoked {
+				require.Equal(t, storage.ErrNotFound, err)
+				require.NotContains(t, session.Refresh, "test")
+				require.Equal(t, float64(1), testutil.ToFloat64(s.refreshTokenReuseCounter))
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, session.Refresh, "test")
+				require.Equal(t, float64(0), testutil.ToFloat64(s.refreshTokenReuseCounter))
+			}
+		})
+	}
+}
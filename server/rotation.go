@@ -72,11 +72,14 @@ func (s *Server) startKeyRotation(ctx context.Context, strategy rotationStrategy
 	rotator := keyRotator{s.storage, strategy, now, s.logger}
 
 	// Try to rotate immediately so properly configured storages will have keys.
-	if err := rotator.rotate(); err != nil {
-		if err == errAlreadyRotated {
-			s.logger.Infof("Key rotation not needed: %v", err)
-		} else {
-			s.logger.Errorf("failed to rotate keys: %v", err)
+	// Other instances wait for the leader to create them.
+	if s.isLeader() {
+		if err := rotator.rotate(); err != nil {
+			if err == errAlreadyRotated {
+				s.logger.Infof("Key rotation not needed: %v", err)
+			} else {
+				s.logger.Errorf("failed to rotate keys: %v", err)
+			}
 		}
 	}
 
@@ -86,6 +89,9 @@ func (s *Server) startKeyRotation(ctx context.Context, strategy rotationStrategy
 			case <-ctx.Done():
 				return
 			case <-time.After(time.Second * 30):
+				if !s.isLeader() {
+					continue
+				}
 				if err := rotator.rotate(); err != nil {
 					s.logger.Errorf("failed to rotate keys: %v", err)
 				}
@@ -185,13 +191,15 @@ type RefreshTokenPolicy struct {
 	validIfNotUsedFor time.Duration // interval from last token update to the end of its life
 	reuseInterval     time.Duration // interval within which old refresh token is allowed to be reused
 
+	revokeOnReuse bool // revoke the refresh token when an obsolete token is presented
+
 	now func() time.Time
 
 	logger log.Logger
 }
 
-func NewRefreshTokenPolicy(logger log.Logger, rotation bool, validIfNotUsedFor, absoluteLifetime, reuseInterval string) (*RefreshTokenPolicy, error) {
-	r := RefreshTokenPolicy{now: time.Now, logger: logger}
+func NewRefreshTokenPolicy(logger log.Logger, rotation bool, validIfNotUsedFor, absoluteLifetime, reuseInterval string, revokeOnReuse bool) (*RefreshTokenPolicy, error) {
+	r := RefreshTokenPolicy{now: time.Now, logger: logger, revokeOnReuse: revokeOnReuse}
 	var err error
 
 	if validIfNotUsedFor != "" {
@@ -220,9 +228,23 @@ func NewRefreshTokenPolicy(logger log.Logger, rotation bool, validIfNotUsedFor,
 
 	r.rotateRefreshTokens = !rotation
 	logger.Infof("config refresh tokens rotation enabled: %v", r.rotateRefreshTokens)
+	if r.revokeOnReuse {
+		logger.Infof("config refresh tokens revoked on reuse: %v", r.revokeOnReuse)
+	}
 	return &r, nil
 }
 
+// gcOptions returns the lifetimes garbage collection uses to prune refresh tokens.
+func (r *RefreshTokenPolicy) gcOptions() storage.GCOptions {
+	if r == nil {
+		return storage.GCOptions{}
+	}
+	return storage.GCOptions{
+		RefreshTokenAbsoluteLifetime:  r.absoluteLifetime,
+		RefreshTokenValidIfNotUsedFor: r.validIfNotUsedFor,
+	}
+}
+
 func (r *RefreshTokenPolicy) RotationEnabled() bool {
 	return r.rotateRefreshTokens
 }
@@ -241,6 +263,14 @@ func (r *RefreshTokenPolicy) ExpiredBecauseUnused(lastUsed time.Time) bool {
 	return r.now().After(lastUsed.Add(r.validIfNotUsedFor))
 }
 
+// RevokeOnReuse reports whether a refresh token should be revoked when one of
+// its obsolete values is presented outside the reuse interval.
+//
+// https://datatracker.ietf.org/doc/html/rfc6819#section-5.2.2.3
+func (r *RefreshTokenPolicy) RevokeOnReuse() bool {
+	return r.revokeOnReuse
+}
+
 func (r *RefreshTokenPolicy) AllowedToReuse(lastUsed time.Time) bool {
 	if r.reuseInterval == 0 {
 		return false // expiration disabled
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
 	"net/url"
@@ -86,6 +87,7 @@ type Config struct {
 	IDTokensValidFor       time.Duration // Defaults to 24 hours
 	AuthRequestsValidFor   time.Duration // Defaults to 24 hours
 	DeviceRequestsValidFor time.Duration // Defaults to 5 minutes
+	CIBARequestsValidFor   time.Duration // Defaults to 5 minutes
 
 	// Refresh token expiration settings
 	RefreshTokenPolicy *RefreshTokenPolicy
@@ -93,8 +95,16 @@ type Config struct {
 	// If set, the server will use this connector to handle password grants
 	PasswordConnector string
 
+	// If set, the server enables Client-Initiated Backchannel Authentication and uses
+	// the notifier to ask users to approve the requests.
+	CIBANotifier CIBANotifier
+
 	GCFrequency time.Duration // Defaults to 5 minutes
 
+	// If set, only the instance holding the leader lease rotates keys and
+	// collects garbage. Otherwise every instance does.
+	LeaderElection *LeaderElectionConfig
+
 	// If specified, the server will use this function for determining time.
 	Now func() time.Time
 
@@ -169,6 +179,11 @@ type Server struct {
 	// Used for password grant
 	passwordConnector string
 
+	// Used for backchannel authentication, nil if disabled
+	cibaNotifier CIBANotifier
+	// Used to ping clients once a backchannel authentication request completes
+	cibaHTTPClient *http.Client
+
 	supportedResponseTypes map[string]bool
 
 	supportedGrantTypes []string
@@ -178,9 +193,20 @@ type Server struct {
 	idTokensValidFor       time.Duration
 	authRequestsValidFor   time.Duration
 	deviceRequestsValidFor time.Duration
+	cibaRequestsValidFor   time.Duration
 
 	refreshTokenPolicy *RefreshTokenPolicy
 
+	// refreshTokenReuseCounter counts refresh tokens revoked because an
+	// obsolete value was replayed. Nil when metrics are disabled.
+	refreshTokenReuseCounter prometheus.Counter
+
+	// Elects the instance running the background loops, nil if disabled.
+	leaderElector *leaderElector
+
+	// Registers the metrics of connectors, nil if metrics are disabled.
+	prometheusRegistry *prometheus.Registry
+
 	logger log.Logger
 }
 
@@ -234,6 +260,10 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 		supportedGrant = append(supportedGrant, grantTypePassword)
 	}
 
+	if c.CIBANotifier != nil {
+		supportedGrant = append(supportedGrant, grantTypeCIBA)
+	}
+
 	sort.Strings(supportedGrant)
 
 	webFS := web.FS()
@@ -271,15 +301,26 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 		idTokensValidFor:       value(c.IDTokensValidFor, 24*time.Hour),
 		authRequestsValidFor:   value(c.AuthRequestsValidFor, 24*time.Hour),
 		deviceRequestsValidFor: value(c.DeviceRequestsValidFor, 5*time.Minute),
+		cibaRequestsValidFor:   value(c.CIBARequestsValidFor, 5*time.Minute),
 		refreshTokenPolicy:     c.RefreshTokenPolicy,
 		skipApproval:           c.SkipApprovalScreen,
 		alwaysShowLogin:        c.AlwaysShowLoginScreen,
 		now:                    now,
 		templates:              tmpls,
 		passwordConnector:      c.PasswordConnector,
+		cibaNotifier:           c.CIBANotifier,
+		cibaHTTPClient:         &http.Client{Timeout: 10 * time.Second},
+		prometheusRegistry:     c.PrometheusRegistry,
 		logger:                 c.Logger,
 	}
 
+	if c.LeaderElection != nil {
+		if c.LeaderElection.Leases == nil || c.LeaderElection.Identity == "" {
+			return nil, errors.New("server: leader election requires leases and an identity")
+		}
+		s.leaderElector = newLeaderElector(*c.LeaderElection, c.Logger)
+	}
+
 	// Retrieves connector objects in backend storage. This list includes the static connectors
 	// defined in the ConfigMap and dynamic connectors retrieved from the storage.
 	storageConnectors, err := c.Storage.ListConnectors()
@@ -312,6 +353,22 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 			return nil, fmt.Errorf("server: Failed to register Prometheus HTTP metrics: %v", err)
 		}
 
+		s.refreshTokenReuseCounter = prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "refresh_token_reuse_detected_total",
+			Help: "Count of refresh tokens revoked because an obsolete token was reused.",
+		})
+
+		err = c.PrometheusRegistry.Register(s.refreshTokenReuseCounter)
+		if err != nil {
+			return nil, fmt.Errorf("server: Failed to register Prometheus refresh token metrics: %v", err)
+		}
+
+		if s.leaderElector != nil {
+			if err := c.PrometheusRegistry.Register(s.leaderElector); err != nil {
+				return nil, fmt.Errorf("server: Failed to register Prometheus leader election metrics: %v", err)
+			}
+		}
+
 		instrumentHandlerCounter = func(handlerName string, handler http.Handler) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
 				m := httpsnoop.CaptureMetrics(handler, w, r)
@@ -366,6 +423,11 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 	// TODO(nabokihms): "/device/token" endpoint is deprecated, consider using /token endpoint instead
 	handleFunc("/device/token", s.handleDeviceTokenDeprecated)
 	handleFunc(deviceCallbackURI, s.handleDeviceCallback)
+	if s.cibaNotifier != nil {
+		handleFunc("/bc-authorize", s.handleBackchannelAuthorize)
+		handleFunc("/bc-authorize/approve", s.handleBackchannelApproval)
+		handleFunc(cibaCallbackURI, s.handleBackchannelCallback)
+	}
 	r.HandleFunc(path.Join(issuerURL.Path, "/callback"), func(w http.ResponseWriter, r *http.Request) {
 		// Strip the X-Remote-* headers to prevent security issues on
 		// misconfigured authproxy connector setups.
@@ -379,6 +441,7 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 	// For easier connector-specific web server configuration, e.g. for the
 	// "authproxy" connector.
 	handleFunc("/callback/{connector}", s.handleConnectorCallback)
+	handleFunc("/saml/{connector}/metadata", s.handleSAMLMetadata)
 	handleFunc("/approval", s.handleApproval)
 	handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !c.HealthChecker.IsHealthy() {
@@ -392,6 +455,9 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 	handlePrefix("/theme", theme)
 	s.mux = r
 
+	if s.leaderElector != nil {
+		s.leaderElector.start(ctx)
+	}
 	s.startKeyRotation(ctx, rotationStrategy, now)
 	s.startGarbageCollection(ctx, value(c.GCFrequency, 5*time.Minute), now)
 
@@ -518,11 +584,14 @@ func (s *Server) startGarbageCollection(ctx context.Context, frequency time.Dura
 			case <-ctx.Done():
 				return
 			case <-time.After(frequency):
-				if r, err := s.storage.GarbageCollect(now()); err != nil {
+				if !s.isLeader() {
+					continue
+				}
+				if r, err := s.storage.GarbageCollect(now(), s.gcOptions()); err != nil {
 					s.logger.Errorf("garbage collection failed: %v", err)
 				} else if !r.IsEmpty() {
-					s.logger.Infof("garbage collection run, delete auth requests=%d, auth codes=%d, device requests=%d, device tokens=%d",
-						r.AuthRequests, r.AuthCodes, r.DeviceRequests, r.DeviceTokens)
+					s.logger.Infof("garbage collection run, delete auth requests=%d, auth codes=%d, device requests=%d, device tokens=%d, ciba requests=%d, ciba tokens=%d, refresh tokens=%d, offline sessions=%d",
+						r.AuthRequests, r.AuthCodes, r.DeviceRequests, r.DeviceTokens, r.CIBARequests, r.CIBATokens, r.RefreshTokens, r.OfflineSessions)
 				}
 			}
 		}
@@ -602,12 +671,64 @@ func (s *Server) OpenConnector(conn storage.Connector) (Connector, error) {
 		Connector:       c,
 	}
 	s.mu.Lock()
+	old, replaced := s.connectors[conn.ID]
 	s.connectors[conn.ID] = connector
 	s.mu.Unlock()
 
+	if replaced {
+		s.closeConnector(conn.ID, old.Connector)
+	}
+	if collector, ok := c.(prometheus.Collector); ok && s.prometheusRegistry != nil {
+		if err := s.prometheusRegistry.Register(collector); err != nil {
+			s.logger.Errorf("failed to register Prometheus metrics of connector %s: %v", conn.ID, err)
+		}
+	}
+
 	return connector, nil
 }
 
+// closeConnector releases the resources of a connector which has been replaced.
+func (s *Server) closeConnector(id string, c connector.Connector) {
+	if collector, ok := c.(prometheus.Collector); ok && s.prometheusRegistry != nil {
+		s.prometheusRegistry.Unregister(collector)
+	}
+	if closer, ok := c.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.Errorf("failed to close connector %s: %v", id, err)
+		}
+	}
+}
+
+// CheckConnectorsHealth checks the connectors which can report whether their
+// upstream identity service is reachable. The details map the IDs of these
+// connectors to their results.
+//
+// Unhealthy connectors are only reported in the details and logged, the check
+// itself doesn't fail: an unreachable upstream doesn't affect the other
+// connectors, and restarting dex wouldn't fix it.
+func (s *Server) CheckConnectorsHealth(ctx context.Context) (interface{}, error) {
+	s.mu.Lock()
+	checkers := make(map[string]connector.HealthChecker)
+	for id, conn := range s.connectors {
+		if checker, ok := conn.Connector.(connector.HealthChecker); ok {
+			checkers[id] = checker
+		}
+	}
+	s.mu.Unlock()
+
+	details := make(map[string]interface{}, len(checkers))
+	for id, checker := range checkers {
+		result, err := checker.CheckHealth(ctx)
+		if err != nil {
+			s.logger.Warnf("connector %s is unhealthy: %v", id, err)
+			details[id] = map[string]interface{}{"error": err.Error(), "details": result}
+			continue
+		}
+		details[id] = result
+	}
+	return details, nil
+}
+
 // getConnector retrieves the connector object with the given id from the storage
 // and updates the connector list for server if necessary.
 func (s *Server) getConnector(id string) (Connector, error) {
@@ -122,7 +122,7 @@ func newTestServer(ctx context.Context, t *testing.T, updateConfig func(c *Confi
 
 	// Default rotation policy
 	if server.refreshTokenPolicy == nil {
-		server.refreshTokenPolicy, err = NewRefreshTokenPolicy(logger, false, "", "", "")
+		server.refreshTokenPolicy, err = NewRefreshTokenPolicy(logger, false, "", "", "", false)
 		if err != nil {
 			t.Fatalf("failed to prepare rotation policy: %v", err)
 		}
@@ -1774,3 +1774,34 @@ func TestServerSupportedGrants(t *testing.T) {
 		})
 	}
 }
+
+type unhealthyConnector struct{}
+
+func (unhealthyConnector) CheckHealth(ctx context.Context) (interface{}, error) {
+	return map[string]string{"host": "ldap.example.com:636"}, errors.New("connection refused")
+}
+
+func TestCheckConnectorsHealth(t *testing.T) {
+	s := &Server{
+		logger: logger,
+		connectors: map[string]Connector{
+			"ldap": {Connector: unhealthyConnector{}},
+			"mock": {Connector: mock.NewCallbackConnector(logger)},
+		},
+	}
+
+	// An unreachable upstream is reported without failing the health check.
+	details, err := s.CheckConnectorsHealth(context.Background())
+	if err != nil {
+		t.Fatalf("expected the check not to fail, got %v", err)
+	}
+	want := map[string]interface{}{
+		"ldap": map[string]interface{}{
+			"error":   "connection refused",
+			"details": map[string]string{"host": "ldap.example.com:636"},
+		},
+	}
+	if diff := pretty.Compare(want, details); diff != "" {
+		t.Errorf("unexpected details: %s", diff)
+	}
+}
@@ -22,6 +22,7 @@ const (
 	tmplError         = "error.html"
 	tmplDevice        = "device.html"
 	tmplDeviceSuccess = "device_success.html"
+	tmplCIBA          = "ciba.html"
 )
 
 var requiredTmpls = []string{
@@ -32,6 +33,7 @@ var requiredTmpls = []string{
 	tmplError,
 	tmplDevice,
 	tmplDeviceSuccess,
+	tmplCIBA,
 }
 
 type templates struct {
@@ -42,6 +44,7 @@ type templates struct {
 	errorTmpl         *template.Template
 	deviceTmpl        *template.Template
 	deviceSuccessTmpl *template.Template
+	cibaTmpl          *template.Template
 }
 
 type webConfig struct {
@@ -164,6 +167,7 @@ func loadTemplates(c webConfig, templatesDir string) (*templates, error) {
 		errorTmpl:         tmpls.Lookup(tmplError),
 		deviceTmpl:        tmpls.Lookup(tmplDevice),
 		deviceSuccessTmpl: tmpls.Lookup(tmplDeviceSuccess),
+		cibaTmpl:          tmpls.Lookup(tmplCIBA),
 	}, nil
 }
 
@@ -274,6 +278,26 @@ func (t *templates) deviceSuccess(r *http.Request, w http.ResponseWriter, client
 	return renderTemplate(w, t.deviceSuccessTmpl, data)
 }
 
+func (t *templates) ciba(r *http.Request, w http.ResponseWriter, approvalCode, clientName, bindingMessage string, scopes []string, denied bool) error {
+	accesses := []string{}
+	for _, scope := range scopes {
+		access, ok := scopeDescriptions[scope]
+		if ok {
+			accesses = append(accesses, access)
+		}
+	}
+	sort.Strings(accesses)
+	data := struct {
+		ApprovalCode   string
+		Client         string
+		BindingMessage string
+		Scopes         []string
+		Denied         bool
+		ReqPath        string
+	}{approvalCode, clientName, bindingMessage, accesses, denied, r.URL.Path}
+	return renderTemplate(w, t.cibaTmpl, data)
+}
+
 func (t *templates) login(r *http.Request, w http.ResponseWriter, connectors []connectorInfo) error {
 	sort.Sort(byName(connectors))
 	data := struct {
@@ -283,15 +307,29 @@ func (t *templates) login(r *http.Request, w http.ResponseWriter, connectors []c
 	return renderTemplate(w, t.loginTmpl, data)
 }
 
-func (t *templates) password(r *http.Request, w http.ResponseWriter, postURL, lastUsername, usernamePrompt string, lastWasInvalid bool, backLink string) error {
+// passwordNotice is shown on the password page when the password policy of
+// the connector restricts a login.
+type passwordNotice struct {
+	// Message explaining the restriction.
+	Message string
+	// Whether to show the form to change the password.
+	ChangePassword bool
+	// Where to continue to if the user logged in regardless.
+	ContinueURL string
+}
+
+func (t *templates) password(r *http.Request, w http.ResponseWriter, postURL, lastUsername, usernamePrompt string, lastWasInvalid bool, backLink string, notice passwordNotice) error {
 	data := struct {
 		PostURL        string
 		BackLink       string
 		Username       string
 		UsernamePrompt string
 		Invalid        bool
+		Notice         string
+		ChangePassword bool
+		ContinueURL    string
 		ReqPath        string
-	}{postURL, backLink, lastUsername, usernamePrompt, lastWasInvalid, r.URL.Path}
+	}{postURL, backLink, lastUsername, usernamePrompt, lastWasInvalid, notice.Message, notice.ChangePassword, notice.ContinueURL, r.URL.Path}
 	return renderTemplate(w, t.passwordTmpl, data)
 }
 
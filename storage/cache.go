@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// Tests for this code are in the "memory" package, since this package doesn't
+// define a concrete storage implementation.
+
+// Kinds of objects cached by WithCache and reported by a ChangeWatcher.
+const (
+	KindClient    = "client"
+	KindConnector = "connector"
+	KindPassword  = "password"
+)
+
+// Change identifies a client, connector or password which was created, updated
+// or deleted. Passwords are identified by their email. An empty ID means that
+// any object of the kind may have changed.
+type Change struct {
+	Kind string
+	ID   string
+}
+
+// ChangeWatcher is implemented by storages which can report changes of clients,
+// connectors and passwords, including the changes made by other dex instances.
+type ChangeWatcher interface {
+	// WatchChanges starts reporting changes to fn in the background until ctx
+	// is done. Changes missed while the watch is interrupted are reported as
+	// changes of all objects of their kind. It returns an error if the storage
+	// can't watch for changes.
+	WatchChanges(ctx context.Context, fn func(Change)) error
+}
+
+// CacheConfig holds how long clients, connectors and passwords are cached. Zero
+// disables the cache for a kind of objects.
+type CacheConfig struct {
+	Clients    time.Duration
+	Connectors time.Duration
+	Passwords  time.Duration
+}
+
+// cachedStorage serves reads of clients, connectors and passwords from memory.
+// Writes through the storage invalidate the objects they change.
+type cachedStorage struct {
+	Storage
+
+	clients    *objectCache
+	connectors *objectCache
+	passwords  *objectCache
+}
+
+// WithCache caches the clients, connectors and passwords read from the
+// underlying storage for the configured durations. Creating, updating or
+// deleting an object through the returned storage removes it from the cache.
+//
+// Without a watcher other dex instances sharing the storage may read outdated
+// objects until they expire. If watcher is not nil, the changes it reports are
+// removed from the cache as well, until ctx is done.
+func WithCache(ctx context.Context, s Storage, config CacheConfig, watcher ChangeWatcher, logger log.Logger) Storage {
+	c := &cachedStorage{
+		Storage:    s,
+		clients:    newObjectCache(config.Clients),
+		connectors: newObjectCache(config.Connectors),
+		passwords:  newObjectCache(config.Passwords),
+	}
+	if watcher != nil {
+		if err := watcher.WatchChanges(ctx, c.changed); err != nil {
+			logger.Errorf("cache: not watching storage for changes, objects are only invalidated by this instance: %v", err)
+		}
+	}
+	return c
+}
+
+func (c *cachedStorage) changed(change Change) {
+	switch change.Kind {
+	case KindClient:
+		c.clients.invalidate(change.ID)
+	case KindConnector:
+		c.connectors.invalidate(change.ID)
+	case KindPassword:
+		c.passwords.invalidate(strings.ToLower(change.ID))
+	}
+}
+
+func (c *cachedStorage) GetClient(id string) (Client, error) {
+	if v, ok := c.clients.get(id); ok {
+		return v.(Client), nil
+	}
+	generation := c.clients.begin()
+	client, err := c.Storage.GetClient(id)
+	if err == nil {
+		c.clients.put(generation, id, client)
+	}
+	return client, err
+}
+
+func (c *cachedStorage) ListClients() ([]Client, error) {
+	if v, ok := c.clients.getList(); ok {
+		return append([]Client(nil), v.([]Client)...), nil
+	}
+	generation := c.clients.begin()
+	clients, err := c.Storage.ListClients()
+	if err == nil {
+		c.clients.putList(generation, append([]Client(nil), clients...))
+	}
+	return clients, err
+}
+
+func (c *cachedStorage) CreateClient(client Client) error {
+	defer c.clients.invalidate(client.ID)
+	return c.Storage.CreateClient(client)
+}
+
+func (c *cachedStorage) UpdateClient(id string, updater func(old Client) (Client, error)) error {
+	defer c.clients.invalidate(id)
+	return c.Storage.UpdateClient(id, updater)
+}
+
+func (c *cachedStorage) DeleteClient(id string) error {
+	defer c.clients.invalidate(id)
+	return c.Storage.DeleteClient(id)
+}
+
+func (c *cachedStorage) GetConnector(id string) (Connector, error) {
+	if v, ok := c.connectors.get(id); ok {
+		return v.(Connector), nil
+	}
+	generation := c.connectors.begin()
+	connector, err := c.Storage.GetConnector(id)
+	if err == nil {
+		c.connectors.put(generation, id, connector)
+	}
+	return connector, err
+}
+
+func (c *cachedStorage) ListConnectors() ([]Connector, error) {
+	if v, ok := c.connectors.getList(); ok {
+		return append([]Connector(nil), v.([]Connector)...), nil
+	}
+	generation := c.connectors.begin()
+	connectors, err := c.Storage.ListConnectors()
+	if err == nil {
+		c.connectors.putList(generation, append([]Connector(nil), connectors...))
+	}
+	return connectors, err
+}
+
+func (c *cachedStorage) CreateConnector(connector Connector) error {
+	defer c.connectors.invalidate(connector.ID)
+	return c.Storage.CreateConnector(connector)
+}
+
+func (c *cachedStorage) UpdateConnector(id string, updater func(old Connector) (Connector, error)) error {
+	defer c.connectors.invalidate(id)
+	return c.Storage.UpdateConnector(id, updater)
+}
+
+func (c *cachedStorage) DeleteConnector(id string) error {
+	defer c.connectors.invalidate(id)
+	return c.Storage.DeleteConnector(id)
+}
+
+func (c *cachedStorage) GetPassword(email string) (Password, error) {
+	key := strings.ToLower(email)
+	if v, ok := c.passwords.get(key); ok {
+		return v.(Password), nil
+	}
+	generation := c.passwords.begin()
+	p, err := c.Storage.GetPassword(email)
+	if err == nil {
+		c.passwords.put(generation, key, p)
+	}
+	return p, err
+}
+
+func (c *cachedStorage) ListPasswords() ([]Password, error) {
+	if v, ok := c.passwords.getList(); ok {
+		return append([]Password(nil), v.([]Password)...), nil
+	}
+	generation := c.passwords.begin()
+	passwords, err := c.Storage.ListPasswords()
+	if err == nil {
+		c.passwords.putList(generation, append([]Password(nil), passwords...))
+	}
+	return passwords, err
+}
+
+func (c *cachedStorage) CreatePassword(p Password) error {
+	defer c.passwords.invalidate(strings.ToLower(p.Email))
+	return c.Storage.CreatePassword(p)
+}
+
+func (c *cachedStorage) UpdatePassword(email string, updater func(p Password) (Password, error)) error {
+	defer c.passwords.invalidate(strings.ToLower(email))
+	return c.Storage.UpdatePassword(email, updater)
+}
+
+func (c *cachedStorage) DeletePassword(email string) error {
+	defer c.passwords.invalidate(strings.ToLower(email))
+	return c.Storage.DeletePassword(email)
+}
+
+// objectCache holds the objects of one kind by ID, and the list of all of them.
+// A nil objectCache caches nothing.
+//
+// Reads which miss the cache call begin before reading from the storage, and
+// pass the returned generation to put. Every invalidation starts a new
+// generation, so a read which raced with a write can't cache the old object.
+type objectCache struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	generation uint64
+	objects    map[string]cacheEntry
+	list       *cacheEntry
+}
+
+type cacheEntry struct {
+	value  interface{}
+	expiry time.Time
+}
+
+func newObjectCache(ttl time.Duration) *objectCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &objectCache{ttl: ttl, objects: make(map[string]cacheEntry)}
+}
+
+func (c *objectCache) get(id string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.objects[id]
+	if !ok || time.Now().After(e.expiry) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *objectCache) getList() (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.list == nil || time.Now().After(c.list.expiry) {
+		return nil, false
+	}
+	return c.list.value, true
+}
+
+func (c *objectCache) begin() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *objectCache) put(generation uint64, id string, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.objects[id] = cacheEntry{value: value, expiry: time.Now().Add(c.ttl)}
+	}
+}
+
+func (c *objectCache) putList(generation uint64, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.list = &cacheEntry{value: value, expiry: time.Now().Add(c.ttl)}
+	}
+}
+
+// invalidate removes an object and the list from the cache. An empty ID
+// removes all objects.
+func (c *objectCache) invalidate(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.list = nil
+	if id == "" {
+		c.objects = make(map[string]cacheEntry)
+		return
+	}
+	delete(c.objects, id)
+}
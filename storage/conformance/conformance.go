@@ -2,6 +2,7 @@
 package conformance
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -46,9 +47,15 @@ func RunTests(t *testing.T, newStorage func() storage.Storage) {
 		{"OfflineSessionCRUD", testOfflineSessionCRUD},
 		{"ConnectorCRUD", testConnectorCRUD},
 		{"GarbageCollection", testGC},
+		{"GarbageCollectionRace", testGCRace},
 		{"TimezoneSupport", testTimezones},
 		{"DeviceRequestCRUD", testDeviceRequestCRUD},
 		{"DeviceTokenCRUD", testDeviceTokenCRUD},
+		{"CIBARequestCRUD", testCIBARequestCRUD},
+		{"CIBATokenCRUD", testCIBATokenCRUD},
+		{"ListPagination", testListPagination},
+		{"ListForUser", testListForUser},
+		{"Leases", testLeases},
 	})
 }
 
@@ -260,6 +267,15 @@ func testClientCRUD(t *testing.T, s storage.Storage) {
 		RedirectURIs: []string{"foo://bar.com/", "https://auth.example.com"},
 		Name:         "dex client",
 		LogoURL:      "https://goo.gl/JIyzIC",
+
+		BackchannelNotificationEndpoint: "https://auth.example.com/ciba",
+		TokenPolicy: storage.ClientTokenPolicy{
+			IDTokensValidFor:             "15m",
+			AuthCodesValidFor:            "5m",
+			RefreshTokenAbsoluteLifetime: "12h",
+		},
+		AllowedConnectors: []string{"github"},
+		AllowedScopes:     []string{"openid", "email"},
 	}
 	err := s.DeleteClient(id1)
 	mustBeErrNotFound(t, "client", err)
@@ -309,6 +325,43 @@ func testClientCRUD(t *testing.T, s storage.Storage) {
 	c1.Secret = newSecret
 	getAndCompare(id1, c1)
 
+	disableRotation := true
+	err = s.UpdateClient(id1, func(old storage.Client) (storage.Client, error) {
+		old.TokenPolicy.DisableRefreshTokenRotation = &disableRotation
+		old.TokenPolicy.RefreshTokenValidIfNotUsedFor = "8h"
+		return old, nil
+	})
+	if err != nil {
+		t.Errorf("update client: %v", err)
+	}
+	c1.TokenPolicy.DisableRefreshTokenRotation = &disableRotation
+	c1.TokenPolicy.RefreshTokenValidIfNotUsedFor = "8h"
+	getAndCompare(id1, c1)
+
+	err = s.UpdateClient(id1, func(old storage.Client) (storage.Client, error) {
+		old.TokenPolicy.DisableRefreshTokenRotation = nil
+		return old, nil
+	})
+	if err != nil {
+		t.Errorf("update client: %v", err)
+	}
+	c1.TokenPolicy.DisableRefreshTokenRotation = nil
+	getAndCompare(id1, c1)
+
+	err = s.UpdateClient(id1, func(old storage.Client) (storage.Client, error) {
+		old.AllowedConnectors = nil
+		old.AllowedGrantTypes = []string{"urn:ietf:params:oauth:grant-type:device_code"}
+		old.AllowedResponseTypes = []string{"code"}
+		return old, nil
+	})
+	if err != nil {
+		t.Errorf("update client: %v", err)
+	}
+	c1.AllowedConnectors = nil
+	c1.AllowedGrantTypes = []string{"urn:ietf:params:oauth:grant-type:device_code"}
+	c1.AllowedResponseTypes = []string{"code"}
+	getAndCompare(id1, c1)
+
 	if err := s.DeleteClient(id1); err != nil {
 		t.Fatalf("delete client: %v", err)
 	}
@@ -591,6 +644,20 @@ func testOfflineSessionCRUD(t *testing.T, s storage.Storage) {
 
 	getAndCompare(userID1, "Conn1", session1)
 
+	sessions, err := s.ListOfflineSessions()
+	if err != nil {
+		t.Fatalf("list offline sessions: %v", err)
+	}
+	listed := make(map[string]storage.OfflineSessions)
+	for _, o := range sessions {
+		listed[o.UserID] = o
+	}
+	for _, want := range []storage.OfflineSessions{session1, session2} {
+		if diff := pretty.Compare(want, listed[want.UserID]); diff != "" {
+			t.Errorf("offline session listed from storage did not match: %s", diff)
+		}
+	}
+
 	if err := s.DeleteOfflineSessions(session1.UserID, session1.ConnID); err != nil {
 		t.Fatalf("failed to delete offline session: %v", err)
 	}
@@ -774,7 +841,7 @@ func testGC(t *testing.T, s storage.Storage) {
 	}
 
 	for _, tz := range []*time.Location{time.UTC, est, pst} {
-		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
 		if err != nil {
 			t.Errorf("garbage collection failed: %v", err)
 		} else if result.AuthCodes != 0 || result.AuthRequests != 0 {
@@ -785,7 +852,7 @@ func testGC(t *testing.T, s storage.Storage) {
 		}
 	}
 
-	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
 		t.Errorf("garbage collection failed: %v", err)
 	} else if r.AuthCodes != 1 {
 		t.Errorf("expected to garbage collect 1 objects, got %d", r.AuthCodes)
@@ -824,7 +891,7 @@ func testGC(t *testing.T, s storage.Storage) {
 	}
 
 	for _, tz := range []*time.Location{time.UTC, est, pst} {
-		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
 		if err != nil {
 			t.Errorf("garbage collection failed: %v", err)
 		} else if result.AuthCodes != 0 || result.AuthRequests != 0 {
@@ -835,7 +902,7 @@ func testGC(t *testing.T, s storage.Storage) {
 		}
 	}
 
-	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
 		t.Errorf("garbage collection failed: %v", err)
 	} else if r.AuthRequests != 1 {
 		t.Errorf("expected to garbage collect 1 objects, got %d", r.AuthRequests)
@@ -861,7 +928,7 @@ func testGC(t *testing.T, s storage.Storage) {
 	}
 
 	for _, tz := range []*time.Location{time.UTC, est, pst} {
-		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
 		if err != nil {
 			t.Errorf("garbage collection failed: %v", err)
 		} else if result.DeviceRequests != 0 {
@@ -871,7 +938,7 @@ func testGC(t *testing.T, s storage.Storage) {
 			t.Errorf("expected to be able to get auth request after GC: %v", err)
 		}
 	}
-	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
 		t.Errorf("garbage collection failed: %v", err)
 	} else if r.DeviceRequests != 1 {
 		t.Errorf("expected to garbage collect 1 device request, got %d", r.DeviceRequests)
@@ -897,7 +964,7 @@ func testGC(t *testing.T, s storage.Storage) {
 	}
 
 	for _, tz := range []*time.Location{time.UTC, est, pst} {
-		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
 		if err != nil {
 			t.Errorf("garbage collection failed: %v", err)
 		} else if result.DeviceTokens != 0 {
@@ -907,7 +974,7 @@ func testGC(t *testing.T, s storage.Storage) {
 			t.Errorf("expected to be able to get device token after GC: %v", err)
 		}
 	}
-	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
 		t.Errorf("garbage collection failed: %v", err)
 	} else if r.DeviceTokens != 1 {
 		t.Errorf("expected to garbage collect 1 device token, got %d", r.DeviceTokens)
@@ -918,6 +985,251 @@ func testGC(t *testing.T, s storage.Storage) {
 	} else if err != storage.ErrNotFound {
 		t.Errorf("expected storage.ErrNotFound, got %v", err)
 	}
+	cr := storage.CIBARequest{
+		ApprovalCode: storage.NewDeviceCode(),
+		AuthReqID:    storage.NewDeviceCode(),
+		ClientID:     "client1",
+		Scopes:       []string{"openid", "email"},
+		LoginHint:    "jane.doe@example.com",
+		Expiry:       expiry,
+	}
+
+	if err := s.CreateCIBARequest(cr); err != nil {
+		t.Fatalf("failed creating ciba request: %v", err)
+	}
+
+	for _, tz := range []*time.Location{time.UTC, est, pst} {
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
+		if err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if result.CIBARequests != 0 {
+			t.Errorf("expected no ciba request garbage collection results, got %#v", result)
+		}
+		if _, err := s.GetCIBARequest(cr.ApprovalCode); err != nil {
+			t.Errorf("expected to be able to get ciba request after GC: %v", err)
+		}
+	}
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
+		t.Errorf("garbage collection failed: %v", err)
+	} else if r.CIBARequests != 1 {
+		t.Errorf("expected to garbage collect 1 ciba request, got %d", r.CIBARequests)
+	}
+
+	if _, err := s.GetCIBARequest(cr.ApprovalCode); err == nil {
+		t.Errorf("expected ciba request to be GC'd")
+	} else if err != storage.ErrNotFound {
+		t.Errorf("expected storage.ErrNotFound, got %v", err)
+	}
+
+	ct := storage.CIBAToken{
+		AuthReqID:       storage.NewDeviceCode(),
+		ClientID:        "client1",
+		Status:          "pending",
+		Expiry:          expiry,
+		LastRequestTime: time.Now(),
+	}
+
+	if err := s.CreateCIBAToken(ct); err != nil {
+		t.Fatalf("failed creating ciba token: %v", err)
+	}
+
+	for _, tz := range []*time.Location{time.UTC, est, pst} {
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz), storage.GCOptions{})
+		if err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if result.CIBATokens != 0 {
+			t.Errorf("expected no ciba token garbage collection results, got %#v", result)
+		}
+		if _, err := s.GetCIBAToken(ct.AuthReqID); err != nil {
+			t.Errorf("expected to be able to get ciba token after GC: %v", err)
+		}
+	}
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour), storage.GCOptions{}); err != nil {
+		t.Errorf("garbage collection failed: %v", err)
+	} else if r.CIBATokens != 1 {
+		t.Errorf("expected to garbage collect 1 ciba token, got %d", r.CIBATokens)
+	}
+
+	if _, err := s.GetCIBAToken(ct.AuthReqID); err == nil {
+		t.Errorf("expected ciba token to be GC'd")
+	} else if err != storage.ErrNotFound {
+		t.Errorf("expected storage.ErrNotFound, got %v", err)
+	}
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	newRefresh := func(createdAt, lastUsed time.Time) storage.RefreshToken {
+		r := storage.RefreshToken{
+			ID:          storage.NewID(),
+			Token:       "bar",
+			Nonce:       "foo",
+			ClientID:    "client_id",
+			ConnectorID: "ldap",
+			Scopes:      []string{"openid", "offline_access"},
+			CreatedAt:   createdAt,
+			LastUsed:    lastUsed,
+			Claims: storage.Claims{
+				UserID:        storage.NewID(),
+				Username:      "jane",
+				Email:         "jane.doe@example.com",
+				EmailVerified: true,
+			},
+		}
+		if err := s.CreateRefresh(r); err != nil {
+			t.Fatalf("failed creating refresh token: %v", err)
+		}
+		o := storage.OfflineSessions{
+			UserID:  r.Claims.UserID,
+			ConnID:  r.ConnectorID,
+			Refresh: map[string]*storage.RefreshTokenRef{r.ClientID: {ID: r.ID, ClientID: r.ClientID, CreatedAt: createdAt, LastUsed: lastUsed}},
+		}
+		if err := s.CreateOfflineSessions(o); err != nil {
+			t.Fatalf("failed creating offline session: %v", err)
+		}
+		return r
+	}
+
+	// Old, but recently used.
+	r1 := newRefresh(now.Add(-2*time.Hour), now.Add(-time.Minute))
+	// Recent, but idle.
+	r2 := newRefresh(now.Add(-10*time.Minute), now.Add(-10*time.Minute))
+
+	for _, tz := range []*time.Location{time.UTC, est, pst} {
+		result, err := s.GarbageCollect(now.In(tz), storage.GCOptions{})
+		if err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if result.RefreshTokens != 0 || result.OfflineSessions != 0 {
+			t.Errorf("expected no refresh token garbage collection results, got %#v", result)
+		}
+	}
+
+	gcRefresh := func(opts storage.GCOptions, removed storage.RefreshToken, kept ...storage.RefreshToken) {
+		t.Helper()
+		if r, err := s.GarbageCollect(now.In(est), opts); err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if r.RefreshTokens != 1 || r.OfflineSessions != 1 {
+			t.Errorf("expected to garbage collect 1 refresh token and 1 offline session, got %#v", r)
+		}
+
+		if _, err := s.GetRefresh(removed.ID); err != storage.ErrNotFound {
+			t.Errorf("expected refresh token to be GC'd, got %v", err)
+		}
+		if _, err := s.GetOfflineSessions(removed.Claims.UserID, removed.ConnectorID); err != storage.ErrNotFound {
+			t.Errorf("expected orphaned offline session to be GC'd, got %v", err)
+		}
+		for _, r := range kept {
+			if _, err := s.GetRefresh(r.ID); err != nil {
+				t.Errorf("expected to be able to get refresh token after GC: %v", err)
+			}
+			if _, err := s.GetOfflineSessions(r.Claims.UserID, r.ConnectorID); err != nil {
+				t.Errorf("expected to be able to get offline session after GC: %v", err)
+			}
+		}
+	}
+
+	gcRefresh(storage.GCOptions{RefreshTokenAbsoluteLifetime: time.Hour}, r1, r2)
+	gcRefresh(storage.GCOptions{RefreshTokenValidIfNotUsedFor: 5 * time.Minute}, r2)
+
+	// Sessions without refresh tokens are written at login, ahead of the code
+	// exchange, and must survive until the first refresh token is added.
+	pending := storage.OfflineSessions{
+		UserID:  storage.NewID(),
+		ConnID:  "ldap",
+		Refresh: make(map[string]*storage.RefreshTokenRef),
+	}
+	if err := s.CreateOfflineSessions(pending); err != nil {
+		t.Fatalf("failed creating offline session: %v", err)
+	}
+	if r, err := s.GarbageCollect(now.In(est), storage.GCOptions{}); err != nil {
+		t.Errorf("garbage collection failed: %v", err)
+	} else if r.RefreshTokens != 0 || r.OfflineSessions != 0 {
+		t.Errorf("expected no garbage collection results, got %#v", r)
+	}
+	if _, err := s.GetOfflineSessions(pending.UserID, pending.ConnID); err != nil {
+		t.Errorf("expected to be able to get offline session without refresh tokens after GC: %v", err)
+	}
+}
+
+// testGCRace replaces the refresh token of an offline session, the way the
+// server does on code exchange, while garbage collection runs. Tokens are
+// created between the garbage collection listing the refresh tokens and
+// deleting the orphaned sessions, which must not delete the live session.
+func testGCRace(t *testing.T, s storage.Storage) {
+	userID := storage.NewID()
+	newRefresh := func() (storage.RefreshToken, error) {
+		now := time.Now().UTC().Round(time.Millisecond)
+		r := storage.RefreshToken{
+			ID:          storage.NewID(),
+			Token:       storage.NewID(),
+			Nonce:       "foo",
+			ClientID:    "client_id",
+			ConnectorID: "ldap",
+			Scopes:      []string{"openid", "offline_access"},
+			CreatedAt:   now,
+			LastUsed:    now,
+			Claims:      storage.Claims{UserID: userID, Username: "jane", Email: "jane.doe@example.com"},
+		}
+		return r, s.CreateRefresh(r)
+	}
+	tokenRef := func(r storage.RefreshToken) *storage.RefreshTokenRef {
+		return &storage.RefreshTokenRef{ID: r.ID, ClientID: r.ClientID, CreatedAt: r.CreatedAt, LastUsed: r.LastUsed}
+	}
+
+	r, err := newRefresh()
+	if err != nil {
+		t.Fatalf("failed creating refresh token: %v", err)
+	}
+	session := storage.OfflineSessions{
+		UserID:  userID,
+		ConnID:  "ldap",
+		Refresh: map[string]*storage.RefreshTokenRef{r.ClientID: tokenRef(r)},
+	}
+	if err := s.CreateOfflineSessions(session); err != nil {
+		t.Fatalf("failed creating offline session: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			next, err := newRefresh()
+			if err != nil {
+				t.Errorf("failed creating refresh token: %v", err)
+				return
+			}
+			err = s.UpdateOfflineSessions(userID, "ldap", func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+				old.Refresh[next.ClientID] = tokenRef(next)
+				return old, nil
+			})
+			if err != nil {
+				t.Errorf("failed updating offline session: %v", err)
+				return
+			}
+			if err := s.DeleteRefresh(r.ID); err != nil {
+				t.Errorf("failed deleting refresh token: %v", err)
+				return
+			}
+			r = next
+		}
+	}()
+
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		if _, err := s.GarbageCollect(time.Now(), storage.GCOptions{}); err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		}
+	}
+
+	got, err := s.GetOfflineSessions(userID, "ldap")
+	if err != nil {
+		t.Fatalf("expected live offline session to survive GC: %v", err)
+	}
+	if ref := got.Refresh[r.ClientID]; ref == nil || ref.ID != r.ID {
+		t.Errorf("expected offline session to reference refresh token %q, got %+v", r.ID, ref)
+	}
 }
 
 // testTimezones tests that backends either fully support timezones or
@@ -1030,3 +1342,423 @@ func testDeviceTokenCRUD(t *testing.T, s storage.Storage) {
 		t.Fatalf("update failed, wanted token %v got %v", "token data", got.Token)
 	}
 }
+
+func testCIBARequestCRUD(t *testing.T, s storage.Storage) {
+	r1 := storage.CIBARequest{
+		ApprovalCode:   storage.NewDeviceCode(),
+		AuthReqID:      storage.NewDeviceCode(),
+		ClientID:       "client1",
+		Scopes:         []string{"openid", "email"},
+		LoginHint:      "jane.doe@example.com",
+		BindingMessage: "W4SCT",
+		Expiry:         neverExpire,
+	}
+
+	if err := s.CreateCIBARequest(r1); err != nil {
+		t.Fatalf("failed creating ciba request: %v", err)
+	}
+
+	// Attempt to create same CIBARequest twice.
+	err := s.CreateCIBARequest(r1)
+	mustBeErrAlreadyExists(t, "ciba request", err)
+
+	got, err := s.GetCIBARequest(r1.ApprovalCode)
+	if err != nil {
+		t.Fatalf("failed to get ciba request: %v", err)
+	}
+	got.Expiry = got.Expiry.In(r1.Expiry.Location())
+	if diff := pretty.Compare(r1, got); diff != "" {
+		t.Errorf("ciba request retrieved from storage did not match: %s", diff)
+	}
+
+	// No manual deletes for ciba requests, will be handled by garbage collection routines
+	// see testGC
+}
+
+func testCIBATokenCRUD(t *testing.T, s storage.Storage) {
+	t1 := storage.CIBAToken{
+		AuthReqID:         storage.NewDeviceCode(),
+		ClientID:          "client1",
+		Status:            "pending",
+		NotificationToken: storage.NewID(),
+		Expiry:            neverExpire,
+		LastRequestTime:   time.Now(),
+	}
+
+	if err := s.CreateCIBAToken(t1); err != nil {
+		t.Fatalf("failed creating ciba token: %v", err)
+	}
+
+	// Attempt to create same CIBA Token twice.
+	err := s.CreateCIBAToken(t1)
+	mustBeErrAlreadyExists(t, "ciba token", err)
+
+	// Update the ciba token, simulate an approval
+	if err := s.UpdateCIBAToken(t1.AuthReqID, func(old storage.CIBAToken) (storage.CIBAToken, error) {
+		old.Token = "token data"
+		old.Status = "complete"
+		old.PollIntervalSeconds = 5
+		return old, nil
+	}); err != nil {
+		t.Fatalf("failed to update ciba token: %v", err)
+	}
+
+	got, err := s.GetCIBAToken(t1.AuthReqID)
+	if err != nil {
+		t.Fatalf("failed to get ciba token: %v", err)
+	}
+
+	if got.Status != "complete" {
+		t.Fatalf("update failed, wanted token status=%v got %v", "complete", got.Status)
+	}
+	if got.Token != "token data" {
+		t.Fatalf("update failed, wanted token %v got %v", "token data", got.Token)
+	}
+	if got.PollIntervalSeconds != 5 {
+		t.Fatalf("update failed, wanted poll interval %v got %v", 5, got.PollIntervalSeconds)
+	}
+	if got.ClientID != t1.ClientID || got.NotificationToken != t1.NotificationToken {
+		t.Fatalf("ciba token retrieved from storage did not match: got %#v", got)
+	}
+}
+
+func testListPagination(t *testing.T, s storage.Storage) {
+	const n = 5
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	var (
+		clients    []string
+		emails     []string
+		connectors []string
+		tokens     = make(map[string]storage.RefreshToken)
+	)
+	for i := 0; i < n; i++ {
+		c := storage.Client{
+			ID:           storage.NewID(),
+			Secret:       "secret",
+			RedirectURIs: []string{"http://localhost/callback"},
+			Name:         "client",
+			LogoURL:      "https://example.com/logo.png",
+		}
+		if err := s.CreateClient(c); err != nil {
+			t.Fatalf("create client: %v", err)
+		}
+		clients = append(clients, c.ID)
+
+		p := storage.Password{
+			Email:    fmt.Sprintf("user%d@example.com", i),
+			Hash:     []byte("hash"),
+			Username: "user",
+			UserID:   fmt.Sprintf("user%d", i%2),
+		}
+		if err := s.CreatePassword(p); err != nil {
+			t.Fatalf("create password: %v", err)
+		}
+		emails = append(emails, p.Email)
+
+		conn := storage.Connector{
+			ID:              storage.NewID(),
+			Type:            "mock",
+			Name:            "mock",
+			ResourceVersion: "1",
+			Config:          []byte(`{}`),
+		}
+		if err := s.CreateConnector(conn); err != nil {
+			t.Fatalf("create connector: %v", err)
+		}
+		connectors = append(connectors, conn.ID)
+
+		r := storage.RefreshToken{
+			ID:          storage.NewID(),
+			Token:       "token",
+			Nonce:       "nonce",
+			ClientID:    fmt.Sprintf("client%d", i%2),
+			ConnectorID: fmt.Sprintf("conn%d", i%3),
+			Scopes:      []string{"openid"},
+			CreatedAt:   now.Add(time.Duration(i) * time.Minute),
+			LastUsed:    now.Add(time.Duration(i) * time.Hour),
+			Claims: storage.Claims{
+				UserID:   fmt.Sprintf("user%d", i%2),
+				Username: "user",
+				Email:    "user@example.com",
+			},
+		}
+		if err := s.CreateRefresh(r); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+		tokens[r.ID] = r
+	}
+
+	// listAll follows the page tokens and returns the sorted IDs of all pages.
+	listAll := func(kind string, filter storage.ListFilter, list func(opts storage.ListOptions) ([]string, string, error)) []string {
+		const pageSize = 2
+		var ids []string
+		token := ""
+		for i := 0; ; i++ {
+			if i > 2*n {
+				t.Fatalf("list %s: too many pages", kind)
+			}
+			page, next, err := list(storage.ListOptions{PageSize: pageSize, PageToken: token, Filter: filter})
+			if err != nil {
+				t.Fatalf("list %s: %v", kind, err)
+			}
+			if len(page) > pageSize {
+				t.Errorf("list %s: page of %d exceeds page size %d", kind, len(page), pageSize)
+			}
+			ids = append(ids, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	sorted := func(ids []string) []string {
+		ids = append([]string(nil), ids...)
+		sort.Strings(ids)
+		return ids
+	}
+
+	listClients := func(opts storage.ListOptions) (ids []string, next string, err error) {
+		page, next, err := s.ListClientsPage(opts)
+		for _, c := range page {
+			ids = append(ids, c.ID)
+		}
+		return ids, next, err
+	}
+	if diff := pretty.Compare(sorted(clients), listAll("clients", storage.ListFilter{}, listClients)); diff != "" {
+		t.Errorf("paginated clients did not match: %s", diff)
+	}
+
+	listPasswords := func(opts storage.ListOptions) (ids []string, next string, err error) {
+		page, next, err := s.ListPasswordsPage(opts)
+		for _, p := range page {
+			ids = append(ids, p.Email)
+		}
+		return ids, next, err
+	}
+	if diff := pretty.Compare(sorted(emails), listAll("passwords", storage.ListFilter{}, listPasswords)); diff != "" {
+		t.Errorf("paginated passwords did not match: %s", diff)
+	}
+	want := []string{emails[1], emails[3]}
+	if diff := pretty.Compare(want, listAll("passwords", storage.ListFilter{UserID: "user1"}, listPasswords)); diff != "" {
+		t.Errorf("passwords filtered by user ID did not match: %s", diff)
+	}
+
+	listConnectors := func(opts storage.ListOptions) (ids []string, next string, err error) {
+		page, next, err := s.ListConnectorsPage(opts)
+		for _, c := range page {
+			ids = append(ids, c.ID)
+		}
+		return ids, next, err
+	}
+	if diff := pretty.Compare(sorted(connectors), listAll("connectors", storage.ListFilter{}, listConnectors)); diff != "" {
+		t.Errorf("paginated connectors did not match: %s", diff)
+	}
+
+	listRefresh := func(opts storage.ListOptions) (ids []string, next string, err error) {
+		page, next, err := s.ListRefreshTokensPage(opts)
+		for _, r := range page {
+			ids = append(ids, r.ID)
+		}
+		return ids, next, err
+	}
+	filters := []storage.ListFilter{
+		{},
+		{ClientID: "client1"},
+		{UserID: "user0"},
+		{ConnectorID: "conn2"},
+		{UserID: "user0", ConnectorID: "conn0"},
+		{CreatedAfter: now.Add(2 * time.Minute)},
+		{CreatedBefore: now.Add(2 * time.Minute)},
+		{LastUsedAfter: now.Add(time.Hour), LastUsedBefore: now.Add(4 * time.Hour)},
+		{ClientID: "unknown"},
+	}
+	for _, filter := range filters {
+		var want []string
+		for id, r := range tokens {
+			if filter.MatchRefreshToken(r) {
+				want = append(want, id)
+			}
+		}
+		if diff := pretty.Compare(sorted(want), listAll("refresh tokens", filter, listRefresh)); diff != "" {
+			t.Errorf("refresh tokens filtered by %+v did not match: %s", filter, diff)
+		}
+	}
+
+	// A zero page size lists all objects at once.
+	all, next, err := s.ListClientsPage(storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("list clients: %v", err)
+	}
+	if len(all) != n || next != "" {
+		t.Errorf("expected all %d clients without a page token, got %d clients and token %q", n, len(all), next)
+	}
+
+	if _, _, err := s.ListRefreshTokensPage(storage.ListOptions{PageSize: 2, PageToken: "not a page token"}); err == nil {
+		t.Errorf("expected an error listing with an invalid page token")
+	}
+}
+
+func testListForUser(t *testing.T, s storage.Storage) {
+	now := time.Now().UTC().Round(time.Millisecond)
+	newToken := func(userID, connID string) storage.RefreshToken {
+		return storage.RefreshToken{
+			ID:          storage.NewID(),
+			Token:       "token",
+			Nonce:       "nonce",
+			ClientID:    storage.NewID(),
+			ConnectorID: connID,
+			Scopes:      []string{"openid"},
+			CreatedAt:   now,
+			LastUsed:    now,
+			Claims: storage.Claims{
+				UserID:   userID,
+				Username: "user",
+				Email:    "user@example.com",
+			},
+		}
+	}
+
+	// The last user and connector share a prefix with the first ones,
+	// storages indexing concatenated IDs must tell them apart.
+	users := [][2]string{
+		{"alice", "conn1"},
+		{"alice", "conn1"},
+		{"alice", "conn2"},
+		{"bob", "conn1"},
+		{"alice|conn1", "x"},
+	}
+	var tokens []storage.RefreshToken
+	for _, u := range users {
+		r := newToken(u[0], u[1])
+		if err := s.CreateRefresh(r); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+		tokens = append(tokens, r)
+
+		o := storage.OfflineSessions{
+			UserID:  u[0],
+			ConnID:  u[1],
+			Refresh: map[string]*storage.RefreshTokenRef{r.ClientID: {ID: r.ID, ClientID: r.ClientID}},
+		}
+		if err := s.CreateOfflineSessions(o); err != nil && err != storage.ErrAlreadyExists {
+			t.Fatalf("create offline session: %v", err)
+		}
+	}
+
+	checkTokens := func(userID, connID string, want ...storage.RefreshToken) {
+		t.Helper()
+		got, err := s.ListRefreshTokensForUser(userID, connID)
+		if err != nil {
+			t.Fatalf("list refresh tokens for %s/%s: %v", userID, connID, err)
+		}
+		var gotIDs, wantIDs []string
+		for _, r := range got {
+			gotIDs = append(gotIDs, r.ID)
+		}
+		for _, r := range want {
+			wantIDs = append(wantIDs, r.ID)
+		}
+		sort.Strings(gotIDs)
+		sort.Strings(wantIDs)
+		if !reflect.DeepEqual(gotIDs, wantIDs) {
+			t.Errorf("list refresh tokens for %s/%s: want %v, got %v", userID, connID, wantIDs, gotIDs)
+		}
+	}
+	checkSessions := func(userID string, wantConns ...string) {
+		t.Helper()
+		got, err := s.ListOfflineSessionsForUser(userID)
+		if err != nil {
+			t.Fatalf("list offline sessions for %s: %v", userID, err)
+		}
+		var gotConns []string
+		for _, o := range got {
+			if o.UserID != userID {
+				t.Errorf("list offline sessions for %s: got session of %s", userID, o.UserID)
+			}
+			gotConns = append(gotConns, o.ConnID)
+		}
+		sort.Strings(gotConns)
+		sort.Strings(wantConns)
+		if !reflect.DeepEqual(gotConns, wantConns) {
+			t.Errorf("list offline sessions for %s: want connectors %v, got %v", userID, wantConns, gotConns)
+		}
+	}
+
+	checkTokens("alice", "conn1", tokens[0], tokens[1])
+	checkTokens("alice", "conn2", tokens[2])
+	checkTokens("bob", "conn1", tokens[3])
+	checkTokens("alice", "conn1|x")
+	checkTokens("carol", "conn1")
+	checkSessions("alice", "conn1", "conn2")
+	checkSessions("bob", "conn1")
+	checkSessions("carol")
+
+	// Updates keep tokens in the index, deletes remove them.
+	if err := s.UpdateRefreshToken(tokens[0].ID, func(old storage.RefreshToken) (storage.RefreshToken, error) {
+		old.LastUsed = now.Add(time.Minute)
+		return old, nil
+	}); err != nil {
+		t.Fatalf("update refresh token: %v", err)
+	}
+	if err := s.DeleteRefresh(tokens[1].ID); err != nil {
+		t.Fatalf("delete refresh token: %v", err)
+	}
+	checkTokens("alice", "conn1", tokens[0])
+
+	if err := s.UpdateOfflineSessions("alice", "conn1", func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+		old.ConnectorData = []byte(`{"some":"data"}`)
+		return old, nil
+	}); err != nil {
+		t.Fatalf("update offline session: %v", err)
+	}
+	if err := s.DeleteOfflineSessions("alice", "conn2"); err != nil {
+		t.Fatalf("delete offline session: %v", err)
+	}
+	checkSessions("alice", "conn1")
+}
+
+func testLeases(t *testing.T, s storage.Storage) {
+	leases, ok := s.(storage.LeaseStorage)
+	if !ok {
+		t.Skip("storage doesn't support leases")
+	}
+
+	acquire := func(holder string, duration time.Duration, want string) {
+		t.Helper()
+		lease, err := leases.AcquireLease("leader", holder, duration)
+		if err != nil {
+			t.Fatalf("acquire lease for %s: %v", holder, err)
+		}
+		if lease.Holder != want {
+			t.Fatalf("acquire lease for %s: expected holder %q, got %q", holder, want, lease.Holder)
+		}
+		if !lease.Held(time.Now()) {
+			t.Fatalf("acquire lease for %s: expected lease to be held, expires %s", holder, lease.Expiry)
+		}
+	}
+	release := func(holder string) {
+		t.Helper()
+		if err := leases.ReleaseLease("leader", holder); err != nil {
+			t.Fatalf("release lease for %s: %v", holder, err)
+		}
+	}
+
+	acquire("a", time.Minute, "a")
+	acquire("b", time.Minute, "a")
+	acquire("a", time.Minute, "a")
+
+	// Only the holder can release the lease.
+	release("b")
+	acquire("b", time.Minute, "a")
+	release("a")
+	acquire("b", time.Minute, "b")
+	release("b")
+
+	// Leases which aren't renewed expire.
+	acquire("a", time.Second, "a")
+	time.Sleep(3 * time.Second)
+	acquire("b", time.Minute, "b")
+}
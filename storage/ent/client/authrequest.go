@@ -58,6 +58,12 @@ func (d *Database) DeleteAuthRequest(id string) error {
 
 // UpdateAuthRequest changes an auth request by id using an updater function and saves it to the database.
 func (d *Database) UpdateAuthRequest(id string, updater func(old storage.AuthRequest) (storage.AuthRequest, error)) error {
+	return d.retryTx(func() error {
+		return d.updateAuthRequest(id, updater)
+	})
+}
+
+func (d *Database) updateAuthRequest(id string, updater func(old storage.AuthRequest) (storage.AuthRequest, error)) error {
 	tx, err := d.BeginTx(context.TODO())
 	if err != nil {
 		return fmt.Errorf("update auth request tx: %w", err)
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+)
+
+// CreateCIBARequest saves provided backchannel authentication request into the database.
+func (d *Database) CreateCIBARequest(request storage.CIBARequest) error {
+	_, err := d.client.CIBARequest.Create().
+		SetApprovalCode(request.ApprovalCode).
+		SetAuthReqID(request.AuthReqID).
+		SetClientID(request.ClientID).
+		SetScopes(request.Scopes).
+		SetLoginHint(request.LoginHint).
+		SetBindingMessage(request.BindingMessage).
+		// Save utc time into database because ent doesn't support comparing dates with different timezones
+		SetExpiry(request.Expiry.UTC()).
+		Save(context.TODO())
+	if err != nil {
+		return convertDBError("create ciba request: %w", err)
+	}
+	return nil
+}
+
+// GetCIBARequest extracts a backchannel authentication request from the database by approval code.
+func (d *Database) GetCIBARequest(approvalCode string) (storage.CIBARequest, error) {
+	cibaRequest, err := d.client.CIBARequest.Query().
+		Where(cibarequest.ApprovalCode(approvalCode)).
+		Only(context.TODO())
+	if err != nil {
+		return storage.CIBARequest{}, convertDBError("get ciba request: %w", err)
+	}
+	return toStorageCIBARequest(cibaRequest), nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
+)
+
+// CreateCIBAToken saves provided token into the database.
+func (d *Database) CreateCIBAToken(token storage.CIBAToken) error {
+	_, err := d.client.CIBAToken.Create().
+		SetAuthReqID(token.AuthReqID).
+		SetClientID(token.ClientID).
+		SetToken([]byte(token.Token)).
+		SetNotificationToken(token.NotificationToken).
+		SetPollInterval(token.PollIntervalSeconds).
+		// Save utc time into database because ent doesn't support comparing dates with different timezones
+		SetExpiry(token.Expiry.UTC()).
+		SetLastRequest(token.LastRequestTime.UTC()).
+		SetStatus(token.Status).
+		Save(context.TODO())
+	if err != nil {
+		return convertDBError("create ciba token: %w", err)
+	}
+	return nil
+}
+
+// GetCIBAToken extracts a token from the database by auth request id.
+func (d *Database) GetCIBAToken(authReqID string) (storage.CIBAToken, error) {
+	cibaToken, err := d.client.CIBAToken.Query().
+		Where(cibatoken.AuthReqID(authReqID)).
+		Only(context.TODO())
+	if err != nil {
+		return storage.CIBAToken{}, convertDBError("get ciba token: %w", err)
+	}
+	return toStorageCIBAToken(cibaToken), nil
+}
+
+// UpdateCIBAToken changes a token by auth request id using an updater function and saves it to the database.
+func (d *Database) UpdateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	return d.retryTx(func() error {
+		return d.updateCIBAToken(authReqID, updater)
+	})
+}
+
+func (d *Database) updateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	tx, err := d.BeginTx(context.TODO())
+	if err != nil {
+		return convertDBError("update ciba token tx: %w", err)
+	}
+
+	token, err := tx.CIBAToken.Query().
+		Where(cibatoken.AuthReqID(authReqID)).
+		Only(context.TODO())
+	if err != nil {
+		return rollback(tx, "update ciba token database: %w", err)
+	}
+
+	newToken, err := updater(toStorageCIBAToken(token))
+	if err != nil {
+		return rollback(tx, "update ciba token updating: %w", err)
+	}
+
+	_, err = tx.CIBAToken.Update().
+		Where(cibatoken.AuthReqID(authReqID)).
+		SetToken([]byte(newToken.Token)).
+		SetPollInterval(newToken.PollIntervalSeconds).
+		SetLastRequest(newToken.LastRequestTime.UTC()).
+		SetStatus(newToken.Status).
+		Save(context.TODO())
+	if err != nil {
+		return rollback(tx, "update ciba token uploading: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return rollback(tx, "update ciba token commit: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db"
+	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 )
 
 // CreateClient saves provided oauth2 client settings into the database.
@@ -16,6 +18,17 @@ func (d *Database) CreateClient(client storage.Client) error {
 		SetLogoURL(client.LogoURL).
 		SetRedirectUris(client.RedirectURIs).
 		SetTrustedPeers(client.TrustedPeers).
+		SetBackchannelNotificationEndpoint(client.BackchannelNotificationEndpoint).
+		SetIDTokensValidFor(client.TokenPolicy.IDTokensValidFor).
+		SetAccessTokensValidFor(client.TokenPolicy.AccessTokensValidFor).
+		SetAuthCodesValidFor(client.TokenPolicy.AuthCodesValidFor).
+		SetNillableDisableRefreshTokenRotation(client.TokenPolicy.DisableRefreshTokenRotation).
+		SetRefreshTokenAbsoluteLifetime(client.TokenPolicy.RefreshTokenAbsoluteLifetime).
+		SetRefreshTokenValidIfNotUsedFor(client.TokenPolicy.RefreshTokenValidIfNotUsedFor).
+		SetAllowedConnectors(client.AllowedConnectors).
+		SetAllowedGrantTypes(client.AllowedGrantTypes).
+		SetAllowedScopes(client.AllowedScopes).
+		SetAllowedResponseTypes(client.AllowedResponseTypes).
 		Save(context.TODO())
 	if err != nil {
 		return convertDBError("create oauth2 client: %w", err)
@@ -37,6 +50,37 @@ func (d *Database) ListClients() ([]storage.Client, error) {
 	return storageClients, nil
 }
 
+// ListClientsPage extracts a page of oauth2 clients ordered by id.
+func (d *Database) ListClientsPage(opts storage.ListOptions) ([]storage.Client, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := d.client.OAuth2Client.Query().Order(db.Asc(oauth2client.FieldID))
+	if after != "" {
+		query = query.Where(oauth2client.IDGT(after))
+	}
+	if opts.PageSize > 0 {
+		query = query.Limit(opts.PageSize + 1)
+	}
+	clients, err := query.All(context.TODO())
+	if err != nil {
+		return nil, "", convertDBError("list clients: %w", err)
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(clients) > opts.PageSize {
+		clients = clients[:opts.PageSize]
+		next = storage.NewPageToken(clients[opts.PageSize-1].ID)
+	}
+	storageClients := make([]storage.Client, 0, len(clients))
+	for _, c := range clients {
+		storageClients = append(storageClients, toStorageClient(c))
+	}
+	return storageClients, next, nil
+}
+
 // GetClient extracts an oauth2 client from the database by id.
 func (d *Database) GetClient(id string) (storage.Client, error) {
 	client, err := d.client.OAuth2Client.Get(context.TODO(), id)
@@ -57,6 +101,12 @@ func (d *Database) DeleteClient(id string) error {
 
 // UpdateClient changes an oauth2 client by id using an updater function and saves it to the database.
 func (d *Database) UpdateClient(id string, updater func(old storage.Client) (storage.Client, error)) error {
+	return d.retryTx(func() error {
+		return d.updateClient(id, updater)
+	})
+}
+
+func (d *Database) updateClient(id string, updater func(old storage.Client) (storage.Client, error)) error {
 	tx, err := d.BeginTx(context.TODO())
 	if err != nil {
 		return convertDBError("update client tx: %w", err)
@@ -72,14 +122,30 @@ func (d *Database) UpdateClient(id string, updater func(old storage.Client) (sto
 		return rollback(tx, "update client updating: %w", err)
 	}
 
-	_, err = tx.OAuth2Client.UpdateOneID(newClient.ID).
+	update := tx.OAuth2Client.UpdateOneID(newClient.ID).
 		SetName(newClient.Name).
 		SetSecret(newClient.Secret).
 		SetPublic(newClient.Public).
 		SetLogoURL(newClient.LogoURL).
 		SetRedirectUris(newClient.RedirectURIs).
 		SetTrustedPeers(newClient.TrustedPeers).
-		Save(context.TODO())
+		SetBackchannelNotificationEndpoint(newClient.BackchannelNotificationEndpoint).
+		SetIDTokensValidFor(newClient.TokenPolicy.IDTokensValidFor).
+		SetAccessTokensValidFor(newClient.TokenPolicy.AccessTokensValidFor).
+		SetAuthCodesValidFor(newClient.TokenPolicy.AuthCodesValidFor).
+		SetRefreshTokenAbsoluteLifetime(newClient.TokenPolicy.RefreshTokenAbsoluteLifetime).
+		SetRefreshTokenValidIfNotUsedFor(newClient.TokenPolicy.RefreshTokenValidIfNotUsedFor).
+		SetAllowedConnectors(newClient.AllowedConnectors).
+		SetAllowedGrantTypes(newClient.AllowedGrantTypes).
+		SetAllowedScopes(newClient.AllowedScopes).
+		SetAllowedResponseTypes(newClient.AllowedResponseTypes)
+	if newClient.TokenPolicy.DisableRefreshTokenRotation != nil {
+		update.SetDisableRefreshTokenRotation(*newClient.TokenPolicy.DisableRefreshTokenRotation)
+	} else {
+		update.ClearDisableRefreshTokenRotation()
+	}
+
+	_, err = update.Save(context.TODO())
 	if err != nil {
 		return rollback(tx, "update client uploading: %w", err)
 	}
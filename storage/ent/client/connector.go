@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db"
+	"github.com/dexidp/dex/storage/ent/db/connector"
 )
 
 // CreateConnector saves a connector into the database.
@@ -35,6 +37,37 @@ func (d *Database) ListConnectors() ([]storage.Connector, error) {
 	return storageConnectors, nil
 }
 
+// ListConnectorsPage extracts a page of connectors ordered by id.
+func (d *Database) ListConnectorsPage(opts storage.ListOptions) ([]storage.Connector, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := d.client.Connector.Query().Order(db.Asc(connector.FieldID))
+	if after != "" {
+		query = query.Where(connector.IDGT(after))
+	}
+	if opts.PageSize > 0 {
+		query = query.Limit(opts.PageSize + 1)
+	}
+	connectors, err := query.All(context.TODO())
+	if err != nil {
+		return nil, "", convertDBError("list connectors: %w", err)
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(connectors) > opts.PageSize {
+		connectors = connectors[:opts.PageSize]
+		next = storage.NewPageToken(connectors[opts.PageSize-1].ID)
+	}
+	storageConnectors := make([]storage.Connector, 0, len(connectors))
+	for _, c := range connectors {
+		storageConnectors = append(storageConnectors, toStorageConnector(c))
+	}
+	return storageConnectors, next, nil
+}
+
 // GetConnector extracts a connector from the database by id.
 func (d *Database) GetConnector(id string) (storage.Connector, error) {
 	connector, err := d.client.Connector.Get(context.TODO(), id)
@@ -55,6 +88,12 @@ func (d *Database) DeleteConnector(id string) error {
 
 // UpdateConnector changes a connector by id using an updater function and saves it to the database.
 func (d *Database) UpdateConnector(id string, updater func(old storage.Connector) (storage.Connector, error)) error {
+	return d.retryTx(func() error {
+		return d.updateConnector(id, updater)
+	})
+}
+
+func (d *Database) updateConnector(id string, updater func(old storage.Connector) (storage.Connector, error)) error {
 	tx, err := d.BeginTx(context.TODO())
 	if err != nil {
 		return convertDBError("update connector tx: %w", err)
@@ -24,6 +24,12 @@ func (d *Database) GetKeys() (storage.Keys, error) {
 
 // UpdateKeys rotates keys using updater function.
 func (d *Database) UpdateKeys(updater func(old storage.Keys) (storage.Keys, error)) error {
+	return d.retryTx(func() error {
+		return d.updateKeys(updater)
+	})
+}
+
+func (d *Database) updateKeys(updater func(old storage.Keys) (storage.Keys, error)) error {
 	firstUpdate := false
 
 	tx, err := d.BeginTx(context.TODO())
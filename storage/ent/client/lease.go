@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db"
+	"github.com/dexidp/dex/storage/ent/db/lease"
+)
+
+// AcquireLease takes over the lease if it's held by the holder or has expired,
+// and creates it if it doesn't exist yet.
+func (d *Database) AcquireLease(name, holder string, duration time.Duration) (storage.Lease, error) {
+	now := time.Now()
+	n, err := d.client.Lease.Update().
+		Where(lease.ID(name), lease.Or(lease.Holder(holder), lease.ExpiryLTE(now))).
+		SetHolder(holder).
+		SetExpiry(now.Add(duration)).
+		Save(context.TODO())
+	if err != nil {
+		return storage.Lease{}, convertDBError("update lease: %w", err)
+	}
+	if n == 0 {
+		_, err := d.client.Lease.Create().
+			SetID(name).
+			SetHolder(holder).
+			SetExpiry(now.Add(duration)).
+			Save(context.TODO())
+		if err != nil && !db.IsConstraintError(err) {
+			return storage.Lease{}, convertDBError("create lease: %w", err)
+		}
+	}
+
+	l, err := d.client.Lease.Get(context.TODO(), name)
+	if err != nil {
+		return storage.Lease{}, convertDBError("get lease: %w", err)
+	}
+	return storage.Lease{Name: l.ID, Holder: l.Holder, Expiry: l.Expiry}, nil
+}
+
+// ReleaseLease deletes the lease if it's held by the holder.
+func (d *Database) ReleaseLease(name, holder string) error {
+	_, err := d.client.Lease.Delete().
+		Where(lease.ID(name), lease.Holder(holder)).
+		Exec(context.TODO())
+	if err != nil {
+		return convertDBError("delete lease: %w", err)
+	}
+	return nil
+}
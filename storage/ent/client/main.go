@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"hash"
 	"time"
 
@@ -10,9 +11,12 @@ import (
 	"github.com/dexidp/dex/storage/ent/db"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
 	"github.com/dexidp/dex/storage/ent/db/migrate"
+	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
 )
 
 var _ storage.Storage = (*Database)(nil)
@@ -20,6 +24,7 @@ var _ storage.Storage = (*Database)(nil)
 type Database struct {
 	client    *db.Client
 	txOptions *sql.TxOptions
+	retryable func(err error) bool
 
 	hasher func() hash.Hash
 }
@@ -54,6 +59,14 @@ func WithTxIsolationLevel(level sql.IsolationLevel) func(*Database) {
 	}
 }
 
+// WithTxRetry retries updates whose transaction failed with an error for
+// which retryable returns true, e.g. a serialization failure.
+func WithTxRetry(retryable func(err error) bool) func(*Database) {
+	return func(s *Database) {
+		s.retryable = retryable
+	}
+}
+
 // Schema exposes migration schema to perform migrations.
 func (d *Database) Schema() *migrate.Schema {
 	return d.client.Schema
@@ -69,8 +82,24 @@ func (d *Database) BeginTx(ctx context.Context) (*db.Tx, error) {
 	return d.client.BeginTx(ctx, d.txOptions)
 }
 
+// maxTxAttempts is how often an update is tried while its transaction keeps
+// conflicting with concurrent transactions.
+const maxTxAttempts = 5
+
+// retryTx runs fn, which runs a transaction, until it succeeds, fails with an
+// error which isn't retryable or was tried maxTxAttempts times.
+func (d *Database) retryTx(fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || d.retryable == nil || attempt == maxTxAttempts || !d.retryable(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * 10 * time.Millisecond)
+	}
+}
+
 // GarbageCollect removes expired entities from the database.
-func (d *Database) GarbageCollect(now time.Time) (storage.GCResult, error) {
+func (d *Database) GarbageCollect(now time.Time, opts storage.GCOptions) (storage.GCResult, error) {
 	result := storage.GCResult{}
 	utcNow := now.UTC()
 
@@ -106,5 +135,90 @@ func (d *Database) GarbageCollect(now time.Time) (storage.GCResult, error) {
 	}
 	result.DeviceTokens = int64(q)
 
+	q, err = d.client.CIBARequest.Delete().
+		Where(cibarequest.ExpiryLT(utcNow)).
+		Exec(context.TODO())
+	if err != nil {
+		return result, convertDBError("gc ciba request: %w", err)
+	}
+	result.CIBARequests = int64(q)
+
+	q, err = d.client.CIBAToken.Delete().
+		Where(cibatoken.ExpiryLT(utcNow)).
+		Exec(context.TODO())
+	if err != nil {
+		return result, convertDBError("gc ciba token: %w", err)
+	}
+	result.CIBATokens = int64(q)
+
+	if opts.RefreshTokenAbsoluteLifetime != 0 {
+		q, err = d.client.RefreshToken.Delete().
+			Where(refreshtoken.CreatedAtLT(utcNow.Add(-opts.RefreshTokenAbsoluteLifetime))).
+			Exec(context.TODO())
+		if err != nil {
+			return result, convertDBError("gc refresh token: %w", err)
+		}
+		result.RefreshTokens += int64(q)
+	}
+
+	if opts.RefreshTokenValidIfNotUsedFor != 0 {
+		q, err = d.client.RefreshToken.Delete().
+			Where(refreshtoken.LastUsedLT(utcNow.Add(-opts.RefreshTokenValidIfNotUsedFor))).
+			Exec(context.TODO())
+		if err != nil {
+			return result, convertDBError("gc refresh token: %w", err)
+		}
+		result.RefreshTokens += int64(q)
+	}
+
+	result.OfflineSessions, err = d.gcOfflineSessions()
 	return result, err
 }
+
+// gcOfflineSessions deletes the offline sessions which only reference refresh
+// tokens that no longer exist. The sessions and refresh tokens are read and the
+// sessions deleted in one serializable transaction, which conflicts with any
+// concurrent transaction adding a refresh token to a session.
+func (d *Database) gcOfflineSessions() (int64, error) {
+	tx, err := d.BeginTx(context.TODO())
+	if err != nil {
+		return 0, convertDBError("gc offline session tx: %w", err)
+	}
+
+	refreshIDs, err := tx.RefreshToken.Query().IDs(context.TODO())
+	if err != nil {
+		return 0, rollback(tx, "gc offline session list refresh tokens: %w", err)
+	}
+	refreshExists := make(map[string]bool, len(refreshIDs))
+	for _, id := range refreshIDs {
+		refreshExists[id] = true
+	}
+
+	offlineSessions, err := tx.OfflineSession.Query().All(context.TODO())
+	if err != nil {
+		return 0, rollback(tx, "gc offline session list: %w", err)
+	}
+
+	var deleted int64
+	for _, o := range offlineSessions {
+		session := storage.OfflineSessions{UserID: o.UserID, ConnID: o.ConnID}
+		if err := json.Unmarshal(o.Refresh, &session.Refresh); err != nil {
+			return 0, rollback(tx, "gc offline session decode refresh: %w", err)
+		}
+		orphaned := storage.OfflineSessionOrphaned(session, func(id string) bool {
+			return refreshExists[id]
+		})
+		if !orphaned {
+			continue
+		}
+		if err := tx.OfflineSession.DeleteOneID(o.ID).Exec(context.TODO()); err != nil {
+			return 0, rollback(tx, "gc offline session delete: %w", err)
+		}
+		deleted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, rollback(tx, "gc offline session commit: %w", err)
+	}
+	return deleted, nil
+}
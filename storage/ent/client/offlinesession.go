@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 )
 
 // CreateOfflineSessions saves provided offline session into the database.
@@ -40,6 +41,36 @@ func (d *Database) GetOfflineSessions(userID, connID string) (storage.OfflineSes
 	return toStorageOfflineSession(offlineSession), nil
 }
 
+// ListOfflineSessions extracts an array of offline sessions from the database.
+func (d *Database) ListOfflineSessions() ([]storage.OfflineSessions, error) {
+	offlineSessions, err := d.client.OfflineSession.Query().All(context.TODO())
+	if err != nil {
+		return nil, convertDBError("list offline sessions: %w", err)
+	}
+
+	storageOfflineSessions := make([]storage.OfflineSessions, 0, len(offlineSessions))
+	for _, o := range offlineSessions {
+		storageOfflineSessions = append(storageOfflineSessions, toStorageOfflineSession(o))
+	}
+	return storageOfflineSessions, nil
+}
+
+// ListOfflineSessionsForUser extracts the offline sessions of a user with all connectors.
+func (d *Database) ListOfflineSessionsForUser(userID string) ([]storage.OfflineSessions, error) {
+	offlineSessions, err := d.client.OfflineSession.Query().
+		Where(offlinesession.UserID(userID)).
+		All(context.TODO())
+	if err != nil {
+		return nil, convertDBError("list offline sessions for user: %w", err)
+	}
+
+	storageOfflineSessions := make([]storage.OfflineSessions, 0, len(offlineSessions))
+	for _, o := range offlineSessions {
+		storageOfflineSessions = append(storageOfflineSessions, toStorageOfflineSession(o))
+	}
+	return storageOfflineSessions, nil
+}
+
 // DeleteOfflineSessions deletes an offline session from the database by user id and connector id.
 func (d *Database) DeleteOfflineSessions(userID, connID string) error {
 	id := offlineSessionID(userID, connID, d.hasher)
@@ -53,6 +84,12 @@ func (d *Database) DeleteOfflineSessions(userID, connID string) error {
 
 // UpdateOfflineSessions changes an offline session by user id and connector id using an updater function.
 func (d *Database) UpdateOfflineSessions(userID string, connID string, updater func(s storage.OfflineSessions) (storage.OfflineSessions, error)) error {
+	return d.retryTx(func() error {
+		return d.updateOfflineSessions(userID, connID, updater)
+	})
+}
+
+func (d *Database) updateOfflineSessions(userID string, connID string, updater func(s storage.OfflineSessions) (storage.OfflineSessions, error)) error {
 	id := offlineSessionID(userID, connID, d.hasher)
 
 	tx, err := d.BeginTx(context.TODO())
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db"
 	"github.com/dexidp/dex/storage/ent/db/password"
 )
 
@@ -36,6 +37,40 @@ func (d *Database) ListPasswords() ([]storage.Password, error) {
 	return storagePasswords, nil
 }
 
+// ListPasswordsPage extracts a page of passwords ordered by email.
+func (d *Database) ListPasswordsPage(opts storage.ListOptions) ([]storage.Password, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := d.client.Password.Query().Order(db.Asc(password.FieldEmail))
+	if after != "" {
+		query = query.Where(password.EmailGT(after))
+	}
+	if opts.Filter.UserID != "" {
+		query = query.Where(password.UserID(opts.Filter.UserID))
+	}
+	if opts.PageSize > 0 {
+		query = query.Limit(opts.PageSize + 1)
+	}
+	passwords, err := query.All(context.TODO())
+	if err != nil {
+		return nil, "", convertDBError("list passwords: %w", err)
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(passwords) > opts.PageSize {
+		passwords = passwords[:opts.PageSize]
+		next = storage.NewPageToken(passwords[opts.PageSize-1].Email)
+	}
+	storagePasswords := make([]storage.Password, 0, len(passwords))
+	for _, p := range passwords {
+		storagePasswords = append(storagePasswords, toStoragePassword(p))
+	}
+	return storagePasswords, next, nil
+}
+
 // GetPassword extracts a password from the database by email.
 func (d *Database) GetPassword(email string) (storage.Password, error) {
 	email = strings.ToLower(email)
@@ -62,6 +97,12 @@ func (d *Database) DeletePassword(email string) error {
 
 // UpdatePassword changes a password by email using an updater function and saves it to the database.
 func (d *Database) UpdatePassword(email string, updater func(old storage.Password) (storage.Password, error)) error {
+	return d.retryTx(func() error {
+		return d.updatePassword(email, updater)
+	})
+}
+
+func (d *Database) updatePassword(email string, updater func(old storage.Password) (storage.Password, error)) error {
 	email = strings.ToLower(email)
 
 	tx, err := d.BeginTx(context.TODO())
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/ent/db"
+	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
 )
 
 // CreateRefresh saves provided refresh token into the database.
@@ -47,6 +49,75 @@ func (d *Database) ListRefreshTokens() ([]storage.RefreshToken, error) {
 	return storageRefreshTokens, nil
 }
 
+// ListRefreshTokensForUser extracts the refresh tokens of a user issued through a connector.
+func (d *Database) ListRefreshTokensForUser(userID string, connID string) ([]storage.RefreshToken, error) {
+	refreshTokens, err := d.client.RefreshToken.Query().
+		Where(refreshtoken.ClaimsUserID(userID), refreshtoken.ConnectorID(connID)).
+		All(context.TODO())
+	if err != nil {
+		return nil, convertDBError("list refresh tokens for user: %w", err)
+	}
+
+	storageRefreshTokens := make([]storage.RefreshToken, 0, len(refreshTokens))
+	for _, r := range refreshTokens {
+		storageRefreshTokens = append(storageRefreshTokens, toStorageRefreshToken(r))
+	}
+	return storageRefreshTokens, nil
+}
+
+// ListRefreshTokensPage extracts a page of refresh tokens ordered by id.
+func (d *Database) ListRefreshTokensPage(opts storage.ListOptions) ([]storage.RefreshToken, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := d.client.RefreshToken.Query().Order(db.Asc(refreshtoken.FieldID))
+	if after != "" {
+		query = query.Where(refreshtoken.IDGT(after))
+	}
+	f := opts.Filter
+	if f.ClientID != "" {
+		query = query.Where(refreshtoken.ClientID(f.ClientID))
+	}
+	if f.UserID != "" {
+		query = query.Where(refreshtoken.ClaimsUserID(f.UserID))
+	}
+	if f.ConnectorID != "" {
+		query = query.Where(refreshtoken.ConnectorID(f.ConnectorID))
+	}
+	if !f.CreatedAfter.IsZero() {
+		query = query.Where(refreshtoken.CreatedAtGTE(f.CreatedAfter))
+	}
+	if !f.CreatedBefore.IsZero() {
+		query = query.Where(refreshtoken.CreatedAtLT(f.CreatedBefore))
+	}
+	if !f.LastUsedAfter.IsZero() {
+		query = query.Where(refreshtoken.LastUsedGTE(f.LastUsedAfter))
+	}
+	if !f.LastUsedBefore.IsZero() {
+		query = query.Where(refreshtoken.LastUsedLT(f.LastUsedBefore))
+	}
+	if opts.PageSize > 0 {
+		query = query.Limit(opts.PageSize + 1)
+	}
+	refreshTokens, err := query.All(context.TODO())
+	if err != nil {
+		return nil, "", convertDBError("list refresh tokens: %w", err)
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(refreshTokens) > opts.PageSize {
+		refreshTokens = refreshTokens[:opts.PageSize]
+		next = storage.NewPageToken(refreshTokens[opts.PageSize-1].ID)
+	}
+	storageRefreshTokens := make([]storage.RefreshToken, 0, len(refreshTokens))
+	for _, r := range refreshTokens {
+		storageRefreshTokens = append(storageRefreshTokens, toStorageRefreshToken(r))
+	}
+	return storageRefreshTokens, next, nil
+}
+
 // GetRefresh extracts a refresh token from the database by id.
 func (d *Database) GetRefresh(id string) (storage.RefreshToken, error) {
 	refreshToken, err := d.client.RefreshToken.Get(context.TODO(), id)
@@ -67,6 +138,12 @@ func (d *Database) DeleteRefresh(id string) error {
 
 // UpdateRefreshToken changes a refresh token by id using an updater function and saves it to the database.
 func (d *Database) UpdateRefreshToken(id string, updater func(old storage.RefreshToken) (storage.RefreshToken, error)) error {
+	return d.retryTx(func() error {
+		return d.updateRefreshToken(id, updater)
+	})
+}
+
+func (d *Database) updateRefreshToken(id string, updater func(old storage.RefreshToken) (storage.RefreshToken, error)) error {
 	tx, err := d.BeginTx(context.TODO())
 	if err != nil {
 		return convertDBError("update refresh token tx: %w", err)
@@ -82,6 +82,20 @@ func toStorageClient(c *db.OAuth2Client) storage.Client {
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+
+		BackchannelNotificationEndpoint: c.BackchannelNotificationEndpoint,
+		TokenPolicy: storage.ClientTokenPolicy{
+			IDTokensValidFor:              c.IDTokensValidFor,
+			AccessTokensValidFor:          c.AccessTokensValidFor,
+			AuthCodesValidFor:             c.AuthCodesValidFor,
+			DisableRefreshTokenRotation:   c.DisableRefreshTokenRotation,
+			RefreshTokenAbsoluteLifetime:  c.RefreshTokenAbsoluteLifetime,
+			RefreshTokenValidIfNotUsedFor: c.RefreshTokenValidIfNotUsedFor,
+		},
+		AllowedConnectors:    c.AllowedConnectors,
+		AllowedGrantTypes:    c.AllowedGrantTypes,
+		AllowedScopes:        c.AllowedScopes,
+		AllowedResponseTypes: c.AllowedResponseTypes,
 	}
 }
 
@@ -166,3 +180,32 @@ func toStorageDeviceToken(t *db.DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollInterval,
 	}
 }
+
+func toStorageCIBARequest(r *db.CIBARequest) storage.CIBARequest {
+	return storage.CIBARequest{
+		ApprovalCode:   r.ApprovalCode,
+		AuthReqID:      r.AuthReqID,
+		ClientID:       r.ClientID,
+		Scopes:         r.Scopes,
+		LoginHint:      r.LoginHint,
+		BindingMessage: r.BindingMessage,
+		Expiry:         r.Expiry,
+	}
+}
+
+func toStorageCIBAToken(t *db.CIBAToken) storage.CIBAToken {
+	var token string
+	if t.Token != nil {
+		token = string(*t.Token)
+	}
+	return storage.CIBAToken{
+		AuthReqID:           t.AuthReqID,
+		ClientID:            t.ClientID,
+		Status:              t.Status,
+		Token:               token,
+		NotificationToken:   t.NotificationToken,
+		Expiry:              t.Expiry,
+		LastRequestTime:     t.LastRequest,
+		PollIntervalSeconds: t.PollInterval,
+	}
+}
@@ -0,0 +1,812 @@
+// Code generated by entc, DO NOT EDIT.
+
+package cibarequest
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// ApprovalCode applies equality check predicate on the "approval_code" field. It's identical to ApprovalCodeEQ.
+func ApprovalCode(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldApprovalCode), v))
+	})
+}
+
+// AuthReqID applies equality check predicate on the "auth_req_id" field. It's identical to AuthReqIDEQ.
+func AuthReqID(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// ClientID applies equality check predicate on the "client_id" field. It's identical to ClientIDEQ.
+func ClientID(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// LoginHint applies equality check predicate on the "login_hint" field. It's identical to LoginHintEQ.
+func LoginHint(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLoginHint), v))
+	})
+}
+
+// BindingMessage applies equality check predicate on the "binding_message" field. It's identical to BindingMessageEQ.
+func BindingMessage(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBindingMessage), v))
+	})
+}
+
+// Expiry applies equality check predicate on the "expiry" field. It's identical to ExpiryEQ.
+func Expiry(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExpiry), v))
+	})
+}
+
+// ApprovalCodeEQ applies the EQ predicate on the "approval_code" field.
+func ApprovalCodeEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeNEQ applies the NEQ predicate on the "approval_code" field.
+func ApprovalCodeNEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeIn applies the In predicate on the "approval_code" field.
+func ApprovalCodeIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldApprovalCode), v...))
+	})
+}
+
+// ApprovalCodeNotIn applies the NotIn predicate on the "approval_code" field.
+func ApprovalCodeNotIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldApprovalCode), v...))
+	})
+}
+
+// ApprovalCodeGT applies the GT predicate on the "approval_code" field.
+func ApprovalCodeGT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeGTE applies the GTE predicate on the "approval_code" field.
+func ApprovalCodeGTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeLT applies the LT predicate on the "approval_code" field.
+func ApprovalCodeLT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeLTE applies the LTE predicate on the "approval_code" field.
+func ApprovalCodeLTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeContains applies the Contains predicate on the "approval_code" field.
+func ApprovalCodeContains(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeHasPrefix applies the HasPrefix predicate on the "approval_code" field.
+func ApprovalCodeHasPrefix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeHasSuffix applies the HasSuffix predicate on the "approval_code" field.
+func ApprovalCodeHasSuffix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeEqualFold applies the EqualFold predicate on the "approval_code" field.
+func ApprovalCodeEqualFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldApprovalCode), v))
+	})
+}
+
+// ApprovalCodeContainsFold applies the ContainsFold predicate on the "approval_code" field.
+func ApprovalCodeContainsFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldApprovalCode), v))
+	})
+}
+
+// AuthReqIDEQ applies the EQ predicate on the "auth_req_id" field.
+func AuthReqIDEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDNEQ applies the NEQ predicate on the "auth_req_id" field.
+func AuthReqIDNEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDIn applies the In predicate on the "auth_req_id" field.
+func AuthReqIDIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAuthReqID), v...))
+	})
+}
+
+// AuthReqIDNotIn applies the NotIn predicate on the "auth_req_id" field.
+func AuthReqIDNotIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAuthReqID), v...))
+	})
+}
+
+// AuthReqIDGT applies the GT predicate on the "auth_req_id" field.
+func AuthReqIDGT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDGTE applies the GTE predicate on the "auth_req_id" field.
+func AuthReqIDGTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDLT applies the LT predicate on the "auth_req_id" field.
+func AuthReqIDLT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDLTE applies the LTE predicate on the "auth_req_id" field.
+func AuthReqIDLTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDContains applies the Contains predicate on the "auth_req_id" field.
+func AuthReqIDContains(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDHasPrefix applies the HasPrefix predicate on the "auth_req_id" field.
+func AuthReqIDHasPrefix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDHasSuffix applies the HasSuffix predicate on the "auth_req_id" field.
+func AuthReqIDHasSuffix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDEqualFold applies the EqualFold predicate on the "auth_req_id" field.
+func AuthReqIDEqualFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDContainsFold applies the ContainsFold predicate on the "auth_req_id" field.
+func AuthReqIDContainsFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAuthReqID), v))
+	})
+}
+
+// ClientIDEQ applies the EQ predicate on the "client_id" field.
+func ClientIDEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDNEQ applies the NEQ predicate on the "client_id" field.
+func ClientIDNEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDIn applies the In predicate on the "client_id" field.
+func ClientIDIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDNotIn applies the NotIn predicate on the "client_id" field.
+func ClientIDNotIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDGT applies the GT predicate on the "client_id" field.
+func ClientIDGT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDGTE applies the GTE predicate on the "client_id" field.
+func ClientIDGTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLT applies the LT predicate on the "client_id" field.
+func ClientIDLT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLTE applies the LTE predicate on the "client_id" field.
+func ClientIDLTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContains applies the Contains predicate on the "client_id" field.
+func ClientIDContains(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasPrefix applies the HasPrefix predicate on the "client_id" field.
+func ClientIDHasPrefix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasSuffix applies the HasSuffix predicate on the "client_id" field.
+func ClientIDHasSuffix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDEqualFold applies the EqualFold predicate on the "client_id" field.
+func ClientIDEqualFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContainsFold applies the ContainsFold predicate on the "client_id" field.
+func ClientIDContainsFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldClientID), v))
+	})
+}
+
+// ScopesIsNil applies the IsNil predicate on the "scopes" field.
+func ScopesIsNil() predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldScopes)))
+	})
+}
+
+// ScopesNotNil applies the NotNil predicate on the "scopes" field.
+func ScopesNotNil() predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldScopes)))
+	})
+}
+
+// LoginHintEQ applies the EQ predicate on the "login_hint" field.
+func LoginHintEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintNEQ applies the NEQ predicate on the "login_hint" field.
+func LoginHintNEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintIn applies the In predicate on the "login_hint" field.
+func LoginHintIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldLoginHint), v...))
+	})
+}
+
+// LoginHintNotIn applies the NotIn predicate on the "login_hint" field.
+func LoginHintNotIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldLoginHint), v...))
+	})
+}
+
+// LoginHintGT applies the GT predicate on the "login_hint" field.
+func LoginHintGT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintGTE applies the GTE predicate on the "login_hint" field.
+func LoginHintGTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintLT applies the LT predicate on the "login_hint" field.
+func LoginHintLT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintLTE applies the LTE predicate on the "login_hint" field.
+func LoginHintLTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintContains applies the Contains predicate on the "login_hint" field.
+func LoginHintContains(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintHasPrefix applies the HasPrefix predicate on the "login_hint" field.
+func LoginHintHasPrefix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintHasSuffix applies the HasSuffix predicate on the "login_hint" field.
+func LoginHintHasSuffix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintEqualFold applies the EqualFold predicate on the "login_hint" field.
+func LoginHintEqualFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldLoginHint), v))
+	})
+}
+
+// LoginHintContainsFold applies the ContainsFold predicate on the "login_hint" field.
+func LoginHintContainsFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldLoginHint), v))
+	})
+}
+
+// BindingMessageEQ applies the EQ predicate on the "binding_message" field.
+func BindingMessageEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageNEQ applies the NEQ predicate on the "binding_message" field.
+func BindingMessageNEQ(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageIn applies the In predicate on the "binding_message" field.
+func BindingMessageIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldBindingMessage), v...))
+	})
+}
+
+// BindingMessageNotIn applies the NotIn predicate on the "binding_message" field.
+func BindingMessageNotIn(vs ...string) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldBindingMessage), v...))
+	})
+}
+
+// BindingMessageGT applies the GT predicate on the "binding_message" field.
+func BindingMessageGT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageGTE applies the GTE predicate on the "binding_message" field.
+func BindingMessageGTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageLT applies the LT predicate on the "binding_message" field.
+func BindingMessageLT(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageLTE applies the LTE predicate on the "binding_message" field.
+func BindingMessageLTE(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageContains applies the Contains predicate on the "binding_message" field.
+func BindingMessageContains(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageHasPrefix applies the HasPrefix predicate on the "binding_message" field.
+func BindingMessageHasPrefix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageHasSuffix applies the HasSuffix predicate on the "binding_message" field.
+func BindingMessageHasSuffix(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageEqualFold applies the EqualFold predicate on the "binding_message" field.
+func BindingMessageEqualFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldBindingMessage), v))
+	})
+}
+
+// BindingMessageContainsFold applies the ContainsFold predicate on the "binding_message" field.
+func BindingMessageContainsFold(v string) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldBindingMessage), v))
+	})
+}
+
+// ExpiryEQ applies the EQ predicate on the "expiry" field.
+func ExpiryEQ(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryNEQ applies the NEQ predicate on the "expiry" field.
+func ExpiryNEQ(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryIn applies the In predicate on the "expiry" field.
+func ExpiryIn(vs ...time.Time) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldExpiry), v...))
+	})
+}
+
+// ExpiryNotIn applies the NotIn predicate on the "expiry" field.
+func ExpiryNotIn(vs ...time.Time) predicate.CIBARequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldExpiry), v...))
+	})
+}
+
+// ExpiryGT applies the GT predicate on the "expiry" field.
+func ExpiryGT(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryGTE applies the GTE predicate on the "expiry" field.
+func ExpiryGTE(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryLT applies the LT predicate on the "expiry" field.
+func ExpiryLT(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryLTE applies the LTE predicate on the "expiry" field.
+func ExpiryLTE(v time.Time) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldExpiry), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.CIBARequest) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.CIBARequest) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.CIBARequest) predicate.CIBARequest {
+	return predicate.CIBARequest(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
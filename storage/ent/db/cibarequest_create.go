@@ -0,0 +1,353 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+)
+
+// CIBARequestCreate is the builder for creating a CIBARequest entity.
+type CIBARequestCreate struct {
+	config
+	mutation *CIBARequestMutation
+	hooks    []Hook
+}
+
+// SetApprovalCode sets the "approval_code" field.
+func (crc *CIBARequestCreate) SetApprovalCode(s string) *CIBARequestCreate {
+	crc.mutation.SetApprovalCode(s)
+	return crc
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (crc *CIBARequestCreate) SetAuthReqID(s string) *CIBARequestCreate {
+	crc.mutation.SetAuthReqID(s)
+	return crc
+}
+
+// SetClientID sets the "client_id" field.
+func (crc *CIBARequestCreate) SetClientID(s string) *CIBARequestCreate {
+	crc.mutation.SetClientID(s)
+	return crc
+}
+
+// SetScopes sets the "scopes" field.
+func (crc *CIBARequestCreate) SetScopes(s []string) *CIBARequestCreate {
+	crc.mutation.SetScopes(s)
+	return crc
+}
+
+// SetLoginHint sets the "login_hint" field.
+func (crc *CIBARequestCreate) SetLoginHint(s string) *CIBARequestCreate {
+	crc.mutation.SetLoginHint(s)
+	return crc
+}
+
+// SetBindingMessage sets the "binding_message" field.
+func (crc *CIBARequestCreate) SetBindingMessage(s string) *CIBARequestCreate {
+	crc.mutation.SetBindingMessage(s)
+	return crc
+}
+
+// SetNillableBindingMessage sets the "binding_message" field if the given value is not nil.
+func (crc *CIBARequestCreate) SetNillableBindingMessage(s *string) *CIBARequestCreate {
+	if s != nil {
+		crc.SetBindingMessage(*s)
+	}
+	return crc
+}
+
+// SetExpiry sets the "expiry" field.
+func (crc *CIBARequestCreate) SetExpiry(t time.Time) *CIBARequestCreate {
+	crc.mutation.SetExpiry(t)
+	return crc
+}
+
+// Mutation returns the CIBARequestMutation object of the builder.
+func (crc *CIBARequestCreate) Mutation() *CIBARequestMutation {
+	return crc.mutation
+}
+
+// Save creates the CIBARequest in the database.
+func (crc *CIBARequestCreate) Save(ctx context.Context) (*CIBARequest, error) {
+	var (
+		err  error
+		node *CIBARequest
+	)
+	crc.defaults()
+	if len(crc.hooks) == 0 {
+		if err = crc.check(); err != nil {
+			return nil, err
+		}
+		node, err = crc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBARequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = crc.check(); err != nil {
+				return nil, err
+			}
+			crc.mutation = mutation
+			if node, err = crc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(crc.hooks) - 1; i >= 0; i-- {
+			if crc.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = crc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, crc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (crc *CIBARequestCreate) SaveX(ctx context.Context) *CIBARequest {
+	v, err := crc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (crc *CIBARequestCreate) Exec(ctx context.Context) error {
+	_, err := crc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (crc *CIBARequestCreate) ExecX(ctx context.Context) {
+	if err := crc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (crc *CIBARequestCreate) defaults() {
+	if _, ok := crc.mutation.BindingMessage(); !ok {
+		v := cibarequest.DefaultBindingMessage
+		crc.mutation.SetBindingMessage(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (crc *CIBARequestCreate) check() error {
+	if _, ok := crc.mutation.ApprovalCode(); !ok {
+		return &ValidationError{Name: "approval_code", err: errors.New(`db: missing required field "CIBARequest.approval_code"`)}
+	}
+	if v, ok := crc.mutation.ApprovalCode(); ok {
+		if err := cibarequest.ApprovalCodeValidator(v); err != nil {
+			return &ValidationError{Name: "approval_code", err: fmt.Errorf(`db: validator failed for field "CIBARequest.approval_code": %w`, err)}
+		}
+	}
+	if _, ok := crc.mutation.AuthReqID(); !ok {
+		return &ValidationError{Name: "auth_req_id", err: errors.New(`db: missing required field "CIBARequest.auth_req_id"`)}
+	}
+	if v, ok := crc.mutation.AuthReqID(); ok {
+		if err := cibarequest.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.auth_req_id": %w`, err)}
+		}
+	}
+	if _, ok := crc.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`db: missing required field "CIBARequest.client_id"`)}
+	}
+	if v, ok := crc.mutation.ClientID(); ok {
+		if err := cibarequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.client_id": %w`, err)}
+		}
+	}
+	if _, ok := crc.mutation.LoginHint(); !ok {
+		return &ValidationError{Name: "login_hint", err: errors.New(`db: missing required field "CIBARequest.login_hint"`)}
+	}
+	if _, ok := crc.mutation.BindingMessage(); !ok {
+		return &ValidationError{Name: "binding_message", err: errors.New(`db: missing required field "CIBARequest.binding_message"`)}
+	}
+	if _, ok := crc.mutation.Expiry(); !ok {
+		return &ValidationError{Name: "expiry", err: errors.New(`db: missing required field "CIBARequest.expiry"`)}
+	}
+	return nil
+}
+
+func (crc *CIBARequestCreate) sqlSave(ctx context.Context) (*CIBARequest, error) {
+	_node, _spec := crc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, crc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (crc *CIBARequestCreate) createSpec() (*CIBARequest, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CIBARequest{config: crc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: cibarequest.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibarequest.FieldID,
+			},
+		}
+	)
+	if value, ok := crc.mutation.ApprovalCode(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldApprovalCode,
+		})
+		_node.ApprovalCode = value
+	}
+	if value, ok := crc.mutation.AuthReqID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldAuthReqID,
+		})
+		_node.AuthReqID = value
+	}
+	if value, ok := crc.mutation.ClientID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldClientID,
+		})
+		_node.ClientID = value
+	}
+	if value, ok := crc.mutation.Scopes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: cibarequest.FieldScopes,
+		})
+		_node.Scopes = value
+	}
+	if value, ok := crc.mutation.LoginHint(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldLoginHint,
+		})
+		_node.LoginHint = value
+	}
+	if value, ok := crc.mutation.BindingMessage(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldBindingMessage,
+		})
+		_node.BindingMessage = value
+	}
+	if value, ok := crc.mutation.Expiry(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibarequest.FieldExpiry,
+		})
+		_node.Expiry = value
+	}
+	return _node, _spec
+}
+
+// CIBARequestCreateBulk is the builder for creating many CIBARequest entities in bulk.
+type CIBARequestCreateBulk struct {
+	config
+	builders []*CIBARequestCreate
+}
+
+// Save creates the CIBARequest entities in the database.
+func (crcb *CIBARequestCreateBulk) Save(ctx context.Context) ([]*CIBARequest, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(crcb.builders))
+	nodes := make([]*CIBARequest, len(crcb.builders))
+	mutators := make([]Mutator, len(crcb.builders))
+	for i := range crcb.builders {
+		func(i int, root context.Context) {
+			builder := crcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CIBARequestMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, crcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, crcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{err.Error(), err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, crcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (crcb *CIBARequestCreateBulk) SaveX(ctx context.Context) []*CIBARequest {
+	v, err := crcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (crcb *CIBARequestCreateBulk) Exec(ctx context.Context) error {
+	_, err := crcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (crcb *CIBARequestCreateBulk) ExecX(ctx context.Context) {
+	if err := crcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
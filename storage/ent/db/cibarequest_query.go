@@ -0,0 +1,919 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// CIBARequestQuery is the builder for querying CIBARequest entities.
+type CIBARequestQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.CIBARequest
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the CIBARequestQuery builder.
+func (crq *CIBARequestQuery) Where(ps ...predicate.CIBARequest) *CIBARequestQuery {
+	crq.predicates = append(crq.predicates, ps...)
+	return crq
+}
+
+// Limit adds a limit step to the query.
+func (crq *CIBARequestQuery) Limit(limit int) *CIBARequestQuery {
+	crq.limit = &limit
+	return crq
+}
+
+// Offset adds an offset step to the query.
+func (crq *CIBARequestQuery) Offset(offset int) *CIBARequestQuery {
+	crq.offset = &offset
+	return crq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (crq *CIBARequestQuery) Unique(unique bool) *CIBARequestQuery {
+	crq.unique = &unique
+	return crq
+}
+
+// Order adds an order step to the query.
+func (crq *CIBARequestQuery) Order(o ...OrderFunc) *CIBARequestQuery {
+	crq.order = append(crq.order, o...)
+	return crq
+}
+
+// First returns the first CIBARequest entity from the query.
+// Returns a *NotFoundError when no CIBARequest was found.
+func (crq *CIBARequestQuery) First(ctx context.Context) (*CIBARequest, error) {
+	nodes, err := crq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{cibarequest.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (crq *CIBARequestQuery) FirstX(ctx context.Context) *CIBARequest {
+	node, err := crq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first CIBARequest ID from the query.
+// Returns a *NotFoundError when no CIBARequest ID was found.
+func (crq *CIBARequestQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = crq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{cibarequest.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (crq *CIBARequestQuery) FirstIDX(ctx context.Context) int {
+	id, err := crq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single CIBARequest entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one CIBARequest entity is found.
+// Returns a *NotFoundError when no CIBARequest entities are found.
+func (crq *CIBARequestQuery) Only(ctx context.Context) (*CIBARequest, error) {
+	nodes, err := crq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{cibarequest.Label}
+	default:
+		return nil, &NotSingularError{cibarequest.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (crq *CIBARequestQuery) OnlyX(ctx context.Context) *CIBARequest {
+	node, err := crq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only CIBARequest ID in the query.
+// Returns a *NotSingularError when more than one CIBARequest ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (crq *CIBARequestQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = crq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = &NotSingularError{cibarequest.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (crq *CIBARequestQuery) OnlyIDX(ctx context.Context) int {
+	id, err := crq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of CIBARequests.
+func (crq *CIBARequestQuery) All(ctx context.Context) ([]*CIBARequest, error) {
+	if err := crq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return crq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (crq *CIBARequestQuery) AllX(ctx context.Context) []*CIBARequest {
+	nodes, err := crq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of CIBARequest IDs.
+func (crq *CIBARequestQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := crq.Select(cibarequest.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (crq *CIBARequestQuery) IDsX(ctx context.Context) []int {
+	ids, err := crq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (crq *CIBARequestQuery) Count(ctx context.Context) (int, error) {
+	if err := crq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return crq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (crq *CIBARequestQuery) CountX(ctx context.Context) int {
+	count, err := crq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (crq *CIBARequestQuery) Exist(ctx context.Context) (bool, error) {
+	if err := crq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return crq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (crq *CIBARequestQuery) ExistX(ctx context.Context) bool {
+	exist, err := crq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the CIBARequestQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (crq *CIBARequestQuery) Clone() *CIBARequestQuery {
+	if crq == nil {
+		return nil
+	}
+	return &CIBARequestQuery{
+		config:     crq.config,
+		limit:      crq.limit,
+		offset:     crq.offset,
+		order:      append([]OrderFunc{}, crq.order...),
+		predicates: append([]predicate.CIBARequest{}, crq.predicates...),
+		// clone intermediate query.
+		sql:    crq.sql.Clone(),
+		path:   crq.path,
+		unique: crq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		ApprovalCode string `json:"approval_code,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.CIBARequest.Query().
+//		GroupBy(cibarequest.FieldApprovalCode).
+//		Aggregate(db.Count()).
+//		Scan(ctx, &v)
+func (crq *CIBARequestQuery) GroupBy(field string, fields ...string) *CIBARequestGroupBy {
+	group := &CIBARequestGroupBy{config: crq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := crq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return crq.sqlQuery(ctx), nil
+	}
+	return group
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		ApprovalCode string `json:"approval_code,omitempty"`
+//	}
+//
+//	client.CIBARequest.Query().
+//		Select(cibarequest.FieldApprovalCode).
+//		Scan(ctx, &v)
+func (crq *CIBARequestQuery) Select(fields ...string) *CIBARequestSelect {
+	crq.fields = append(crq.fields, fields...)
+	return &CIBARequestSelect{CIBARequestQuery: crq}
+}
+
+func (crq *CIBARequestQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range crq.fields {
+		if !cibarequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+		}
+	}
+	if crq.path != nil {
+		prev, err := crq.path(ctx)
+		if err != nil {
+			return err
+		}
+		crq.sql = prev
+	}
+	return nil
+}
+
+func (crq *CIBARequestQuery) sqlAll(ctx context.Context) ([]*CIBARequest, error) {
+	var (
+		nodes = []*CIBARequest{}
+		_spec = crq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		node := &CIBARequest{config: crq.config}
+		nodes = append(nodes, node)
+		return node.scanValues(columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("db: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(columns, values)
+	}
+	if err := sqlgraph.QueryNodes(ctx, crq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (crq *CIBARequestQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := crq.querySpec()
+	_spec.Node.Columns = crq.fields
+	if len(crq.fields) > 0 {
+		_spec.Unique = crq.unique != nil && *crq.unique
+	}
+	return sqlgraph.CountNodes(ctx, crq.driver, _spec)
+}
+
+func (crq *CIBARequestQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := crq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("db: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (crq *CIBARequestQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibarequest.Table,
+			Columns: cibarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibarequest.FieldID,
+			},
+		},
+		From:   crq.sql,
+		Unique: true,
+	}
+	if unique := crq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := crq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, cibarequest.FieldID)
+		for i := range fields {
+			if fields[i] != cibarequest.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := crq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := crq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := crq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := crq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (crq *CIBARequestQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(crq.driver.Dialect())
+	t1 := builder.Table(cibarequest.Table)
+	columns := crq.fields
+	if len(columns) == 0 {
+		columns = cibarequest.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if crq.sql != nil {
+		selector = crq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if crq.unique != nil && *crq.unique {
+		selector.Distinct()
+	}
+	for _, p := range crq.predicates {
+		p(selector)
+	}
+	for _, p := range crq.order {
+		p(selector)
+	}
+	if offset := crq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := crq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// CIBARequestGroupBy is the group-by builder for CIBARequest entities.
+type CIBARequestGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (crgb *CIBARequestGroupBy) Aggregate(fns ...AggregateFunc) *CIBARequestGroupBy {
+	crgb.fns = append(crgb.fns, fns...)
+	return crgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (crgb *CIBARequestGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := crgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	crgb.sql = query
+	return crgb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := crgb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(crgb.fields) > 1 {
+		return nil, errors.New("db: CIBARequestGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := crgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) StringsX(ctx context.Context) []string {
+	v, err := crgb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = crgb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) StringX(ctx context.Context) string {
+	v, err := crgb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(crgb.fields) > 1 {
+		return nil, errors.New("db: CIBARequestGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := crgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) IntsX(ctx context.Context) []int {
+	v, err := crgb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = crgb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) IntX(ctx context.Context) int {
+	v, err := crgb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(crgb.fields) > 1 {
+		return nil, errors.New("db: CIBARequestGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := crgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := crgb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = crgb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := crgb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(crgb.fields) > 1 {
+		return nil, errors.New("db: CIBARequestGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := crgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := crgb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (crgb *CIBARequestGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = crgb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (crgb *CIBARequestGroupBy) BoolX(ctx context.Context) bool {
+	v, err := crgb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (crgb *CIBARequestGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range crgb.fields {
+		if !cibarequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := crgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := crgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (crgb *CIBARequestGroupBy) sqlQuery() *sql.Selector {
+	selector := crgb.sql.Select()
+	aggregation := make([]string, 0, len(crgb.fns))
+	for _, fn := range crgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(crgb.fields)+len(crgb.fns))
+		for _, f := range crgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(crgb.fields...)...)
+}
+
+// CIBARequestSelect is the builder for selecting fields of CIBARequest entities.
+type CIBARequestSelect struct {
+	*CIBARequestQuery
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (crs *CIBARequestSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := crs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	crs.sql = crs.CIBARequestQuery.sqlQuery(ctx)
+	return crs.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (crs *CIBARequestSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := crs.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(crs.fields) > 1 {
+		return nil, errors.New("db: CIBARequestSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := crs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (crs *CIBARequestSelect) StringsX(ctx context.Context) []string {
+	v, err := crs.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = crs.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (crs *CIBARequestSelect) StringX(ctx context.Context) string {
+	v, err := crs.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(crs.fields) > 1 {
+		return nil, errors.New("db: CIBARequestSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := crs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (crs *CIBARequestSelect) IntsX(ctx context.Context) []int {
+	v, err := crs.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = crs.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (crs *CIBARequestSelect) IntX(ctx context.Context) int {
+	v, err := crs.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(crs.fields) > 1 {
+		return nil, errors.New("db: CIBARequestSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := crs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (crs *CIBARequestSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := crs.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = crs.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (crs *CIBARequestSelect) Float64X(ctx context.Context) float64 {
+	v, err := crs.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(crs.fields) > 1 {
+		return nil, errors.New("db: CIBARequestSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := crs.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (crs *CIBARequestSelect) BoolsX(ctx context.Context) []bool {
+	v, err := crs.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a selector. It is only allowed when selecting one field.
+func (crs *CIBARequestSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = crs.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibarequest.Label}
+	default:
+		err = fmt.Errorf("db: CIBARequestSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (crs *CIBARequestSelect) BoolX(ctx context.Context) bool {
+	v, err := crs.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (crs *CIBARequestSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := crs.sql.Query()
+	if err := crs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,514 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// CIBARequestUpdate is the builder for updating CIBARequest entities.
+type CIBARequestUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CIBARequestMutation
+}
+
+// Where appends a list predicates to the CIBARequestUpdate builder.
+func (cru *CIBARequestUpdate) Where(ps ...predicate.CIBARequest) *CIBARequestUpdate {
+	cru.mutation.Where(ps...)
+	return cru
+}
+
+// SetApprovalCode sets the "approval_code" field.
+func (cru *CIBARequestUpdate) SetApprovalCode(s string) *CIBARequestUpdate {
+	cru.mutation.SetApprovalCode(s)
+	return cru
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (cru *CIBARequestUpdate) SetAuthReqID(s string) *CIBARequestUpdate {
+	cru.mutation.SetAuthReqID(s)
+	return cru
+}
+
+// SetClientID sets the "client_id" field.
+func (cru *CIBARequestUpdate) SetClientID(s string) *CIBARequestUpdate {
+	cru.mutation.SetClientID(s)
+	return cru
+}
+
+// SetScopes sets the "scopes" field.
+func (cru *CIBARequestUpdate) SetScopes(s []string) *CIBARequestUpdate {
+	cru.mutation.SetScopes(s)
+	return cru
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (cru *CIBARequestUpdate) ClearScopes() *CIBARequestUpdate {
+	cru.mutation.ClearScopes()
+	return cru
+}
+
+// SetLoginHint sets the "login_hint" field.
+func (cru *CIBARequestUpdate) SetLoginHint(s string) *CIBARequestUpdate {
+	cru.mutation.SetLoginHint(s)
+	return cru
+}
+
+// SetBindingMessage sets the "binding_message" field.
+func (cru *CIBARequestUpdate) SetBindingMessage(s string) *CIBARequestUpdate {
+	cru.mutation.SetBindingMessage(s)
+	return cru
+}
+
+// SetNillableBindingMessage sets the "binding_message" field if the given value is not nil.
+func (cru *CIBARequestUpdate) SetNillableBindingMessage(s *string) *CIBARequestUpdate {
+	if s != nil {
+		cru.SetBindingMessage(*s)
+	}
+	return cru
+}
+
+// SetExpiry sets the "expiry" field.
+func (cru *CIBARequestUpdate) SetExpiry(t time.Time) *CIBARequestUpdate {
+	cru.mutation.SetExpiry(t)
+	return cru
+}
+
+// Mutation returns the CIBARequestMutation object of the builder.
+func (cru *CIBARequestUpdate) Mutation() *CIBARequestMutation {
+	return cru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cru *CIBARequestUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(cru.hooks) == 0 {
+		if err = cru.check(); err != nil {
+			return 0, err
+		}
+		affected, err = cru.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBARequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = cru.check(); err != nil {
+				return 0, err
+			}
+			cru.mutation = mutation
+			affected, err = cru.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(cru.hooks) - 1; i >= 0; i-- {
+			if cru.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = cru.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, cru.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cru *CIBARequestUpdate) SaveX(ctx context.Context) int {
+	affected, err := cru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cru *CIBARequestUpdate) Exec(ctx context.Context) error {
+	_, err := cru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cru *CIBARequestUpdate) ExecX(ctx context.Context) {
+	if err := cru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cru *CIBARequestUpdate) check() error {
+	if v, ok := cru.mutation.ApprovalCode(); ok {
+		if err := cibarequest.ApprovalCodeValidator(v); err != nil {
+			return &ValidationError{Name: "approval_code", err: fmt.Errorf(`db: validator failed for field "CIBARequest.approval_code": %w`, err)}
+		}
+	}
+	if v, ok := cru.mutation.AuthReqID(); ok {
+		if err := cibarequest.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.auth_req_id": %w`, err)}
+		}
+	}
+	if v, ok := cru.mutation.ClientID(); ok {
+		if err := cibarequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cru *CIBARequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibarequest.Table,
+			Columns: cibarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibarequest.FieldID,
+			},
+		},
+	}
+	if ps := cru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cru.mutation.ApprovalCode(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldApprovalCode,
+		})
+	}
+	if value, ok := cru.mutation.AuthReqID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldAuthReqID,
+		})
+	}
+	if value, ok := cru.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldClientID,
+		})
+	}
+	if value, ok := cru.mutation.Scopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: cibarequest.FieldScopes,
+		})
+	}
+	if cru.mutation.ScopesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: cibarequest.FieldScopes,
+		})
+	}
+	if value, ok := cru.mutation.LoginHint(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldLoginHint,
+		})
+	}
+	if value, ok := cru.mutation.BindingMessage(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldBindingMessage,
+		})
+	}
+	if value, ok := cru.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibarequest.FieldExpiry,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{cibarequest.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// CIBARequestUpdateOne is the builder for updating a single CIBARequest entity.
+type CIBARequestUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CIBARequestMutation
+}
+
+// SetApprovalCode sets the "approval_code" field.
+func (cruo *CIBARequestUpdateOne) SetApprovalCode(s string) *CIBARequestUpdateOne {
+	cruo.mutation.SetApprovalCode(s)
+	return cruo
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (cruo *CIBARequestUpdateOne) SetAuthReqID(s string) *CIBARequestUpdateOne {
+	cruo.mutation.SetAuthReqID(s)
+	return cruo
+}
+
+// SetClientID sets the "client_id" field.
+func (cruo *CIBARequestUpdateOne) SetClientID(s string) *CIBARequestUpdateOne {
+	cruo.mutation.SetClientID(s)
+	return cruo
+}
+
+// SetScopes sets the "scopes" field.
+func (cruo *CIBARequestUpdateOne) SetScopes(s []string) *CIBARequestUpdateOne {
+	cruo.mutation.SetScopes(s)
+	return cruo
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (cruo *CIBARequestUpdateOne) ClearScopes() *CIBARequestUpdateOne {
+	cruo.mutation.ClearScopes()
+	return cruo
+}
+
+// SetLoginHint sets the "login_hint" field.
+func (cruo *CIBARequestUpdateOne) SetLoginHint(s string) *CIBARequestUpdateOne {
+	cruo.mutation.SetLoginHint(s)
+	return cruo
+}
+
+// SetBindingMessage sets the "binding_message" field.
+func (cruo *CIBARequestUpdateOne) SetBindingMessage(s string) *CIBARequestUpdateOne {
+	cruo.mutation.SetBindingMessage(s)
+	return cruo
+}
+
+// SetNillableBindingMessage sets the "binding_message" field if the given value is not nil.
+func (cruo *CIBARequestUpdateOne) SetNillableBindingMessage(s *string) *CIBARequestUpdateOne {
+	if s != nil {
+		cruo.SetBindingMessage(*s)
+	}
+	return cruo
+}
+
+// SetExpiry sets the "expiry" field.
+func (cruo *CIBARequestUpdateOne) SetExpiry(t time.Time) *CIBARequestUpdateOne {
+	cruo.mutation.SetExpiry(t)
+	return cruo
+}
+
+// Mutation returns the CIBARequestMutation object of the builder.
+func (cruo *CIBARequestUpdateOne) Mutation() *CIBARequestMutation {
+	return cruo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cruo *CIBARequestUpdateOne) Select(field string, fields ...string) *CIBARequestUpdateOne {
+	cruo.fields = append([]string{field}, fields...)
+	return cruo
+}
+
+// Save executes the query and returns the updated CIBARequest entity.
+func (cruo *CIBARequestUpdateOne) Save(ctx context.Context) (*CIBARequest, error) {
+	var (
+		err  error
+		node *CIBARequest
+	)
+	if len(cruo.hooks) == 0 {
+		if err = cruo.check(); err != nil {
+			return nil, err
+		}
+		node, err = cruo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBARequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = cruo.check(); err != nil {
+				return nil, err
+			}
+			cruo.mutation = mutation
+			node, err = cruo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(cruo.hooks) - 1; i >= 0; i-- {
+			if cruo.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = cruo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, cruo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cruo *CIBARequestUpdateOne) SaveX(ctx context.Context) *CIBARequest {
+	node, err := cruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cruo *CIBARequestUpdateOne) Exec(ctx context.Context) error {
+	_, err := cruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cruo *CIBARequestUpdateOne) ExecX(ctx context.Context) {
+	if err := cruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cruo *CIBARequestUpdateOne) check() error {
+	if v, ok := cruo.mutation.ApprovalCode(); ok {
+		if err := cibarequest.ApprovalCodeValidator(v); err != nil {
+			return &ValidationError{Name: "approval_code", err: fmt.Errorf(`db: validator failed for field "CIBARequest.approval_code": %w`, err)}
+		}
+	}
+	if v, ok := cruo.mutation.AuthReqID(); ok {
+		if err := cibarequest.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.auth_req_id": %w`, err)}
+		}
+	}
+	if v, ok := cruo.mutation.ClientID(); ok {
+		if err := cibarequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBARequest.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cruo *CIBARequestUpdateOne) sqlSave(ctx context.Context) (_node *CIBARequest, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibarequest.Table,
+			Columns: cibarequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibarequest.FieldID,
+			},
+		},
+	}
+	id, ok := cruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`db: missing "CIBARequest.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, cibarequest.FieldID)
+		for _, f := range fields {
+			if !cibarequest.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+			}
+			if f != cibarequest.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cruo.mutation.ApprovalCode(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldApprovalCode,
+		})
+	}
+	if value, ok := cruo.mutation.AuthReqID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldAuthReqID,
+		})
+	}
+	if value, ok := cruo.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldClientID,
+		})
+	}
+	if value, ok := cruo.mutation.Scopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: cibarequest.FieldScopes,
+		})
+	}
+	if cruo.mutation.ScopesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: cibarequest.FieldScopes,
+		})
+	}
+	if value, ok := cruo.mutation.LoginHint(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldLoginHint,
+		})
+	}
+	if value, ok := cruo.mutation.BindingMessage(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibarequest.FieldBindingMessage,
+		})
+	}
+	if value, ok := cruo.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibarequest.FieldExpiry,
+		})
+	}
+	_node = &CIBARequest{config: cruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{cibarequest.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
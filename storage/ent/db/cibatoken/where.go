@@ -0,0 +1,943 @@
+// Code generated by entc, DO NOT EDIT.
+
+package cibatoken
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// AuthReqID applies equality check predicate on the "auth_req_id" field. It's identical to AuthReqIDEQ.
+func AuthReqID(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// ClientID applies equality check predicate on the "client_id" field. It's identical to ClientIDEQ.
+func ClientID(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldStatus), v))
+	})
+}
+
+// Token applies equality check predicate on the "token" field. It's identical to TokenEQ.
+func Token(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldToken), v))
+	})
+}
+
+// NotificationToken applies equality check predicate on the "notification_token" field. It's identical to NotificationTokenEQ.
+func NotificationToken(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldNotificationToken), v))
+	})
+}
+
+// Expiry applies equality check predicate on the "expiry" field. It's identical to ExpiryEQ.
+func Expiry(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExpiry), v))
+	})
+}
+
+// LastRequest applies equality check predicate on the "last_request" field. It's identical to LastRequestEQ.
+func LastRequest(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastRequest), v))
+	})
+}
+
+// PollInterval applies equality check predicate on the "poll_interval" field. It's identical to PollIntervalEQ.
+func PollInterval(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPollInterval), v))
+	})
+}
+
+// AuthReqIDEQ applies the EQ predicate on the "auth_req_id" field.
+func AuthReqIDEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDNEQ applies the NEQ predicate on the "auth_req_id" field.
+func AuthReqIDNEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDIn applies the In predicate on the "auth_req_id" field.
+func AuthReqIDIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAuthReqID), v...))
+	})
+}
+
+// AuthReqIDNotIn applies the NotIn predicate on the "auth_req_id" field.
+func AuthReqIDNotIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAuthReqID), v...))
+	})
+}
+
+// AuthReqIDGT applies the GT predicate on the "auth_req_id" field.
+func AuthReqIDGT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDGTE applies the GTE predicate on the "auth_req_id" field.
+func AuthReqIDGTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDLT applies the LT predicate on the "auth_req_id" field.
+func AuthReqIDLT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDLTE applies the LTE predicate on the "auth_req_id" field.
+func AuthReqIDLTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDContains applies the Contains predicate on the "auth_req_id" field.
+func AuthReqIDContains(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDHasPrefix applies the HasPrefix predicate on the "auth_req_id" field.
+func AuthReqIDHasPrefix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDHasSuffix applies the HasSuffix predicate on the "auth_req_id" field.
+func AuthReqIDHasSuffix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDEqualFold applies the EqualFold predicate on the "auth_req_id" field.
+func AuthReqIDEqualFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAuthReqID), v))
+	})
+}
+
+// AuthReqIDContainsFold applies the ContainsFold predicate on the "auth_req_id" field.
+func AuthReqIDContainsFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAuthReqID), v))
+	})
+}
+
+// ClientIDEQ applies the EQ predicate on the "client_id" field.
+func ClientIDEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDNEQ applies the NEQ predicate on the "client_id" field.
+func ClientIDNEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDIn applies the In predicate on the "client_id" field.
+func ClientIDIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDNotIn applies the NotIn predicate on the "client_id" field.
+func ClientIDNotIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClientID), v...))
+	})
+}
+
+// ClientIDGT applies the GT predicate on the "client_id" field.
+func ClientIDGT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDGTE applies the GTE predicate on the "client_id" field.
+func ClientIDGTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLT applies the LT predicate on the "client_id" field.
+func ClientIDLT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDLTE applies the LTE predicate on the "client_id" field.
+func ClientIDLTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContains applies the Contains predicate on the "client_id" field.
+func ClientIDContains(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasPrefix applies the HasPrefix predicate on the "client_id" field.
+func ClientIDHasPrefix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDHasSuffix applies the HasSuffix predicate on the "client_id" field.
+func ClientIDHasSuffix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDEqualFold applies the EqualFold predicate on the "client_id" field.
+func ClientIDEqualFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldClientID), v))
+	})
+}
+
+// ClientIDContainsFold applies the ContainsFold predicate on the "client_id" field.
+func ClientIDContainsFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldClientID), v))
+	})
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldStatus), v))
+	})
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldStatus), v))
+	})
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldStatus), v...))
+	})
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldStatus), v...))
+	})
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldStatus), v))
+	})
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldStatus), v))
+	})
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldStatus), v))
+	})
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldStatus), v))
+	})
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldStatus), v))
+	})
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldStatus), v))
+	})
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldStatus), v))
+	})
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldStatus), v))
+	})
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldStatus), v))
+	})
+}
+
+// TokenEQ applies the EQ predicate on the "token" field.
+func TokenEQ(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldToken), v))
+	})
+}
+
+// TokenNEQ applies the NEQ predicate on the "token" field.
+func TokenNEQ(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldToken), v))
+	})
+}
+
+// TokenIn applies the In predicate on the "token" field.
+func TokenIn(vs ...[]byte) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldToken), v...))
+	})
+}
+
+// TokenNotIn applies the NotIn predicate on the "token" field.
+func TokenNotIn(vs ...[]byte) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldToken), v...))
+	})
+}
+
+// TokenGT applies the GT predicate on the "token" field.
+func TokenGT(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldToken), v))
+	})
+}
+
+// TokenGTE applies the GTE predicate on the "token" field.
+func TokenGTE(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldToken), v))
+	})
+}
+
+// TokenLT applies the LT predicate on the "token" field.
+func TokenLT(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldToken), v))
+	})
+}
+
+// TokenLTE applies the LTE predicate on the "token" field.
+func TokenLTE(v []byte) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldToken), v))
+	})
+}
+
+// TokenIsNil applies the IsNil predicate on the "token" field.
+func TokenIsNil() predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldToken)))
+	})
+}
+
+// TokenNotNil applies the NotNil predicate on the "token" field.
+func TokenNotNil() predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldToken)))
+	})
+}
+
+// NotificationTokenEQ applies the EQ predicate on the "notification_token" field.
+func NotificationTokenEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenNEQ applies the NEQ predicate on the "notification_token" field.
+func NotificationTokenNEQ(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenIn applies the In predicate on the "notification_token" field.
+func NotificationTokenIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldNotificationToken), v...))
+	})
+}
+
+// NotificationTokenNotIn applies the NotIn predicate on the "notification_token" field.
+func NotificationTokenNotIn(vs ...string) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldNotificationToken), v...))
+	})
+}
+
+// NotificationTokenGT applies the GT predicate on the "notification_token" field.
+func NotificationTokenGT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenGTE applies the GTE predicate on the "notification_token" field.
+func NotificationTokenGTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenLT applies the LT predicate on the "notification_token" field.
+func NotificationTokenLT(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenLTE applies the LTE predicate on the "notification_token" field.
+func NotificationTokenLTE(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenContains applies the Contains predicate on the "notification_token" field.
+func NotificationTokenContains(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenHasPrefix applies the HasPrefix predicate on the "notification_token" field.
+func NotificationTokenHasPrefix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenHasSuffix applies the HasSuffix predicate on the "notification_token" field.
+func NotificationTokenHasSuffix(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenEqualFold applies the EqualFold predicate on the "notification_token" field.
+func NotificationTokenEqualFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldNotificationToken), v))
+	})
+}
+
+// NotificationTokenContainsFold applies the ContainsFold predicate on the "notification_token" field.
+func NotificationTokenContainsFold(v string) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldNotificationToken), v))
+	})
+}
+
+// ExpiryEQ applies the EQ predicate on the "expiry" field.
+func ExpiryEQ(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryNEQ applies the NEQ predicate on the "expiry" field.
+func ExpiryNEQ(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryIn applies the In predicate on the "expiry" field.
+func ExpiryIn(vs ...time.Time) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldExpiry), v...))
+	})
+}
+
+// ExpiryNotIn applies the NotIn predicate on the "expiry" field.
+func ExpiryNotIn(vs ...time.Time) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldExpiry), v...))
+	})
+}
+
+// ExpiryGT applies the GT predicate on the "expiry" field.
+func ExpiryGT(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryGTE applies the GTE predicate on the "expiry" field.
+func ExpiryGTE(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryLT applies the LT predicate on the "expiry" field.
+func ExpiryLT(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldExpiry), v))
+	})
+}
+
+// ExpiryLTE applies the LTE predicate on the "expiry" field.
+func ExpiryLTE(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldExpiry), v))
+	})
+}
+
+// LastRequestEQ applies the EQ predicate on the "last_request" field.
+func LastRequestEQ(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastRequest), v))
+	})
+}
+
+// LastRequestNEQ applies the NEQ predicate on the "last_request" field.
+func LastRequestNEQ(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastRequest), v))
+	})
+}
+
+// LastRequestIn applies the In predicate on the "last_request" field.
+func LastRequestIn(vs ...time.Time) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldLastRequest), v...))
+	})
+}
+
+// LastRequestNotIn applies the NotIn predicate on the "last_request" field.
+func LastRequestNotIn(vs ...time.Time) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldLastRequest), v...))
+	})
+}
+
+// LastRequestGT applies the GT predicate on the "last_request" field.
+func LastRequestGT(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastRequest), v))
+	})
+}
+
+// LastRequestGTE applies the GTE predicate on the "last_request" field.
+func LastRequestGTE(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastRequest), v))
+	})
+}
+
+// LastRequestLT applies the LT predicate on the "last_request" field.
+func LastRequestLT(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastRequest), v))
+	})
+}
+
+// LastRequestLTE applies the LTE predicate on the "last_request" field.
+func LastRequestLTE(v time.Time) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastRequest), v))
+	})
+}
+
+// PollIntervalEQ applies the EQ predicate on the "poll_interval" field.
+func PollIntervalEQ(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPollInterval), v))
+	})
+}
+
+// PollIntervalNEQ applies the NEQ predicate on the "poll_interval" field.
+func PollIntervalNEQ(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPollInterval), v))
+	})
+}
+
+// PollIntervalIn applies the In predicate on the "poll_interval" field.
+func PollIntervalIn(vs ...int) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPollInterval), v...))
+	})
+}
+
+// PollIntervalNotIn applies the NotIn predicate on the "poll_interval" field.
+func PollIntervalNotIn(vs ...int) predicate.CIBAToken {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPollInterval), v...))
+	})
+}
+
+// PollIntervalGT applies the GT predicate on the "poll_interval" field.
+func PollIntervalGT(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPollInterval), v))
+	})
+}
+
+// PollIntervalGTE applies the GTE predicate on the "poll_interval" field.
+func PollIntervalGTE(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPollInterval), v))
+	})
+}
+
+// PollIntervalLT applies the LT predicate on the "poll_interval" field.
+func PollIntervalLT(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPollInterval), v))
+	})
+}
+
+// PollIntervalLTE applies the LTE predicate on the "poll_interval" field.
+func PollIntervalLTE(v int) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPollInterval), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.CIBAToken) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.CIBAToken) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.CIBAToken) predicate.CIBAToken {
+	return predicate.CIBAToken(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,370 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
+)
+
+// CIBATokenCreate is the builder for creating a CIBAToken entity.
+type CIBATokenCreate struct {
+	config
+	mutation *CIBATokenMutation
+	hooks    []Hook
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (ctc *CIBATokenCreate) SetAuthReqID(s string) *CIBATokenCreate {
+	ctc.mutation.SetAuthReqID(s)
+	return ctc
+}
+
+// SetClientID sets the "client_id" field.
+func (ctc *CIBATokenCreate) SetClientID(s string) *CIBATokenCreate {
+	ctc.mutation.SetClientID(s)
+	return ctc
+}
+
+// SetStatus sets the "status" field.
+func (ctc *CIBATokenCreate) SetStatus(s string) *CIBATokenCreate {
+	ctc.mutation.SetStatus(s)
+	return ctc
+}
+
+// SetToken sets the "token" field.
+func (ctc *CIBATokenCreate) SetToken(b []byte) *CIBATokenCreate {
+	ctc.mutation.SetToken(b)
+	return ctc
+}
+
+// SetNotificationToken sets the "notification_token" field.
+func (ctc *CIBATokenCreate) SetNotificationToken(s string) *CIBATokenCreate {
+	ctc.mutation.SetNotificationToken(s)
+	return ctc
+}
+
+// SetNillableNotificationToken sets the "notification_token" field if the given value is not nil.
+func (ctc *CIBATokenCreate) SetNillableNotificationToken(s *string) *CIBATokenCreate {
+	if s != nil {
+		ctc.SetNotificationToken(*s)
+	}
+	return ctc
+}
+
+// SetExpiry sets the "expiry" field.
+func (ctc *CIBATokenCreate) SetExpiry(t time.Time) *CIBATokenCreate {
+	ctc.mutation.SetExpiry(t)
+	return ctc
+}
+
+// SetLastRequest sets the "last_request" field.
+func (ctc *CIBATokenCreate) SetLastRequest(t time.Time) *CIBATokenCreate {
+	ctc.mutation.SetLastRequest(t)
+	return ctc
+}
+
+// SetPollInterval sets the "poll_interval" field.
+func (ctc *CIBATokenCreate) SetPollInterval(i int) *CIBATokenCreate {
+	ctc.mutation.SetPollInterval(i)
+	return ctc
+}
+
+// Mutation returns the CIBATokenMutation object of the builder.
+func (ctc *CIBATokenCreate) Mutation() *CIBATokenMutation {
+	return ctc.mutation
+}
+
+// Save creates the CIBAToken in the database.
+func (ctc *CIBATokenCreate) Save(ctx context.Context) (*CIBAToken, error) {
+	var (
+		err  error
+		node *CIBAToken
+	)
+	ctc.defaults()
+	if len(ctc.hooks) == 0 {
+		if err = ctc.check(); err != nil {
+			return nil, err
+		}
+		node, err = ctc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBATokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ctc.check(); err != nil {
+				return nil, err
+			}
+			ctc.mutation = mutation
+			if node, err = ctc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ctc.hooks) - 1; i >= 0; i-- {
+			if ctc.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = ctc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ctc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ctc *CIBATokenCreate) SaveX(ctx context.Context) *CIBAToken {
+	v, err := ctc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ctc *CIBATokenCreate) Exec(ctx context.Context) error {
+	_, err := ctc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctc *CIBATokenCreate) ExecX(ctx context.Context) {
+	if err := ctc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ctc *CIBATokenCreate) defaults() {
+	if _, ok := ctc.mutation.NotificationToken(); !ok {
+		v := cibatoken.DefaultNotificationToken
+		ctc.mutation.SetNotificationToken(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctc *CIBATokenCreate) check() error {
+	if _, ok := ctc.mutation.AuthReqID(); !ok {
+		return &ValidationError{Name: "auth_req_id", err: errors.New(`db: missing required field "CIBAToken.auth_req_id"`)}
+	}
+	if v, ok := ctc.mutation.AuthReqID(); ok {
+		if err := cibatoken.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.auth_req_id": %w`, err)}
+		}
+	}
+	if _, ok := ctc.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`db: missing required field "CIBAToken.client_id"`)}
+	}
+	if v, ok := ctc.mutation.ClientID(); ok {
+		if err := cibatoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.client_id": %w`, err)}
+		}
+	}
+	if _, ok := ctc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`db: missing required field "CIBAToken.status"`)}
+	}
+	if v, ok := ctc.mutation.Status(); ok {
+		if err := cibatoken.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`db: validator failed for field "CIBAToken.status": %w`, err)}
+		}
+	}
+	if _, ok := ctc.mutation.NotificationToken(); !ok {
+		return &ValidationError{Name: "notification_token", err: errors.New(`db: missing required field "CIBAToken.notification_token"`)}
+	}
+	if _, ok := ctc.mutation.Expiry(); !ok {
+		return &ValidationError{Name: "expiry", err: errors.New(`db: missing required field "CIBAToken.expiry"`)}
+	}
+	if _, ok := ctc.mutation.LastRequest(); !ok {
+		return &ValidationError{Name: "last_request", err: errors.New(`db: missing required field "CIBAToken.last_request"`)}
+	}
+	if _, ok := ctc.mutation.PollInterval(); !ok {
+		return &ValidationError{Name: "poll_interval", err: errors.New(`db: missing required field "CIBAToken.poll_interval"`)}
+	}
+	return nil
+}
+
+func (ctc *CIBATokenCreate) sqlSave(ctx context.Context) (*CIBAToken, error) {
+	_node, _spec := ctc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ctc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (ctc *CIBATokenCreate) createSpec() (*CIBAToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CIBAToken{config: ctc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: cibatoken.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibatoken.FieldID,
+			},
+		}
+	)
+	if value, ok := ctc.mutation.AuthReqID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldAuthReqID,
+		})
+		_node.AuthReqID = value
+	}
+	if value, ok := ctc.mutation.ClientID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldClientID,
+		})
+		_node.ClientID = value
+	}
+	if value, ok := ctc.mutation.Status(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldStatus,
+		})
+		_node.Status = value
+	}
+	if value, ok := ctc.mutation.Token(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: cibatoken.FieldToken,
+		})
+		_node.Token = &value
+	}
+	if value, ok := ctc.mutation.NotificationToken(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldNotificationToken,
+		})
+		_node.NotificationToken = value
+	}
+	if value, ok := ctc.mutation.Expiry(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldExpiry,
+		})
+		_node.Expiry = value
+	}
+	if value, ok := ctc.mutation.LastRequest(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldLastRequest,
+		})
+		_node.LastRequest = value
+	}
+	if value, ok := ctc.mutation.PollInterval(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: cibatoken.FieldPollInterval,
+		})
+		_node.PollInterval = value
+	}
+	return _node, _spec
+}
+
+// CIBATokenCreateBulk is the builder for creating many CIBAToken entities in bulk.
+type CIBATokenCreateBulk struct {
+	config
+	builders []*CIBATokenCreate
+}
+
+// Save creates the CIBAToken entities in the database.
+func (ctcb *CIBATokenCreateBulk) Save(ctx context.Context) ([]*CIBAToken, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(ctcb.builders))
+	nodes := make([]*CIBAToken, len(ctcb.builders))
+	mutators := make([]Mutator, len(ctcb.builders))
+	for i := range ctcb.builders {
+		func(i int, root context.Context) {
+			builder := ctcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CIBATokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ctcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ctcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{err.Error(), err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ctcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctcb *CIBATokenCreateBulk) SaveX(ctx context.Context) []*CIBAToken {
+	v, err := ctcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ctcb *CIBATokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := ctcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctcb *CIBATokenCreateBulk) ExecX(ctx context.Context) {
+	if err := ctcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
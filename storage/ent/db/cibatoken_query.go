@@ -0,0 +1,919 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// CIBATokenQuery is the builder for querying CIBAToken entities.
+type CIBATokenQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.CIBAToken
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the CIBATokenQuery builder.
+func (ctq *CIBATokenQuery) Where(ps ...predicate.CIBAToken) *CIBATokenQuery {
+	ctq.predicates = append(ctq.predicates, ps...)
+	return ctq
+}
+
+// Limit adds a limit step to the query.
+func (ctq *CIBATokenQuery) Limit(limit int) *CIBATokenQuery {
+	ctq.limit = &limit
+	return ctq
+}
+
+// Offset adds an offset step to the query.
+func (ctq *CIBATokenQuery) Offset(offset int) *CIBATokenQuery {
+	ctq.offset = &offset
+	return ctq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (ctq *CIBATokenQuery) Unique(unique bool) *CIBATokenQuery {
+	ctq.unique = &unique
+	return ctq
+}
+
+// Order adds an order step to the query.
+func (ctq *CIBATokenQuery) Order(o ...OrderFunc) *CIBATokenQuery {
+	ctq.order = append(ctq.order, o...)
+	return ctq
+}
+
+// First returns the first CIBAToken entity from the query.
+// Returns a *NotFoundError when no CIBAToken was found.
+func (ctq *CIBATokenQuery) First(ctx context.Context) (*CIBAToken, error) {
+	nodes, err := ctq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{cibatoken.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (ctq *CIBATokenQuery) FirstX(ctx context.Context) *CIBAToken {
+	node, err := ctq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first CIBAToken ID from the query.
+// Returns a *NotFoundError when no CIBAToken ID was found.
+func (ctq *CIBATokenQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ctq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{cibatoken.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (ctq *CIBATokenQuery) FirstIDX(ctx context.Context) int {
+	id, err := ctq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single CIBAToken entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one CIBAToken entity is found.
+// Returns a *NotFoundError when no CIBAToken entities are found.
+func (ctq *CIBATokenQuery) Only(ctx context.Context) (*CIBAToken, error) {
+	nodes, err := ctq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{cibatoken.Label}
+	default:
+		return nil, &NotSingularError{cibatoken.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (ctq *CIBATokenQuery) OnlyX(ctx context.Context) *CIBAToken {
+	node, err := ctq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only CIBAToken ID in the query.
+// Returns a *NotSingularError when more than one CIBAToken ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (ctq *CIBATokenQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ctq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = &NotSingularError{cibatoken.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (ctq *CIBATokenQuery) OnlyIDX(ctx context.Context) int {
+	id, err := ctq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of CIBATokens.
+func (ctq *CIBATokenQuery) All(ctx context.Context) ([]*CIBAToken, error) {
+	if err := ctq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return ctq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (ctq *CIBATokenQuery) AllX(ctx context.Context) []*CIBAToken {
+	nodes, err := ctq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of CIBAToken IDs.
+func (ctq *CIBATokenQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := ctq.Select(cibatoken.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (ctq *CIBATokenQuery) IDsX(ctx context.Context) []int {
+	ids, err := ctq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (ctq *CIBATokenQuery) Count(ctx context.Context) (int, error) {
+	if err := ctq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return ctq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (ctq *CIBATokenQuery) CountX(ctx context.Context) int {
+	count, err := ctq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (ctq *CIBATokenQuery) Exist(ctx context.Context) (bool, error) {
+	if err := ctq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return ctq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (ctq *CIBATokenQuery) ExistX(ctx context.Context) bool {
+	exist, err := ctq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the CIBATokenQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (ctq *CIBATokenQuery) Clone() *CIBATokenQuery {
+	if ctq == nil {
+		return nil
+	}
+	return &CIBATokenQuery{
+		config:     ctq.config,
+		limit:      ctq.limit,
+		offset:     ctq.offset,
+		order:      append([]OrderFunc{}, ctq.order...),
+		predicates: append([]predicate.CIBAToken{}, ctq.predicates...),
+		// clone intermediate query.
+		sql:    ctq.sql.Clone(),
+		path:   ctq.path,
+		unique: ctq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		AuthReqID string `json:"auth_req_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.CIBAToken.Query().
+//		GroupBy(cibatoken.FieldAuthReqID).
+//		Aggregate(db.Count()).
+//		Scan(ctx, &v)
+func (ctq *CIBATokenQuery) GroupBy(field string, fields ...string) *CIBATokenGroupBy {
+	group := &CIBATokenGroupBy{config: ctq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := ctq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return ctq.sqlQuery(ctx), nil
+	}
+	return group
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		AuthReqID string `json:"auth_req_id,omitempty"`
+//	}
+//
+//	client.CIBAToken.Query().
+//		Select(cibatoken.FieldAuthReqID).
+//		Scan(ctx, &v)
+func (ctq *CIBATokenQuery) Select(fields ...string) *CIBATokenSelect {
+	ctq.fields = append(ctq.fields, fields...)
+	return &CIBATokenSelect{CIBATokenQuery: ctq}
+}
+
+func (ctq *CIBATokenQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range ctq.fields {
+		if !cibatoken.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+		}
+	}
+	if ctq.path != nil {
+		prev, err := ctq.path(ctx)
+		if err != nil {
+			return err
+		}
+		ctq.sql = prev
+	}
+	return nil
+}
+
+func (ctq *CIBATokenQuery) sqlAll(ctx context.Context) ([]*CIBAToken, error) {
+	var (
+		nodes = []*CIBAToken{}
+		_spec = ctq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		node := &CIBAToken{config: ctq.config}
+		nodes = append(nodes, node)
+		return node.scanValues(columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("db: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(columns, values)
+	}
+	if err := sqlgraph.QueryNodes(ctx, ctq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (ctq *CIBATokenQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := ctq.querySpec()
+	_spec.Node.Columns = ctq.fields
+	if len(ctq.fields) > 0 {
+		_spec.Unique = ctq.unique != nil && *ctq.unique
+	}
+	return sqlgraph.CountNodes(ctx, ctq.driver, _spec)
+}
+
+func (ctq *CIBATokenQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := ctq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("db: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (ctq *CIBATokenQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibatoken.Table,
+			Columns: cibatoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibatoken.FieldID,
+			},
+		},
+		From:   ctq.sql,
+		Unique: true,
+	}
+	if unique := ctq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := ctq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, cibatoken.FieldID)
+		for i := range fields {
+			if fields[i] != cibatoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := ctq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := ctq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := ctq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := ctq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (ctq *CIBATokenQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(ctq.driver.Dialect())
+	t1 := builder.Table(cibatoken.Table)
+	columns := ctq.fields
+	if len(columns) == 0 {
+		columns = cibatoken.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if ctq.sql != nil {
+		selector = ctq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if ctq.unique != nil && *ctq.unique {
+		selector.Distinct()
+	}
+	for _, p := range ctq.predicates {
+		p(selector)
+	}
+	for _, p := range ctq.order {
+		p(selector)
+	}
+	if offset := ctq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := ctq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// CIBATokenGroupBy is the group-by builder for CIBAToken entities.
+type CIBATokenGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (ctgb *CIBATokenGroupBy) Aggregate(fns ...AggregateFunc) *CIBATokenGroupBy {
+	ctgb.fns = append(ctgb.fns, fns...)
+	return ctgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (ctgb *CIBATokenGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := ctgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	ctgb.sql = query
+	return ctgb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := ctgb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(ctgb.fields) > 1 {
+		return nil, errors.New("db: CIBATokenGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := ctgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) StringsX(ctx context.Context) []string {
+	v, err := ctgb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = ctgb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) StringX(ctx context.Context) string {
+	v, err := ctgb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(ctgb.fields) > 1 {
+		return nil, errors.New("db: CIBATokenGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := ctgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) IntsX(ctx context.Context) []int {
+	v, err := ctgb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = ctgb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) IntX(ctx context.Context) int {
+	v, err := ctgb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(ctgb.fields) > 1 {
+		return nil, errors.New("db: CIBATokenGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := ctgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := ctgb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = ctgb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := ctgb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(ctgb.fields) > 1 {
+		return nil, errors.New("db: CIBATokenGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := ctgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := ctgb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (ctgb *CIBATokenGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = ctgb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (ctgb *CIBATokenGroupBy) BoolX(ctx context.Context) bool {
+	v, err := ctgb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (ctgb *CIBATokenGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range ctgb.fields {
+		if !cibatoken.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := ctgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ctgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (ctgb *CIBATokenGroupBy) sqlQuery() *sql.Selector {
+	selector := ctgb.sql.Select()
+	aggregation := make([]string, 0, len(ctgb.fns))
+	for _, fn := range ctgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(ctgb.fields)+len(ctgb.fns))
+		for _, f := range ctgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(ctgb.fields...)...)
+}
+
+// CIBATokenSelect is the builder for selecting fields of CIBAToken entities.
+type CIBATokenSelect struct {
+	*CIBATokenQuery
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cts *CIBATokenSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := cts.prepareQuery(ctx); err != nil {
+		return err
+	}
+	cts.sql = cts.CIBATokenQuery.sqlQuery(ctx)
+	return cts.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (cts *CIBATokenSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := cts.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(cts.fields) > 1 {
+		return nil, errors.New("db: CIBATokenSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := cts.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (cts *CIBATokenSelect) StringsX(ctx context.Context) []string {
+	v, err := cts.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = cts.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (cts *CIBATokenSelect) StringX(ctx context.Context) string {
+	v, err := cts.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(cts.fields) > 1 {
+		return nil, errors.New("db: CIBATokenSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := cts.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (cts *CIBATokenSelect) IntsX(ctx context.Context) []int {
+	v, err := cts.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = cts.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (cts *CIBATokenSelect) IntX(ctx context.Context) int {
+	v, err := cts.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(cts.fields) > 1 {
+		return nil, errors.New("db: CIBATokenSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := cts.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (cts *CIBATokenSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := cts.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = cts.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (cts *CIBATokenSelect) Float64X(ctx context.Context) float64 {
+	v, err := cts.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(cts.fields) > 1 {
+		return nil, errors.New("db: CIBATokenSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := cts.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (cts *CIBATokenSelect) BoolsX(ctx context.Context) []bool {
+	v, err := cts.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a selector. It is only allowed when selecting one field.
+func (cts *CIBATokenSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = cts.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{cibatoken.Label}
+	default:
+		err = fmt.Errorf("db: CIBATokenSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (cts *CIBATokenSelect) BoolX(ctx context.Context) bool {
+	v, err := cts.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (cts *CIBATokenSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := cts.sql.Query()
+	if err := cts.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
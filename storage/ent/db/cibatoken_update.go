@@ -0,0 +1,568 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// CIBATokenUpdate is the builder for updating CIBAToken entities.
+type CIBATokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CIBATokenMutation
+}
+
+// Where appends a list predicates to the CIBATokenUpdate builder.
+func (ctu *CIBATokenUpdate) Where(ps ...predicate.CIBAToken) *CIBATokenUpdate {
+	ctu.mutation.Where(ps...)
+	return ctu
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (ctu *CIBATokenUpdate) SetAuthReqID(s string) *CIBATokenUpdate {
+	ctu.mutation.SetAuthReqID(s)
+	return ctu
+}
+
+// SetClientID sets the "client_id" field.
+func (ctu *CIBATokenUpdate) SetClientID(s string) *CIBATokenUpdate {
+	ctu.mutation.SetClientID(s)
+	return ctu
+}
+
+// SetStatus sets the "status" field.
+func (ctu *CIBATokenUpdate) SetStatus(s string) *CIBATokenUpdate {
+	ctu.mutation.SetStatus(s)
+	return ctu
+}
+
+// SetToken sets the "token" field.
+func (ctu *CIBATokenUpdate) SetToken(b []byte) *CIBATokenUpdate {
+	ctu.mutation.SetToken(b)
+	return ctu
+}
+
+// ClearToken clears the value of the "token" field.
+func (ctu *CIBATokenUpdate) ClearToken() *CIBATokenUpdate {
+	ctu.mutation.ClearToken()
+	return ctu
+}
+
+// SetNotificationToken sets the "notification_token" field.
+func (ctu *CIBATokenUpdate) SetNotificationToken(s string) *CIBATokenUpdate {
+	ctu.mutation.SetNotificationToken(s)
+	return ctu
+}
+
+// SetNillableNotificationToken sets the "notification_token" field if the given value is not nil.
+func (ctu *CIBATokenUpdate) SetNillableNotificationToken(s *string) *CIBATokenUpdate {
+	if s != nil {
+		ctu.SetNotificationToken(*s)
+	}
+	return ctu
+}
+
+// SetExpiry sets the "expiry" field.
+func (ctu *CIBATokenUpdate) SetExpiry(t time.Time) *CIBATokenUpdate {
+	ctu.mutation.SetExpiry(t)
+	return ctu
+}
+
+// SetLastRequest sets the "last_request" field.
+func (ctu *CIBATokenUpdate) SetLastRequest(t time.Time) *CIBATokenUpdate {
+	ctu.mutation.SetLastRequest(t)
+	return ctu
+}
+
+// SetPollInterval sets the "poll_interval" field.
+func (ctu *CIBATokenUpdate) SetPollInterval(i int) *CIBATokenUpdate {
+	ctu.mutation.ResetPollInterval()
+	ctu.mutation.SetPollInterval(i)
+	return ctu
+}
+
+// AddPollInterval adds i to the "poll_interval" field.
+func (ctu *CIBATokenUpdate) AddPollInterval(i int) *CIBATokenUpdate {
+	ctu.mutation.AddPollInterval(i)
+	return ctu
+}
+
+// Mutation returns the CIBATokenMutation object of the builder.
+func (ctu *CIBATokenUpdate) Mutation() *CIBATokenMutation {
+	return ctu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ctu *CIBATokenUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(ctu.hooks) == 0 {
+		if err = ctu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = ctu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBATokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ctu.check(); err != nil {
+				return 0, err
+			}
+			ctu.mutation = mutation
+			affected, err = ctu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(ctu.hooks) - 1; i >= 0; i-- {
+			if ctu.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = ctu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ctu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctu *CIBATokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := ctu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ctu *CIBATokenUpdate) Exec(ctx context.Context) error {
+	_, err := ctu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctu *CIBATokenUpdate) ExecX(ctx context.Context) {
+	if err := ctu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctu *CIBATokenUpdate) check() error {
+	if v, ok := ctu.mutation.AuthReqID(); ok {
+		if err := cibatoken.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.auth_req_id": %w`, err)}
+		}
+	}
+	if v, ok := ctu.mutation.ClientID(); ok {
+		if err := cibatoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.client_id": %w`, err)}
+		}
+	}
+	if v, ok := ctu.mutation.Status(); ok {
+		if err := cibatoken.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`db: validator failed for field "CIBAToken.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ctu *CIBATokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibatoken.Table,
+			Columns: cibatoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibatoken.FieldID,
+			},
+		},
+	}
+	if ps := ctu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ctu.mutation.AuthReqID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldAuthReqID,
+		})
+	}
+	if value, ok := ctu.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldClientID,
+		})
+	}
+	if value, ok := ctu.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldStatus,
+		})
+	}
+	if value, ok := ctu.mutation.Token(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: cibatoken.FieldToken,
+		})
+	}
+	if ctu.mutation.TokenCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: cibatoken.FieldToken,
+		})
+	}
+	if value, ok := ctu.mutation.NotificationToken(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldNotificationToken,
+		})
+	}
+	if value, ok := ctu.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldExpiry,
+		})
+	}
+	if value, ok := ctu.mutation.LastRequest(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldLastRequest,
+		})
+	}
+	if value, ok := ctu.mutation.PollInterval(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: cibatoken.FieldPollInterval,
+		})
+	}
+	if value, ok := ctu.mutation.AddedPollInterval(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: cibatoken.FieldPollInterval,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ctu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{cibatoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// CIBATokenUpdateOne is the builder for updating a single CIBAToken entity.
+type CIBATokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CIBATokenMutation
+}
+
+// SetAuthReqID sets the "auth_req_id" field.
+func (ctuo *CIBATokenUpdateOne) SetAuthReqID(s string) *CIBATokenUpdateOne {
+	ctuo.mutation.SetAuthReqID(s)
+	return ctuo
+}
+
+// SetClientID sets the "client_id" field.
+func (ctuo *CIBATokenUpdateOne) SetClientID(s string) *CIBATokenUpdateOne {
+	ctuo.mutation.SetClientID(s)
+	return ctuo
+}
+
+// SetStatus sets the "status" field.
+func (ctuo *CIBATokenUpdateOne) SetStatus(s string) *CIBATokenUpdateOne {
+	ctuo.mutation.SetStatus(s)
+	return ctuo
+}
+
+// SetToken sets the "token" field.
+func (ctuo *CIBATokenUpdateOne) SetToken(b []byte) *CIBATokenUpdateOne {
+	ctuo.mutation.SetToken(b)
+	return ctuo
+}
+
+// ClearToken clears the value of the "token" field.
+func (ctuo *CIBATokenUpdateOne) ClearToken() *CIBATokenUpdateOne {
+	ctuo.mutation.ClearToken()
+	return ctuo
+}
+
+// SetNotificationToken sets the "notification_token" field.
+func (ctuo *CIBATokenUpdateOne) SetNotificationToken(s string) *CIBATokenUpdateOne {
+	ctuo.mutation.SetNotificationToken(s)
+	return ctuo
+}
+
+// SetNillableNotificationToken sets the "notification_token" field if the given value is not nil.
+func (ctuo *CIBATokenUpdateOne) SetNillableNotificationToken(s *string) *CIBATokenUpdateOne {
+	if s != nil {
+		ctuo.SetNotificationToken(*s)
+	}
+	return ctuo
+}
+
+// SetExpiry sets the "expiry" field.
+func (ctuo *CIBATokenUpdateOne) SetExpiry(t time.Time) *CIBATokenUpdateOne {
+	ctuo.mutation.SetExpiry(t)
+	return ctuo
+}
+
+// SetLastRequest sets the "last_request" field.
+func (ctuo *CIBATokenUpdateOne) SetLastRequest(t time.Time) *CIBATokenUpdateOne {
+	ctuo.mutation.SetLastRequest(t)
+	return ctuo
+}
+
+// SetPollInterval sets the "poll_interval" field.
+func (ctuo *CIBATokenUpdateOne) SetPollInterval(i int) *CIBATokenUpdateOne {
+	ctuo.mutation.ResetPollInterval()
+	ctuo.mutation.SetPollInterval(i)
+	return ctuo
+}
+
+// AddPollInterval adds i to the "poll_interval" field.
+func (ctuo *CIBATokenUpdateOne) AddPollInterval(i int) *CIBATokenUpdateOne {
+	ctuo.mutation.AddPollInterval(i)
+	return ctuo
+}
+
+// Mutation returns the CIBATokenMutation object of the builder.
+func (ctuo *CIBATokenUpdateOne) Mutation() *CIBATokenMutation {
+	return ctuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ctuo *CIBATokenUpdateOne) Select(field string, fields ...string) *CIBATokenUpdateOne {
+	ctuo.fields = append([]string{field}, fields...)
+	return ctuo
+}
+
+// Save executes the query and returns the updated CIBAToken entity.
+func (ctuo *CIBATokenUpdateOne) Save(ctx context.Context) (*CIBAToken, error) {
+	var (
+		err  error
+		node *CIBAToken
+	)
+	if len(ctuo.hooks) == 0 {
+		if err = ctuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = ctuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*CIBATokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ctuo.check(); err != nil {
+				return nil, err
+			}
+			ctuo.mutation = mutation
+			node, err = ctuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ctuo.hooks) - 1; i >= 0; i-- {
+			if ctuo.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = ctuo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ctuo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctuo *CIBATokenUpdateOne) SaveX(ctx context.Context) *CIBAToken {
+	node, err := ctuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ctuo *CIBATokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := ctuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctuo *CIBATokenUpdateOne) ExecX(ctx context.Context) {
+	if err := ctuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctuo *CIBATokenUpdateOne) check() error {
+	if v, ok := ctuo.mutation.AuthReqID(); ok {
+		if err := cibatoken.AuthReqIDValidator(v); err != nil {
+			return &ValidationError{Name: "auth_req_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.auth_req_id": %w`, err)}
+		}
+	}
+	if v, ok := ctuo.mutation.ClientID(); ok {
+		if err := cibatoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "CIBAToken.client_id": %w`, err)}
+		}
+	}
+	if v, ok := ctuo.mutation.Status(); ok {
+		if err := cibatoken.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`db: validator failed for field "CIBAToken.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ctuo *CIBATokenUpdateOne) sqlSave(ctx context.Context) (_node *CIBAToken, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   cibatoken.Table,
+			Columns: cibatoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: cibatoken.FieldID,
+			},
+		},
+	}
+	id, ok := ctuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`db: missing "CIBAToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ctuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, cibatoken.FieldID)
+		for _, f := range fields {
+			if !cibatoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+			}
+			if f != cibatoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ctuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ctuo.mutation.AuthReqID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldAuthReqID,
+		})
+	}
+	if value, ok := ctuo.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldClientID,
+		})
+	}
+	if value, ok := ctuo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldStatus,
+		})
+	}
+	if value, ok := ctuo.mutation.Token(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: cibatoken.FieldToken,
+		})
+	}
+	if ctuo.mutation.TokenCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: cibatoken.FieldToken,
+		})
+	}
+	if value, ok := ctuo.mutation.NotificationToken(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: cibatoken.FieldNotificationToken,
+		})
+	}
+	if value, ok := ctuo.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldExpiry,
+		})
+	}
+	if value, ok := ctuo.mutation.LastRequest(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: cibatoken.FieldLastRequest,
+		})
+	}
+	if value, ok := ctuo.mutation.PollInterval(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: cibatoken.FieldPollInterval,
+		})
+	}
+	if value, ok := ctuo.mutation.AddedPollInterval(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: cibatoken.FieldPollInterval,
+		})
+	}
+	_node = &CIBAToken{config: ctuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ctuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{cibatoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -11,10 +11,13 @@ import (
 
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
+	"github.com/dexidp/dex/storage/ent/db/lease"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
@@ -33,6 +36,10 @@ type Client struct {
 	AuthCode *AuthCodeClient
 	// AuthRequest is the client for interacting with the AuthRequest builders.
 	AuthRequest *AuthRequestClient
+	// CIBARequest is the client for interacting with the CIBARequest builders.
+	CIBARequest *CIBARequestClient
+	// CIBAToken is the client for interacting with the CIBAToken builders.
+	CIBAToken *CIBATokenClient
 	// Connector is the client for interacting with the Connector builders.
 	Connector *ConnectorClient
 	// DeviceRequest is the client for interacting with the DeviceRequest builders.
@@ -41,6 +48,8 @@ type Client struct {
 	DeviceToken *DeviceTokenClient
 	// Keys is the client for interacting with the Keys builders.
 	Keys *KeysClient
+	// Lease is the client for interacting with the Lease builders.
+	Lease *LeaseClient
 	// OAuth2Client is the client for interacting with the OAuth2Client builders.
 	OAuth2Client *OAuth2ClientClient
 	// OfflineSession is the client for interacting with the OfflineSession builders.
@@ -64,10 +73,13 @@ func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.AuthCode = NewAuthCodeClient(c.config)
 	c.AuthRequest = NewAuthRequestClient(c.config)
+	c.CIBARequest = NewCIBARequestClient(c.config)
+	c.CIBAToken = NewCIBATokenClient(c.config)
 	c.Connector = NewConnectorClient(c.config)
 	c.DeviceRequest = NewDeviceRequestClient(c.config)
 	c.DeviceToken = NewDeviceTokenClient(c.config)
 	c.Keys = NewKeysClient(c.config)
+	c.Lease = NewLeaseClient(c.config)
 	c.OAuth2Client = NewOAuth2ClientClient(c.config)
 	c.OfflineSession = NewOfflineSessionClient(c.config)
 	c.Password = NewPasswordClient(c.config)
@@ -107,10 +119,13 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		config:         cfg,
 		AuthCode:       NewAuthCodeClient(cfg),
 		AuthRequest:    NewAuthRequestClient(cfg),
+		CIBARequest:    NewCIBARequestClient(cfg),
+		CIBAToken:      NewCIBATokenClient(cfg),
 		Connector:      NewConnectorClient(cfg),
 		DeviceRequest:  NewDeviceRequestClient(cfg),
 		DeviceToken:    NewDeviceTokenClient(cfg),
 		Keys:           NewKeysClient(cfg),
+		Lease:          NewLeaseClient(cfg),
 		OAuth2Client:   NewOAuth2ClientClient(cfg),
 		OfflineSession: NewOfflineSessionClient(cfg),
 		Password:       NewPasswordClient(cfg),
@@ -136,10 +151,13 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		config:         cfg,
 		AuthCode:       NewAuthCodeClient(cfg),
 		AuthRequest:    NewAuthRequestClient(cfg),
+		CIBARequest:    NewCIBARequestClient(cfg),
+		CIBAToken:      NewCIBATokenClient(cfg),
 		Connector:      NewConnectorClient(cfg),
 		DeviceRequest:  NewDeviceRequestClient(cfg),
 		DeviceToken:    NewDeviceTokenClient(cfg),
 		Keys:           NewKeysClient(cfg),
+		Lease:          NewLeaseClient(cfg),
 		OAuth2Client:   NewOAuth2ClientClient(cfg),
 		OfflineSession: NewOfflineSessionClient(cfg),
 		Password:       NewPasswordClient(cfg),
@@ -175,10 +193,13 @@ func (c *Client) Close() error {
 func (c *Client) Use(hooks ...Hook) {
 	c.AuthCode.Use(hooks...)
 	c.AuthRequest.Use(hooks...)
+	c.CIBARequest.Use(hooks...)
+	c.CIBAToken.Use(hooks...)
 	c.Connector.Use(hooks...)
 	c.DeviceRequest.Use(hooks...)
 	c.DeviceToken.Use(hooks...)
 	c.Keys.Use(hooks...)
+	c.Lease.Use(hooks...)
 	c.OAuth2Client.Use(hooks...)
 	c.OfflineSession.Use(hooks...)
 	c.Password.Use(hooks...)
@@ -365,6 +386,186 @@ func (c *AuthRequestClient) Hooks() []Hook {
 	return c.hooks.AuthRequest
 }
 
+// CIBARequestClient is a client for the CIBARequest schema.
+type CIBARequestClient struct {
+	config
+}
+
+// NewCIBARequestClient returns a client for the CIBARequest from the given config.
+func NewCIBARequestClient(c config) *CIBARequestClient {
+	return &CIBARequestClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `cibarequest.Hooks(f(g(h())))`.
+func (c *CIBARequestClient) Use(hooks ...Hook) {
+	c.hooks.CIBARequest = append(c.hooks.CIBARequest, hooks...)
+}
+
+// Create returns a create builder for CIBARequest.
+func (c *CIBARequestClient) Create() *CIBARequestCreate {
+	mutation := newCIBARequestMutation(c.config, OpCreate)
+	return &CIBARequestCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of CIBARequest entities.
+func (c *CIBARequestClient) CreateBulk(builders ...*CIBARequestCreate) *CIBARequestCreateBulk {
+	return &CIBARequestCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for CIBARequest.
+func (c *CIBARequestClient) Update() *CIBARequestUpdate {
+	mutation := newCIBARequestMutation(c.config, OpUpdate)
+	return &CIBARequestUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *CIBARequestClient) UpdateOne(cr *CIBARequest) *CIBARequestUpdateOne {
+	mutation := newCIBARequestMutation(c.config, OpUpdateOne, withCIBARequest(cr))
+	return &CIBARequestUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *CIBARequestClient) UpdateOneID(id int) *CIBARequestUpdateOne {
+	mutation := newCIBARequestMutation(c.config, OpUpdateOne, withCIBARequestID(id))
+	return &CIBARequestUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for CIBARequest.
+func (c *CIBARequestClient) Delete() *CIBARequestDelete {
+	mutation := newCIBARequestMutation(c.config, OpDelete)
+	return &CIBARequestDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *CIBARequestClient) DeleteOne(cr *CIBARequest) *CIBARequestDeleteOne {
+	return c.DeleteOneID(cr.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *CIBARequestClient) DeleteOneID(id int) *CIBARequestDeleteOne {
+	builder := c.Delete().Where(cibarequest.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &CIBARequestDeleteOne{builder}
+}
+
+// Query returns a query builder for CIBARequest.
+func (c *CIBARequestClient) Query() *CIBARequestQuery {
+	return &CIBARequestQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a CIBARequest entity by its id.
+func (c *CIBARequestClient) Get(ctx context.Context, id int) (*CIBARequest, error) {
+	return c.Query().Where(cibarequest.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *CIBARequestClient) GetX(ctx context.Context, id int) *CIBARequest {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *CIBARequestClient) Hooks() []Hook {
+	return c.hooks.CIBARequest
+}
+
+// CIBATokenClient is a client for the CIBAToken schema.
+type CIBATokenClient struct {
+	config
+}
+
+// NewCIBATokenClient returns a client for the CIBAToken from the given config.
+func NewCIBATokenClient(c config) *CIBATokenClient {
+	return &CIBATokenClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `cibatoken.Hooks(f(g(h())))`.
+func (c *CIBATokenClient) Use(hooks ...Hook) {
+	c.hooks.CIBAToken = append(c.hooks.CIBAToken, hooks...)
+}
+
+// Create returns a create builder for CIBAToken.
+func (c *CIBATokenClient) Create() *CIBATokenCreate {
+	mutation := newCIBATokenMutation(c.config, OpCreate)
+	return &CIBATokenCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of CIBAToken entities.
+func (c *CIBATokenClient) CreateBulk(builders ...*CIBATokenCreate) *CIBATokenCreateBulk {
+	return &CIBATokenCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for CIBAToken.
+func (c *CIBATokenClient) Update() *CIBATokenUpdate {
+	mutation := newCIBATokenMutation(c.config, OpUpdate)
+	return &CIBATokenUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *CIBATokenClient) UpdateOne(ct *CIBAToken) *CIBATokenUpdateOne {
+	mutation := newCIBATokenMutation(c.config, OpUpdateOne, withCIBAToken(ct))
+	return &CIBATokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *CIBATokenClient) UpdateOneID(id int) *CIBATokenUpdateOne {
+	mutation := newCIBATokenMutation(c.config, OpUpdateOne, withCIBATokenID(id))
+	return &CIBATokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for CIBAToken.
+func (c *CIBATokenClient) Delete() *CIBATokenDelete {
+	mutation := newCIBATokenMutation(c.config, OpDelete)
+	return &CIBATokenDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *CIBATokenClient) DeleteOne(ct *CIBAToken) *CIBATokenDeleteOne {
+	return c.DeleteOneID(ct.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *CIBATokenClient) DeleteOneID(id int) *CIBATokenDeleteOne {
+	builder := c.Delete().Where(cibatoken.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &CIBATokenDeleteOne{builder}
+}
+
+// Query returns a query builder for CIBAToken.
+func (c *CIBATokenClient) Query() *CIBATokenQuery {
+	return &CIBATokenQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a CIBAToken entity by its id.
+func (c *CIBATokenClient) Get(ctx context.Context, id int) (*CIBAToken, error) {
+	return c.Query().Where(cibatoken.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *CIBATokenClient) GetX(ctx context.Context, id int) *CIBAToken {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *CIBATokenClient) Hooks() []Hook {
+	return c.hooks.CIBAToken
+}
+
 // ConnectorClient is a client for the Connector schema.
 type ConnectorClient struct {
 	config
@@ -725,6 +926,96 @@ func (c *KeysClient) Hooks() []Hook {
 	return c.hooks.Keys
 }
 
+// LeaseClient is a client for the Lease schema.
+type LeaseClient struct {
+	config
+}
+
+// NewLeaseClient returns a client for the Lease from the given config.
+func NewLeaseClient(c config) *LeaseClient {
+	return &LeaseClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `lease.Hooks(f(g(h())))`.
+func (c *LeaseClient) Use(hooks ...Hook) {
+	c.hooks.Lease = append(c.hooks.Lease, hooks...)
+}
+
+// Create returns a create builder for Lease.
+func (c *LeaseClient) Create() *LeaseCreate {
+	mutation := newLeaseMutation(c.config, OpCreate)
+	return &LeaseCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Lease entities.
+func (c *LeaseClient) CreateBulk(builders ...*LeaseCreate) *LeaseCreateBulk {
+	return &LeaseCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Lease.
+func (c *LeaseClient) Update() *LeaseUpdate {
+	mutation := newLeaseMutation(c.config, OpUpdate)
+	return &LeaseUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *LeaseClient) UpdateOne(l *Lease) *LeaseUpdateOne {
+	mutation := newLeaseMutation(c.config, OpUpdateOne, withLease(l))
+	return &LeaseUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *LeaseClient) UpdateOneID(id string) *LeaseUpdateOne {
+	mutation := newLeaseMutation(c.config, OpUpdateOne, withLeaseID(id))
+	return &LeaseUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Lease.
+func (c *LeaseClient) Delete() *LeaseDelete {
+	mutation := newLeaseMutation(c.config, OpDelete)
+	return &LeaseDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *LeaseClient) DeleteOne(l *Lease) *LeaseDeleteOne {
+	return c.DeleteOneID(l.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *LeaseClient) DeleteOneID(id string) *LeaseDeleteOne {
+	builder := c.Delete().Where(lease.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &LeaseDeleteOne{builder}
+}
+
+// Query returns a query builder for Lease.
+func (c *LeaseClient) Query() *LeaseQuery {
+	return &LeaseQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a Lease entity by its id.
+func (c *LeaseClient) Get(ctx context.Context, id string) (*Lease, error) {
+	return c.Query().Where(lease.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *LeaseClient) GetX(ctx context.Context, id string) *Lease {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *LeaseClient) Hooks() []Hook {
+	return c.hooks.Lease
+}
+
 // OAuth2ClientClient is a client for the OAuth2Client schema.
 type OAuth2ClientClient struct {
 	config
@@ -26,10 +26,13 @@ type config struct {
 type hooks struct {
 	AuthCode       []ent.Hook
 	AuthRequest    []ent.Hook
+	CIBARequest    []ent.Hook
+	CIBAToken      []ent.Hook
 	Connector      []ent.Hook
 	DeviceRequest  []ent.Hook
 	DeviceToken    []ent.Hook
 	Keys           []ent.Hook
+	Lease          []ent.Hook
 	OAuth2Client   []ent.Hook
 	OfflineSession []ent.Hook
 	Password       []ent.Hook
@@ -10,10 +10,13 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
+	"github.com/dexidp/dex/storage/ent/db/lease"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
@@ -40,10 +43,13 @@ func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
 		authcode.Table:       authcode.ValidColumn,
 		authrequest.Table:    authrequest.ValidColumn,
+		cibarequest.Table:    cibarequest.ValidColumn,
+		cibatoken.Table:      cibatoken.ValidColumn,
 		connector.Table:      connector.ValidColumn,
 		devicerequest.Table:  devicerequest.ValidColumn,
 		devicetoken.Table:    devicetoken.ValidColumn,
 		keys.Table:           keys.ValidColumn,
+		lease.Table:          lease.ValidColumn,
 		oauth2client.Table:   oauth2client.ValidColumn,
 		offlinesession.Table: offlinesession.ValidColumn,
 		password.Table:       password.ValidColumn,
@@ -35,6 +35,32 @@ func (f AuthRequestFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, e
 	return f(ctx, mv)
 }
 
+// The CIBARequestFunc type is an adapter to allow the use of ordinary
+// function as CIBARequest mutator.
+type CIBARequestFunc func(context.Context, *db.CIBARequestMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f CIBARequestFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.CIBARequestMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.CIBARequestMutation", m)
+	}
+	return f(ctx, mv)
+}
+
+// The CIBATokenFunc type is an adapter to allow the use of ordinary
+// function as CIBAToken mutator.
+type CIBATokenFunc func(context.Context, *db.CIBATokenMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f CIBATokenFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.CIBATokenMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.CIBATokenMutation", m)
+	}
+	return f(ctx, mv)
+}
+
 // The ConnectorFunc type is an adapter to allow the use of ordinary
 // function as Connector mutator.
 type ConnectorFunc func(context.Context, *db.ConnectorMutation) (db.Value, error)
@@ -87,6 +113,19 @@ func (f KeysFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
 	return f(ctx, mv)
 }
 
+// The LeaseFunc type is an adapter to allow the use of ordinary
+// function as Lease mutator.
+type LeaseFunc func(context.Context, *db.LeaseMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f LeaseFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.LeaseMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.LeaseMutation", m)
+	}
+	return f(ctx, mv)
+}
+
 // The OAuth2ClientFunc type is an adapter to allow the use of ordinary
 // function as OAuth2Client mutator.
 type OAuth2ClientFunc func(context.Context, *db.OAuth2ClientMutation) (db.Value, error)
@@ -12,10 +12,13 @@ import (
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
+	"github.com/dexidp/dex/storage/ent/db/lease"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
@@ -37,10 +40,13 @@ const (
 	// Node types.
 	TypeAuthCode       = "AuthCode"
 	TypeAuthRequest    = "AuthRequest"
+	TypeCIBARequest    = "CIBARequest"
+	TypeCIBAToken      = "CIBAToken"
 	TypeConnector      = "Connector"
 	TypeDeviceRequest  = "DeviceRequest"
 	TypeDeviceToken    = "DeviceToken"
 	TypeKeys           = "Keys"
+	TypeLease          = "Lease"
 	TypeOAuth2Client   = "OAuth2Client"
 	TypeOfflineSession = "OfflineSession"
 	TypePassword       = "Password"
@@ -2548,33 +2554,36 @@ func (m *AuthRequestMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown AuthRequest edge %s", name)
 }
 
-// ConnectorMutation represents an operation that mutates the Connector nodes in the graph.
-type ConnectorMutation struct {
+// CIBARequestMutation represents an operation that mutates the CIBARequest nodes in the graph.
+type CIBARequestMutation struct {
 	config
-	op               Op
-	typ              string
-	id               *string
-	_type            *string
-	name             *string
-	resource_version *string
-	_config          *[]byte
-	clearedFields    map[string]struct{}
-	done             bool
-	oldValue         func(context.Context) (*Connector, error)
-	predicates       []predicate.Connector
-}
-
-var _ ent.Mutation = (*ConnectorMutation)(nil)
-
-// connectorOption allows management of the mutation configuration using functional options.
-type connectorOption func(*ConnectorMutation)
-
-// newConnectorMutation creates new mutation for the Connector entity.
-func newConnectorMutation(c config, op Op, opts ...connectorOption) *ConnectorMutation {
-	m := &ConnectorMutation{
+	op              Op
+	typ             string
+	id              *int
+	approval_code   *string
+	auth_req_id     *string
+	client_id       *string
+	scopes          *[]string
+	login_hint      *string
+	binding_message *string
+	expiry          *time.Time
+	clearedFields   map[string]struct{}
+	done            bool
+	oldValue        func(context.Context) (*CIBARequest, error)
+	predicates      []predicate.CIBARequest
+}
+
+var _ ent.Mutation = (*CIBARequestMutation)(nil)
+
+// cibarequestOption allows management of the mutation configuration using functional options.
+type cibarequestOption func(*CIBARequestMutation)
+
+// newCIBARequestMutation creates new mutation for the CIBARequest entity.
+func newCIBARequestMutation(c config, op Op, opts ...cibarequestOption) *CIBARequestMutation {
+	m := &CIBARequestMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeConnector,
+		typ:           TypeCIBARequest,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2583,20 +2592,20 @@ func newConnectorMutation(c config, op Op, opts ...connectorOption) *ConnectorMu
 	return m
 }
 
-// withConnectorID sets the ID field of the mutation.
-func withConnectorID(id string) connectorOption {
-	return func(m *ConnectorMutation) {
+// withCIBARequestID sets the ID field of the mutation.
+func withCIBARequestID(id int) cibarequestOption {
+	return func(m *CIBARequestMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Connector
+			value *CIBARequest
 		)
-		m.oldValue = func(ctx context.Context) (*Connector, error) {
+		m.oldValue = func(ctx context.Context) (*CIBARequest, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Connector.Get(ctx, id)
+					value, err = m.Client().CIBARequest.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2605,10 +2614,10 @@ func withConnectorID(id string) connectorOption {
 	}
 }
 
-// withConnector sets the old Connector of the mutation.
-func withConnector(node *Connector) connectorOption {
-	return func(m *ConnectorMutation) {
-		m.oldValue = func(context.Context) (*Connector, error) {
+// withCIBARequest sets the old CIBARequest of the mutation.
+func withCIBARequest(node *CIBARequest) cibarequestOption {
+	return func(m *CIBARequestMutation) {
+		m.oldValue = func(context.Context) (*CIBARequest, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2617,7 +2626,7 @@ func withConnector(node *Connector) connectorOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ConnectorMutation) Client() *Client {
+func (m CIBARequestMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2625,7 +2634,7 @@ func (m ConnectorMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ConnectorMutation) Tx() (*Tx, error) {
+func (m CIBARequestMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -2634,15 +2643,9 @@ func (m ConnectorMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Connector entities.
-func (m *ConnectorMutation) SetID(id string) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ConnectorMutation) ID() (id string, exists bool) {
+func (m *CIBARequestMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2653,196 +2656,326 @@ func (m *ConnectorMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ConnectorMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *CIBARequestMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []string{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Connector.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().CIBARequest.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetType sets the "type" field.
-func (m *ConnectorMutation) SetType(s string) {
-	m._type = &s
+// SetApprovalCode sets the "approval_code" field.
+func (m *CIBARequestMutation) SetApprovalCode(s string) {
+	m.approval_code = &s
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *ConnectorMutation) GetType() (r string, exists bool) {
-	v := m._type
+// ApprovalCode returns the value of the "approval_code" field in the mutation.
+func (m *CIBARequestMutation) ApprovalCode() (r string, exists bool) {
+	v := m.approval_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Connector entity.
-// If the Connector object wasn't provided to the builder, the object is fetched from the database.
+// OldApprovalCode returns the old "approval_code" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ConnectorMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *CIBARequestMutation) OldApprovalCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldApprovalCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldApprovalCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldApprovalCode: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.ApprovalCode, nil
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *ConnectorMutation) ResetType() {
-	m._type = nil
+// ResetApprovalCode resets all changes to the "approval_code" field.
+func (m *CIBARequestMutation) ResetApprovalCode() {
+	m.approval_code = nil
 }
 
-// SetName sets the "name" field.
-func (m *ConnectorMutation) SetName(s string) {
-	m.name = &s
+// SetAuthReqID sets the "auth_req_id" field.
+func (m *CIBARequestMutation) SetAuthReqID(s string) {
+	m.auth_req_id = &s
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *ConnectorMutation) Name() (r string, exists bool) {
-	v := m.name
+// AuthReqID returns the value of the "auth_req_id" field in the mutation.
+func (m *CIBARequestMutation) AuthReqID() (r string, exists bool) {
+	v := m.auth_req_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Connector entity.
-// If the Connector object wasn't provided to the builder, the object is fetched from the database.
+// OldAuthReqID returns the old "auth_req_id" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ConnectorMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *CIBARequestMutation) OldAuthReqID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldAuthReqID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldAuthReqID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldAuthReqID: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.AuthReqID, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *ConnectorMutation) ResetName() {
-	m.name = nil
+// ResetAuthReqID resets all changes to the "auth_req_id" field.
+func (m *CIBARequestMutation) ResetAuthReqID() {
+	m.auth_req_id = nil
 }
 
-// SetResourceVersion sets the "resource_version" field.
-func (m *ConnectorMutation) SetResourceVersion(s string) {
-	m.resource_version = &s
+// SetClientID sets the "client_id" field.
+func (m *CIBARequestMutation) SetClientID(s string) {
+	m.client_id = &s
 }
 
-// ResourceVersion returns the value of the "resource_version" field in the mutation.
-func (m *ConnectorMutation) ResourceVersion() (r string, exists bool) {
-	v := m.resource_version
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *CIBARequestMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldResourceVersion returns the old "resource_version" field's value of the Connector entity.
-// If the Connector object wasn't provided to the builder, the object is fetched from the database.
+// OldClientID returns the old "client_id" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ConnectorMutation) OldResourceVersion(ctx context.Context) (v string, err error) {
+func (m *CIBARequestMutation) OldClientID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldResourceVersion is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldResourceVersion requires an ID field in the mutation")
+		return v, errors.New("OldClientID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldResourceVersion: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
 	}
-	return oldValue.ResourceVersion, nil
+	return oldValue.ClientID, nil
 }
 
-// ResetResourceVersion resets all changes to the "resource_version" field.
-func (m *ConnectorMutation) ResetResourceVersion() {
-	m.resource_version = nil
+// ResetClientID resets all changes to the "client_id" field.
+func (m *CIBARequestMutation) ResetClientID() {
+	m.client_id = nil
 }
 
-// SetConfig sets the "config" field.
-func (m *ConnectorMutation) SetConfig(b []byte) {
-	m._config = &b
+// SetScopes sets the "scopes" field.
+func (m *CIBARequestMutation) SetScopes(s []string) {
+	m.scopes = &s
 }
 
-// Config returns the value of the "config" field in the mutation.
-func (m *ConnectorMutation) Config() (r []byte, exists bool) {
-	v := m._config
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *CIBARequestMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConfig returns the old "config" field's value of the Connector entity.
-// If the Connector object wasn't provided to the builder, the object is fetched from the database.
+// OldScopes returns the old "scopes" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ConnectorMutation) OldConfig(ctx context.Context) (v []byte, err error) {
+func (m *CIBARequestMutation) OldScopes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConfig is only allowed on UpdateOne operations")
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConfig requires an ID field in the mutation")
+		return v, errors.New("OldScopes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConfig: %w", err)
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
 	}
-	return oldValue.Config, nil
+	return oldValue.Scopes, nil
 }
 
-// ResetConfig resets all changes to the "config" field.
-func (m *ConnectorMutation) ResetConfig() {
-	m._config = nil
+// ClearScopes clears the value of the "scopes" field.
+func (m *CIBARequestMutation) ClearScopes() {
+	m.scopes = nil
+	m.clearedFields[cibarequest.FieldScopes] = struct{}{}
 }
 
-// Where appends a list predicates to the ConnectorMutation builder.
-func (m *ConnectorMutation) Where(ps ...predicate.Connector) {
+// ScopesCleared returns if the "scopes" field was cleared in this mutation.
+func (m *CIBARequestMutation) ScopesCleared() bool {
+	_, ok := m.clearedFields[cibarequest.FieldScopes]
+	return ok
+}
+
+// ResetScopes resets all changes to the "scopes" field.
+func (m *CIBARequestMutation) ResetScopes() {
+	m.scopes = nil
+	delete(m.clearedFields, cibarequest.FieldScopes)
+}
+
+// SetLoginHint sets the "login_hint" field.
+func (m *CIBARequestMutation) SetLoginHint(s string) {
+	m.login_hint = &s
+}
+
+// LoginHint returns the value of the "login_hint" field in the mutation.
+func (m *CIBARequestMutation) LoginHint() (r string, exists bool) {
+	v := m.login_hint
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLoginHint returns the old "login_hint" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CIBARequestMutation) OldLoginHint(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLoginHint is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLoginHint requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLoginHint: %w", err)
+	}
+	return oldValue.LoginHint, nil
+}
+
+// ResetLoginHint resets all changes to the "login_hint" field.
+func (m *CIBARequestMutation) ResetLoginHint() {
+	m.login_hint = nil
+}
+
+// SetBindingMessage sets the "binding_message" field.
+func (m *CIBARequestMutation) SetBindingMessage(s string) {
+	m.binding_message = &s
+}
+
+// BindingMessage returns the value of the "binding_message" field in the mutation.
+func (m *CIBARequestMutation) BindingMessage() (r string, exists bool) {
+	v := m.binding_message
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBindingMessage returns the old "binding_message" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CIBARequestMutation) OldBindingMessage(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBindingMessage is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBindingMessage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBindingMessage: %w", err)
+	}
+	return oldValue.BindingMessage, nil
+}
+
+// ResetBindingMessage resets all changes to the "binding_message" field.
+func (m *CIBARequestMutation) ResetBindingMessage() {
+	m.binding_message = nil
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *CIBARequestMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *CIBARequestMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the CIBARequest entity.
+// If the CIBARequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CIBARequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *CIBARequestMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// Where appends a list predicates to the CIBARequestMutation builder.
+func (m *CIBARequestMutation) Where(ps ...predicate.CIBARequest) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *ConnectorMutation) Op() Op {
+func (m *CIBARequestMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Connector).
-func (m *ConnectorMutation) Type() string {
+// Type returns the node type of this mutation (CIBARequest).
+func (m *CIBARequestMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ConnectorMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m._type != nil {
-		fields = append(fields, connector.FieldType)
+func (m *CIBARequestMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.approval_code != nil {
+		fields = append(fields, cibarequest.FieldApprovalCode)
 	}
-	if m.name != nil {
-		fields = append(fields, connector.FieldName)
+	if m.auth_req_id != nil {
+		fields = append(fields, cibarequest.FieldAuthReqID)
 	}
-	if m.resource_version != nil {
-		fields = append(fields, connector.FieldResourceVersion)
+	if m.client_id != nil {
+		fields = append(fields, cibarequest.FieldClientID)
 	}
-	if m._config != nil {
-		fields = append(fields, connector.FieldConfig)
+	if m.scopes != nil {
+		fields = append(fields, cibarequest.FieldScopes)
+	}
+	if m.login_hint != nil {
+		fields = append(fields, cibarequest.FieldLoginHint)
+	}
+	if m.binding_message != nil {
+		fields = append(fields, cibarequest.FieldBindingMessage)
+	}
+	if m.expiry != nil {
+		fields = append(fields, cibarequest.FieldExpiry)
 	}
 	return fields
 }
@@ -2850,16 +2983,22 @@ func (m *ConnectorMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ConnectorMutation) Field(name string) (ent.Value, bool) {
+func (m *CIBARequestMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case connector.FieldType:
-		return m.GetType()
-	case connector.FieldName:
-		return m.Name()
-	case connector.FieldResourceVersion:
-		return m.ResourceVersion()
-	case connector.FieldConfig:
-		return m.Config()
+	case cibarequest.FieldApprovalCode:
+		return m.ApprovalCode()
+	case cibarequest.FieldAuthReqID:
+		return m.AuthReqID()
+	case cibarequest.FieldClientID:
+		return m.ClientID()
+	case cibarequest.FieldScopes:
+		return m.Scopes()
+	case cibarequest.FieldLoginHint:
+		return m.LoginHint()
+	case cibarequest.FieldBindingMessage:
+		return m.BindingMessage()
+	case cibarequest.FieldExpiry:
+		return m.Expiry()
 	}
 	return nil, false
 }
@@ -2867,195 +3006,243 @@ func (m *ConnectorMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ConnectorMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *CIBARequestMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case connector.FieldType:
-		return m.OldType(ctx)
-	case connector.FieldName:
-		return m.OldName(ctx)
-	case connector.FieldResourceVersion:
-		return m.OldResourceVersion(ctx)
-	case connector.FieldConfig:
-		return m.OldConfig(ctx)
+	case cibarequest.FieldApprovalCode:
+		return m.OldApprovalCode(ctx)
+	case cibarequest.FieldAuthReqID:
+		return m.OldAuthReqID(ctx)
+	case cibarequest.FieldClientID:
+		return m.OldClientID(ctx)
+	case cibarequest.FieldScopes:
+		return m.OldScopes(ctx)
+	case cibarequest.FieldLoginHint:
+		return m.OldLoginHint(ctx)
+	case cibarequest.FieldBindingMessage:
+		return m.OldBindingMessage(ctx)
+	case cibarequest.FieldExpiry:
+		return m.OldExpiry(ctx)
 	}
-	return nil, fmt.Errorf("unknown Connector field %s", name)
+	return nil, fmt.Errorf("unknown CIBARequest field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ConnectorMutation) SetField(name string, value ent.Value) error {
+func (m *CIBARequestMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case connector.FieldType:
+	case cibarequest.FieldApprovalCode:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetType(v)
+		m.SetApprovalCode(v)
 		return nil
-	case connector.FieldName:
+	case cibarequest.FieldAuthReqID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetAuthReqID(v)
 		return nil
-	case connector.FieldResourceVersion:
+	case cibarequest.FieldClientID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetResourceVersion(v)
+		m.SetClientID(v)
 		return nil
-	case connector.FieldConfig:
-		v, ok := value.([]byte)
+	case cibarequest.FieldScopes:
+		v, ok := value.([]string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetConfig(v)
+		m.SetScopes(v)
+		return nil
+	case cibarequest.FieldLoginHint:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLoginHint(v)
+		return nil
+	case cibarequest.FieldBindingMessage:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBindingMessage(v)
+		return nil
+	case cibarequest.FieldExpiry:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiry(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Connector field %s", name)
+	return fmt.Errorf("unknown CIBARequest field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ConnectorMutation) AddedFields() []string {
+func (m *CIBARequestMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ConnectorMutation) AddedField(name string) (ent.Value, bool) {
+func (m *CIBARequestMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ConnectorMutation) AddField(name string, value ent.Value) error {
+func (m *CIBARequestMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Connector numeric field %s", name)
+	return fmt.Errorf("unknown CIBARequest numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ConnectorMutation) ClearedFields() []string {
-	return nil
+func (m *CIBARequestMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(cibarequest.FieldScopes) {
+		fields = append(fields, cibarequest.FieldScopes)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ConnectorMutation) FieldCleared(name string) bool {
+func (m *CIBARequestMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ConnectorMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Connector nullable field %s", name)
+func (m *CIBARequestMutation) ClearField(name string) error {
+	switch name {
+	case cibarequest.FieldScopes:
+		m.ClearScopes()
+		return nil
+	}
+	return fmt.Errorf("unknown CIBARequest nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ConnectorMutation) ResetField(name string) error {
+func (m *CIBARequestMutation) ResetField(name string) error {
 	switch name {
-	case connector.FieldType:
-		m.ResetType()
+	case cibarequest.FieldApprovalCode:
+		m.ResetApprovalCode()
 		return nil
-	case connector.FieldName:
-		m.ResetName()
+	case cibarequest.FieldAuthReqID:
+		m.ResetAuthReqID()
 		return nil
-	case connector.FieldResourceVersion:
-		m.ResetResourceVersion()
+	case cibarequest.FieldClientID:
+		m.ResetClientID()
 		return nil
-	case connector.FieldConfig:
-		m.ResetConfig()
+	case cibarequest.FieldScopes:
+		m.ResetScopes()
+		return nil
+	case cibarequest.FieldLoginHint:
+		m.ResetLoginHint()
+		return nil
+	case cibarequest.FieldBindingMessage:
+		m.ResetBindingMessage()
+		return nil
+	case cibarequest.FieldExpiry:
+		m.ResetExpiry()
 		return nil
 	}
-	return fmt.Errorf("unknown Connector field %s", name)
+	return fmt.Errorf("unknown CIBARequest field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ConnectorMutation) AddedEdges() []string {
+func (m *CIBARequestMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ConnectorMutation) AddedIDs(name string) []ent.Value {
+func (m *CIBARequestMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ConnectorMutation) RemovedEdges() []string {
+func (m *CIBARequestMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ConnectorMutation) RemovedIDs(name string) []ent.Value {
+func (m *CIBARequestMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ConnectorMutation) ClearedEdges() []string {
+func (m *CIBARequestMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ConnectorMutation) EdgeCleared(name string) bool {
+func (m *CIBARequestMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ConnectorMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Connector unique edge %s", name)
+func (m *CIBARequestMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown CIBARequest unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ConnectorMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Connector edge %s", name)
+func (m *CIBARequestMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown CIBARequest edge %s", name)
 }
 
-// DeviceRequestMutation represents an operation that mutates the DeviceRequest nodes in the graph.
-type DeviceRequestMutation struct {
+// CIBATokenMutation represents an operation that mutates the CIBAToken nodes in the graph.
+type CIBATokenMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	user_code     *string
-	device_code   *string
-	client_id     *string
-	client_secret *string
-	scopes        *[]string
-	expiry        *time.Time
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*DeviceRequest, error)
-	predicates    []predicate.DeviceRequest
-}
-
-var _ ent.Mutation = (*DeviceRequestMutation)(nil)
-
-// devicerequestOption allows management of the mutation configuration using functional options.
-type devicerequestOption func(*DeviceRequestMutation)
-
-// newDeviceRequestMutation creates new mutation for the DeviceRequest entity.
-func newDeviceRequestMutation(c config, op Op, opts ...devicerequestOption) *DeviceRequestMutation {
-	m := &DeviceRequestMutation{
+	op                 Op
+	typ                string
+	id                 *int
+	auth_req_id        *string
+	client_id          *string
+	status             *string
+	token              *[]byte
+	notification_token *string
+	expiry             *time.Time
+	last_request       *time.Time
+	poll_interval      *int
+	addpoll_interval   *int
+	clearedFields      map[string]struct{}
+	done               bool
+	oldValue           func(context.Context) (*CIBAToken, error)
+	predicates         []predicate.CIBAToken
+}
+
+var _ ent.Mutation = (*CIBATokenMutation)(nil)
+
+// cibatokenOption allows management of the mutation configuration using functional options.
+type cibatokenOption func(*CIBATokenMutation)
+
+// newCIBATokenMutation creates new mutation for the CIBAToken entity.
+func newCIBATokenMutation(c config, op Op, opts ...cibatokenOption) *CIBATokenMutation {
+	m := &CIBATokenMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeDeviceRequest,
+		typ:           TypeCIBAToken,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3064,20 +3251,20 @@ func newDeviceRequestMutation(c config, op Op, opts ...devicerequestOption) *Dev
 	return m
 }
 
-// withDeviceRequestID sets the ID field of the mutation.
-func withDeviceRequestID(id int) devicerequestOption {
-	return func(m *DeviceRequestMutation) {
+// withCIBATokenID sets the ID field of the mutation.
+func withCIBATokenID(id int) cibatokenOption {
+	return func(m *CIBATokenMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *DeviceRequest
+			value *CIBAToken
 		)
-		m.oldValue = func(ctx context.Context) (*DeviceRequest, error) {
+		m.oldValue = func(ctx context.Context) (*CIBAToken, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().DeviceRequest.Get(ctx, id)
+					value, err = m.Client().CIBAToken.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3086,10 +3273,10 @@ func withDeviceRequestID(id int) devicerequestOption {
 	}
 }
 
-// withDeviceRequest sets the old DeviceRequest of the mutation.
-func withDeviceRequest(node *DeviceRequest) devicerequestOption {
-	return func(m *DeviceRequestMutation) {
-		m.oldValue = func(context.Context) (*DeviceRequest, error) {
+// withCIBAToken sets the old CIBAToken of the mutation.
+func withCIBAToken(node *CIBAToken) cibatokenOption {
+	return func(m *CIBATokenMutation) {
+		m.oldValue = func(context.Context) (*CIBAToken, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3098,7 +3285,7 @@ func withDeviceRequest(node *DeviceRequest) devicerequestOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m DeviceRequestMutation) Client() *Client {
+func (m CIBATokenMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3106,7 +3293,7 @@ func (m DeviceRequestMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m DeviceRequestMutation) Tx() (*Tx, error) {
+func (m CIBATokenMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -3117,7 +3304,7 @@ func (m DeviceRequestMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *DeviceRequestMutation) ID() (id int, exists bool) {
+func (m *CIBATokenMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -3128,7 +3315,7 @@ func (m *DeviceRequestMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *DeviceRequestMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *CIBATokenMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -3137,212 +3324,212 @@ func (m *DeviceRequestMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().DeviceRequest.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().CIBAToken.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUserCode sets the "user_code" field.
-func (m *DeviceRequestMutation) SetUserCode(s string) {
-	m.user_code = &s
+// SetAuthReqID sets the "auth_req_id" field.
+func (m *CIBATokenMutation) SetAuthReqID(s string) {
+	m.auth_req_id = &s
 }
 
-// UserCode returns the value of the "user_code" field in the mutation.
-func (m *DeviceRequestMutation) UserCode() (r string, exists bool) {
-	v := m.user_code
+// AuthReqID returns the value of the "auth_req_id" field in the mutation.
+func (m *CIBATokenMutation) AuthReqID() (r string, exists bool) {
+	v := m.auth_req_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserCode returns the old "user_code" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldAuthReqID returns the old "auth_req_id" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldUserCode(ctx context.Context) (v string, err error) {
+func (m *CIBATokenMutation) OldAuthReqID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldAuthReqID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserCode requires an ID field in the mutation")
+		return v, errors.New("OldAuthReqID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldAuthReqID: %w", err)
 	}
-	return oldValue.UserCode, nil
+	return oldValue.AuthReqID, nil
 }
 
-// ResetUserCode resets all changes to the "user_code" field.
-func (m *DeviceRequestMutation) ResetUserCode() {
-	m.user_code = nil
+// ResetAuthReqID resets all changes to the "auth_req_id" field.
+func (m *CIBATokenMutation) ResetAuthReqID() {
+	m.auth_req_id = nil
 }
 
-// SetDeviceCode sets the "device_code" field.
-func (m *DeviceRequestMutation) SetDeviceCode(s string) {
-	m.device_code = &s
+// SetClientID sets the "client_id" field.
+func (m *CIBATokenMutation) SetClientID(s string) {
+	m.client_id = &s
 }
 
-// DeviceCode returns the value of the "device_code" field in the mutation.
-func (m *DeviceRequestMutation) DeviceCode() (r string, exists bool) {
-	v := m.device_code
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *CIBATokenMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDeviceCode returns the old "device_code" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldClientID returns the old "client_id" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldDeviceCode(ctx context.Context) (v string, err error) {
+func (m *CIBATokenMutation) OldClientID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeviceCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeviceCode requires an ID field in the mutation")
+		return v, errors.New("OldClientID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeviceCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
 	}
-	return oldValue.DeviceCode, nil
+	return oldValue.ClientID, nil
 }
 
-// ResetDeviceCode resets all changes to the "device_code" field.
-func (m *DeviceRequestMutation) ResetDeviceCode() {
-	m.device_code = nil
+// ResetClientID resets all changes to the "client_id" field.
+func (m *CIBATokenMutation) ResetClientID() {
+	m.client_id = nil
 }
 
-// SetClientID sets the "client_id" field.
-func (m *DeviceRequestMutation) SetClientID(s string) {
-	m.client_id = &s
+// SetStatus sets the "status" field.
+func (m *CIBATokenMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// ClientID returns the value of the "client_id" field in the mutation.
-func (m *DeviceRequestMutation) ClientID() (r string, exists bool) {
-	v := m.client_id
+// Status returns the value of the "status" field in the mutation.
+func (m *CIBATokenMutation) Status() (r string, exists bool) {
+	v := m.status
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClientID returns the old "client_id" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldStatus returns the old "status" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldClientID(ctx context.Context) (v string, err error) {
+func (m *CIBATokenMutation) OldStatus(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClientID requires an ID field in the mutation")
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return oldValue.ClientID, nil
+	return oldValue.Status, nil
 }
 
-// ResetClientID resets all changes to the "client_id" field.
-func (m *DeviceRequestMutation) ResetClientID() {
-	m.client_id = nil
+// ResetStatus resets all changes to the "status" field.
+func (m *CIBATokenMutation) ResetStatus() {
+	m.status = nil
 }
 
-// SetClientSecret sets the "client_secret" field.
-func (m *DeviceRequestMutation) SetClientSecret(s string) {
-	m.client_secret = &s
+// SetToken sets the "token" field.
+func (m *CIBATokenMutation) SetToken(b []byte) {
+	m.token = &b
 }
 
-// ClientSecret returns the value of the "client_secret" field in the mutation.
-func (m *DeviceRequestMutation) ClientSecret() (r string, exists bool) {
-	v := m.client_secret
+// Token returns the value of the "token" field in the mutation.
+func (m *CIBATokenMutation) Token() (r []byte, exists bool) {
+	v := m.token
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClientSecret returns the old "client_secret" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldToken returns the old "token" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldClientSecret(ctx context.Context) (v string, err error) {
+func (m *CIBATokenMutation) OldToken(ctx context.Context) (v *[]byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClientSecret is only allowed on UpdateOne operations")
+		return v, errors.New("OldToken is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClientSecret requires an ID field in the mutation")
+		return v, errors.New("OldToken requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClientSecret: %w", err)
+		return v, fmt.Errorf("querying old value for OldToken: %w", err)
 	}
-	return oldValue.ClientSecret, nil
+	return oldValue.Token, nil
 }
 
-// ResetClientSecret resets all changes to the "client_secret" field.
-func (m *DeviceRequestMutation) ResetClientSecret() {
-	m.client_secret = nil
+// ClearToken clears the value of the "token" field.
+func (m *CIBATokenMutation) ClearToken() {
+	m.token = nil
+	m.clearedFields[cibatoken.FieldToken] = struct{}{}
 }
 
-// SetScopes sets the "scopes" field.
-func (m *DeviceRequestMutation) SetScopes(s []string) {
-	m.scopes = &s
+// TokenCleared returns if the "token" field was cleared in this mutation.
+func (m *CIBATokenMutation) TokenCleared() bool {
+	_, ok := m.clearedFields[cibatoken.FieldToken]
+	return ok
 }
 
-// Scopes returns the value of the "scopes" field in the mutation.
-func (m *DeviceRequestMutation) Scopes() (r []string, exists bool) {
-	v := m.scopes
+// ResetToken resets all changes to the "token" field.
+func (m *CIBATokenMutation) ResetToken() {
+	m.token = nil
+	delete(m.clearedFields, cibatoken.FieldToken)
+}
+
+// SetNotificationToken sets the "notification_token" field.
+func (m *CIBATokenMutation) SetNotificationToken(s string) {
+	m.notification_token = &s
+}
+
+// NotificationToken returns the value of the "notification_token" field in the mutation.
+func (m *CIBATokenMutation) NotificationToken() (r string, exists bool) {
+	v := m.notification_token
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldScopes returns the old "scopes" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldNotificationToken returns the old "notification_token" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldScopes(ctx context.Context) (v []string, err error) {
+func (m *CIBATokenMutation) OldNotificationToken(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
+		return v, errors.New("OldNotificationToken is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldScopes requires an ID field in the mutation")
+		return v, errors.New("OldNotificationToken requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
+		return v, fmt.Errorf("querying old value for OldNotificationToken: %w", err)
 	}
-	return oldValue.Scopes, nil
-}
-
-// ClearScopes clears the value of the "scopes" field.
-func (m *DeviceRequestMutation) ClearScopes() {
-	m.scopes = nil
-	m.clearedFields[devicerequest.FieldScopes] = struct{}{}
-}
-
-// ScopesCleared returns if the "scopes" field was cleared in this mutation.
-func (m *DeviceRequestMutation) ScopesCleared() bool {
-	_, ok := m.clearedFields[devicerequest.FieldScopes]
-	return ok
+	return oldValue.NotificationToken, nil
 }
 
-// ResetScopes resets all changes to the "scopes" field.
-func (m *DeviceRequestMutation) ResetScopes() {
-	m.scopes = nil
-	delete(m.clearedFields, devicerequest.FieldScopes)
+// ResetNotificationToken resets all changes to the "notification_token" field.
+func (m *CIBATokenMutation) ResetNotificationToken() {
+	m.notification_token = nil
 }
 
 // SetExpiry sets the "expiry" field.
-func (m *DeviceRequestMutation) SetExpiry(t time.Time) {
+func (m *CIBATokenMutation) SetExpiry(t time.Time) {
 	m.expiry = &t
 }
 
 // Expiry returns the value of the "expiry" field in the mutation.
-func (m *DeviceRequestMutation) Expiry() (r time.Time, exists bool) {
+func (m *CIBATokenMutation) Expiry() (r time.Time, exists bool) {
 	v := m.expiry
 	if v == nil {
 		return
@@ -3350,10 +3537,10 @@ func (m *DeviceRequestMutation) Expiry() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldExpiry returns the old "expiry" field's value of the DeviceRequest entity.
-// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// OldExpiry returns the old "expiry" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+func (m *CIBATokenMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
 	}
@@ -3368,47 +3555,145 @@ func (m *DeviceRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err
 }
 
 // ResetExpiry resets all changes to the "expiry" field.
-func (m *DeviceRequestMutation) ResetExpiry() {
+func (m *CIBATokenMutation) ResetExpiry() {
 	m.expiry = nil
 }
 
-// Where appends a list predicates to the DeviceRequestMutation builder.
-func (m *DeviceRequestMutation) Where(ps ...predicate.DeviceRequest) {
-	m.predicates = append(m.predicates, ps...)
+// SetLastRequest sets the "last_request" field.
+func (m *CIBATokenMutation) SetLastRequest(t time.Time) {
+	m.last_request = &t
 }
 
-// Op returns the operation name.
-func (m *DeviceRequestMutation) Op() Op {
+// LastRequest returns the value of the "last_request" field in the mutation.
+func (m *CIBATokenMutation) LastRequest() (r time.Time, exists bool) {
+	v := m.last_request
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastRequest returns the old "last_request" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CIBATokenMutation) OldLastRequest(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastRequest is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastRequest requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastRequest: %w", err)
+	}
+	return oldValue.LastRequest, nil
+}
+
+// ResetLastRequest resets all changes to the "last_request" field.
+func (m *CIBATokenMutation) ResetLastRequest() {
+	m.last_request = nil
+}
+
+// SetPollInterval sets the "poll_interval" field.
+func (m *CIBATokenMutation) SetPollInterval(i int) {
+	m.poll_interval = &i
+	m.addpoll_interval = nil
+}
+
+// PollInterval returns the value of the "poll_interval" field in the mutation.
+func (m *CIBATokenMutation) PollInterval() (r int, exists bool) {
+	v := m.poll_interval
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPollInterval returns the old "poll_interval" field's value of the CIBAToken entity.
+// If the CIBAToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CIBATokenMutation) OldPollInterval(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPollInterval is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPollInterval requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPollInterval: %w", err)
+	}
+	return oldValue.PollInterval, nil
+}
+
+// AddPollInterval adds i to the "poll_interval" field.
+func (m *CIBATokenMutation) AddPollInterval(i int) {
+	if m.addpoll_interval != nil {
+		*m.addpoll_interval += i
+	} else {
+		m.addpoll_interval = &i
+	}
+}
+
+// AddedPollInterval returns the value that was added to the "poll_interval" field in this mutation.
+func (m *CIBATokenMutation) AddedPollInterval() (r int, exists bool) {
+	v := m.addpoll_interval
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPollInterval resets all changes to the "poll_interval" field.
+func (m *CIBATokenMutation) ResetPollInterval() {
+	m.poll_interval = nil
+	m.addpoll_interval = nil
+}
+
+// Where appends a list predicates to the CIBATokenMutation builder.
+func (m *CIBATokenMutation) Where(ps ...predicate.CIBAToken) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *CIBATokenMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (DeviceRequest).
-func (m *DeviceRequestMutation) Type() string {
+// Type returns the node type of this mutation (CIBAToken).
+func (m *CIBATokenMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *DeviceRequestMutation) Fields() []string {
-	fields := make([]string, 0, 6)
-	if m.user_code != nil {
-		fields = append(fields, devicerequest.FieldUserCode)
-	}
-	if m.device_code != nil {
-		fields = append(fields, devicerequest.FieldDeviceCode)
+func (m *CIBATokenMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.auth_req_id != nil {
+		fields = append(fields, cibatoken.FieldAuthReqID)
 	}
 	if m.client_id != nil {
-		fields = append(fields, devicerequest.FieldClientID)
+		fields = append(fields, cibatoken.FieldClientID)
 	}
-	if m.client_secret != nil {
-		fields = append(fields, devicerequest.FieldClientSecret)
+	if m.status != nil {
+		fields = append(fields, cibatoken.FieldStatus)
 	}
-	if m.scopes != nil {
-		fields = append(fields, devicerequest.FieldScopes)
+	if m.token != nil {
+		fields = append(fields, cibatoken.FieldToken)
+	}
+	if m.notification_token != nil {
+		fields = append(fields, cibatoken.FieldNotificationToken)
 	}
 	if m.expiry != nil {
-		fields = append(fields, devicerequest.FieldExpiry)
+		fields = append(fields, cibatoken.FieldExpiry)
+	}
+	if m.last_request != nil {
+		fields = append(fields, cibatoken.FieldLastRequest)
+	}
+	if m.poll_interval != nil {
+		fields = append(fields, cibatoken.FieldPollInterval)
 	}
 	return fields
 }
@@ -3416,20 +3701,24 @@ func (m *DeviceRequestMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *DeviceRequestMutation) Field(name string) (ent.Value, bool) {
+func (m *CIBATokenMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case devicerequest.FieldUserCode:
-		return m.UserCode()
-	case devicerequest.FieldDeviceCode:
-		return m.DeviceCode()
-	case devicerequest.FieldClientID:
+	case cibatoken.FieldAuthReqID:
+		return m.AuthReqID()
+	case cibatoken.FieldClientID:
 		return m.ClientID()
-	case devicerequest.FieldClientSecret:
-		return m.ClientSecret()
-	case devicerequest.FieldScopes:
-		return m.Scopes()
-	case devicerequest.FieldExpiry:
+	case cibatoken.FieldStatus:
+		return m.Status()
+	case cibatoken.FieldToken:
+		return m.Token()
+	case cibatoken.FieldNotificationToken:
+		return m.NotificationToken()
+	case cibatoken.FieldExpiry:
 		return m.Expiry()
+	case cibatoken.FieldLastRequest:
+		return m.LastRequest()
+	case cibatoken.FieldPollInterval:
+		return m.PollInterval()
 	}
 	return nil, false
 }
@@ -3437,229 +3726,265 @@ func (m *DeviceRequestMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *DeviceRequestMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *CIBATokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case devicerequest.FieldUserCode:
-		return m.OldUserCode(ctx)
-	case devicerequest.FieldDeviceCode:
-		return m.OldDeviceCode(ctx)
-	case devicerequest.FieldClientID:
+	case cibatoken.FieldAuthReqID:
+		return m.OldAuthReqID(ctx)
+	case cibatoken.FieldClientID:
 		return m.OldClientID(ctx)
-	case devicerequest.FieldClientSecret:
-		return m.OldClientSecret(ctx)
-	case devicerequest.FieldScopes:
-		return m.OldScopes(ctx)
-	case devicerequest.FieldExpiry:
+	case cibatoken.FieldStatus:
+		return m.OldStatus(ctx)
+	case cibatoken.FieldToken:
+		return m.OldToken(ctx)
+	case cibatoken.FieldNotificationToken:
+		return m.OldNotificationToken(ctx)
+	case cibatoken.FieldExpiry:
 		return m.OldExpiry(ctx)
+	case cibatoken.FieldLastRequest:
+		return m.OldLastRequest(ctx)
+	case cibatoken.FieldPollInterval:
+		return m.OldPollInterval(ctx)
 	}
-	return nil, fmt.Errorf("unknown DeviceRequest field %s", name)
+	return nil, fmt.Errorf("unknown CIBAToken field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DeviceRequestMutation) SetField(name string, value ent.Value) error {
+func (m *CIBATokenMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case devicerequest.FieldUserCode:
+	case cibatoken.FieldAuthReqID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUserCode(v)
+		m.SetAuthReqID(v)
 		return nil
-	case devicerequest.FieldDeviceCode:
+	case cibatoken.FieldClientID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeviceCode(v)
+		m.SetClientID(v)
 		return nil
-	case devicerequest.FieldClientID:
+	case cibatoken.FieldStatus:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetClientID(v)
+		m.SetStatus(v)
 		return nil
-	case devicerequest.FieldClientSecret:
-		v, ok := value.(string)
+	case cibatoken.FieldToken:
+		v, ok := value.([]byte)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetClientSecret(v)
+		m.SetToken(v)
 		return nil
-	case devicerequest.FieldScopes:
-		v, ok := value.([]string)
+	case cibatoken.FieldNotificationToken:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetScopes(v)
+		m.SetNotificationToken(v)
 		return nil
-	case devicerequest.FieldExpiry:
+	case cibatoken.FieldExpiry:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetExpiry(v)
 		return nil
+	case cibatoken.FieldLastRequest:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastRequest(v)
+		return nil
+	case cibatoken.FieldPollInterval:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPollInterval(v)
+		return nil
 	}
-	return fmt.Errorf("unknown DeviceRequest field %s", name)
+	return fmt.Errorf("unknown CIBAToken field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *DeviceRequestMutation) AddedFields() []string {
-	return nil
+func (m *CIBATokenMutation) AddedFields() []string {
+	var fields []string
+	if m.addpoll_interval != nil {
+		fields = append(fields, cibatoken.FieldPollInterval)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *DeviceRequestMutation) AddedField(name string) (ent.Value, bool) {
+func (m *CIBATokenMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case cibatoken.FieldPollInterval:
+		return m.AddedPollInterval()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DeviceRequestMutation) AddField(name string, value ent.Value) error {
+func (m *CIBATokenMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case cibatoken.FieldPollInterval:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPollInterval(v)
+		return nil
 	}
-	return fmt.Errorf("unknown DeviceRequest numeric field %s", name)
+	return fmt.Errorf("unknown CIBAToken numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *DeviceRequestMutation) ClearedFields() []string {
+func (m *CIBATokenMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(devicerequest.FieldScopes) {
-		fields = append(fields, devicerequest.FieldScopes)
+	if m.FieldCleared(cibatoken.FieldToken) {
+		fields = append(fields, cibatoken.FieldToken)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *DeviceRequestMutation) FieldCleared(name string) bool {
+func (m *CIBATokenMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *DeviceRequestMutation) ClearField(name string) error {
+func (m *CIBATokenMutation) ClearField(name string) error {
 	switch name {
-	case devicerequest.FieldScopes:
-		m.ClearScopes()
+	case cibatoken.FieldToken:
+		m.ClearToken()
 		return nil
 	}
-	return fmt.Errorf("unknown DeviceRequest nullable field %s", name)
+	return fmt.Errorf("unknown CIBAToken nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *DeviceRequestMutation) ResetField(name string) error {
+func (m *CIBATokenMutation) ResetField(name string) error {
 	switch name {
-	case devicerequest.FieldUserCode:
-		m.ResetUserCode()
-		return nil
-	case devicerequest.FieldDeviceCode:
-		m.ResetDeviceCode()
+	case cibatoken.FieldAuthReqID:
+		m.ResetAuthReqID()
 		return nil
-	case devicerequest.FieldClientID:
+	case cibatoken.FieldClientID:
 		m.ResetClientID()
 		return nil
-	case devicerequest.FieldClientSecret:
-		m.ResetClientSecret()
+	case cibatoken.FieldStatus:
+		m.ResetStatus()
 		return nil
-	case devicerequest.FieldScopes:
-		m.ResetScopes()
+	case cibatoken.FieldToken:
+		m.ResetToken()
 		return nil
-	case devicerequest.FieldExpiry:
+	case cibatoken.FieldNotificationToken:
+		m.ResetNotificationToken()
+		return nil
+	case cibatoken.FieldExpiry:
 		m.ResetExpiry()
 		return nil
+	case cibatoken.FieldLastRequest:
+		m.ResetLastRequest()
+		return nil
+	case cibatoken.FieldPollInterval:
+		m.ResetPollInterval()
+		return nil
 	}
-	return fmt.Errorf("unknown DeviceRequest field %s", name)
+	return fmt.Errorf("unknown CIBAToken field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *DeviceRequestMutation) AddedEdges() []string {
+func (m *CIBATokenMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *DeviceRequestMutation) AddedIDs(name string) []ent.Value {
+func (m *CIBATokenMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *DeviceRequestMutation) RemovedEdges() []string {
+func (m *CIBATokenMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *DeviceRequestMutation) RemovedIDs(name string) []ent.Value {
+func (m *CIBATokenMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *DeviceRequestMutation) ClearedEdges() []string {
+func (m *CIBATokenMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *DeviceRequestMutation) EdgeCleared(name string) bool {
+func (m *CIBATokenMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *DeviceRequestMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown DeviceRequest unique edge %s", name)
+func (m *CIBATokenMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown CIBAToken unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *DeviceRequestMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown DeviceRequest edge %s", name)
+func (m *CIBATokenMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown CIBAToken edge %s", name)
 }
 
-// DeviceTokenMutation represents an operation that mutates the DeviceToken nodes in the graph.
-type DeviceTokenMutation struct {
+// ConnectorMutation represents an operation that mutates the Connector nodes in the graph.
+type ConnectorMutation struct {
 	config
 	op               Op
 	typ              string
-	id               *int
-	device_code      *string
-	status           *string
-	token            *[]byte
-	expiry           *time.Time
-	last_request     *time.Time
-	poll_interval    *int
-	addpoll_interval *int
+	id               *string
+	_type            *string
+	name             *string
+	resource_version *string
+	_config          *[]byte
 	clearedFields    map[string]struct{}
 	done             bool
-	oldValue         func(context.Context) (*DeviceToken, error)
-	predicates       []predicate.DeviceToken
+	oldValue         func(context.Context) (*Connector, error)
+	predicates       []predicate.Connector
 }
 
-var _ ent.Mutation = (*DeviceTokenMutation)(nil)
+var _ ent.Mutation = (*ConnectorMutation)(nil)
 
-// devicetokenOption allows management of the mutation configuration using functional options.
-type devicetokenOption func(*DeviceTokenMutation)
+// connectorOption allows management of the mutation configuration using functional options.
+type connectorOption func(*ConnectorMutation)
 
-// newDeviceTokenMutation creates new mutation for the DeviceToken entity.
-func newDeviceTokenMutation(c config, op Op, opts ...devicetokenOption) *DeviceTokenMutation {
-	m := &DeviceTokenMutation{
+// newConnectorMutation creates new mutation for the Connector entity.
+func newConnectorMutation(c config, op Op, opts ...connectorOption) *ConnectorMutation {
+	m := &ConnectorMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeDeviceToken,
+		typ:           TypeConnector,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3668,20 +3993,20 @@ func newDeviceTokenMutation(c config, op Op, opts ...devicetokenOption) *DeviceT
 	return m
 }
 
-// withDeviceTokenID sets the ID field of the mutation.
-func withDeviceTokenID(id int) devicetokenOption {
-	return func(m *DeviceTokenMutation) {
+// withConnectorID sets the ID field of the mutation.
+func withConnectorID(id string) connectorOption {
+	return func(m *ConnectorMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *DeviceToken
+			value *Connector
 		)
-		m.oldValue = func(ctx context.Context) (*DeviceToken, error) {
+		m.oldValue = func(ctx context.Context) (*Connector, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().DeviceToken.Get(ctx, id)
+					value, err = m.Client().Connector.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3690,10 +4015,10 @@ func withDeviceTokenID(id int) devicetokenOption {
 	}
 }
 
-// withDeviceToken sets the old DeviceToken of the mutation.
-func withDeviceToken(node *DeviceToken) devicetokenOption {
-	return func(m *DeviceTokenMutation) {
-		m.oldValue = func(context.Context) (*DeviceToken, error) {
+// withConnector sets the old Connector of the mutation.
+func withConnector(node *Connector) connectorOption {
+	return func(m *ConnectorMutation) {
+		m.oldValue = func(context.Context) (*Connector, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3702,7 +4027,7 @@ func withDeviceToken(node *DeviceToken) devicetokenOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m DeviceTokenMutation) Client() *Client {
+func (m ConnectorMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3710,7 +4035,7 @@ func (m DeviceTokenMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m DeviceTokenMutation) Tx() (*Tx, error) {
+func (m ConnectorMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -3719,9 +4044,15 @@ func (m DeviceTokenMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Connector entities.
+func (m *ConnectorMutation) SetID(id string) {
+	m.id = &id
+}
+
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *DeviceTokenMutation) ID() (id int, exists bool) {
+func (m *ConnectorMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -3732,570 +4063,409 @@ func (m *DeviceTokenMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *DeviceTokenMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *ConnectorMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []int{id}, nil
+			return []string{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().DeviceToken.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Connector.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetDeviceCode sets the "device_code" field.
-func (m *DeviceTokenMutation) SetDeviceCode(s string) {
-	m.device_code = &s
+// SetType sets the "type" field.
+func (m *ConnectorMutation) SetType(s string) {
+	m._type = &s
 }
 
-// DeviceCode returns the value of the "device_code" field in the mutation.
-func (m *DeviceTokenMutation) DeviceCode() (r string, exists bool) {
-	v := m.device_code
+// GetType returns the value of the "type" field in the mutation.
+func (m *ConnectorMutation) GetType() (r string, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDeviceCode returns the old "device_code" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the Connector entity.
+// If the Connector object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldDeviceCode(ctx context.Context) (v string, err error) {
+func (m *ConnectorMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeviceCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeviceCode requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeviceCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.DeviceCode, nil
+	return oldValue.Type, nil
 }
 
-// ResetDeviceCode resets all changes to the "device_code" field.
-func (m *DeviceTokenMutation) ResetDeviceCode() {
-	m.device_code = nil
+// ResetType resets all changes to the "type" field.
+func (m *ConnectorMutation) ResetType() {
+	m._type = nil
 }
 
-// SetStatus sets the "status" field.
-func (m *DeviceTokenMutation) SetStatus(s string) {
-	m.status = &s
+// SetName sets the "name" field.
+func (m *ConnectorMutation) SetName(s string) {
+	m.name = &s
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *DeviceTokenMutation) Status() (r string, exists bool) {
-	v := m.status
+// Name returns the value of the "name" field in the mutation.
+func (m *ConnectorMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Connector entity.
+// If the Connector object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldStatus(ctx context.Context) (v string, err error) {
+func (m *ConnectorMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.Name, nil
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *DeviceTokenMutation) ResetStatus() {
-	m.status = nil
+// ResetName resets all changes to the "name" field.
+func (m *ConnectorMutation) ResetName() {
+	m.name = nil
 }
 
-// SetToken sets the "token" field.
-func (m *DeviceTokenMutation) SetToken(b []byte) {
-	m.token = &b
+// SetResourceVersion sets the "resource_version" field.
+func (m *ConnectorMutation) SetResourceVersion(s string) {
+	m.resource_version = &s
 }
 
-// Token returns the value of the "token" field in the mutation.
-func (m *DeviceTokenMutation) Token() (r []byte, exists bool) {
-	v := m.token
+// ResourceVersion returns the value of the "resource_version" field in the mutation.
+func (m *ConnectorMutation) ResourceVersion() (r string, exists bool) {
+	v := m.resource_version
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldToken returns the old "token" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// OldResourceVersion returns the old "resource_version" field's value of the Connector entity.
+// If the Connector object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldToken(ctx context.Context) (v *[]byte, err error) {
+func (m *ConnectorMutation) OldResourceVersion(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldToken is only allowed on UpdateOne operations")
+		return v, errors.New("OldResourceVersion is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldToken requires an ID field in the mutation")
+		return v, errors.New("OldResourceVersion requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldToken: %w", err)
+		return v, fmt.Errorf("querying old value for OldResourceVersion: %w", err)
 	}
-	return oldValue.Token, nil
-}
-
-// ClearToken clears the value of the "token" field.
-func (m *DeviceTokenMutation) ClearToken() {
-	m.token = nil
-	m.clearedFields[devicetoken.FieldToken] = struct{}{}
-}
-
-// TokenCleared returns if the "token" field was cleared in this mutation.
-func (m *DeviceTokenMutation) TokenCleared() bool {
-	_, ok := m.clearedFields[devicetoken.FieldToken]
-	return ok
+	return oldValue.ResourceVersion, nil
 }
 
-// ResetToken resets all changes to the "token" field.
-func (m *DeviceTokenMutation) ResetToken() {
-	m.token = nil
-	delete(m.clearedFields, devicetoken.FieldToken)
+// ResetResourceVersion resets all changes to the "resource_version" field.
+func (m *ConnectorMutation) ResetResourceVersion() {
+	m.resource_version = nil
 }
 
-// SetExpiry sets the "expiry" field.
-func (m *DeviceTokenMutation) SetExpiry(t time.Time) {
-	m.expiry = &t
+// SetConfig sets the "config" field.
+func (m *ConnectorMutation) SetConfig(b []byte) {
+	m._config = &b
 }
 
-// Expiry returns the value of the "expiry" field in the mutation.
-func (m *DeviceTokenMutation) Expiry() (r time.Time, exists bool) {
-	v := m.expiry
+// Config returns the value of the "config" field in the mutation.
+func (m *ConnectorMutation) Config() (r []byte, exists bool) {
+	v := m._config
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiry returns the old "expiry" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// OldConfig returns the old "config" field's value of the Connector entity.
+// If the Connector object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+func (m *ConnectorMutation) OldConfig(ctx context.Context) (v []byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+		return v, errors.New("OldConfig is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiry requires an ID field in the mutation")
+		return v, errors.New("OldConfig requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+		return v, fmt.Errorf("querying old value for OldConfig: %w", err)
 	}
-	return oldValue.Expiry, nil
+	return oldValue.Config, nil
 }
 
-// ResetExpiry resets all changes to the "expiry" field.
-func (m *DeviceTokenMutation) ResetExpiry() {
-	m.expiry = nil
+// ResetConfig resets all changes to the "config" field.
+func (m *ConnectorMutation) ResetConfig() {
+	m._config = nil
 }
 
-// SetLastRequest sets the "last_request" field.
-func (m *DeviceTokenMutation) SetLastRequest(t time.Time) {
-	m.last_request = &t
+// Where appends a list predicates to the ConnectorMutation builder.
+func (m *ConnectorMutation) Where(ps ...predicate.Connector) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// LastRequest returns the value of the "last_request" field in the mutation.
-func (m *DeviceTokenMutation) LastRequest() (r time.Time, exists bool) {
-	v := m.last_request
-	if v == nil {
-		return
-	}
-	return *v, true
+// Op returns the operation name.
+func (m *ConnectorMutation) Op() Op {
+	return m.op
 }
 
-// OldLastRequest returns the old "last_request" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldLastRequest(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastRequest is only allowed on UpdateOne operations")
+// Type returns the node type of this mutation (Connector).
+func (m *ConnectorMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ConnectorMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m._type != nil {
+		fields = append(fields, connector.FieldType)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastRequest requires an ID field in the mutation")
+	if m.name != nil {
+		fields = append(fields, connector.FieldName)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastRequest: %w", err)
+	if m.resource_version != nil {
+		fields = append(fields, connector.FieldResourceVersion)
 	}
-	return oldValue.LastRequest, nil
-}
-
-// ResetLastRequest resets all changes to the "last_request" field.
-func (m *DeviceTokenMutation) ResetLastRequest() {
-	m.last_request = nil
+	if m._config != nil {
+		fields = append(fields, connector.FieldConfig)
+	}
+	return fields
 }
 
-// SetPollInterval sets the "poll_interval" field.
-func (m *DeviceTokenMutation) SetPollInterval(i int) {
-	m.poll_interval = &i
-	m.addpoll_interval = nil
-}
-
-// PollInterval returns the value of the "poll_interval" field in the mutation.
-func (m *DeviceTokenMutation) PollInterval() (r int, exists bool) {
-	v := m.poll_interval
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldPollInterval returns the old "poll_interval" field's value of the DeviceToken entity.
-// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DeviceTokenMutation) OldPollInterval(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPollInterval is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPollInterval requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPollInterval: %w", err)
-	}
-	return oldValue.PollInterval, nil
-}
-
-// AddPollInterval adds i to the "poll_interval" field.
-func (m *DeviceTokenMutation) AddPollInterval(i int) {
-	if m.addpoll_interval != nil {
-		*m.addpoll_interval += i
-	} else {
-		m.addpoll_interval = &i
-	}
-}
-
-// AddedPollInterval returns the value that was added to the "poll_interval" field in this mutation.
-func (m *DeviceTokenMutation) AddedPollInterval() (r int, exists bool) {
-	v := m.addpoll_interval
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// ResetPollInterval resets all changes to the "poll_interval" field.
-func (m *DeviceTokenMutation) ResetPollInterval() {
-	m.poll_interval = nil
-	m.addpoll_interval = nil
-}
-
-// Where appends a list predicates to the DeviceTokenMutation builder.
-func (m *DeviceTokenMutation) Where(ps ...predicate.DeviceToken) {
-	m.predicates = append(m.predicates, ps...)
-}
-
-// Op returns the operation name.
-func (m *DeviceTokenMutation) Op() Op {
-	return m.op
-}
-
-// Type returns the node type of this mutation (DeviceToken).
-func (m *DeviceTokenMutation) Type() string {
-	return m.typ
-}
-
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *DeviceTokenMutation) Fields() []string {
-	fields := make([]string, 0, 6)
-	if m.device_code != nil {
-		fields = append(fields, devicetoken.FieldDeviceCode)
-	}
-	if m.status != nil {
-		fields = append(fields, devicetoken.FieldStatus)
-	}
-	if m.token != nil {
-		fields = append(fields, devicetoken.FieldToken)
-	}
-	if m.expiry != nil {
-		fields = append(fields, devicetoken.FieldExpiry)
-	}
-	if m.last_request != nil {
-		fields = append(fields, devicetoken.FieldLastRequest)
-	}
-	if m.poll_interval != nil {
-		fields = append(fields, devicetoken.FieldPollInterval)
-	}
-	return fields
-}
-
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *DeviceTokenMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case devicetoken.FieldDeviceCode:
-		return m.DeviceCode()
-	case devicetoken.FieldStatus:
-		return m.Status()
-	case devicetoken.FieldToken:
-		return m.Token()
-	case devicetoken.FieldExpiry:
-		return m.Expiry()
-	case devicetoken.FieldLastRequest:
-		return m.LastRequest()
-	case devicetoken.FieldPollInterval:
-		return m.PollInterval()
-	}
-	return nil, false
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ConnectorMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case connector.FieldType:
+		return m.GetType()
+	case connector.FieldName:
+		return m.Name()
+	case connector.FieldResourceVersion:
+		return m.ResourceVersion()
+	case connector.FieldConfig:
+		return m.Config()
+	}
+	return nil, false
 }
 
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *DeviceTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ConnectorMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case devicetoken.FieldDeviceCode:
-		return m.OldDeviceCode(ctx)
-	case devicetoken.FieldStatus:
-		return m.OldStatus(ctx)
-	case devicetoken.FieldToken:
-		return m.OldToken(ctx)
-	case devicetoken.FieldExpiry:
-		return m.OldExpiry(ctx)
-	case devicetoken.FieldLastRequest:
-		return m.OldLastRequest(ctx)
-	case devicetoken.FieldPollInterval:
-		return m.OldPollInterval(ctx)
+	case connector.FieldType:
+		return m.OldType(ctx)
+	case connector.FieldName:
+		return m.OldName(ctx)
+	case connector.FieldResourceVersion:
+		return m.OldResourceVersion(ctx)
+	case connector.FieldConfig:
+		return m.OldConfig(ctx)
 	}
-	return nil, fmt.Errorf("unknown DeviceToken field %s", name)
+	return nil, fmt.Errorf("unknown Connector field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DeviceTokenMutation) SetField(name string, value ent.Value) error {
+func (m *ConnectorMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case devicetoken.FieldDeviceCode:
+	case connector.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeviceCode(v)
+		m.SetType(v)
 		return nil
-	case devicetoken.FieldStatus:
+	case connector.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetStatus(v)
-		return nil
-	case devicetoken.FieldToken:
-		v, ok := value.([]byte)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetToken(v)
-		return nil
-	case devicetoken.FieldExpiry:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetExpiry(v)
+		m.SetName(v)
 		return nil
-	case devicetoken.FieldLastRequest:
-		v, ok := value.(time.Time)
+	case connector.FieldResourceVersion:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLastRequest(v)
+		m.SetResourceVersion(v)
 		return nil
-	case devicetoken.FieldPollInterval:
-		v, ok := value.(int)
+	case connector.FieldConfig:
+		v, ok := value.([]byte)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPollInterval(v)
+		m.SetConfig(v)
 		return nil
 	}
-	return fmt.Errorf("unknown DeviceToken field %s", name)
+	return fmt.Errorf("unknown Connector field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *DeviceTokenMutation) AddedFields() []string {
-	var fields []string
-	if m.addpoll_interval != nil {
-		fields = append(fields, devicetoken.FieldPollInterval)
-	}
-	return fields
+func (m *ConnectorMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *DeviceTokenMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case devicetoken.FieldPollInterval:
-		return m.AddedPollInterval()
-	}
+func (m *ConnectorMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DeviceTokenMutation) AddField(name string, value ent.Value) error {
+func (m *ConnectorMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case devicetoken.FieldPollInterval:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddPollInterval(v)
-		return nil
 	}
-	return fmt.Errorf("unknown DeviceToken numeric field %s", name)
+	return fmt.Errorf("unknown Connector numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *DeviceTokenMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(devicetoken.FieldToken) {
-		fields = append(fields, devicetoken.FieldToken)
-	}
-	return fields
+func (m *ConnectorMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *DeviceTokenMutation) FieldCleared(name string) bool {
+func (m *ConnectorMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *DeviceTokenMutation) ClearField(name string) error {
-	switch name {
-	case devicetoken.FieldToken:
-		m.ClearToken()
-		return nil
-	}
-	return fmt.Errorf("unknown DeviceToken nullable field %s", name)
+func (m *ConnectorMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Connector nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *DeviceTokenMutation) ResetField(name string) error {
+func (m *ConnectorMutation) ResetField(name string) error {
 	switch name {
-	case devicetoken.FieldDeviceCode:
-		m.ResetDeviceCode()
-		return nil
-	case devicetoken.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case devicetoken.FieldToken:
-		m.ResetToken()
+	case connector.FieldType:
+		m.ResetType()
 		return nil
-	case devicetoken.FieldExpiry:
-		m.ResetExpiry()
+	case connector.FieldName:
+		m.ResetName()
 		return nil
-	case devicetoken.FieldLastRequest:
-		m.ResetLastRequest()
+	case connector.FieldResourceVersion:
+		m.ResetResourceVersion()
 		return nil
-	case devicetoken.FieldPollInterval:
-		m.ResetPollInterval()
+	case connector.FieldConfig:
+		m.ResetConfig()
 		return nil
 	}
-	return fmt.Errorf("unknown DeviceToken field %s", name)
+	return fmt.Errorf("unknown Connector field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *DeviceTokenMutation) AddedEdges() []string {
+func (m *ConnectorMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *DeviceTokenMutation) AddedIDs(name string) []ent.Value {
+func (m *ConnectorMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *DeviceTokenMutation) RemovedEdges() []string {
+func (m *ConnectorMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *DeviceTokenMutation) RemovedIDs(name string) []ent.Value {
+func (m *ConnectorMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *DeviceTokenMutation) ClearedEdges() []string {
+func (m *ConnectorMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *DeviceTokenMutation) EdgeCleared(name string) bool {
+func (m *ConnectorMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *DeviceTokenMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown DeviceToken unique edge %s", name)
+func (m *ConnectorMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Connector unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *DeviceTokenMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown DeviceToken edge %s", name)
+func (m *ConnectorMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Connector edge %s", name)
 }
 
-// KeysMutation represents an operation that mutates the Keys nodes in the graph.
-type KeysMutation struct {
+// DeviceRequestMutation represents an operation that mutates the DeviceRequest nodes in the graph.
+type DeviceRequestMutation struct {
 	config
-	op                Op
-	typ               string
-	id                *string
-	verification_keys *[]storage.VerificationKey
-	signing_key       *jose.JSONWebKey
-	signing_key_pub   *jose.JSONWebKey
-	next_rotation     *time.Time
-	clearedFields     map[string]struct{}
-	done              bool
-	oldValue          func(context.Context) (*Keys, error)
-	predicates        []predicate.Keys
+	op            Op
+	typ           string
+	id            *int
+	user_code     *string
+	device_code   *string
+	client_id     *string
+	client_secret *string
+	scopes        *[]string
+	expiry        *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*DeviceRequest, error)
+	predicates    []predicate.DeviceRequest
 }
 
-var _ ent.Mutation = (*KeysMutation)(nil)
+var _ ent.Mutation = (*DeviceRequestMutation)(nil)
 
-// keysOption allows management of the mutation configuration using functional options.
-type keysOption func(*KeysMutation)
+// devicerequestOption allows management of the mutation configuration using functional options.
+type devicerequestOption func(*DeviceRequestMutation)
 
-// newKeysMutation creates new mutation for the Keys entity.
-func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
-	m := &KeysMutation{
+// newDeviceRequestMutation creates new mutation for the DeviceRequest entity.
+func newDeviceRequestMutation(c config, op Op, opts ...devicerequestOption) *DeviceRequestMutation {
+	m := &DeviceRequestMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeKeys,
+		typ:           TypeDeviceRequest,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4304,20 +4474,20 @@ func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
 	return m
 }
 
-// withKeysID sets the ID field of the mutation.
-func withKeysID(id string) keysOption {
-	return func(m *KeysMutation) {
+// withDeviceRequestID sets the ID field of the mutation.
+func withDeviceRequestID(id int) devicerequestOption {
+	return func(m *DeviceRequestMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Keys
+			value *DeviceRequest
 		)
-		m.oldValue = func(ctx context.Context) (*Keys, error) {
+		m.oldValue = func(ctx context.Context) (*DeviceRequest, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Keys.Get(ctx, id)
+					value, err = m.Client().DeviceRequest.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4326,10 +4496,10 @@ func withKeysID(id string) keysOption {
 	}
 }
 
-// withKeys sets the old Keys of the mutation.
-func withKeys(node *Keys) keysOption {
-	return func(m *KeysMutation) {
-		m.oldValue = func(context.Context) (*Keys, error) {
+// withDeviceRequest sets the old DeviceRequest of the mutation.
+func withDeviceRequest(node *DeviceRequest) devicerequestOption {
+	return func(m *DeviceRequestMutation) {
+		m.oldValue = func(context.Context) (*DeviceRequest, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4338,7 +4508,7 @@ func withKeys(node *Keys) keysOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m KeysMutation) Client() *Client {
+func (m DeviceRequestMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4346,7 +4516,7 @@ func (m KeysMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m KeysMutation) Tx() (*Tx, error) {
+func (m DeviceRequestMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -4355,15 +4525,9 @@ func (m KeysMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Keys entities.
-func (m *KeysMutation) SetID(id string) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *KeysMutation) ID() (id string, exists bool) {
+func (m *DeviceRequestMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -4374,196 +4538,287 @@ func (m *KeysMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *KeysMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *DeviceRequestMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []string{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Keys.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().DeviceRequest.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetVerificationKeys sets the "verification_keys" field.
-func (m *KeysMutation) SetVerificationKeys(sk []storage.VerificationKey) {
-	m.verification_keys = &sk
+// SetUserCode sets the "user_code" field.
+func (m *DeviceRequestMutation) SetUserCode(s string) {
+	m.user_code = &s
 }
 
-// VerificationKeys returns the value of the "verification_keys" field in the mutation.
-func (m *KeysMutation) VerificationKeys() (r []storage.VerificationKey, exists bool) {
-	v := m.verification_keys
+// UserCode returns the value of the "user_code" field in the mutation.
+func (m *DeviceRequestMutation) UserCode() (r string, exists bool) {
+	v := m.user_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVerificationKeys returns the old "verification_keys" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldUserCode returns the old "user_code" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldVerificationKeys(ctx context.Context) (v []storage.VerificationKey, err error) {
+func (m *DeviceRequestMutation) OldUserCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVerificationKeys is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVerificationKeys requires an ID field in the mutation")
+		return v, errors.New("OldUserCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVerificationKeys: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserCode: %w", err)
 	}
-	return oldValue.VerificationKeys, nil
+	return oldValue.UserCode, nil
 }
 
-// ResetVerificationKeys resets all changes to the "verification_keys" field.
-func (m *KeysMutation) ResetVerificationKeys() {
-	m.verification_keys = nil
+// ResetUserCode resets all changes to the "user_code" field.
+func (m *DeviceRequestMutation) ResetUserCode() {
+	m.user_code = nil
 }
 
-// SetSigningKey sets the "signing_key" field.
-func (m *KeysMutation) SetSigningKey(jwk jose.JSONWebKey) {
-	m.signing_key = &jwk
+// SetDeviceCode sets the "device_code" field.
+func (m *DeviceRequestMutation) SetDeviceCode(s string) {
+	m.device_code = &s
 }
 
-// SigningKey returns the value of the "signing_key" field in the mutation.
-func (m *KeysMutation) SigningKey() (r jose.JSONWebKey, exists bool) {
-	v := m.signing_key
+// DeviceCode returns the value of the "device_code" field in the mutation.
+func (m *DeviceRequestMutation) DeviceCode() (r string, exists bool) {
+	v := m.device_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSigningKey returns the old "signing_key" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldDeviceCode returns the old "device_code" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldSigningKey(ctx context.Context) (v jose.JSONWebKey, err error) {
+func (m *DeviceRequestMutation) OldDeviceCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSigningKey is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeviceCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSigningKey requires an ID field in the mutation")
+		return v, errors.New("OldDeviceCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSigningKey: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeviceCode: %w", err)
 	}
-	return oldValue.SigningKey, nil
+	return oldValue.DeviceCode, nil
 }
 
-// ResetSigningKey resets all changes to the "signing_key" field.
-func (m *KeysMutation) ResetSigningKey() {
-	m.signing_key = nil
+// ResetDeviceCode resets all changes to the "device_code" field.
+func (m *DeviceRequestMutation) ResetDeviceCode() {
+	m.device_code = nil
 }
 
-// SetSigningKeyPub sets the "signing_key_pub" field.
-func (m *KeysMutation) SetSigningKeyPub(jwk jose.JSONWebKey) {
-	m.signing_key_pub = &jwk
+// SetClientID sets the "client_id" field.
+func (m *DeviceRequestMutation) SetClientID(s string) {
+	m.client_id = &s
 }
 
-// SigningKeyPub returns the value of the "signing_key_pub" field in the mutation.
-func (m *KeysMutation) SigningKeyPub() (r jose.JSONWebKey, exists bool) {
-	v := m.signing_key_pub
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *DeviceRequestMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSigningKeyPub returns the old "signing_key_pub" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldClientID returns the old "client_id" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldSigningKeyPub(ctx context.Context) (v jose.JSONWebKey, err error) {
+func (m *DeviceRequestMutation) OldClientID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSigningKeyPub is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSigningKeyPub requires an ID field in the mutation")
+		return v, errors.New("OldClientID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSigningKeyPub: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
 	}
-	return oldValue.SigningKeyPub, nil
+	return oldValue.ClientID, nil
 }
 
-// ResetSigningKeyPub resets all changes to the "signing_key_pub" field.
-func (m *KeysMutation) ResetSigningKeyPub() {
-	m.signing_key_pub = nil
+// ResetClientID resets all changes to the "client_id" field.
+func (m *DeviceRequestMutation) ResetClientID() {
+	m.client_id = nil
 }
 
-// SetNextRotation sets the "next_rotation" field.
-func (m *KeysMutation) SetNextRotation(t time.Time) {
-	m.next_rotation = &t
+// SetClientSecret sets the "client_secret" field.
+func (m *DeviceRequestMutation) SetClientSecret(s string) {
+	m.client_secret = &s
 }
 
-// NextRotation returns the value of the "next_rotation" field in the mutation.
-func (m *KeysMutation) NextRotation() (r time.Time, exists bool) {
-	v := m.next_rotation
+// ClientSecret returns the value of the "client_secret" field in the mutation.
+func (m *DeviceRequestMutation) ClientSecret() (r string, exists bool) {
+	v := m.client_secret
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNextRotation returns the old "next_rotation" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldClientSecret returns the old "client_secret" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldNextRotation(ctx context.Context) (v time.Time, err error) {
+func (m *DeviceRequestMutation) OldClientSecret(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNextRotation is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientSecret is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNextRotation requires an ID field in the mutation")
+		return v, errors.New("OldClientSecret requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNextRotation: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientSecret: %w", err)
 	}
-	return oldValue.NextRotation, nil
-}
-
-// ResetNextRotation resets all changes to the "next_rotation" field.
-func (m *KeysMutation) ResetNextRotation() {
-	m.next_rotation = nil
+	return oldValue.ClientSecret, nil
 }
 
-// Where appends a list predicates to the KeysMutation builder.
-func (m *KeysMutation) Where(ps ...predicate.Keys) {
-	m.predicates = append(m.predicates, ps...)
+// ResetClientSecret resets all changes to the "client_secret" field.
+func (m *DeviceRequestMutation) ResetClientSecret() {
+	m.client_secret = nil
 }
 
-// Op returns the operation name.
-func (m *KeysMutation) Op() Op {
-	return m.op
+// SetScopes sets the "scopes" field.
+func (m *DeviceRequestMutation) SetScopes(s []string) {
+	m.scopes = &s
 }
 
-// Type returns the node type of this mutation (Keys).
-func (m *KeysMutation) Type() string {
-	return m.typ
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *DeviceRequestMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *KeysMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.verification_keys != nil {
-		fields = append(fields, keys.FieldVerificationKeys)
-	}
-	if m.signing_key != nil {
-		fields = append(fields, keys.FieldSigningKey)
+// OldScopes returns the old "scopes" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceRequestMutation) OldScopes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
 	}
-	if m.signing_key_pub != nil {
-		fields = append(fields, keys.FieldSigningKeyPub)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScopes requires an ID field in the mutation")
 	}
-	if m.next_rotation != nil {
-		fields = append(fields, keys.FieldNextRotation)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
+	}
+	return oldValue.Scopes, nil
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (m *DeviceRequestMutation) ClearScopes() {
+	m.scopes = nil
+	m.clearedFields[devicerequest.FieldScopes] = struct{}{}
+}
+
+// ScopesCleared returns if the "scopes" field was cleared in this mutation.
+func (m *DeviceRequestMutation) ScopesCleared() bool {
+	_, ok := m.clearedFields[devicerequest.FieldScopes]
+	return ok
+}
+
+// ResetScopes resets all changes to the "scopes" field.
+func (m *DeviceRequestMutation) ResetScopes() {
+	m.scopes = nil
+	delete(m.clearedFields, devicerequest.FieldScopes)
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *DeviceRequestMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *DeviceRequestMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the DeviceRequest entity.
+// If the DeviceRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *DeviceRequestMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// Where appends a list predicates to the DeviceRequestMutation builder.
+func (m *DeviceRequestMutation) Where(ps ...predicate.DeviceRequest) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *DeviceRequestMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (DeviceRequest).
+func (m *DeviceRequestMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *DeviceRequestMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.user_code != nil {
+		fields = append(fields, devicerequest.FieldUserCode)
+	}
+	if m.device_code != nil {
+		fields = append(fields, devicerequest.FieldDeviceCode)
+	}
+	if m.client_id != nil {
+		fields = append(fields, devicerequest.FieldClientID)
+	}
+	if m.client_secret != nil {
+		fields = append(fields, devicerequest.FieldClientSecret)
+	}
+	if m.scopes != nil {
+		fields = append(fields, devicerequest.FieldScopes)
+	}
+	if m.expiry != nil {
+		fields = append(fields, devicerequest.FieldExpiry)
 	}
 	return fields
 }
@@ -4571,16 +4826,20 @@ func (m *KeysMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *KeysMutation) Field(name string) (ent.Value, bool) {
+func (m *DeviceRequestMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case keys.FieldVerificationKeys:
-		return m.VerificationKeys()
-	case keys.FieldSigningKey:
-		return m.SigningKey()
-	case keys.FieldSigningKeyPub:
-		return m.SigningKeyPub()
-	case keys.FieldNextRotation:
-		return m.NextRotation()
+	case devicerequest.FieldUserCode:
+		return m.UserCode()
+	case devicerequest.FieldDeviceCode:
+		return m.DeviceCode()
+	case devicerequest.FieldClientID:
+		return m.ClientID()
+	case devicerequest.FieldClientSecret:
+		return m.ClientSecret()
+	case devicerequest.FieldScopes:
+		return m.Scopes()
+	case devicerequest.FieldExpiry:
+		return m.Expiry()
 	}
 	return nil, false
 }
@@ -4588,195 +4847,229 @@ func (m *KeysMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *KeysMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *DeviceRequestMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case keys.FieldVerificationKeys:
-		return m.OldVerificationKeys(ctx)
-	case keys.FieldSigningKey:
-		return m.OldSigningKey(ctx)
-	case keys.FieldSigningKeyPub:
-		return m.OldSigningKeyPub(ctx)
-	case keys.FieldNextRotation:
-		return m.OldNextRotation(ctx)
+	case devicerequest.FieldUserCode:
+		return m.OldUserCode(ctx)
+	case devicerequest.FieldDeviceCode:
+		return m.OldDeviceCode(ctx)
+	case devicerequest.FieldClientID:
+		return m.OldClientID(ctx)
+	case devicerequest.FieldClientSecret:
+		return m.OldClientSecret(ctx)
+	case devicerequest.FieldScopes:
+		return m.OldScopes(ctx)
+	case devicerequest.FieldExpiry:
+		return m.OldExpiry(ctx)
 	}
-	return nil, fmt.Errorf("unknown Keys field %s", name)
+	return nil, fmt.Errorf("unknown DeviceRequest field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *KeysMutation) SetField(name string, value ent.Value) error {
+func (m *DeviceRequestMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case keys.FieldVerificationKeys:
-		v, ok := value.([]storage.VerificationKey)
+	case devicerequest.FieldUserCode:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetVerificationKeys(v)
+		m.SetUserCode(v)
 		return nil
-	case keys.FieldSigningKey:
-		v, ok := value.(jose.JSONWebKey)
+	case devicerequest.FieldDeviceCode:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSigningKey(v)
+		m.SetDeviceCode(v)
 		return nil
-	case keys.FieldSigningKeyPub:
-		v, ok := value.(jose.JSONWebKey)
+	case devicerequest.FieldClientID:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSigningKeyPub(v)
+		m.SetClientID(v)
 		return nil
-	case keys.FieldNextRotation:
+	case devicerequest.FieldClientSecret:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientSecret(v)
+		return nil
+	case devicerequest.FieldScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScopes(v)
+		return nil
+	case devicerequest.FieldExpiry:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetNextRotation(v)
+		m.SetExpiry(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Keys field %s", name)
+	return fmt.Errorf("unknown DeviceRequest field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *KeysMutation) AddedFields() []string {
+func (m *DeviceRequestMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *KeysMutation) AddedField(name string) (ent.Value, bool) {
+func (m *DeviceRequestMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *KeysMutation) AddField(name string, value ent.Value) error {
+func (m *DeviceRequestMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Keys numeric field %s", name)
+	return fmt.Errorf("unknown DeviceRequest numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *KeysMutation) ClearedFields() []string {
-	return nil
+func (m *DeviceRequestMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(devicerequest.FieldScopes) {
+		fields = append(fields, devicerequest.FieldScopes)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *KeysMutation) FieldCleared(name string) bool {
+func (m *DeviceRequestMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *KeysMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Keys nullable field %s", name)
+func (m *DeviceRequestMutation) ClearField(name string) error {
+	switch name {
+	case devicerequest.FieldScopes:
+		m.ClearScopes()
+		return nil
+	}
+	return fmt.Errorf("unknown DeviceRequest nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *KeysMutation) ResetField(name string) error {
+func (m *DeviceRequestMutation) ResetField(name string) error {
 	switch name {
-	case keys.FieldVerificationKeys:
-		m.ResetVerificationKeys()
+	case devicerequest.FieldUserCode:
+		m.ResetUserCode()
 		return nil
-	case keys.FieldSigningKey:
-		m.ResetSigningKey()
+	case devicerequest.FieldDeviceCode:
+		m.ResetDeviceCode()
 		return nil
-	case keys.FieldSigningKeyPub:
-		m.ResetSigningKeyPub()
+	case devicerequest.FieldClientID:
+		m.ResetClientID()
 		return nil
-	case keys.FieldNextRotation:
-		m.ResetNextRotation()
+	case devicerequest.FieldClientSecret:
+		m.ResetClientSecret()
+		return nil
+	case devicerequest.FieldScopes:
+		m.ResetScopes()
+		return nil
+	case devicerequest.FieldExpiry:
+		m.ResetExpiry()
 		return nil
 	}
-	return fmt.Errorf("unknown Keys field %s", name)
+	return fmt.Errorf("unknown DeviceRequest field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *KeysMutation) AddedEdges() []string {
+func (m *DeviceRequestMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *KeysMutation) AddedIDs(name string) []ent.Value {
+func (m *DeviceRequestMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *KeysMutation) RemovedEdges() []string {
+func (m *DeviceRequestMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *KeysMutation) RemovedIDs(name string) []ent.Value {
+func (m *DeviceRequestMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *KeysMutation) ClearedEdges() []string {
+func (m *DeviceRequestMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *KeysMutation) EdgeCleared(name string) bool {
+func (m *DeviceRequestMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *KeysMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Keys unique edge %s", name)
+func (m *DeviceRequestMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown DeviceRequest unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *KeysMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Keys edge %s", name)
+func (m *DeviceRequestMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown DeviceRequest edge %s", name)
 }
 
-// OAuth2ClientMutation represents an operation that mutates the OAuth2Client nodes in the graph.
-type OAuth2ClientMutation struct {
+// DeviceTokenMutation represents an operation that mutates the DeviceToken nodes in the graph.
+type DeviceTokenMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *string
-	secret        *string
-	redirect_uris *[]string
-	trusted_peers *[]string
-	public        *bool
-	name          *string
-	logo_url      *string
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*OAuth2Client, error)
-	predicates    []predicate.OAuth2Client
+	op               Op
+	typ              string
+	id               *int
+	device_code      *string
+	status           *string
+	token            *[]byte
+	expiry           *time.Time
+	last_request     *time.Time
+	poll_interval    *int
+	addpoll_interval *int
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*DeviceToken, error)
+	predicates       []predicate.DeviceToken
 }
 
-var _ ent.Mutation = (*OAuth2ClientMutation)(nil)
+var _ ent.Mutation = (*DeviceTokenMutation)(nil)
 
-// oauth2clientOption allows management of the mutation configuration using functional options.
-type oauth2clientOption func(*OAuth2ClientMutation)
+// devicetokenOption allows management of the mutation configuration using functional options.
+type devicetokenOption func(*DeviceTokenMutation)
 
-// newOAuth2ClientMutation creates new mutation for the OAuth2Client entity.
-func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth2ClientMutation {
-	m := &OAuth2ClientMutation{
+// newDeviceTokenMutation creates new mutation for the DeviceToken entity.
+func newDeviceTokenMutation(c config, op Op, opts ...devicetokenOption) *DeviceTokenMutation {
+	m := &DeviceTokenMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeOAuth2Client,
+		typ:           TypeDeviceToken,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4785,20 +5078,20 @@ func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth
 	return m
 }
 
-// withOAuth2ClientID sets the ID field of the mutation.
-func withOAuth2ClientID(id string) oauth2clientOption {
-	return func(m *OAuth2ClientMutation) {
+// withDeviceTokenID sets the ID field of the mutation.
+func withDeviceTokenID(id int) devicetokenOption {
+	return func(m *DeviceTokenMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *OAuth2Client
+			value *DeviceToken
 		)
-		m.oldValue = func(ctx context.Context) (*OAuth2Client, error) {
+		m.oldValue = func(ctx context.Context) (*DeviceToken, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().OAuth2Client.Get(ctx, id)
+					value, err = m.Client().DeviceToken.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4807,10 +5100,10 @@ func withOAuth2ClientID(id string) oauth2clientOption {
 	}
 }
 
-// withOAuth2Client sets the old OAuth2Client of the mutation.
-func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
-	return func(m *OAuth2ClientMutation) {
-		m.oldValue = func(context.Context) (*OAuth2Client, error) {
+// withDeviceToken sets the old DeviceToken of the mutation.
+func withDeviceToken(node *DeviceToken) devicetokenOption {
+	return func(m *DeviceTokenMutation) {
+		m.oldValue = func(context.Context) (*DeviceToken, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4819,7 +5112,7 @@ func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m OAuth2ClientMutation) Client() *Client {
+func (m DeviceTokenMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4827,7 +5120,7 @@ func (m OAuth2ClientMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m OAuth2ClientMutation) Tx() (*Tx, error) {
+func (m DeviceTokenMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -4836,280 +5129,2240 @@ func (m OAuth2ClientMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of OAuth2Client entities.
-func (m *OAuth2ClientMutation) SetID(id string) {
-	m.id = &id
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *DeviceTokenMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *DeviceTokenMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().DeviceToken.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetDeviceCode sets the "device_code" field.
+func (m *DeviceTokenMutation) SetDeviceCode(s string) {
+	m.device_code = &s
+}
+
+// DeviceCode returns the value of the "device_code" field in the mutation.
+func (m *DeviceTokenMutation) DeviceCode() (r string, exists bool) {
+	v := m.device_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeviceCode returns the old "device_code" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldDeviceCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeviceCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeviceCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeviceCode: %w", err)
+	}
+	return oldValue.DeviceCode, nil
+}
+
+// ResetDeviceCode resets all changes to the "device_code" field.
+func (m *DeviceTokenMutation) ResetDeviceCode() {
+	m.device_code = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *DeviceTokenMutation) SetStatus(s string) {
+	m.status = &s
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *DeviceTokenMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *DeviceTokenMutation) ResetStatus() {
+	m.status = nil
+}
+
+// SetToken sets the "token" field.
+func (m *DeviceTokenMutation) SetToken(b []byte) {
+	m.token = &b
+}
+
+// Token returns the value of the "token" field in the mutation.
+func (m *DeviceTokenMutation) Token() (r []byte, exists bool) {
+	v := m.token
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldToken returns the old "token" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldToken(ctx context.Context) (v *[]byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldToken is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldToken requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldToken: %w", err)
+	}
+	return oldValue.Token, nil
+}
+
+// ClearToken clears the value of the "token" field.
+func (m *DeviceTokenMutation) ClearToken() {
+	m.token = nil
+	m.clearedFields[devicetoken.FieldToken] = struct{}{}
+}
+
+// TokenCleared returns if the "token" field was cleared in this mutation.
+func (m *DeviceTokenMutation) TokenCleared() bool {
+	_, ok := m.clearedFields[devicetoken.FieldToken]
+	return ok
+}
+
+// ResetToken resets all changes to the "token" field.
+func (m *DeviceTokenMutation) ResetToken() {
+	m.token = nil
+	delete(m.clearedFields, devicetoken.FieldToken)
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *DeviceTokenMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *DeviceTokenMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *DeviceTokenMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// SetLastRequest sets the "last_request" field.
+func (m *DeviceTokenMutation) SetLastRequest(t time.Time) {
+	m.last_request = &t
+}
+
+// LastRequest returns the value of the "last_request" field in the mutation.
+func (m *DeviceTokenMutation) LastRequest() (r time.Time, exists bool) {
+	v := m.last_request
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastRequest returns the old "last_request" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldLastRequest(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastRequest is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastRequest requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastRequest: %w", err)
+	}
+	return oldValue.LastRequest, nil
+}
+
+// ResetLastRequest resets all changes to the "last_request" field.
+func (m *DeviceTokenMutation) ResetLastRequest() {
+	m.last_request = nil
+}
+
+// SetPollInterval sets the "poll_interval" field.
+func (m *DeviceTokenMutation) SetPollInterval(i int) {
+	m.poll_interval = &i
+	m.addpoll_interval = nil
+}
+
+// PollInterval returns the value of the "poll_interval" field in the mutation.
+func (m *DeviceTokenMutation) PollInterval() (r int, exists bool) {
+	v := m.poll_interval
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPollInterval returns the old "poll_interval" field's value of the DeviceToken entity.
+// If the DeviceToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceTokenMutation) OldPollInterval(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPollInterval is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPollInterval requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPollInterval: %w", err)
+	}
+	return oldValue.PollInterval, nil
+}
+
+// AddPollInterval adds i to the "poll_interval" field.
+func (m *DeviceTokenMutation) AddPollInterval(i int) {
+	if m.addpoll_interval != nil {
+		*m.addpoll_interval += i
+	} else {
+		m.addpoll_interval = &i
+	}
+}
+
+// AddedPollInterval returns the value that was added to the "poll_interval" field in this mutation.
+func (m *DeviceTokenMutation) AddedPollInterval() (r int, exists bool) {
+	v := m.addpoll_interval
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPollInterval resets all changes to the "poll_interval" field.
+func (m *DeviceTokenMutation) ResetPollInterval() {
+	m.poll_interval = nil
+	m.addpoll_interval = nil
+}
+
+// Where appends a list predicates to the DeviceTokenMutation builder.
+func (m *DeviceTokenMutation) Where(ps ...predicate.DeviceToken) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *DeviceTokenMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (DeviceToken).
+func (m *DeviceTokenMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *DeviceTokenMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.device_code != nil {
+		fields = append(fields, devicetoken.FieldDeviceCode)
+	}
+	if m.status != nil {
+		fields = append(fields, devicetoken.FieldStatus)
+	}
+	if m.token != nil {
+		fields = append(fields, devicetoken.FieldToken)
+	}
+	if m.expiry != nil {
+		fields = append(fields, devicetoken.FieldExpiry)
+	}
+	if m.last_request != nil {
+		fields = append(fields, devicetoken.FieldLastRequest)
+	}
+	if m.poll_interval != nil {
+		fields = append(fields, devicetoken.FieldPollInterval)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *DeviceTokenMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case devicetoken.FieldDeviceCode:
+		return m.DeviceCode()
+	case devicetoken.FieldStatus:
+		return m.Status()
+	case devicetoken.FieldToken:
+		return m.Token()
+	case devicetoken.FieldExpiry:
+		return m.Expiry()
+	case devicetoken.FieldLastRequest:
+		return m.LastRequest()
+	case devicetoken.FieldPollInterval:
+		return m.PollInterval()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *DeviceTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case devicetoken.FieldDeviceCode:
+		return m.OldDeviceCode(ctx)
+	case devicetoken.FieldStatus:
+		return m.OldStatus(ctx)
+	case devicetoken.FieldToken:
+		return m.OldToken(ctx)
+	case devicetoken.FieldExpiry:
+		return m.OldExpiry(ctx)
+	case devicetoken.FieldLastRequest:
+		return m.OldLastRequest(ctx)
+	case devicetoken.FieldPollInterval:
+		return m.OldPollInterval(ctx)
+	}
+	return nil, fmt.Errorf("unknown DeviceToken field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DeviceTokenMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case devicetoken.FieldDeviceCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeviceCode(v)
+		return nil
+	case devicetoken.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case devicetoken.FieldToken:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetToken(v)
+		return nil
+	case devicetoken.FieldExpiry:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiry(v)
+		return nil
+	case devicetoken.FieldLastRequest:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastRequest(v)
+		return nil
+	case devicetoken.FieldPollInterval:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPollInterval(v)
+		return nil
+	}
+	return fmt.Errorf("unknown DeviceToken field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *DeviceTokenMutation) AddedFields() []string {
+	var fields []string
+	if m.addpoll_interval != nil {
+		fields = append(fields, devicetoken.FieldPollInterval)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *DeviceTokenMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case devicetoken.FieldPollInterval:
+		return m.AddedPollInterval()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DeviceTokenMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case devicetoken.FieldPollInterval:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPollInterval(v)
+		return nil
+	}
+	return fmt.Errorf("unknown DeviceToken numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *DeviceTokenMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(devicetoken.FieldToken) {
+		fields = append(fields, devicetoken.FieldToken)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *DeviceTokenMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *DeviceTokenMutation) ClearField(name string) error {
+	switch name {
+	case devicetoken.FieldToken:
+		m.ClearToken()
+		return nil
+	}
+	return fmt.Errorf("unknown DeviceToken nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *DeviceTokenMutation) ResetField(name string) error {
+	switch name {
+	case devicetoken.FieldDeviceCode:
+		m.ResetDeviceCode()
+		return nil
+	case devicetoken.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case devicetoken.FieldToken:
+		m.ResetToken()
+		return nil
+	case devicetoken.FieldExpiry:
+		m.ResetExpiry()
+		return nil
+	case devicetoken.FieldLastRequest:
+		m.ResetLastRequest()
+		return nil
+	case devicetoken.FieldPollInterval:
+		m.ResetPollInterval()
+		return nil
+	}
+	return fmt.Errorf("unknown DeviceToken field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *DeviceTokenMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *DeviceTokenMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *DeviceTokenMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *DeviceTokenMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *DeviceTokenMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *DeviceTokenMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *DeviceTokenMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown DeviceToken unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *DeviceTokenMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown DeviceToken edge %s", name)
+}
+
+// KeysMutation represents an operation that mutates the Keys nodes in the graph.
+type KeysMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *string
+	verification_keys *[]storage.VerificationKey
+	signing_key       *jose.JSONWebKey
+	signing_key_pub   *jose.JSONWebKey
+	next_rotation     *time.Time
+	clearedFields     map[string]struct{}
+	done              bool
+	oldValue          func(context.Context) (*Keys, error)
+	predicates        []predicate.Keys
+}
+
+var _ ent.Mutation = (*KeysMutation)(nil)
+
+// keysOption allows management of the mutation configuration using functional options.
+type keysOption func(*KeysMutation)
+
+// newKeysMutation creates new mutation for the Keys entity.
+func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
+	m := &KeysMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeKeys,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withKeysID sets the ID field of the mutation.
+func withKeysID(id string) keysOption {
+	return func(m *KeysMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Keys
+		)
+		m.oldValue = func(ctx context.Context) (*Keys, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Keys.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withKeys sets the old Keys of the mutation.
+func withKeys(node *Keys) keysOption {
+	return func(m *KeysMutation) {
+		m.oldValue = func(context.Context) (*Keys, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m KeysMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m KeysMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Keys entities.
+func (m *KeysMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *KeysMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *KeysMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Keys.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetVerificationKeys sets the "verification_keys" field.
+func (m *KeysMutation) SetVerificationKeys(sk []storage.VerificationKey) {
+	m.verification_keys = &sk
+}
+
+// VerificationKeys returns the value of the "verification_keys" field in the mutation.
+func (m *KeysMutation) VerificationKeys() (r []storage.VerificationKey, exists bool) {
+	v := m.verification_keys
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerificationKeys returns the old "verification_keys" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *KeysMutation) OldVerificationKeys(ctx context.Context) (v []storage.VerificationKey, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerificationKeys is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerificationKeys requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerificationKeys: %w", err)
+	}
+	return oldValue.VerificationKeys, nil
+}
+
+// ResetVerificationKeys resets all changes to the "verification_keys" field.
+func (m *KeysMutation) ResetVerificationKeys() {
+	m.verification_keys = nil
+}
+
+// SetSigningKey sets the "signing_key" field.
+func (m *KeysMutation) SetSigningKey(jwk jose.JSONWebKey) {
+	m.signing_key = &jwk
+}
+
+// SigningKey returns the value of the "signing_key" field in the mutation.
+func (m *KeysMutation) SigningKey() (r jose.JSONWebKey, exists bool) {
+	v := m.signing_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSigningKey returns the old "signing_key" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *KeysMutation) OldSigningKey(ctx context.Context) (v jose.JSONWebKey, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSigningKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSigningKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSigningKey: %w", err)
+	}
+	return oldValue.SigningKey, nil
+}
+
+// ResetSigningKey resets all changes to the "signing_key" field.
+func (m *KeysMutation) ResetSigningKey() {
+	m.signing_key = nil
+}
+
+// SetSigningKeyPub sets the "signing_key_pub" field.
+func (m *KeysMutation) SetSigningKeyPub(jwk jose.JSONWebKey) {
+	m.signing_key_pub = &jwk
+}
+
+// SigningKeyPub returns the value of the "signing_key_pub" field in the mutation.
+func (m *KeysMutation) SigningKeyPub() (r jose.JSONWebKey, exists bool) {
+	v := m.signing_key_pub
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSigningKeyPub returns the old "signing_key_pub" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *KeysMutation) OldSigningKeyPub(ctx context.Context) (v jose.JSONWebKey, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSigningKeyPub is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSigningKeyPub requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSigningKeyPub: %w", err)
+	}
+	return oldValue.SigningKeyPub, nil
+}
+
+// ResetSigningKeyPub resets all changes to the "signing_key_pub" field.
+func (m *KeysMutation) ResetSigningKeyPub() {
+	m.signing_key_pub = nil
+}
+
+// SetNextRotation sets the "next_rotation" field.
+func (m *KeysMutation) SetNextRotation(t time.Time) {
+	m.next_rotation = &t
+}
+
+// NextRotation returns the value of the "next_rotation" field in the mutation.
+func (m *KeysMutation) NextRotation() (r time.Time, exists bool) {
+	v := m.next_rotation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNextRotation returns the old "next_rotation" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *KeysMutation) OldNextRotation(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNextRotation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNextRotation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNextRotation: %w", err)
+	}
+	return oldValue.NextRotation, nil
+}
+
+// ResetNextRotation resets all changes to the "next_rotation" field.
+func (m *KeysMutation) ResetNextRotation() {
+	m.next_rotation = nil
+}
+
+// Where appends a list predicates to the KeysMutation builder.
+func (m *KeysMutation) Where(ps ...predicate.Keys) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *KeysMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Keys).
+func (m *KeysMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *KeysMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.verification_keys != nil {
+		fields = append(fields, keys.FieldVerificationKeys)
+	}
+	if m.signing_key != nil {
+		fields = append(fields, keys.FieldSigningKey)
+	}
+	if m.signing_key_pub != nil {
+		fields = append(fields, keys.FieldSigningKeyPub)
+	}
+	if m.next_rotation != nil {
+		fields = append(fields, keys.FieldNextRotation)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *KeysMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case keys.FieldVerificationKeys:
+		return m.VerificationKeys()
+	case keys.FieldSigningKey:
+		return m.SigningKey()
+	case keys.FieldSigningKeyPub:
+		return m.SigningKeyPub()
+	case keys.FieldNextRotation:
+		return m.NextRotation()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *KeysMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case keys.FieldVerificationKeys:
+		return m.OldVerificationKeys(ctx)
+	case keys.FieldSigningKey:
+		return m.OldSigningKey(ctx)
+	case keys.FieldSigningKeyPub:
+		return m.OldSigningKeyPub(ctx)
+	case keys.FieldNextRotation:
+		return m.OldNextRotation(ctx)
+	}
+	return nil, fmt.Errorf("unknown Keys field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *KeysMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case keys.FieldVerificationKeys:
+		v, ok := value.([]storage.VerificationKey)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerificationKeys(v)
+		return nil
+	case keys.FieldSigningKey:
+		v, ok := value.(jose.JSONWebKey)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSigningKey(v)
+		return nil
+	case keys.FieldSigningKeyPub:
+		v, ok := value.(jose.JSONWebKey)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSigningKeyPub(v)
+		return nil
+	case keys.FieldNextRotation:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNextRotation(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Keys field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *KeysMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *KeysMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *KeysMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Keys numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *KeysMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *KeysMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *KeysMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Keys nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *KeysMutation) ResetField(name string) error {
+	switch name {
+	case keys.FieldVerificationKeys:
+		m.ResetVerificationKeys()
+		return nil
+	case keys.FieldSigningKey:
+		m.ResetSigningKey()
+		return nil
+	case keys.FieldSigningKeyPub:
+		m.ResetSigningKeyPub()
+		return nil
+	case keys.FieldNextRotation:
+		m.ResetNextRotation()
+		return nil
+	}
+	return fmt.Errorf("unknown Keys field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *KeysMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *KeysMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *KeysMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *KeysMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *KeysMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *KeysMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *KeysMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Keys unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *KeysMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Keys edge %s", name)
+}
+
+// LeaseMutation represents an operation that mutates the Lease nodes in the graph.
+type LeaseMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	holder        *string
+	expiry        *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*Lease, error)
+	predicates    []predicate.Lease
+}
+
+var _ ent.Mutation = (*LeaseMutation)(nil)
+
+// leaseOption allows management of the mutation configuration using functional options.
+type leaseOption func(*LeaseMutation)
+
+// newLeaseMutation creates new mutation for the Lease entity.
+func newLeaseMutation(c config, op Op, opts ...leaseOption) *LeaseMutation {
+	m := &LeaseMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeLease,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withLeaseID sets the ID field of the mutation.
+func withLeaseID(id string) leaseOption {
+	return func(m *LeaseMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Lease
+		)
+		m.oldValue = func(ctx context.Context) (*Lease, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Lease.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withLease sets the old Lease of the mutation.
+func withLease(node *Lease) leaseOption {
+	return func(m *LeaseMutation) {
+		m.oldValue = func(context.Context) (*Lease, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m LeaseMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m LeaseMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Lease entities.
+func (m *LeaseMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *LeaseMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *LeaseMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Lease.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetHolder sets the "holder" field.
+func (m *LeaseMutation) SetHolder(s string) {
+	m.holder = &s
+}
+
+// Holder returns the value of the "holder" field in the mutation.
+func (m *LeaseMutation) Holder() (r string, exists bool) {
+	v := m.holder
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHolder returns the old "holder" field's value of the Lease entity.
+// If the Lease object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LeaseMutation) OldHolder(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHolder is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHolder requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHolder: %w", err)
+	}
+	return oldValue.Holder, nil
+}
+
+// ResetHolder resets all changes to the "holder" field.
+func (m *LeaseMutation) ResetHolder() {
+	m.holder = nil
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *LeaseMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *LeaseMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the Lease entity.
+// If the Lease object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LeaseMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *LeaseMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// Where appends a list predicates to the LeaseMutation builder.
+func (m *LeaseMutation) Where(ps ...predicate.Lease) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *LeaseMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Lease).
+func (m *LeaseMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *LeaseMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.holder != nil {
+		fields = append(fields, lease.FieldHolder)
+	}
+	if m.expiry != nil {
+		fields = append(fields, lease.FieldExpiry)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *LeaseMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case lease.FieldHolder:
+		return m.Holder()
+	case lease.FieldExpiry:
+		return m.Expiry()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *LeaseMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case lease.FieldHolder:
+		return m.OldHolder(ctx)
+	case lease.FieldExpiry:
+		return m.OldExpiry(ctx)
+	}
+	return nil, fmt.Errorf("unknown Lease field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LeaseMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case lease.FieldHolder:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHolder(v)
+		return nil
+	case lease.FieldExpiry:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiry(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Lease field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *LeaseMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *LeaseMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LeaseMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Lease numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *LeaseMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *LeaseMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *LeaseMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Lease nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *LeaseMutation) ResetField(name string) error {
+	switch name {
+	case lease.FieldHolder:
+		m.ResetHolder()
+		return nil
+	case lease.FieldExpiry:
+		m.ResetExpiry()
+		return nil
+	}
+	return fmt.Errorf("unknown Lease field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *LeaseMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *LeaseMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *LeaseMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *LeaseMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *LeaseMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *LeaseMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *LeaseMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Lease unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *LeaseMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Lease edge %s", name)
+}
+
+// OAuth2ClientMutation represents an operation that mutates the OAuth2Client nodes in the graph.
+type OAuth2ClientMutation struct {
+	config
+	op                                  Op
+	typ                                 string
+	id                                  *string
+	secret                              *string
+	redirect_uris                       *[]string
+	trusted_peers                       *[]string
+	public                              *bool
+	name                                *string
+	logo_url                            *string
+	backchannel_notification_endpoint   *string
+	id_tokens_valid_for                 *string
+	access_tokens_valid_for             *string
+	auth_codes_valid_for                *string
+	disable_refresh_token_rotation      *bool
+	refresh_token_absolute_lifetime     *string
+	refresh_token_valid_if_not_used_for *string
+	allowed_connectors                  *[]string
+	allowed_grant_types                 *[]string
+	allowed_scopes                      *[]string
+	allowed_response_types              *[]string
+	clearedFields                       map[string]struct{}
+	done                                bool
+	oldValue                            func(context.Context) (*OAuth2Client, error)
+	predicates                          []predicate.OAuth2Client
+}
+
+var _ ent.Mutation = (*OAuth2ClientMutation)(nil)
+
+// oauth2clientOption allows management of the mutation configuration using functional options.
+type oauth2clientOption func(*OAuth2ClientMutation)
+
+// newOAuth2ClientMutation creates new mutation for the OAuth2Client entity.
+func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth2ClientMutation {
+	m := &OAuth2ClientMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeOAuth2Client,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withOAuth2ClientID sets the ID field of the mutation.
+func withOAuth2ClientID(id string) oauth2clientOption {
+	return func(m *OAuth2ClientMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *OAuth2Client
+		)
+		m.oldValue = func(ctx context.Context) (*OAuth2Client, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().OAuth2Client.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withOAuth2Client sets the old OAuth2Client of the mutation.
+func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
+	return func(m *OAuth2ClientMutation) {
+		m.oldValue = func(context.Context) (*OAuth2Client, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m OAuth2ClientMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m OAuth2ClientMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of OAuth2Client entities.
+func (m *OAuth2ClientMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *OAuth2ClientMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *OAuth2ClientMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().OAuth2Client.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSecret sets the "secret" field.
+func (m *OAuth2ClientMutation) SetSecret(s string) {
+	m.secret = &s
+}
+
+// Secret returns the value of the "secret" field in the mutation.
+func (m *OAuth2ClientMutation) Secret() (r string, exists bool) {
+	v := m.secret
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSecret returns the old "secret" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldSecret(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSecret is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSecret requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSecret: %w", err)
+	}
+	return oldValue.Secret, nil
+}
+
+// ResetSecret resets all changes to the "secret" field.
+func (m *OAuth2ClientMutation) ResetSecret() {
+	m.secret = nil
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (m *OAuth2ClientMutation) SetRedirectUris(s []string) {
+	m.redirect_uris = &s
+}
+
+// RedirectUris returns the value of the "redirect_uris" field in the mutation.
+func (m *OAuth2ClientMutation) RedirectUris() (r []string, exists bool) {
+	v := m.redirect_uris
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRedirectUris returns the old "redirect_uris" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldRedirectUris(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRedirectUris is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRedirectUris requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRedirectUris: %w", err)
+	}
+	return oldValue.RedirectUris, nil
+}
+
+// ClearRedirectUris clears the value of the "redirect_uris" field.
+func (m *OAuth2ClientMutation) ClearRedirectUris() {
+	m.redirect_uris = nil
+	m.clearedFields[oauth2client.FieldRedirectUris] = struct{}{}
+}
+
+// RedirectUrisCleared returns if the "redirect_uris" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) RedirectUrisCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldRedirectUris]
+	return ok
+}
+
+// ResetRedirectUris resets all changes to the "redirect_uris" field.
+func (m *OAuth2ClientMutation) ResetRedirectUris() {
+	m.redirect_uris = nil
+	delete(m.clearedFields, oauth2client.FieldRedirectUris)
+}
+
+// SetTrustedPeers sets the "trusted_peers" field.
+func (m *OAuth2ClientMutation) SetTrustedPeers(s []string) {
+	m.trusted_peers = &s
+}
+
+// TrustedPeers returns the value of the "trusted_peers" field in the mutation.
+func (m *OAuth2ClientMutation) TrustedPeers() (r []string, exists bool) {
+	v := m.trusted_peers
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTrustedPeers returns the old "trusted_peers" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldTrustedPeers(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTrustedPeers is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTrustedPeers requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTrustedPeers: %w", err)
+	}
+	return oldValue.TrustedPeers, nil
+}
+
+// ClearTrustedPeers clears the value of the "trusted_peers" field.
+func (m *OAuth2ClientMutation) ClearTrustedPeers() {
+	m.trusted_peers = nil
+	m.clearedFields[oauth2client.FieldTrustedPeers] = struct{}{}
+}
+
+// TrustedPeersCleared returns if the "trusted_peers" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) TrustedPeersCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldTrustedPeers]
+	return ok
+}
+
+// ResetTrustedPeers resets all changes to the "trusted_peers" field.
+func (m *OAuth2ClientMutation) ResetTrustedPeers() {
+	m.trusted_peers = nil
+	delete(m.clearedFields, oauth2client.FieldTrustedPeers)
+}
+
+// SetPublic sets the "public" field.
+func (m *OAuth2ClientMutation) SetPublic(b bool) {
+	m.public = &b
+}
+
+// Public returns the value of the "public" field in the mutation.
+func (m *OAuth2ClientMutation) Public() (r bool, exists bool) {
+	v := m.public
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPublic returns the old "public" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldPublic(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPublic is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPublic requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPublic: %w", err)
+	}
+	return oldValue.Public, nil
+}
+
+// ResetPublic resets all changes to the "public" field.
+func (m *OAuth2ClientMutation) ResetPublic() {
+	m.public = nil
+}
+
+// SetName sets the "name" field.
+func (m *OAuth2ClientMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *OAuth2ClientMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *OAuth2ClientMutation) ResetName() {
+	m.name = nil
+}
+
+// SetLogoURL sets the "logo_url" field.
+func (m *OAuth2ClientMutation) SetLogoURL(s string) {
+	m.logo_url = &s
+}
+
+// LogoURL returns the value of the "logo_url" field in the mutation.
+func (m *OAuth2ClientMutation) LogoURL() (r string, exists bool) {
+	v := m.logo_url
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLogoURL returns the old "logo_url" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldLogoURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLogoURL is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLogoURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLogoURL: %w", err)
+	}
+	return oldValue.LogoURL, nil
+}
+
+// ResetLogoURL resets all changes to the "logo_url" field.
+func (m *OAuth2ClientMutation) ResetLogoURL() {
+	m.logo_url = nil
+}
+
+// SetBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field.
+func (m *OAuth2ClientMutation) SetBackchannelNotificationEndpoint(s string) {
+	m.backchannel_notification_endpoint = &s
+}
+
+// BackchannelNotificationEndpoint returns the value of the "backchannel_notification_endpoint" field in the mutation.
+func (m *OAuth2ClientMutation) BackchannelNotificationEndpoint() (r string, exists bool) {
+	v := m.backchannel_notification_endpoint
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBackchannelNotificationEndpoint returns the old "backchannel_notification_endpoint" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldBackchannelNotificationEndpoint(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBackchannelNotificationEndpoint is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBackchannelNotificationEndpoint requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBackchannelNotificationEndpoint: %w", err)
+	}
+	return oldValue.BackchannelNotificationEndpoint, nil
+}
+
+// ResetBackchannelNotificationEndpoint resets all changes to the "backchannel_notification_endpoint" field.
+func (m *OAuth2ClientMutation) ResetBackchannelNotificationEndpoint() {
+	m.backchannel_notification_endpoint = nil
+}
+
+// SetIDTokensValidFor sets the "id_tokens_valid_for" field.
+func (m *OAuth2ClientMutation) SetIDTokensValidFor(s string) {
+	m.id_tokens_valid_for = &s
+}
+
+// IDTokensValidFor returns the value of the "id_tokens_valid_for" field in the mutation.
+func (m *OAuth2ClientMutation) IDTokensValidFor() (r string, exists bool) {
+	v := m.id_tokens_valid_for
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIDTokensValidFor returns the old "id_tokens_valid_for" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldIDTokensValidFor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIDTokensValidFor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIDTokensValidFor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIDTokensValidFor: %w", err)
+	}
+	return oldValue.IDTokensValidFor, nil
+}
+
+// ResetIDTokensValidFor resets all changes to the "id_tokens_valid_for" field.
+func (m *OAuth2ClientMutation) ResetIDTokensValidFor() {
+	m.id_tokens_valid_for = nil
+}
+
+// SetAccessTokensValidFor sets the "access_tokens_valid_for" field.
+func (m *OAuth2ClientMutation) SetAccessTokensValidFor(s string) {
+	m.access_tokens_valid_for = &s
+}
+
+// AccessTokensValidFor returns the value of the "access_tokens_valid_for" field in the mutation.
+func (m *OAuth2ClientMutation) AccessTokensValidFor() (r string, exists bool) {
+	v := m.access_tokens_valid_for
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAccessTokensValidFor returns the old "access_tokens_valid_for" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldAccessTokensValidFor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAccessTokensValidFor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAccessTokensValidFor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAccessTokensValidFor: %w", err)
+	}
+	return oldValue.AccessTokensValidFor, nil
+}
+
+// ResetAccessTokensValidFor resets all changes to the "access_tokens_valid_for" field.
+func (m *OAuth2ClientMutation) ResetAccessTokensValidFor() {
+	m.access_tokens_valid_for = nil
+}
+
+// SetAuthCodesValidFor sets the "auth_codes_valid_for" field.
+func (m *OAuth2ClientMutation) SetAuthCodesValidFor(s string) {
+	m.auth_codes_valid_for = &s
+}
+
+// AuthCodesValidFor returns the value of the "auth_codes_valid_for" field in the mutation.
+func (m *OAuth2ClientMutation) AuthCodesValidFor() (r string, exists bool) {
+	v := m.auth_codes_valid_for
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthCodesValidFor returns the old "auth_codes_valid_for" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldAuthCodesValidFor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthCodesValidFor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthCodesValidFor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthCodesValidFor: %w", err)
+	}
+	return oldValue.AuthCodesValidFor, nil
+}
+
+// ResetAuthCodesValidFor resets all changes to the "auth_codes_valid_for" field.
+func (m *OAuth2ClientMutation) ResetAuthCodesValidFor() {
+	m.auth_codes_valid_for = nil
+}
+
+// SetDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field.
+func (m *OAuth2ClientMutation) SetDisableRefreshTokenRotation(b bool) {
+	m.disable_refresh_token_rotation = &b
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *OAuth2ClientMutation) ID() (id string, exists bool) {
-	if m.id == nil {
+// DisableRefreshTokenRotation returns the value of the "disable_refresh_token_rotation" field in the mutation.
+func (m *OAuth2ClientMutation) DisableRefreshTokenRotation() (r bool, exists bool) {
+	v := m.disable_refresh_token_rotation
+	if v == nil {
 		return
 	}
-	return *m.id, true
+	return *v, true
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *OAuth2ClientMutation) IDs(ctx context.Context) ([]string, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []string{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().OAuth2Client.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+// OldDisableRefreshTokenRotation returns the old "disable_refresh_token_rotation" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldDisableRefreshTokenRotation(ctx context.Context) (v *bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisableRefreshTokenRotation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisableRefreshTokenRotation requires an ID field in the mutation")
 	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisableRefreshTokenRotation: %w", err)
+	}
+	return oldValue.DisableRefreshTokenRotation, nil
 }
 
-// SetSecret sets the "secret" field.
-func (m *OAuth2ClientMutation) SetSecret(s string) {
-	m.secret = &s
+// ClearDisableRefreshTokenRotation clears the value of the "disable_refresh_token_rotation" field.
+func (m *OAuth2ClientMutation) ClearDisableRefreshTokenRotation() {
+	m.disable_refresh_token_rotation = nil
+	m.clearedFields[oauth2client.FieldDisableRefreshTokenRotation] = struct{}{}
 }
 
-// Secret returns the value of the "secret" field in the mutation.
-func (m *OAuth2ClientMutation) Secret() (r string, exists bool) {
-	v := m.secret
+// DisableRefreshTokenRotationCleared returns if the "disable_refresh_token_rotation" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) DisableRefreshTokenRotationCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldDisableRefreshTokenRotation]
+	return ok
+}
+
+// ResetDisableRefreshTokenRotation resets all changes to the "disable_refresh_token_rotation" field.
+func (m *OAuth2ClientMutation) ResetDisableRefreshTokenRotation() {
+	m.disable_refresh_token_rotation = nil
+	delete(m.clearedFields, oauth2client.FieldDisableRefreshTokenRotation)
+}
+
+// SetRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field.
+func (m *OAuth2ClientMutation) SetRefreshTokenAbsoluteLifetime(s string) {
+	m.refresh_token_absolute_lifetime = &s
+}
+
+// RefreshTokenAbsoluteLifetime returns the value of the "refresh_token_absolute_lifetime" field in the mutation.
+func (m *OAuth2ClientMutation) RefreshTokenAbsoluteLifetime() (r string, exists bool) {
+	v := m.refresh_token_absolute_lifetime
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSecret returns the old "secret" field's value of the OAuth2Client entity.
+// OldRefreshTokenAbsoluteLifetime returns the old "refresh_token_absolute_lifetime" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldSecret(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldRefreshTokenAbsoluteLifetime(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSecret is only allowed on UpdateOne operations")
+		return v, errors.New("OldRefreshTokenAbsoluteLifetime is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSecret requires an ID field in the mutation")
+		return v, errors.New("OldRefreshTokenAbsoluteLifetime requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSecret: %w", err)
+		return v, fmt.Errorf("querying old value for OldRefreshTokenAbsoluteLifetime: %w", err)
 	}
-	return oldValue.Secret, nil
+	return oldValue.RefreshTokenAbsoluteLifetime, nil
 }
 
-// ResetSecret resets all changes to the "secret" field.
-func (m *OAuth2ClientMutation) ResetSecret() {
-	m.secret = nil
+// ResetRefreshTokenAbsoluteLifetime resets all changes to the "refresh_token_absolute_lifetime" field.
+func (m *OAuth2ClientMutation) ResetRefreshTokenAbsoluteLifetime() {
+	m.refresh_token_absolute_lifetime = nil
 }
 
-// SetRedirectUris sets the "redirect_uris" field.
-func (m *OAuth2ClientMutation) SetRedirectUris(s []string) {
-	m.redirect_uris = &s
+// SetRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field.
+func (m *OAuth2ClientMutation) SetRefreshTokenValidIfNotUsedFor(s string) {
+	m.refresh_token_valid_if_not_used_for = &s
 }
 
-// RedirectUris returns the value of the "redirect_uris" field in the mutation.
-func (m *OAuth2ClientMutation) RedirectUris() (r []string, exists bool) {
-	v := m.redirect_uris
+// RefreshTokenValidIfNotUsedFor returns the value of the "refresh_token_valid_if_not_used_for" field in the mutation.
+func (m *OAuth2ClientMutation) RefreshTokenValidIfNotUsedFor() (r string, exists bool) {
+	v := m.refresh_token_valid_if_not_used_for
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRedirectUris returns the old "redirect_uris" field's value of the OAuth2Client entity.
+// OldRefreshTokenValidIfNotUsedFor returns the old "refresh_token_valid_if_not_used_for" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldRedirectUris(ctx context.Context) (v []string, err error) {
+func (m *OAuth2ClientMutation) OldRefreshTokenValidIfNotUsedFor(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRedirectUris is only allowed on UpdateOne operations")
+		return v, errors.New("OldRefreshTokenValidIfNotUsedFor is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRedirectUris requires an ID field in the mutation")
+		return v, errors.New("OldRefreshTokenValidIfNotUsedFor requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRedirectUris: %w", err)
+		return v, fmt.Errorf("querying old value for OldRefreshTokenValidIfNotUsedFor: %w", err)
 	}
-	return oldValue.RedirectUris, nil
-}
-
-// ClearRedirectUris clears the value of the "redirect_uris" field.
-func (m *OAuth2ClientMutation) ClearRedirectUris() {
-	m.redirect_uris = nil
-	m.clearedFields[oauth2client.FieldRedirectUris] = struct{}{}
-}
-
-// RedirectUrisCleared returns if the "redirect_uris" field was cleared in this mutation.
-func (m *OAuth2ClientMutation) RedirectUrisCleared() bool {
-	_, ok := m.clearedFields[oauth2client.FieldRedirectUris]
-	return ok
+	return oldValue.RefreshTokenValidIfNotUsedFor, nil
 }
 
-// ResetRedirectUris resets all changes to the "redirect_uris" field.
-func (m *OAuth2ClientMutation) ResetRedirectUris() {
-	m.redirect_uris = nil
-	delete(m.clearedFields, oauth2client.FieldRedirectUris)
+// ResetRefreshTokenValidIfNotUsedFor resets all changes to the "refresh_token_valid_if_not_used_for" field.
+func (m *OAuth2ClientMutation) ResetRefreshTokenValidIfNotUsedFor() {
+	m.refresh_token_valid_if_not_used_for = nil
 }
 
-// SetTrustedPeers sets the "trusted_peers" field.
-func (m *OAuth2ClientMutation) SetTrustedPeers(s []string) {
-	m.trusted_peers = &s
+// SetAllowedConnectors sets the "allowed_connectors" field.
+func (m *OAuth2ClientMutation) SetAllowedConnectors(s []string) {
+	m.allowed_connectors = &s
 }
 
-// TrustedPeers returns the value of the "trusted_peers" field in the mutation.
-func (m *OAuth2ClientMutation) TrustedPeers() (r []string, exists bool) {
-	v := m.trusted_peers
+// AllowedConnectors returns the value of the "allowed_connectors" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedConnectors() (r []string, exists bool) {
+	v := m.allowed_connectors
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTrustedPeers returns the old "trusted_peers" field's value of the OAuth2Client entity.
+// OldAllowedConnectors returns the old "allowed_connectors" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldTrustedPeers(ctx context.Context) (v []string, err error) {
+func (m *OAuth2ClientMutation) OldAllowedConnectors(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTrustedPeers is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedConnectors is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTrustedPeers requires an ID field in the mutation")
+		return v, errors.New("OldAllowedConnectors requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTrustedPeers: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedConnectors: %w", err)
 	}
-	return oldValue.TrustedPeers, nil
+	return oldValue.AllowedConnectors, nil
 }
 
-// ClearTrustedPeers clears the value of the "trusted_peers" field.
-func (m *OAuth2ClientMutation) ClearTrustedPeers() {
-	m.trusted_peers = nil
-	m.clearedFields[oauth2client.FieldTrustedPeers] = struct{}{}
+// ClearAllowedConnectors clears the value of the "allowed_connectors" field.
+func (m *OAuth2ClientMutation) ClearAllowedConnectors() {
+	m.allowed_connectors = nil
+	m.clearedFields[oauth2client.FieldAllowedConnectors] = struct{}{}
 }
 
-// TrustedPeersCleared returns if the "trusted_peers" field was cleared in this mutation.
-func (m *OAuth2ClientMutation) TrustedPeersCleared() bool {
-	_, ok := m.clearedFields[oauth2client.FieldTrustedPeers]
+// AllowedConnectorsCleared returns if the "allowed_connectors" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) AllowedConnectorsCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldAllowedConnectors]
 	return ok
 }
 
-// ResetTrustedPeers resets all changes to the "trusted_peers" field.
-func (m *OAuth2ClientMutation) ResetTrustedPeers() {
-	m.trusted_peers = nil
-	delete(m.clearedFields, oauth2client.FieldTrustedPeers)
+// ResetAllowedConnectors resets all changes to the "allowed_connectors" field.
+func (m *OAuth2ClientMutation) ResetAllowedConnectors() {
+	m.allowed_connectors = nil
+	delete(m.clearedFields, oauth2client.FieldAllowedConnectors)
 }
 
-// SetPublic sets the "public" field.
-func (m *OAuth2ClientMutation) SetPublic(b bool) {
-	m.public = &b
+// SetAllowedGrantTypes sets the "allowed_grant_types" field.
+func (m *OAuth2ClientMutation) SetAllowedGrantTypes(s []string) {
+	m.allowed_grant_types = &s
 }
 
-// Public returns the value of the "public" field in the mutation.
-func (m *OAuth2ClientMutation) Public() (r bool, exists bool) {
-	v := m.public
+// AllowedGrantTypes returns the value of the "allowed_grant_types" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedGrantTypes() (r []string, exists bool) {
+	v := m.allowed_grant_types
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPublic returns the old "public" field's value of the OAuth2Client entity.
+// OldAllowedGrantTypes returns the old "allowed_grant_types" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldPublic(ctx context.Context) (v bool, err error) {
+func (m *OAuth2ClientMutation) OldAllowedGrantTypes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPublic is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedGrantTypes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPublic requires an ID field in the mutation")
+		return v, errors.New("OldAllowedGrantTypes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPublic: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedGrantTypes: %w", err)
 	}
-	return oldValue.Public, nil
+	return oldValue.AllowedGrantTypes, nil
 }
 
-// ResetPublic resets all changes to the "public" field.
-func (m *OAuth2ClientMutation) ResetPublic() {
-	m.public = nil
+// ClearAllowedGrantTypes clears the value of the "allowed_grant_types" field.
+func (m *OAuth2ClientMutation) ClearAllowedGrantTypes() {
+	m.allowed_grant_types = nil
+	m.clearedFields[oauth2client.FieldAllowedGrantTypes] = struct{}{}
 }
 
-// SetName sets the "name" field.
-func (m *OAuth2ClientMutation) SetName(s string) {
-	m.name = &s
+// AllowedGrantTypesCleared returns if the "allowed_grant_types" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) AllowedGrantTypesCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldAllowedGrantTypes]
+	return ok
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *OAuth2ClientMutation) Name() (r string, exists bool) {
-	v := m.name
+// ResetAllowedGrantTypes resets all changes to the "allowed_grant_types" field.
+func (m *OAuth2ClientMutation) ResetAllowedGrantTypes() {
+	m.allowed_grant_types = nil
+	delete(m.clearedFields, oauth2client.FieldAllowedGrantTypes)
+}
+
+// SetAllowedScopes sets the "allowed_scopes" field.
+func (m *OAuth2ClientMutation) SetAllowedScopes(s []string) {
+	m.allowed_scopes = &s
+}
+
+// AllowedScopes returns the value of the "allowed_scopes" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedScopes() (r []string, exists bool) {
+	v := m.allowed_scopes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the OAuth2Client entity.
+// OldAllowedScopes returns the old "allowed_scopes" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldAllowedScopes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedScopes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldAllowedScopes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedScopes: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.AllowedScopes, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *OAuth2ClientMutation) ResetName() {
-	m.name = nil
+// ClearAllowedScopes clears the value of the "allowed_scopes" field.
+func (m *OAuth2ClientMutation) ClearAllowedScopes() {
+	m.allowed_scopes = nil
+	m.clearedFields[oauth2client.FieldAllowedScopes] = struct{}{}
 }
 
-// SetLogoURL sets the "logo_url" field.
-func (m *OAuth2ClientMutation) SetLogoURL(s string) {
-	m.logo_url = &s
+// AllowedScopesCleared returns if the "allowed_scopes" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) AllowedScopesCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldAllowedScopes]
+	return ok
 }
 
-// LogoURL returns the value of the "logo_url" field in the mutation.
-func (m *OAuth2ClientMutation) LogoURL() (r string, exists bool) {
-	v := m.logo_url
+// ResetAllowedScopes resets all changes to the "allowed_scopes" field.
+func (m *OAuth2ClientMutation) ResetAllowedScopes() {
+	m.allowed_scopes = nil
+	delete(m.clearedFields, oauth2client.FieldAllowedScopes)
+}
+
+// SetAllowedResponseTypes sets the "allowed_response_types" field.
+func (m *OAuth2ClientMutation) SetAllowedResponseTypes(s []string) {
+	m.allowed_response_types = &s
+}
+
+// AllowedResponseTypes returns the value of the "allowed_response_types" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedResponseTypes() (r []string, exists bool) {
+	v := m.allowed_response_types
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLogoURL returns the old "logo_url" field's value of the OAuth2Client entity.
+// OldAllowedResponseTypes returns the old "allowed_response_types" field's value of the OAuth2Client entity.
 // If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldLogoURL(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldAllowedResponseTypes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLogoURL is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedResponseTypes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLogoURL requires an ID field in the mutation")
+		return v, errors.New("OldAllowedResponseTypes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLogoURL: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedResponseTypes: %w", err)
 	}
-	return oldValue.LogoURL, nil
+	return oldValue.AllowedResponseTypes, nil
 }
 
-// ResetLogoURL resets all changes to the "logo_url" field.
-func (m *OAuth2ClientMutation) ResetLogoURL() {
-	m.logo_url = nil
+// ClearAllowedResponseTypes clears the value of the "allowed_response_types" field.
+func (m *OAuth2ClientMutation) ClearAllowedResponseTypes() {
+	m.allowed_response_types = nil
+	m.clearedFields[oauth2client.FieldAllowedResponseTypes] = struct{}{}
+}
+
+// AllowedResponseTypesCleared returns if the "allowed_response_types" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) AllowedResponseTypesCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldAllowedResponseTypes]
+	return ok
+}
+
+// ResetAllowedResponseTypes resets all changes to the "allowed_response_types" field.
+func (m *OAuth2ClientMutation) ResetAllowedResponseTypes() {
+	m.allowed_response_types = nil
+	delete(m.clearedFields, oauth2client.FieldAllowedResponseTypes)
 }
 
 // Where appends a list predicates to the OAuth2ClientMutation builder.
@@ -5131,7 +7384,7 @@ func (m *OAuth2ClientMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *OAuth2ClientMutation) Fields() []string {
-	fields := make([]string, 0, 6)
+	fields := make([]string, 0, 17)
 	if m.secret != nil {
 		fields = append(fields, oauth2client.FieldSecret)
 	}
@@ -5150,6 +7403,39 @@ func (m *OAuth2ClientMutation) Fields() []string {
 	if m.logo_url != nil {
 		fields = append(fields, oauth2client.FieldLogoURL)
 	}
+	if m.backchannel_notification_endpoint != nil {
+		fields = append(fields, oauth2client.FieldBackchannelNotificationEndpoint)
+	}
+	if m.id_tokens_valid_for != nil {
+		fields = append(fields, oauth2client.FieldIDTokensValidFor)
+	}
+	if m.access_tokens_valid_for != nil {
+		fields = append(fields, oauth2client.FieldAccessTokensValidFor)
+	}
+	if m.auth_codes_valid_for != nil {
+		fields = append(fields, oauth2client.FieldAuthCodesValidFor)
+	}
+	if m.disable_refresh_token_rotation != nil {
+		fields = append(fields, oauth2client.FieldDisableRefreshTokenRotation)
+	}
+	if m.refresh_token_absolute_lifetime != nil {
+		fields = append(fields, oauth2client.FieldRefreshTokenAbsoluteLifetime)
+	}
+	if m.refresh_token_valid_if_not_used_for != nil {
+		fields = append(fields, oauth2client.FieldRefreshTokenValidIfNotUsedFor)
+	}
+	if m.allowed_connectors != nil {
+		fields = append(fields, oauth2client.FieldAllowedConnectors)
+	}
+	if m.allowed_grant_types != nil {
+		fields = append(fields, oauth2client.FieldAllowedGrantTypes)
+	}
+	if m.allowed_scopes != nil {
+		fields = append(fields, oauth2client.FieldAllowedScopes)
+	}
+	if m.allowed_response_types != nil {
+		fields = append(fields, oauth2client.FieldAllowedResponseTypes)
+	}
 	return fields
 }
 
@@ -5170,6 +7456,28 @@ func (m *OAuth2ClientMutation) Field(name string) (ent.Value, bool) {
 		return m.Name()
 	case oauth2client.FieldLogoURL:
 		return m.LogoURL()
+	case oauth2client.FieldBackchannelNotificationEndpoint:
+		return m.BackchannelNotificationEndpoint()
+	case oauth2client.FieldIDTokensValidFor:
+		return m.IDTokensValidFor()
+	case oauth2client.FieldAccessTokensValidFor:
+		return m.AccessTokensValidFor()
+	case oauth2client.FieldAuthCodesValidFor:
+		return m.AuthCodesValidFor()
+	case oauth2client.FieldDisableRefreshTokenRotation:
+		return m.DisableRefreshTokenRotation()
+	case oauth2client.FieldRefreshTokenAbsoluteLifetime:
+		return m.RefreshTokenAbsoluteLifetime()
+	case oauth2client.FieldRefreshTokenValidIfNotUsedFor:
+		return m.RefreshTokenValidIfNotUsedFor()
+	case oauth2client.FieldAllowedConnectors:
+		return m.AllowedConnectors()
+	case oauth2client.FieldAllowedGrantTypes:
+		return m.AllowedGrantTypes()
+	case oauth2client.FieldAllowedScopes:
+		return m.AllowedScopes()
+	case oauth2client.FieldAllowedResponseTypes:
+		return m.AllowedResponseTypes()
 	}
 	return nil, false
 }
@@ -5191,6 +7499,28 @@ func (m *OAuth2ClientMutation) OldField(ctx context.Context, name string) (ent.V
 		return m.OldName(ctx)
 	case oauth2client.FieldLogoURL:
 		return m.OldLogoURL(ctx)
+	case oauth2client.FieldBackchannelNotificationEndpoint:
+		return m.OldBackchannelNotificationEndpoint(ctx)
+	case oauth2client.FieldIDTokensValidFor:
+		return m.OldIDTokensValidFor(ctx)
+	case oauth2client.FieldAccessTokensValidFor:
+		return m.OldAccessTokensValidFor(ctx)
+	case oauth2client.FieldAuthCodesValidFor:
+		return m.OldAuthCodesValidFor(ctx)
+	case oauth2client.FieldDisableRefreshTokenRotation:
+		return m.OldDisableRefreshTokenRotation(ctx)
+	case oauth2client.FieldRefreshTokenAbsoluteLifetime:
+		return m.OldRefreshTokenAbsoluteLifetime(ctx)
+	case oauth2client.FieldRefreshTokenValidIfNotUsedFor:
+		return m.OldRefreshTokenValidIfNotUsedFor(ctx)
+	case oauth2client.FieldAllowedConnectors:
+		return m.OldAllowedConnectors(ctx)
+	case oauth2client.FieldAllowedGrantTypes:
+		return m.OldAllowedGrantTypes(ctx)
+	case oauth2client.FieldAllowedScopes:
+		return m.OldAllowedScopes(ctx)
+	case oauth2client.FieldAllowedResponseTypes:
+		return m.OldAllowedResponseTypes(ctx)
 	}
 	return nil, fmt.Errorf("unknown OAuth2Client field %s", name)
 }
@@ -5242,6 +7572,83 @@ func (m *OAuth2ClientMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetLogoURL(v)
 		return nil
+	case oauth2client.FieldBackchannelNotificationEndpoint:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBackchannelNotificationEndpoint(v)
+		return nil
+	case oauth2client.FieldIDTokensValidFor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIDTokensValidFor(v)
+		return nil
+	case oauth2client.FieldAccessTokensValidFor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAccessTokensValidFor(v)
+		return nil
+	case oauth2client.FieldAuthCodesValidFor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthCodesValidFor(v)
+		return nil
+	case oauth2client.FieldDisableRefreshTokenRotation:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisableRefreshTokenRotation(v)
+		return nil
+	case oauth2client.FieldRefreshTokenAbsoluteLifetime:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRefreshTokenAbsoluteLifetime(v)
+		return nil
+	case oauth2client.FieldRefreshTokenValidIfNotUsedFor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRefreshTokenValidIfNotUsedFor(v)
+		return nil
+	case oauth2client.FieldAllowedConnectors:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedConnectors(v)
+		return nil
+	case oauth2client.FieldAllowedGrantTypes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedGrantTypes(v)
+		return nil
+	case oauth2client.FieldAllowedScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedScopes(v)
+		return nil
+	case oauth2client.FieldAllowedResponseTypes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedResponseTypes(v)
+		return nil
 	}
 	return fmt.Errorf("unknown OAuth2Client field %s", name)
 }
@@ -5278,6 +7685,21 @@ func (m *OAuth2ClientMutation) ClearedFields() []string {
 	if m.FieldCleared(oauth2client.FieldTrustedPeers) {
 		fields = append(fields, oauth2client.FieldTrustedPeers)
 	}
+	if m.FieldCleared(oauth2client.FieldDisableRefreshTokenRotation) {
+		fields = append(fields, oauth2client.FieldDisableRefreshTokenRotation)
+	}
+	if m.FieldCleared(oauth2client.FieldAllowedConnectors) {
+		fields = append(fields, oauth2client.FieldAllowedConnectors)
+	}
+	if m.FieldCleared(oauth2client.FieldAllowedGrantTypes) {
+		fields = append(fields, oauth2client.FieldAllowedGrantTypes)
+	}
+	if m.FieldCleared(oauth2client.FieldAllowedScopes) {
+		fields = append(fields, oauth2client.FieldAllowedScopes)
+	}
+	if m.FieldCleared(oauth2client.FieldAllowedResponseTypes) {
+		fields = append(fields, oauth2client.FieldAllowedResponseTypes)
+	}
 	return fields
 }
 
@@ -5298,6 +7720,21 @@ func (m *OAuth2ClientMutation) ClearField(name string) error {
 	case oauth2client.FieldTrustedPeers:
 		m.ClearTrustedPeers()
 		return nil
+	case oauth2client.FieldDisableRefreshTokenRotation:
+		m.ClearDisableRefreshTokenRotation()
+		return nil
+	case oauth2client.FieldAllowedConnectors:
+		m.ClearAllowedConnectors()
+		return nil
+	case oauth2client.FieldAllowedGrantTypes:
+		m.ClearAllowedGrantTypes()
+		return nil
+	case oauth2client.FieldAllowedScopes:
+		m.ClearAllowedScopes()
+		return nil
+	case oauth2client.FieldAllowedResponseTypes:
+		m.ClearAllowedResponseTypes()
+		return nil
 	}
 	return fmt.Errorf("unknown OAuth2Client nullable field %s", name)
 }
@@ -5324,6 +7761,39 @@ func (m *OAuth2ClientMutation) ResetField(name string) error {
 	case oauth2client.FieldLogoURL:
 		m.ResetLogoURL()
 		return nil
+	case oauth2client.FieldBackchannelNotificationEndpoint:
+		m.ResetBackchannelNotificationEndpoint()
+		return nil
+	case oauth2client.FieldIDTokensValidFor:
+		m.ResetIDTokensValidFor()
+		return nil
+	case oauth2client.FieldAccessTokensValidFor:
+		m.ResetAccessTokensValidFor()
+		return nil
+	case oauth2client.FieldAuthCodesValidFor:
+		m.ResetAuthCodesValidFor()
+		return nil
+	case oauth2client.FieldDisableRefreshTokenRotation:
+		m.ResetDisableRefreshTokenRotation()
+		return nil
+	case oauth2client.FieldRefreshTokenAbsoluteLifetime:
+		m.ResetRefreshTokenAbsoluteLifetime()
+		return nil
+	case oauth2client.FieldRefreshTokenValidIfNotUsedFor:
+		m.ResetRefreshTokenValidIfNotUsedFor()
+		return nil
+	case oauth2client.FieldAllowedConnectors:
+		m.ResetAllowedConnectors()
+		return nil
+	case oauth2client.FieldAllowedGrantTypes:
+		m.ResetAllowedGrantTypes()
+		return nil
+	case oauth2client.FieldAllowedScopes:
+		m.ResetAllowedScopes()
+		return nil
+	case oauth2client.FieldAllowedResponseTypes:
+		m.ResetAllowedResponseTypes()
+		return nil
 	}
 	return fmt.Errorf("unknown OAuth2Client field %s", name)
 }
@@ -28,6 +28,28 @@ type OAuth2Client struct {
 	Name string `json:"name,omitempty"`
 	// LogoURL holds the value of the "logo_url" field.
 	LogoURL string `json:"logo_url,omitempty"`
+	// BackchannelNotificationEndpoint holds the value of the "backchannel_notification_endpoint" field.
+	BackchannelNotificationEndpoint string `json:"backchannel_notification_endpoint,omitempty"`
+	// IDTokensValidFor holds the value of the "id_tokens_valid_for" field.
+	IDTokensValidFor string `json:"id_tokens_valid_for,omitempty"`
+	// AccessTokensValidFor holds the value of the "access_tokens_valid_for" field.
+	AccessTokensValidFor string `json:"access_tokens_valid_for,omitempty"`
+	// AuthCodesValidFor holds the value of the "auth_codes_valid_for" field.
+	AuthCodesValidFor string `json:"auth_codes_valid_for,omitempty"`
+	// DisableRefreshTokenRotation holds the value of the "disable_refresh_token_rotation" field.
+	DisableRefreshTokenRotation *bool `json:"disable_refresh_token_rotation,omitempty"`
+	// RefreshTokenAbsoluteLifetime holds the value of the "refresh_token_absolute_lifetime" field.
+	RefreshTokenAbsoluteLifetime string `json:"refresh_token_absolute_lifetime,omitempty"`
+	// RefreshTokenValidIfNotUsedFor holds the value of the "refresh_token_valid_if_not_used_for" field.
+	RefreshTokenValidIfNotUsedFor string `json:"refresh_token_valid_if_not_used_for,omitempty"`
+	// AllowedConnectors holds the value of the "allowed_connectors" field.
+	AllowedConnectors []string `json:"allowed_connectors,omitempty"`
+	// AllowedGrantTypes holds the value of the "allowed_grant_types" field.
+	AllowedGrantTypes []string `json:"allowed_grant_types,omitempty"`
+	// AllowedScopes holds the value of the "allowed_scopes" field.
+	AllowedScopes []string `json:"allowed_scopes,omitempty"`
+	// AllowedResponseTypes holds the value of the "allowed_response_types" field.
+	AllowedResponseTypes []string `json:"allowed_response_types,omitempty"`
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -35,11 +57,11 @@ func (*OAuth2Client) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case oauth2client.FieldRedirectUris, oauth2client.FieldTrustedPeers:
+		case oauth2client.FieldRedirectUris, oauth2client.FieldTrustedPeers, oauth2client.FieldAllowedConnectors, oauth2client.FieldAllowedGrantTypes, oauth2client.FieldAllowedScopes, oauth2client.FieldAllowedResponseTypes:
 			values[i] = new([]byte)
-		case oauth2client.FieldPublic:
+		case oauth2client.FieldPublic, oauth2client.FieldDisableRefreshTokenRotation:
 			values[i] = new(sql.NullBool)
-		case oauth2client.FieldID, oauth2client.FieldSecret, oauth2client.FieldName, oauth2client.FieldLogoURL:
+		case oauth2client.FieldID, oauth2client.FieldSecret, oauth2client.FieldName, oauth2client.FieldLogoURL, oauth2client.FieldBackchannelNotificationEndpoint, oauth2client.FieldIDTokensValidFor, oauth2client.FieldAccessTokensValidFor, oauth2client.FieldAuthCodesValidFor, oauth2client.FieldRefreshTokenAbsoluteLifetime, oauth2client.FieldRefreshTokenValidIfNotUsedFor:
 			values[i] = new(sql.NullString)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type OAuth2Client", columns[i])
@@ -102,6 +124,81 @@ func (o *OAuth2Client) assignValues(columns []string, values []interface{}) erro
 			} else if value.Valid {
 				o.LogoURL = value.String
 			}
+		case oauth2client.FieldBackchannelNotificationEndpoint:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field backchannel_notification_endpoint", values[i])
+			} else if value.Valid {
+				o.BackchannelNotificationEndpoint = value.String
+			}
+		case oauth2client.FieldIDTokensValidFor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id_tokens_valid_for", values[i])
+			} else if value.Valid {
+				o.IDTokensValidFor = value.String
+			}
+		case oauth2client.FieldAccessTokensValidFor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field access_tokens_valid_for", values[i])
+			} else if value.Valid {
+				o.AccessTokensValidFor = value.String
+			}
+		case oauth2client.FieldAuthCodesValidFor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field auth_codes_valid_for", values[i])
+			} else if value.Valid {
+				o.AuthCodesValidFor = value.String
+			}
+		case oauth2client.FieldDisableRefreshTokenRotation:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field disable_refresh_token_rotation", values[i])
+			} else if value.Valid {
+				o.DisableRefreshTokenRotation = new(bool)
+				*o.DisableRefreshTokenRotation = value.Bool
+			}
+		case oauth2client.FieldRefreshTokenAbsoluteLifetime:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field refresh_token_absolute_lifetime", values[i])
+			} else if value.Valid {
+				o.RefreshTokenAbsoluteLifetime = value.String
+			}
+		case oauth2client.FieldRefreshTokenValidIfNotUsedFor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field refresh_token_valid_if_not_used_for", values[i])
+			} else if value.Valid {
+				o.RefreshTokenValidIfNotUsedFor = value.String
+			}
+		case oauth2client.FieldAllowedConnectors:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_connectors", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.AllowedConnectors); err != nil {
+					return fmt.Errorf("unmarshal field allowed_connectors: %w", err)
+				}
+			}
+		case oauth2client.FieldAllowedGrantTypes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_grant_types", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.AllowedGrantTypes); err != nil {
+					return fmt.Errorf("unmarshal field allowed_grant_types: %w", err)
+				}
+			}
+		case oauth2client.FieldAllowedScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.AllowedScopes); err != nil {
+					return fmt.Errorf("unmarshal field allowed_scopes: %w", err)
+				}
+			}
+		case oauth2client.FieldAllowedResponseTypes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_response_types", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.AllowedResponseTypes); err != nil {
+					return fmt.Errorf("unmarshal field allowed_response_types: %w", err)
+				}
+			}
 		}
 	}
 	return nil
@@ -142,6 +239,30 @@ func (o *OAuth2Client) String() string {
 	builder.WriteString(o.Name)
 	builder.WriteString(", logo_url=")
 	builder.WriteString(o.LogoURL)
+	builder.WriteString(", backchannel_notification_endpoint=")
+	builder.WriteString(o.BackchannelNotificationEndpoint)
+	builder.WriteString(", id_tokens_valid_for=")
+	builder.WriteString(o.IDTokensValidFor)
+	builder.WriteString(", access_tokens_valid_for=")
+	builder.WriteString(o.AccessTokensValidFor)
+	builder.WriteString(", auth_codes_valid_for=")
+	builder.WriteString(o.AuthCodesValidFor)
+	if v := o.DisableRefreshTokenRotation; v != nil {
+		builder.WriteString(", disable_refresh_token_rotation=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", refresh_token_absolute_lifetime=")
+	builder.WriteString(o.RefreshTokenAbsoluteLifetime)
+	builder.WriteString(", refresh_token_valid_if_not_used_for=")
+	builder.WriteString(o.RefreshTokenValidIfNotUsedFor)
+	builder.WriteString(", allowed_connectors=")
+	builder.WriteString(fmt.Sprintf("%v", o.AllowedConnectors))
+	builder.WriteString(", allowed_grant_types=")
+	builder.WriteString(fmt.Sprintf("%v", o.AllowedGrantTypes))
+	builder.WriteString(", allowed_scopes=")
+	builder.WriteString(fmt.Sprintf("%v", o.AllowedScopes))
+	builder.WriteString(", allowed_response_types=")
+	builder.WriteString(fmt.Sprintf("%v", o.AllowedResponseTypes))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -19,6 +19,28 @@ const (
 	FieldName = "name"
 	// FieldLogoURL holds the string denoting the logo_url field in the database.
 	FieldLogoURL = "logo_url"
+	// FieldBackchannelNotificationEndpoint holds the string denoting the backchannel_notification_endpoint field in the database.
+	FieldBackchannelNotificationEndpoint = "backchannel_notification_endpoint"
+	// FieldIDTokensValidFor holds the string denoting the id_tokens_valid_for field in the database.
+	FieldIDTokensValidFor = "id_tokens_valid_for"
+	// FieldAccessTokensValidFor holds the string denoting the access_tokens_valid_for field in the database.
+	FieldAccessTokensValidFor = "access_tokens_valid_for"
+	// FieldAuthCodesValidFor holds the string denoting the auth_codes_valid_for field in the database.
+	FieldAuthCodesValidFor = "auth_codes_valid_for"
+	// FieldDisableRefreshTokenRotation holds the string denoting the disable_refresh_token_rotation field in the database.
+	FieldDisableRefreshTokenRotation = "disable_refresh_token_rotation"
+	// FieldRefreshTokenAbsoluteLifetime holds the string denoting the refresh_token_absolute_lifetime field in the database.
+	FieldRefreshTokenAbsoluteLifetime = "refresh_token_absolute_lifetime"
+	// FieldRefreshTokenValidIfNotUsedFor holds the string denoting the refresh_token_valid_if_not_used_for field in the database.
+	FieldRefreshTokenValidIfNotUsedFor = "refresh_token_valid_if_not_used_for"
+	// FieldAllowedConnectors holds the string denoting the allowed_connectors field in the database.
+	FieldAllowedConnectors = "allowed_connectors"
+	// FieldAllowedGrantTypes holds the string denoting the allowed_grant_types field in the database.
+	FieldAllowedGrantTypes = "allowed_grant_types"
+	// FieldAllowedScopes holds the string denoting the allowed_scopes field in the database.
+	FieldAllowedScopes = "allowed_scopes"
+	// FieldAllowedResponseTypes holds the string denoting the allowed_response_types field in the database.
+	FieldAllowedResponseTypes = "allowed_response_types"
 	// Table holds the table name of the oauth2client in the database.
 	Table = "oauth2clients"
 )
@@ -32,6 +54,17 @@ var Columns = []string{
 	FieldPublic,
 	FieldName,
 	FieldLogoURL,
+	FieldBackchannelNotificationEndpoint,
+	FieldIDTokensValidFor,
+	FieldAccessTokensValidFor,
+	FieldAuthCodesValidFor,
+	FieldDisableRefreshTokenRotation,
+	FieldRefreshTokenAbsoluteLifetime,
+	FieldRefreshTokenValidIfNotUsedFor,
+	FieldAllowedConnectors,
+	FieldAllowedGrantTypes,
+	FieldAllowedScopes,
+	FieldAllowedResponseTypes,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -51,6 +84,18 @@ var (
 	NameValidator func(string) error
 	// LogoURLValidator is a validator for the "logo_url" field. It is called by the builders before save.
 	LogoURLValidator func(string) error
+	// DefaultBackchannelNotificationEndpoint holds the default value on creation for the "backchannel_notification_endpoint" field.
+	DefaultBackchannelNotificationEndpoint string
+	// DefaultIDTokensValidFor holds the default value on creation for the "id_tokens_valid_for" field.
+	DefaultIDTokensValidFor string
+	// DefaultAccessTokensValidFor holds the default value on creation for the "access_tokens_valid_for" field.
+	DefaultAccessTokensValidFor string
+	// DefaultAuthCodesValidFor holds the default value on creation for the "auth_codes_valid_for" field.
+	DefaultAuthCodesValidFor string
+	// DefaultRefreshTokenAbsoluteLifetime holds the default value on creation for the "refresh_token_absolute_lifetime" field.
+	DefaultRefreshTokenAbsoluteLifetime string
+	// DefaultRefreshTokenValidIfNotUsedFor holds the default value on creation for the "refresh_token_valid_if_not_used_for" field.
+	DefaultRefreshTokenValidIfNotUsedFor string
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(string) error
 )
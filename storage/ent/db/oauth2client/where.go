@@ -118,6 +118,55 @@ func LogoURL(v string) predicate.OAuth2Client {
 	})
 }
 
+// BackchannelNotificationEndpoint applies equality check predicate on the "backchannel_notification_endpoint" field. It's identical to BackchannelNotificationEndpointEQ.
+func BackchannelNotificationEndpoint(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// IDTokensValidFor applies equality check predicate on the "id_tokens_valid_for" field. It's identical to IDTokensValidForEQ.
+func IDTokensValidFor(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidFor applies equality check predicate on the "access_tokens_valid_for" field. It's identical to AccessTokensValidForEQ.
+func AccessTokensValidFor(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AuthCodesValidFor applies equality check predicate on the "auth_codes_valid_for" field. It's identical to AuthCodesValidForEQ.
+func AuthCodesValidFor(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// DisableRefreshTokenRotation applies equality check predicate on the "disable_refresh_token_rotation" field. It's identical to DisableRefreshTokenRotationEQ.
+func DisableRefreshTokenRotation(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDisableRefreshTokenRotation), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetime applies equality check predicate on the "refresh_token_absolute_lifetime" field. It's identical to RefreshTokenAbsoluteLifetimeEQ.
+func RefreshTokenAbsoluteLifetime(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedFor applies equality check predicate on the "refresh_token_valid_if_not_used_for" field. It's identical to RefreshTokenValidIfNotUsedForEQ.
+func RefreshTokenValidIfNotUsedFor(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
 // SecretEQ applies the EQ predicate on the "secret" field.
 func SecretEQ(v string) predicate.OAuth2Client {
 	return predicate.OAuth2Client(func(s *sql.Selector) {
@@ -493,6 +542,756 @@ func LogoURLContainsFold(v string) predicate.OAuth2Client {
 	})
 }
 
+// BackchannelNotificationEndpointEQ applies the EQ predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointNEQ applies the NEQ predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointIn applies the In predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldBackchannelNotificationEndpoint), v...))
+	})
+}
+
+// BackchannelNotificationEndpointNotIn applies the NotIn predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldBackchannelNotificationEndpoint), v...))
+	})
+}
+
+// BackchannelNotificationEndpointGT applies the GT predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointGTE applies the GTE predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointLT applies the LT predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointLTE applies the LTE predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointContains applies the Contains predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointHasPrefix applies the HasPrefix predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointHasSuffix applies the HasSuffix predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointEqualFold applies the EqualFold predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// BackchannelNotificationEndpointContainsFold applies the ContainsFold predicate on the "backchannel_notification_endpoint" field.
+func BackchannelNotificationEndpointContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldBackchannelNotificationEndpoint), v))
+	})
+}
+
+// IDTokensValidForEQ applies the EQ predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForNEQ applies the NEQ predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForIn applies the In predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldIDTokensValidFor), v...))
+	})
+}
+
+// IDTokensValidForNotIn applies the NotIn predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldIDTokensValidFor), v...))
+	})
+}
+
+// IDTokensValidForGT applies the GT predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForGTE applies the GTE predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForLT applies the LT predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForLTE applies the LTE predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForContains applies the Contains predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForHasPrefix applies the HasPrefix predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForHasSuffix applies the HasSuffix predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForEqualFold applies the EqualFold predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// IDTokensValidForContainsFold applies the ContainsFold predicate on the "id_tokens_valid_for" field.
+func IDTokensValidForContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldIDTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForEQ applies the EQ predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForNEQ applies the NEQ predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForIn applies the In predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAccessTokensValidFor), v...))
+	})
+}
+
+// AccessTokensValidForNotIn applies the NotIn predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAccessTokensValidFor), v...))
+	})
+}
+
+// AccessTokensValidForGT applies the GT predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForGTE applies the GTE predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForLT applies the LT predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForLTE applies the LTE predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForContains applies the Contains predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForHasPrefix applies the HasPrefix predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForHasSuffix applies the HasSuffix predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForEqualFold applies the EqualFold predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AccessTokensValidForContainsFold applies the ContainsFold predicate on the "access_tokens_valid_for" field.
+func AccessTokensValidForContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAccessTokensValidFor), v))
+	})
+}
+
+// AuthCodesValidForEQ applies the EQ predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForNEQ applies the NEQ predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForIn applies the In predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAuthCodesValidFor), v...))
+	})
+}
+
+// AuthCodesValidForNotIn applies the NotIn predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAuthCodesValidFor), v...))
+	})
+}
+
+// AuthCodesValidForGT applies the GT predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForGTE applies the GTE predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForLT applies the LT predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForLTE applies the LTE predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForContains applies the Contains predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForHasPrefix applies the HasPrefix predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForHasSuffix applies the HasSuffix predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForEqualFold applies the EqualFold predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// AuthCodesValidForContainsFold applies the ContainsFold predicate on the "auth_codes_valid_for" field.
+func AuthCodesValidForContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAuthCodesValidFor), v))
+	})
+}
+
+// DisableRefreshTokenRotationEQ applies the EQ predicate on the "disable_refresh_token_rotation" field.
+func DisableRefreshTokenRotationEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDisableRefreshTokenRotation), v))
+	})
+}
+
+// DisableRefreshTokenRotationNEQ applies the NEQ predicate on the "disable_refresh_token_rotation" field.
+func DisableRefreshTokenRotationNEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDisableRefreshTokenRotation), v))
+	})
+}
+
+// DisableRefreshTokenRotationIsNil applies the IsNil predicate on the "disable_refresh_token_rotation" field.
+func DisableRefreshTokenRotationIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDisableRefreshTokenRotation)))
+	})
+}
+
+// DisableRefreshTokenRotationNotNil applies the NotNil predicate on the "disable_refresh_token_rotation" field.
+func DisableRefreshTokenRotationNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDisableRefreshTokenRotation)))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeEQ applies the EQ predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeNEQ applies the NEQ predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeIn applies the In predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldRefreshTokenAbsoluteLifetime), v...))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeNotIn applies the NotIn predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldRefreshTokenAbsoluteLifetime), v...))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeGT applies the GT predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeGTE applies the GTE predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeLT applies the LT predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeLTE applies the LTE predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeContains applies the Contains predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeHasPrefix applies the HasPrefix predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeHasSuffix applies the HasSuffix predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeEqualFold applies the EqualFold predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenAbsoluteLifetimeContainsFold applies the ContainsFold predicate on the "refresh_token_absolute_lifetime" field.
+func RefreshTokenAbsoluteLifetimeContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldRefreshTokenAbsoluteLifetime), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForEQ applies the EQ predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForNEQ applies the NEQ predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForIn applies the In predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldRefreshTokenValidIfNotUsedFor), v...))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForNotIn applies the NotIn predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldRefreshTokenValidIfNotUsedFor), v...))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForGT applies the GT predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForGTE applies the GTE predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForLT applies the LT predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForLTE applies the LTE predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForContains applies the Contains predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForHasPrefix applies the HasPrefix predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForHasSuffix applies the HasSuffix predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForEqualFold applies the EqualFold predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// RefreshTokenValidIfNotUsedForContainsFold applies the ContainsFold predicate on the "refresh_token_valid_if_not_used_for" field.
+func RefreshTokenValidIfNotUsedForContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldRefreshTokenValidIfNotUsedFor), v))
+	})
+}
+
+// AllowedConnectorsIsNil applies the IsNil predicate on the "allowed_connectors" field.
+func AllowedConnectorsIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAllowedConnectors)))
+	})
+}
+
+// AllowedConnectorsNotNil applies the NotNil predicate on the "allowed_connectors" field.
+func AllowedConnectorsNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAllowedConnectors)))
+	})
+}
+
+// AllowedGrantTypesIsNil applies the IsNil predicate on the "allowed_grant_types" field.
+func AllowedGrantTypesIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAllowedGrantTypes)))
+	})
+}
+
+// AllowedGrantTypesNotNil applies the NotNil predicate on the "allowed_grant_types" field.
+func AllowedGrantTypesNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAllowedGrantTypes)))
+	})
+}
+
+// AllowedScopesIsNil applies the IsNil predicate on the "allowed_scopes" field.
+func AllowedScopesIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAllowedScopes)))
+	})
+}
+
+// AllowedScopesNotNil applies the NotNil predicate on the "allowed_scopes" field.
+func AllowedScopesNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAllowedScopes)))
+	})
+}
+
+// AllowedResponseTypesIsNil applies the IsNil predicate on the "allowed_response_types" field.
+func AllowedResponseTypesIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAllowedResponseTypes)))
+	})
+}
+
+// AllowedResponseTypesNotNil applies the NotNil predicate on the "allowed_response_types" field.
+func AllowedResponseTypesNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAllowedResponseTypes)))
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.OAuth2Client) predicate.OAuth2Client {
 	return predicate.OAuth2Client(func(s *sql.Selector) {
@@ -55,6 +55,128 @@ func (oc *OAuth2ClientCreate) SetLogoURL(s string) *OAuth2ClientCreate {
 	return oc
 }
 
+// SetBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field.
+func (oc *OAuth2ClientCreate) SetBackchannelNotificationEndpoint(s string) *OAuth2ClientCreate {
+	oc.mutation.SetBackchannelNotificationEndpoint(s)
+	return oc
+}
+
+// SetNillableBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableBackchannelNotificationEndpoint(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetBackchannelNotificationEndpoint(*s)
+	}
+	return oc
+}
+
+// SetIDTokensValidFor sets the "id_tokens_valid_for" field.
+func (oc *OAuth2ClientCreate) SetIDTokensValidFor(s string) *OAuth2ClientCreate {
+	oc.mutation.SetIDTokensValidFor(s)
+	return oc
+}
+
+// SetNillableIDTokensValidFor sets the "id_tokens_valid_for" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableIDTokensValidFor(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetIDTokensValidFor(*s)
+	}
+	return oc
+}
+
+// SetAccessTokensValidFor sets the "access_tokens_valid_for" field.
+func (oc *OAuth2ClientCreate) SetAccessTokensValidFor(s string) *OAuth2ClientCreate {
+	oc.mutation.SetAccessTokensValidFor(s)
+	return oc
+}
+
+// SetNillableAccessTokensValidFor sets the "access_tokens_valid_for" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableAccessTokensValidFor(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetAccessTokensValidFor(*s)
+	}
+	return oc
+}
+
+// SetAuthCodesValidFor sets the "auth_codes_valid_for" field.
+func (oc *OAuth2ClientCreate) SetAuthCodesValidFor(s string) *OAuth2ClientCreate {
+	oc.mutation.SetAuthCodesValidFor(s)
+	return oc
+}
+
+// SetNillableAuthCodesValidFor sets the "auth_codes_valid_for" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableAuthCodesValidFor(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetAuthCodesValidFor(*s)
+	}
+	return oc
+}
+
+// SetDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field.
+func (oc *OAuth2ClientCreate) SetDisableRefreshTokenRotation(b bool) *OAuth2ClientCreate {
+	oc.mutation.SetDisableRefreshTokenRotation(b)
+	return oc
+}
+
+// SetNillableDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableDisableRefreshTokenRotation(b *bool) *OAuth2ClientCreate {
+	if b != nil {
+		oc.SetDisableRefreshTokenRotation(*b)
+	}
+	return oc
+}
+
+// SetRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field.
+func (oc *OAuth2ClientCreate) SetRefreshTokenAbsoluteLifetime(s string) *OAuth2ClientCreate {
+	oc.mutation.SetRefreshTokenAbsoluteLifetime(s)
+	return oc
+}
+
+// SetNillableRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableRefreshTokenAbsoluteLifetime(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetRefreshTokenAbsoluteLifetime(*s)
+	}
+	return oc
+}
+
+// SetRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field.
+func (oc *OAuth2ClientCreate) SetRefreshTokenValidIfNotUsedFor(s string) *OAuth2ClientCreate {
+	oc.mutation.SetRefreshTokenValidIfNotUsedFor(s)
+	return oc
+}
+
+// SetNillableRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableRefreshTokenValidIfNotUsedFor(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetRefreshTokenValidIfNotUsedFor(*s)
+	}
+	return oc
+}
+
+// SetAllowedConnectors sets the "allowed_connectors" field.
+func (oc *OAuth2ClientCreate) SetAllowedConnectors(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedConnectors(s)
+	return oc
+}
+
+// SetAllowedGrantTypes sets the "allowed_grant_types" field.
+func (oc *OAuth2ClientCreate) SetAllowedGrantTypes(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedGrantTypes(s)
+	return oc
+}
+
+// SetAllowedScopes sets the "allowed_scopes" field.
+func (oc *OAuth2ClientCreate) SetAllowedScopes(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedScopes(s)
+	return oc
+}
+
+// SetAllowedResponseTypes sets the "allowed_response_types" field.
+func (oc *OAuth2ClientCreate) SetAllowedResponseTypes(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedResponseTypes(s)
+	return oc
+}
+
 // SetID sets the "id" field.
 func (oc *OAuth2ClientCreate) SetID(s string) *OAuth2ClientCreate {
 	oc.mutation.SetID(s)
@@ -72,6 +194,7 @@ func (oc *OAuth2ClientCreate) Save(ctx context.Context) (*OAuth2Client, error) {
 		err  error
 		node *OAuth2Client
 	)
+	oc.defaults()
 	if len(oc.hooks) == 0 {
 		if err = oc.check(); err != nil {
 			return nil, err
@@ -129,6 +252,34 @@ func (oc *OAuth2ClientCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (oc *OAuth2ClientCreate) defaults() {
+	if _, ok := oc.mutation.BackchannelNotificationEndpoint(); !ok {
+		v := oauth2client.DefaultBackchannelNotificationEndpoint
+		oc.mutation.SetBackchannelNotificationEndpoint(v)
+	}
+	if _, ok := oc.mutation.IDTokensValidFor(); !ok {
+		v := oauth2client.DefaultIDTokensValidFor
+		oc.mutation.SetIDTokensValidFor(v)
+	}
+	if _, ok := oc.mutation.AccessTokensValidFor(); !ok {
+		v := oauth2client.DefaultAccessTokensValidFor
+		oc.mutation.SetAccessTokensValidFor(v)
+	}
+	if _, ok := oc.mutation.AuthCodesValidFor(); !ok {
+		v := oauth2client.DefaultAuthCodesValidFor
+		oc.mutation.SetAuthCodesValidFor(v)
+	}
+	if _, ok := oc.mutation.RefreshTokenAbsoluteLifetime(); !ok {
+		v := oauth2client.DefaultRefreshTokenAbsoluteLifetime
+		oc.mutation.SetRefreshTokenAbsoluteLifetime(v)
+	}
+	if _, ok := oc.mutation.RefreshTokenValidIfNotUsedFor(); !ok {
+		v := oauth2client.DefaultRefreshTokenValidIfNotUsedFor
+		oc.mutation.SetRefreshTokenValidIfNotUsedFor(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (oc *OAuth2ClientCreate) check() error {
 	if _, ok := oc.mutation.Secret(); !ok {
@@ -158,6 +309,24 @@ func (oc *OAuth2ClientCreate) check() error {
 			return &ValidationError{Name: "logo_url", err: fmt.Errorf(`db: validator failed for field "OAuth2Client.logo_url": %w`, err)}
 		}
 	}
+	if _, ok := oc.mutation.BackchannelNotificationEndpoint(); !ok {
+		return &ValidationError{Name: "backchannel_notification_endpoint", err: errors.New(`db: missing required field "OAuth2Client.backchannel_notification_endpoint"`)}
+	}
+	if _, ok := oc.mutation.IDTokensValidFor(); !ok {
+		return &ValidationError{Name: "id_tokens_valid_for", err: errors.New(`db: missing required field "OAuth2Client.id_tokens_valid_for"`)}
+	}
+	if _, ok := oc.mutation.AccessTokensValidFor(); !ok {
+		return &ValidationError{Name: "access_tokens_valid_for", err: errors.New(`db: missing required field "OAuth2Client.access_tokens_valid_for"`)}
+	}
+	if _, ok := oc.mutation.AuthCodesValidFor(); !ok {
+		return &ValidationError{Name: "auth_codes_valid_for", err: errors.New(`db: missing required field "OAuth2Client.auth_codes_valid_for"`)}
+	}
+	if _, ok := oc.mutation.RefreshTokenAbsoluteLifetime(); !ok {
+		return &ValidationError{Name: "refresh_token_absolute_lifetime", err: errors.New(`db: missing required field "OAuth2Client.refresh_token_absolute_lifetime"`)}
+	}
+	if _, ok := oc.mutation.RefreshTokenValidIfNotUsedFor(); !ok {
+		return &ValidationError{Name: "refresh_token_valid_if_not_used_for", err: errors.New(`db: missing required field "OAuth2Client.refresh_token_valid_if_not_used_for"`)}
+	}
 	if v, ok := oc.mutation.ID(); ok {
 		if err := oauth2client.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`db: validator failed for field "OAuth2Client.id": %w`, err)}
@@ -247,6 +416,94 @@ func (oc *OAuth2ClientCreate) createSpec() (*OAuth2Client, *sqlgraph.CreateSpec)
 		})
 		_node.LogoURL = value
 	}
+	if value, ok := oc.mutation.BackchannelNotificationEndpoint(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldBackchannelNotificationEndpoint,
+		})
+		_node.BackchannelNotificationEndpoint = value
+	}
+	if value, ok := oc.mutation.IDTokensValidFor(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldIDTokensValidFor,
+		})
+		_node.IDTokensValidFor = value
+	}
+	if value, ok := oc.mutation.AccessTokensValidFor(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAccessTokensValidFor,
+		})
+		_node.AccessTokensValidFor = value
+	}
+	if value, ok := oc.mutation.AuthCodesValidFor(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAuthCodesValidFor,
+		})
+		_node.AuthCodesValidFor = value
+	}
+	if value, ok := oc.mutation.DisableRefreshTokenRotation(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldDisableRefreshTokenRotation,
+		})
+		_node.DisableRefreshTokenRotation = &value
+	}
+	if value, ok := oc.mutation.RefreshTokenAbsoluteLifetime(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenAbsoluteLifetime,
+		})
+		_node.RefreshTokenAbsoluteLifetime = value
+	}
+	if value, ok := oc.mutation.RefreshTokenValidIfNotUsedFor(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenValidIfNotUsedFor,
+		})
+		_node.RefreshTokenValidIfNotUsedFor = value
+	}
+	if value, ok := oc.mutation.AllowedConnectors(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedConnectors,
+		})
+		_node.AllowedConnectors = value
+	}
+	if value, ok := oc.mutation.AllowedGrantTypes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGrantTypes,
+		})
+		_node.AllowedGrantTypes = value
+	}
+	if value, ok := oc.mutation.AllowedScopes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedScopes,
+		})
+		_node.AllowedScopes = value
+	}
+	if value, ok := oc.mutation.AllowedResponseTypes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedResponseTypes,
+		})
+		_node.AllowedResponseTypes = value
+	}
 	return _node, _spec
 }
 
@@ -264,6 +521,7 @@ func (ocb *OAuth2ClientCreateBulk) Save(ctx context.Context) ([]*OAuth2Client, e
 	for i := range ocb.builders {
 		func(i int, root context.Context) {
 			builder := ocb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*OAuth2ClientMutation)
 				if !ok {
@@ -75,6 +75,158 @@ func (ou *OAuth2ClientUpdate) SetLogoURL(s string) *OAuth2ClientUpdate {
 	return ou
 }
 
+// SetBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field.
+func (ou *OAuth2ClientUpdate) SetBackchannelNotificationEndpoint(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetBackchannelNotificationEndpoint(s)
+	return ou
+}
+
+// SetNillableBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableBackchannelNotificationEndpoint(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetBackchannelNotificationEndpoint(*s)
+	}
+	return ou
+}
+
+// SetIDTokensValidFor sets the "id_tokens_valid_for" field.
+func (ou *OAuth2ClientUpdate) SetIDTokensValidFor(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetIDTokensValidFor(s)
+	return ou
+}
+
+// SetNillableIDTokensValidFor sets the "id_tokens_valid_for" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableIDTokensValidFor(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetIDTokensValidFor(*s)
+	}
+	return ou
+}
+
+// SetAccessTokensValidFor sets the "access_tokens_valid_for" field.
+func (ou *OAuth2ClientUpdate) SetAccessTokensValidFor(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetAccessTokensValidFor(s)
+	return ou
+}
+
+// SetNillableAccessTokensValidFor sets the "access_tokens_valid_for" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableAccessTokensValidFor(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetAccessTokensValidFor(*s)
+	}
+	return ou
+}
+
+// SetAuthCodesValidFor sets the "auth_codes_valid_for" field.
+func (ou *OAuth2ClientUpdate) SetAuthCodesValidFor(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetAuthCodesValidFor(s)
+	return ou
+}
+
+// SetNillableAuthCodesValidFor sets the "auth_codes_valid_for" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableAuthCodesValidFor(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetAuthCodesValidFor(*s)
+	}
+	return ou
+}
+
+// SetDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field.
+func (ou *OAuth2ClientUpdate) SetDisableRefreshTokenRotation(b bool) *OAuth2ClientUpdate {
+	ou.mutation.SetDisableRefreshTokenRotation(b)
+	return ou
+}
+
+// SetNillableDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableDisableRefreshTokenRotation(b *bool) *OAuth2ClientUpdate {
+	if b != nil {
+		ou.SetDisableRefreshTokenRotation(*b)
+	}
+	return ou
+}
+
+// ClearDisableRefreshTokenRotation clears the value of the "disable_refresh_token_rotation" field.
+func (ou *OAuth2ClientUpdate) ClearDisableRefreshTokenRotation() *OAuth2ClientUpdate {
+	ou.mutation.ClearDisableRefreshTokenRotation()
+	return ou
+}
+
+// SetRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field.
+func (ou *OAuth2ClientUpdate) SetRefreshTokenAbsoluteLifetime(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetRefreshTokenAbsoluteLifetime(s)
+	return ou
+}
+
+// SetNillableRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableRefreshTokenAbsoluteLifetime(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetRefreshTokenAbsoluteLifetime(*s)
+	}
+	return ou
+}
+
+// SetRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field.
+func (ou *OAuth2ClientUpdate) SetRefreshTokenValidIfNotUsedFor(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetRefreshTokenValidIfNotUsedFor(s)
+	return ou
+}
+
+// SetNillableRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableRefreshTokenValidIfNotUsedFor(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetRefreshTokenValidIfNotUsedFor(*s)
+	}
+	return ou
+}
+
+// SetAllowedConnectors sets the "allowed_connectors" field.
+func (ou *OAuth2ClientUpdate) SetAllowedConnectors(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedConnectors(s)
+	return ou
+}
+
+// ClearAllowedConnectors clears the value of the "allowed_connectors" field.
+func (ou *OAuth2ClientUpdate) ClearAllowedConnectors() *OAuth2ClientUpdate {
+	ou.mutation.ClearAllowedConnectors()
+	return ou
+}
+
+// SetAllowedGrantTypes sets the "allowed_grant_types" field.
+func (ou *OAuth2ClientUpdate) SetAllowedGrantTypes(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedGrantTypes(s)
+	return ou
+}
+
+// ClearAllowedGrantTypes clears the value of the "allowed_grant_types" field.
+func (ou *OAuth2ClientUpdate) ClearAllowedGrantTypes() *OAuth2ClientUpdate {
+	ou.mutation.ClearAllowedGrantTypes()
+	return ou
+}
+
+// SetAllowedScopes sets the "allowed_scopes" field.
+func (ou *OAuth2ClientUpdate) SetAllowedScopes(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedScopes(s)
+	return ou
+}
+
+// ClearAllowedScopes clears the value of the "allowed_scopes" field.
+func (ou *OAuth2ClientUpdate) ClearAllowedScopes() *OAuth2ClientUpdate {
+	ou.mutation.ClearAllowedScopes()
+	return ou
+}
+
+// SetAllowedResponseTypes sets the "allowed_response_types" field.
+func (ou *OAuth2ClientUpdate) SetAllowedResponseTypes(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedResponseTypes(s)
+	return ou
+}
+
+// ClearAllowedResponseTypes clears the value of the "allowed_response_types" field.
+func (ou *OAuth2ClientUpdate) ClearAllowedResponseTypes() *OAuth2ClientUpdate {
+	ou.mutation.ClearAllowedResponseTypes()
+	return ou
+}
+
 // Mutation returns the OAuth2ClientMutation object of the builder.
 func (ou *OAuth2ClientUpdate) Mutation() *OAuth2ClientMutation {
 	return ou.mutation
@@ -232,6 +384,113 @@ func (ou *OAuth2ClientUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: oauth2client.FieldLogoURL,
 		})
 	}
+	if value, ok := ou.mutation.BackchannelNotificationEndpoint(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldBackchannelNotificationEndpoint,
+		})
+	}
+	if value, ok := ou.mutation.IDTokensValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldIDTokensValidFor,
+		})
+	}
+	if value, ok := ou.mutation.AccessTokensValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAccessTokensValidFor,
+		})
+	}
+	if value, ok := ou.mutation.AuthCodesValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAuthCodesValidFor,
+		})
+	}
+	if value, ok := ou.mutation.DisableRefreshTokenRotation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldDisableRefreshTokenRotation,
+		})
+	}
+	if ou.mutation.DisableRefreshTokenRotationCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Column: oauth2client.FieldDisableRefreshTokenRotation,
+		})
+	}
+	if value, ok := ou.mutation.RefreshTokenAbsoluteLifetime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenAbsoluteLifetime,
+		})
+	}
+	if value, ok := ou.mutation.RefreshTokenValidIfNotUsedFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenValidIfNotUsedFor,
+		})
+	}
+	if value, ok := ou.mutation.AllowedConnectors(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedConnectors,
+		})
+	}
+	if ou.mutation.AllowedConnectorsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedConnectors,
+		})
+	}
+	if value, ok := ou.mutation.AllowedGrantTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGrantTypes,
+		})
+	}
+	if ou.mutation.AllowedGrantTypesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedGrantTypes,
+		})
+	}
+	if value, ok := ou.mutation.AllowedScopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedScopes,
+		})
+	}
+	if ou.mutation.AllowedScopesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedScopes,
+		})
+	}
+	if value, ok := ou.mutation.AllowedResponseTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedResponseTypes,
+		})
+	}
+	if ou.mutation.AllowedResponseTypesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedResponseTypes,
+		})
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, ou.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{oauth2client.Label}
@@ -299,6 +558,158 @@ func (ouo *OAuth2ClientUpdateOne) SetLogoURL(s string) *OAuth2ClientUpdateOne {
 	return ouo
 }
 
+// SetBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field.
+func (ouo *OAuth2ClientUpdateOne) SetBackchannelNotificationEndpoint(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetBackchannelNotificationEndpoint(s)
+	return ouo
+}
+
+// SetNillableBackchannelNotificationEndpoint sets the "backchannel_notification_endpoint" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableBackchannelNotificationEndpoint(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetBackchannelNotificationEndpoint(*s)
+	}
+	return ouo
+}
+
+// SetIDTokensValidFor sets the "id_tokens_valid_for" field.
+func (ouo *OAuth2ClientUpdateOne) SetIDTokensValidFor(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetIDTokensValidFor(s)
+	return ouo
+}
+
+// SetNillableIDTokensValidFor sets the "id_tokens_valid_for" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableIDTokensValidFor(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetIDTokensValidFor(*s)
+	}
+	return ouo
+}
+
+// SetAccessTokensValidFor sets the "access_tokens_valid_for" field.
+func (ouo *OAuth2ClientUpdateOne) SetAccessTokensValidFor(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAccessTokensValidFor(s)
+	return ouo
+}
+
+// SetNillableAccessTokensValidFor sets the "access_tokens_valid_for" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableAccessTokensValidFor(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetAccessTokensValidFor(*s)
+	}
+	return ouo
+}
+
+// SetAuthCodesValidFor sets the "auth_codes_valid_for" field.
+func (ouo *OAuth2ClientUpdateOne) SetAuthCodesValidFor(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAuthCodesValidFor(s)
+	return ouo
+}
+
+// SetNillableAuthCodesValidFor sets the "auth_codes_valid_for" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableAuthCodesValidFor(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetAuthCodesValidFor(*s)
+	}
+	return ouo
+}
+
+// SetDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field.
+func (ouo *OAuth2ClientUpdateOne) SetDisableRefreshTokenRotation(b bool) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetDisableRefreshTokenRotation(b)
+	return ouo
+}
+
+// SetNillableDisableRefreshTokenRotation sets the "disable_refresh_token_rotation" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableDisableRefreshTokenRotation(b *bool) *OAuth2ClientUpdateOne {
+	if b != nil {
+		ouo.SetDisableRefreshTokenRotation(*b)
+	}
+	return ouo
+}
+
+// ClearDisableRefreshTokenRotation clears the value of the "disable_refresh_token_rotation" field.
+func (ouo *OAuth2ClientUpdateOne) ClearDisableRefreshTokenRotation() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearDisableRefreshTokenRotation()
+	return ouo
+}
+
+// SetRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field.
+func (ouo *OAuth2ClientUpdateOne) SetRefreshTokenAbsoluteLifetime(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetRefreshTokenAbsoluteLifetime(s)
+	return ouo
+}
+
+// SetNillableRefreshTokenAbsoluteLifetime sets the "refresh_token_absolute_lifetime" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableRefreshTokenAbsoluteLifetime(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetRefreshTokenAbsoluteLifetime(*s)
+	}
+	return ouo
+}
+
+// SetRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field.
+func (ouo *OAuth2ClientUpdateOne) SetRefreshTokenValidIfNotUsedFor(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetRefreshTokenValidIfNotUsedFor(s)
+	return ouo
+}
+
+// SetNillableRefreshTokenValidIfNotUsedFor sets the "refresh_token_valid_if_not_used_for" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableRefreshTokenValidIfNotUsedFor(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetRefreshTokenValidIfNotUsedFor(*s)
+	}
+	return ouo
+}
+
+// SetAllowedConnectors sets the "allowed_connectors" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedConnectors(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedConnectors(s)
+	return ouo
+}
+
+// ClearAllowedConnectors clears the value of the "allowed_connectors" field.
+func (ouo *OAuth2ClientUpdateOne) ClearAllowedConnectors() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearAllowedConnectors()
+	return ouo
+}
+
+// SetAllowedGrantTypes sets the "allowed_grant_types" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedGrantTypes(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedGrantTypes(s)
+	return ouo
+}
+
+// ClearAllowedGrantTypes clears the value of the "allowed_grant_types" field.
+func (ouo *OAuth2ClientUpdateOne) ClearAllowedGrantTypes() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearAllowedGrantTypes()
+	return ouo
+}
+
+// SetAllowedScopes sets the "allowed_scopes" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedScopes(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedScopes(s)
+	return ouo
+}
+
+// ClearAllowedScopes clears the value of the "allowed_scopes" field.
+func (ouo *OAuth2ClientUpdateOne) ClearAllowedScopes() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearAllowedScopes()
+	return ouo
+}
+
+// SetAllowedResponseTypes sets the "allowed_response_types" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedResponseTypes(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedResponseTypes(s)
+	return ouo
+}
+
+// ClearAllowedResponseTypes clears the value of the "allowed_response_types" field.
+func (ouo *OAuth2ClientUpdateOne) ClearAllowedResponseTypes() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearAllowedResponseTypes()
+	return ouo
+}
+
 // Mutation returns the OAuth2ClientMutation object of the builder.
 func (ouo *OAuth2ClientUpdateOne) Mutation() *OAuth2ClientMutation {
 	return ouo.mutation
@@ -480,6 +891,113 @@ func (ouo *OAuth2ClientUpdateOne) sqlSave(ctx context.Context) (_node *OAuth2Cli
 			Column: oauth2client.FieldLogoURL,
 		})
 	}
+	if value, ok := ouo.mutation.BackchannelNotificationEndpoint(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldBackchannelNotificationEndpoint,
+		})
+	}
+	if value, ok := ouo.mutation.IDTokensValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldIDTokensValidFor,
+		})
+	}
+	if value, ok := ouo.mutation.AccessTokensValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAccessTokensValidFor,
+		})
+	}
+	if value, ok := ouo.mutation.AuthCodesValidFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAuthCodesValidFor,
+		})
+	}
+	if value, ok := ouo.mutation.DisableRefreshTokenRotation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldDisableRefreshTokenRotation,
+		})
+	}
+	if ouo.mutation.DisableRefreshTokenRotationCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Column: oauth2client.FieldDisableRefreshTokenRotation,
+		})
+	}
+	if value, ok := ouo.mutation.RefreshTokenAbsoluteLifetime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenAbsoluteLifetime,
+		})
+	}
+	if value, ok := ouo.mutation.RefreshTokenValidIfNotUsedFor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRefreshTokenValidIfNotUsedFor,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedConnectors(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedConnectors,
+		})
+	}
+	if ouo.mutation.AllowedConnectorsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedConnectors,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedGrantTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGrantTypes,
+		})
+	}
+	if ouo.mutation.AllowedGrantTypesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedGrantTypes,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedScopes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedScopes,
+		})
+	}
+	if ouo.mutation.AllowedScopesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedScopes,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedResponseTypes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedResponseTypes,
+		})
+	}
+	if ouo.mutation.AllowedResponseTypesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedResponseTypes,
+		})
+	}
 	_node = &OAuth2Client{config: ouo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
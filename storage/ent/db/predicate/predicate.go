@@ -12,6 +12,12 @@ type AuthCode func(*sql.Selector)
 // AuthRequest is the predicate function for authrequest builders.
 type AuthRequest func(*sql.Selector)
 
+// CIBARequest is the predicate function for cibarequest builders.
+type CIBARequest func(*sql.Selector)
+
+// CIBAToken is the predicate function for cibatoken builders.
+type CIBAToken func(*sql.Selector)
+
 // Connector is the predicate function for connector builders.
 type Connector func(*sql.Selector)
 
@@ -24,6 +30,9 @@ type DeviceToken func(*sql.Selector)
 // Keys is the predicate function for keys builders.
 type Keys func(*sql.Selector)
 
+// Lease is the predicate function for lease builders.
+type Lease func(*sql.Selector)
+
 // OAuth2Client is the predicate function for oauth2client builders.
 type OAuth2Client func(*sql.Selector)
 
@@ -7,10 +7,13 @@ import (
 
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
+	"github.com/dexidp/dex/storage/ent/db/cibarequest"
+	"github.com/dexidp/dex/storage/ent/db/cibatoken"
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
+	"github.com/dexidp/dex/storage/ent/db/lease"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
@@ -86,6 +89,42 @@ func init() {
 	authrequestDescID := authrequestFields[0].Descriptor()
 	// authrequest.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	authrequest.IDValidator = authrequestDescID.Validators[0].(func(string) error)
+	cibarequestFields := schema.CIBARequest{}.Fields()
+	_ = cibarequestFields
+	// cibarequestDescApprovalCode is the schema descriptor for approval_code field.
+	cibarequestDescApprovalCode := cibarequestFields[0].Descriptor()
+	// cibarequest.ApprovalCodeValidator is a validator for the "approval_code" field. It is called by the builders before save.
+	cibarequest.ApprovalCodeValidator = cibarequestDescApprovalCode.Validators[0].(func(string) error)
+	// cibarequestDescAuthReqID is the schema descriptor for auth_req_id field.
+	cibarequestDescAuthReqID := cibarequestFields[1].Descriptor()
+	// cibarequest.AuthReqIDValidator is a validator for the "auth_req_id" field. It is called by the builders before save.
+	cibarequest.AuthReqIDValidator = cibarequestDescAuthReqID.Validators[0].(func(string) error)
+	// cibarequestDescClientID is the schema descriptor for client_id field.
+	cibarequestDescClientID := cibarequestFields[2].Descriptor()
+	// cibarequest.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
+	cibarequest.ClientIDValidator = cibarequestDescClientID.Validators[0].(func(string) error)
+	// cibarequestDescBindingMessage is the schema descriptor for binding_message field.
+	cibarequestDescBindingMessage := cibarequestFields[5].Descriptor()
+	// cibarequest.DefaultBindingMessage holds the default value on creation for the binding_message field.
+	cibarequest.DefaultBindingMessage = cibarequestDescBindingMessage.Default.(string)
+	cibatokenFields := schema.CIBAToken{}.Fields()
+	_ = cibatokenFields
+	// cibatokenDescAuthReqID is the schema descriptor for auth_req_id field.
+	cibatokenDescAuthReqID := cibatokenFields[0].Descriptor()
+	// cibatoken.AuthReqIDValidator is a validator for the "auth_req_id" field. It is called by the builders before save.
+	cibatoken.AuthReqIDValidator = cibatokenDescAuthReqID.Validators[0].(func(string) error)
+	// cibatokenDescClientID is the schema descriptor for client_id field.
+	cibatokenDescClientID := cibatokenFields[1].Descriptor()
+	// cibatoken.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
+	cibatoken.ClientIDValidator = cibatokenDescClientID.Validators[0].(func(string) error)
+	// cibatokenDescStatus is the schema descriptor for status field.
+	cibatokenDescStatus := cibatokenFields[2].Descriptor()
+	// cibatoken.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	cibatoken.StatusValidator = cibatokenDescStatus.Validators[0].(func(string) error)
+	// cibatokenDescNotificationToken is the schema descriptor for notification_token field.
+	cibatokenDescNotificationToken := cibatokenFields[4].Descriptor()
+	// cibatoken.DefaultNotificationToken holds the default value on creation for the notification_token field.
+	cibatoken.DefaultNotificationToken = cibatokenDescNotificationToken.Default.(string)
 	connectorFields := schema.Connector{}.Fields()
 	_ = connectorFields
 	// connectorDescType is the schema descriptor for type field.
@@ -148,6 +187,30 @@ func init() {
 	keysDescID := keysFields[0].Descriptor()
 	// keys.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	keys.IDValidator = keysDescID.Validators[0].(func(string) error)
+	leaseFields := schema.Lease{}.Fields()
+	_ = leaseFields
+	// leaseDescHolder is the schema descriptor for holder field.
+	leaseDescHolder := leaseFields[1].Descriptor()
+	// lease.HolderValidator is a validator for the "holder" field. It is called by the builders before save.
+	lease.HolderValidator = leaseDescHolder.Validators[0].(func(string) error)
+	// leaseDescID is the schema descriptor for id field.
+	leaseDescID := leaseFields[0].Descriptor()
+	// lease.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	lease.IDValidator = func() func(string) error {
+		validators := leaseDescID.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(id string) error {
+			for _, fn := range fns {
+				if err := fn(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
 	oauth2clientFields := schema.OAuth2Client{}.Fields()
 	_ = oauth2clientFields
 	// oauth2clientDescSecret is the schema descriptor for secret field.
@@ -162,6 +225,30 @@ func init() {
 	oauth2clientDescLogoURL := oauth2clientFields[6].Descriptor()
 	// oauth2client.LogoURLValidator is a validator for the "logo_url" field. It is called by the builders before save.
 	oauth2client.LogoURLValidator = oauth2clientDescLogoURL.Validators[0].(func(string) error)
+	// oauth2clientDescBackchannelNotificationEndpoint is the schema descriptor for backchannel_notification_endpoint field.
+	oauth2clientDescBackchannelNotificationEndpoint := oauth2clientFields[7].Descriptor()
+	// oauth2client.DefaultBackchannelNotificationEndpoint holds the default value on creation for the backchannel_notification_endpoint field.
+	oauth2client.DefaultBackchannelNotificationEndpoint = oauth2clientDescBackchannelNotificationEndpoint.Default.(string)
+	// oauth2clientDescIDTokensValidFor is the schema descriptor for id_tokens_valid_for field.
+	oauth2clientDescIDTokensValidFor := oauth2clientFields[8].Descriptor()
+	// oauth2client.DefaultIDTokensValidFor holds the default value on creation for the id_tokens_valid_for field.
+	oauth2client.DefaultIDTokensValidFor = oauth2clientDescIDTokensValidFor.Default.(string)
+	// oauth2clientDescAccessTokensValidFor is the schema descriptor for access_tokens_valid_for field.
+	oauth2clientDescAccessTokensValidFor := oauth2clientFields[9].Descriptor()
+	// oauth2client.DefaultAccessTokensValidFor holds the default value on creation for the access_tokens_valid_for field.
+	oauth2client.DefaultAccessTokensValidFor = oauth2clientDescAccessTokensValidFor.Default.(string)
+	// oauth2clientDescAuthCodesValidFor is the schema descriptor for auth_codes_valid_for field.
+	oauth2clientDescAuthCodesValidFor := oauth2clientFields[10].Descriptor()
+	// oauth2client.DefaultAuthCodesValidFor holds the default value on creation for the auth_codes_valid_for field.
+	oauth2client.DefaultAuthCodesValidFor = oauth2clientDescAuthCodesValidFor.Default.(string)
+	// oauth2clientDescRefreshTokenAbsoluteLifetime is the schema descriptor for refresh_token_absolute_lifetime field.
+	oauth2clientDescRefreshTokenAbsoluteLifetime := oauth2clientFields[12].Descriptor()
+	// oauth2client.DefaultRefreshTokenAbsoluteLifetime holds the default value on creation for the refresh_token_absolute_lifetime field.
+	oauth2client.DefaultRefreshTokenAbsoluteLifetime = oauth2clientDescRefreshTokenAbsoluteLifetime.Default.(string)
+	// oauth2clientDescRefreshTokenValidIfNotUsedFor is the schema descriptor for refresh_token_valid_if_not_used_for field.
+	oauth2clientDescRefreshTokenValidIfNotUsedFor := oauth2clientFields[13].Descriptor()
+	// oauth2client.DefaultRefreshTokenValidIfNotUsedFor holds the default value on creation for the refresh_token_valid_if_not_used_for field.
+	oauth2client.DefaultRefreshTokenValidIfNotUsedFor = oauth2clientDescRefreshTokenValidIfNotUsedFor.Default.(string)
 	// oauth2clientDescID is the schema descriptor for id field.
 	oauth2clientDescID := oauth2clientFields[0].Descriptor()
 	// oauth2client.IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -16,6 +16,10 @@ type Tx struct {
 	AuthCode *AuthCodeClient
 	// AuthRequest is the client for interacting with the AuthRequest builders.
 	AuthRequest *AuthRequestClient
+	// CIBARequest is the client for interacting with the CIBARequest builders.
+	CIBARequest *CIBARequestClient
+	// CIBAToken is the client for interacting with the CIBAToken builders.
+	CIBAToken *CIBATokenClient
 	// Connector is the client for interacting with the Connector builders.
 	Connector *ConnectorClient
 	// DeviceRequest is the client for interacting with the DeviceRequest builders.
@@ -24,6 +28,8 @@ type Tx struct {
 	DeviceToken *DeviceTokenClient
 	// Keys is the client for interacting with the Keys builders.
 	Keys *KeysClient
+	// Lease is the client for interacting with the Lease builders.
+	Lease *LeaseClient
 	// OAuth2Client is the client for interacting with the OAuth2Client builders.
 	OAuth2Client *OAuth2ClientClient
 	// OfflineSession is the client for interacting with the OfflineSession builders.
@@ -169,10 +175,13 @@ func (tx *Tx) Client() *Client {
 func (tx *Tx) init() {
 	tx.AuthCode = NewAuthCodeClient(tx.config)
 	tx.AuthRequest = NewAuthRequestClient(tx.config)
+	tx.CIBARequest = NewCIBARequestClient(tx.config)
+	tx.CIBAToken = NewCIBATokenClient(tx.config)
 	tx.Connector = NewConnectorClient(tx.config)
 	tx.DeviceRequest = NewDeviceRequestClient(tx.config)
 	tx.DeviceToken = NewDeviceTokenClient(tx.config)
 	tx.Keys = NewKeysClient(tx.config)
+	tx.Lease = NewLeaseClient(tx.config)
 	tx.OAuth2Client = NewOAuth2ClientClient(tx.config)
 	tx.OfflineSession = NewOfflineSessionClient(tx.config)
 	tx.Password = NewPasswordClient(tx.config)
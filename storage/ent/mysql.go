@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -33,6 +34,9 @@ const (
 	mysqlSSLCustom     = "custom"
 )
 
+// MySQL error codes
+const mysqlErrLockDeadlock = 1213
+
 // MySQL options for creating an SQL db.
 type MySQL struct {
 	NetworkDB
@@ -55,6 +59,7 @@ func (m *MySQL) Open(logger log.Logger) (storage.Storage, error) {
 		client.WithHasher(sha256.New),
 		// Set tx isolation leve for each transaction as dex does for postgres
 		client.WithTxIsolationLevel(sql.LevelSerializable),
+		client.WithTxRetry(isMySQLDeadlock),
 	)
 
 	if err := databaseClient.Schema().Create(context.TODO(), schema.WithAtlas(false)); err != nil {
@@ -130,6 +135,21 @@ func (m *MySQL) dsn(tlsConfig string) string {
 	return cfg.FormatDSN()
 }
 
+// MariaDB options for creating an SQL db. MariaDB takes the same options as
+// MySQL, and uses the MySQL dialect. ent detects MariaDB when migrating and
+// handles its JSON columns, which are longtext.
+type MariaDB struct {
+	MySQL
+}
+
+// isMySQLDeadlock reports whether a transaction was aborted because it
+// conflicted with a concurrent transaction. Both MySQL and MariaDB report such
+// serialization failures as deadlocks.
+func isMySQLDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrLockDeadlock
+}
+
 func (m *MySQL) makeTLSConfig() error {
 	cfg := &tls.Config{}
 
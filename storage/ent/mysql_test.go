@@ -74,6 +74,43 @@ func TestMySQL(t *testing.T) {
 	conformance.RunTransactionTests(t, newStorage)
 }
 
+const (
+	MariaDBEntHostEnv = "DEX_MARIADB_ENT_HOST"
+	MariaDBEntPortEnv = "DEX_MARIADB_ENT_PORT"
+)
+
+func TestMariaDB(t *testing.T) {
+	host := os.Getenv(MariaDBEntHostEnv)
+	if host == "" {
+		t.Skipf("test environment variable %s not set, skipping", MariaDBEntHostEnv)
+	}
+
+	port := uint64(3306)
+	if rawPort := os.Getenv(MariaDBEntPortEnv); rawPort != "" {
+		var err error
+
+		port, err = strconv.ParseUint(rawPort, 10, 32)
+		require.NoError(t, err, "invalid mariadb port %q: %s", rawPort, err)
+	}
+
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	newStorage := func() storage.Storage {
+		cfg := &MariaDB{MySQL: *mysqlTestConfig(host, port)}
+		s, err := cfg.Open(logger)
+		if err != nil {
+			panic(err)
+		}
+		return s
+	}
+	conformance.RunTests(t, newStorage)
+	conformance.RunTransactionTests(t, newStorage)
+}
+
 func TestMySQLDSN(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"regexp"
@@ -14,8 +15,7 @@ import (
 	entSQL "entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/schema"
 
-	// Register postgres driver.
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/storage"
@@ -32,6 +32,9 @@ const (
 	pgSSLVerifyFull = "verify-full"
 )
 
+// postgres error codes
+const pgErrSerializationFailure = "40001" // serialization_failure
+
 // Postgres options for creating an SQL db.
 type Postgres struct {
 	NetworkDB
@@ -55,6 +58,7 @@ func (p *Postgres) Open(logger log.Logger) (storage.Storage, error) {
 		//
 		// See: https://www.postgresql.org/docs/9.3/static/sql-set-transaction.html
 		client.WithTxIsolationLevel(sql.LevelSerializable),
+		client.WithTxRetry(isPostgresSerializationFailure),
 	)
 
 	if err := databaseClient.Schema().Create(context.TODO(), schema.WithAtlas(false)); err != nil {
@@ -64,6 +68,31 @@ func (p *Postgres) Open(logger log.Logger) (storage.Storage, error) {
 	return databaseClient, nil
 }
 
+// CockroachDB options for creating an SQL db. CockroachDB takes the same
+// options as Postgres, but defaults to its own port.
+type CockroachDB struct {
+	Postgres
+}
+
+// Open returns a new storage backed by CockroachDB, which uses the Postgres
+// dialect.
+func (c *CockroachDB) Open(logger log.Logger) (storage.Storage, error) {
+	p := c.Postgres
+	if p.Port == 0 {
+		p.Port = 26257
+	}
+	return p.Open(logger)
+}
+
+// isPostgresSerializationFailure reports whether a transaction was aborted
+// because it conflicted with a concurrent transaction. CockroachDB aborts
+// conflicting transactions rather than blocking them, so clients are expected
+// to retry them.
+func isPostgresSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgErrSerializationFailure
+}
+
 func (p *Postgres) driver() (*entSQL.Driver, error) {
 	drv, err := entSQL.Open("postgres", p.dsn())
 	if err != nil {
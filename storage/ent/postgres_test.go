@@ -71,6 +71,46 @@ func TestPostgres(t *testing.T) {
 	conformance.RunTransactionTests(t, newStorage)
 }
 
+const (
+	CockroachDBEntHostEnv = "DEX_COCKROACHDB_ENT_HOST"
+	CockroachDBEntPortEnv = "DEX_COCKROACHDB_ENT_PORT"
+)
+
+func TestCockroachDB(t *testing.T) {
+	host := os.Getenv(CockroachDBEntHostEnv)
+	if host == "" {
+		t.Skipf("test environment variable %s not set, skipping", CockroachDBEntHostEnv)
+	}
+
+	port := uint64(26257)
+	if rawPort := os.Getenv(CockroachDBEntPortEnv); rawPort != "" {
+		var err error
+
+		port, err = strconv.ParseUint(rawPort, 10, 32)
+		require.NoError(t, err, "invalid cockroachdb port %q: %s", rawPort, err)
+	}
+
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	newStorage := func() storage.Storage {
+		cfg := &CockroachDB{Postgres: *postgresTestConfig(host, port)}
+		cfg.Database = getenv(PostgresEntDatabaseEnv, "defaultdb")
+		cfg.User = getenv(PostgresEntUserEnv, "root")
+		cfg.Password = os.Getenv(PostgresEntPasswordEnv)
+		s, err := cfg.Open(logger)
+		if err != nil {
+			panic(err)
+		}
+		return s
+	}
+	conformance.RunTests(t, newStorage)
+	conformance.RunTransactionTests(t, newStorage)
+}
+
 func TestPostgresDSN(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+/* Original SQL table:
+create table ciba_request
+(
+    approval_code   text      not null  primary key,
+    auth_req_id     text      not null,
+    client_id       text      not null,
+    scopes          blob      not null,
+    login_hint      text      not null,
+    binding_message text      not null,
+    expiry          timestamp not null
+);
+*/
+
+// CIBARequest holds the schema definition for the CIBARequest entity.
+type CIBARequest struct {
+	ent.Schema
+}
+
+// Fields of the CIBARequest.
+func (CIBARequest) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("approval_code").
+			SchemaType(textSchema).
+			NotEmpty().
+			Unique(),
+		field.Text("auth_req_id").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.Text("client_id").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.JSON("scopes", []string{}).
+			Optional(),
+		field.Text("login_hint").
+			SchemaType(textSchema),
+		field.Text("binding_message").
+			SchemaType(textSchema).
+			Default(""),
+		field.Time("expiry").
+			SchemaType(timeSchema),
+	}
+}
+
+// Edges of the CIBARequest.
+func (CIBARequest) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+/* Original SQL table:
+create table ciba_token
+(
+    auth_req_id        text      not null primary key,
+    client_id          text      not null,
+    status             text      not null,
+    token              blob,
+    notification_token text      not null,
+    expiry             timestamp not null,
+    last_request       timestamp not null,
+    poll_interval      integer   not null
+);
+*/
+
+// CIBAToken holds the schema definition for the CIBAToken entity.
+type CIBAToken struct {
+	ent.Schema
+}
+
+// Fields of the CIBAToken.
+func (CIBAToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("auth_req_id").
+			SchemaType(textSchema).
+			NotEmpty().
+			Unique(),
+		field.Text("client_id").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.Text("status").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.Bytes("token").Nillable().Optional(),
+		field.Text("notification_token").
+			SchemaType(textSchema).
+			Default(""),
+		field.Time("expiry").
+			SchemaType(timeSchema),
+		field.Time("last_request").
+			SchemaType(timeSchema),
+		field.Int("poll_interval"),
+	}
+}
+
+// Edges of the CIBAToken.
+func (CIBAToken) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
@@ -14,7 +14,8 @@ create table client
     trusted_peers blob    not null,
     public        integer not null,
     name          text    not null,
-    logo_url      text    not null
+    logo_url      text    not null,
+    backchannel_notification_endpoint text not null default ''
 );
 */
 
@@ -45,6 +46,35 @@ func (OAuth2Client) Fields() []ent.Field {
 		field.Text("logo_url").
 			SchemaType(textSchema).
 			NotEmpty(),
+		field.Text("backchannel_notification_endpoint").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("id_tokens_valid_for").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("access_tokens_valid_for").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("auth_codes_valid_for").
+			SchemaType(textSchema).
+			Default(""),
+		field.Bool("disable_refresh_token_rotation").
+			Optional().
+			Nillable(),
+		field.Text("refresh_token_absolute_lifetime").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("refresh_token_valid_if_not_used_for").
+			SchemaType(textSchema).
+			Default(""),
+		field.JSON("allowed_connectors", []string{}).
+			Optional(),
+		field.JSON("allowed_grant_types", []string{}).
+			Optional(),
+		field.JSON("allowed_scopes", []string{}).
+			Optional(),
+		field.JSON("allowed_response_types", []string{}).
+			Optional(),
 	}
 }
 
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+/* Original SQL table:
+create table lease
+(
+    name   text      not null primary key,
+    holder text      not null,
+    expiry timestamp not null
+);
+*/
+
+// Lease holds the schema definition for the Lease entity.
+type Lease struct {
+	ent.Schema
+}
+
+// Fields of the Lease.
+func (Lease) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("id").
+			SchemaType(textSchema).
+			MaxLen(100).
+			NotEmpty().
+			Unique(),
+		field.Text("holder").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.Time("expiry").
+			SchemaType(timeSchema),
+	}
+}
+
+// Edges of the Lease.
+func (Lease) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 /* Original SQL table:
@@ -44,3 +45,10 @@ func (OfflineSession) Fields() []ent.Field {
 func (OfflineSession) Edges() []ent.Edge {
 	return []ent.Edge{}
 }
+
+// Indexes of the OfflineSession.
+func (OfflineSession) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
@@ -1,13 +1,21 @@
 package ent
 
 import (
+	"context"
+	"crypto/sha256"
+	"errors"
 	"os"
 	"testing"
 
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/conformance"
+	"github.com/dexidp/dex/storage/ent/client"
+	"github.com/dexidp/dex/storage/ent/db"
 )
 
 func newSQLiteStorage() storage.Storage {
@@ -28,3 +36,42 @@ func newSQLiteStorage() storage.Storage {
 func TestSQLite3(t *testing.T) {
 	conformance.RunTests(t, newSQLiteStorage)
 }
+
+func TestSQLite3TxRetry(t *testing.T) {
+	drv, err := sql.Open("sqlite3", addFK(":memory:"))
+	require.NoError(t, err)
+	drv.DB().SetMaxOpenConns(1)
+
+	errConflict := errors.New("conflict")
+	s := client.NewDatabase(
+		client.WithClient(db.NewClient(db.Driver(drv))),
+		client.WithHasher(sha256.New),
+		client.WithTxRetry(func(err error) bool { return errors.Is(err, errConflict) }),
+	)
+	defer s.Close()
+	require.NoError(t, s.Schema().Create(context.TODO(), schema.WithAtlas(false)))
+
+	c := storage.Client{ID: storage.NewID(), Secret: "secret", Name: "client", LogoURL: "https://example.com/logo.png"}
+	require.NoError(t, s.CreateClient(c))
+
+	attempts := 0
+	err = s.UpdateClient(c.ID, func(old storage.Client) (storage.Client, error) {
+		attempts++
+		if attempts < 3 {
+			return old, errConflict
+		}
+		old.Name = "updated"
+		return old, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	got, err := s.GetClient(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", got.Name)
+
+	err = s.UpdateClient(c.ID, func(old storage.Client) (storage.Client, error) {
+		return old, errors.New("not retryable")
+	})
+	require.Error(t, err)
+}
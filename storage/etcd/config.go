@@ -1,6 +1,8 @@
 package etcd
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"go.etcd.io/etcd/client/pkg/v3/transport"
@@ -87,5 +89,12 @@ func (p *Etcd) open(logger log.Logger) (*conn, error) {
 		db:     db,
 		logger: logger,
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	if err := c.indexRefreshTokens(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("index refresh tokens: %v", err)
+	}
 	return c, nil
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"github.com/dexidp/dex/pkg/log"
@@ -24,6 +26,16 @@ const (
 	keysName             = "openid-connect-keys"
 	deviceRequestPrefix  = "device_req/"
 	deviceTokenPrefix    = "device_token/"
+	cibaRequestPrefix    = "ciba_req/"
+	cibaTokenPrefix      = "ciba_token/"
+	leasePrefix          = "lease/"
+
+	// refreshTokenUserPrefix indexes refresh tokens by user and connector, the
+	// index is written in the same transaction as the refresh token.
+	refreshTokenUserPrefix = "refresh_token_user/"
+	// refreshTokenUserIndexed marks that refresh tokens created before the
+	// index was introduced have been added to it.
+	refreshTokenUserIndexed = "refresh_token_user_indexed"
 
 	// defaultStorageTimeout will be applied to all storage's operations.
 	defaultStorageTimeout = 5 * time.Second
@@ -38,7 +50,7 @@ func (c *conn) Close() error {
 	return c.db.Close()
 }
 
-func (c *conn) GarbageCollect(now time.Time) (result storage.GCResult, err error) {
+func (c *conn) GarbageCollect(now time.Time, opts storage.GCOptions) (result storage.GCResult, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
 	authRequests, err := c.listAuthRequests(ctx)
@@ -104,6 +116,84 @@ func (c *conn) GarbageCollect(now time.Time) (result storage.GCResult, err error
 			result.DeviceTokens++
 		}
 	}
+
+	cibaRequests, err := c.listCIBARequests(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, cibaRequest := range cibaRequests {
+		if now.After(cibaRequest.Expiry) {
+			if err := c.deleteKey(ctx, keyID(cibaRequestPrefix, cibaRequest.ApprovalCode)); err != nil {
+				c.logger.Errorf("failed to delete ciba request %v", err)
+				delErr = fmt.Errorf("failed to delete ciba request: %v", err)
+			}
+			result.CIBARequests++
+		}
+	}
+
+	cibaTokens, err := c.listCIBATokens(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, cibaToken := range cibaTokens {
+		if now.After(cibaToken.Expiry) {
+			if err := c.deleteKey(ctx, keyID(cibaTokenPrefix, cibaToken.AuthReqID)); err != nil {
+				c.logger.Errorf("failed to delete ciba token %v", err)
+				delErr = fmt.Errorf("failed to delete ciba token: %v", err)
+			}
+			result.CIBATokens++
+		}
+	}
+
+	// Offline sessions are listed before the refresh tokens. A session which
+	// references a refresh token created after the listing has been updated
+	// since, and is left alone by the conditional delete below.
+	sessionsResp, err := c.db.Get(ctx, offlineSessionPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return result, err
+	}
+
+	refreshTokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	refreshExists := make(map[string]bool, len(refreshTokens))
+	for _, refreshToken := range refreshTokens {
+		if opts.RefreshTokenExpired(toStorageRefreshToken(refreshToken), now) {
+			if err := c.deleteRefresh(ctx, refreshToken); err != nil {
+				c.logger.Errorf("failed to delete refresh token %v", err)
+				delErr = fmt.Errorf("failed to delete refresh token: %v", err)
+				refreshExists[refreshToken.ID] = true
+			}
+			result.RefreshTokens++
+			continue
+		}
+		refreshExists[refreshToken.ID] = true
+	}
+
+	for _, kv := range sessionsResp.Kvs {
+		var offlineSession OfflineSessions
+		if err := json.Unmarshal(kv.Value, &offlineSession); err != nil {
+			return result, err
+		}
+		orphaned := storage.OfflineSessionOrphaned(toStorageOfflineSessions(offlineSession), func(id string) bool {
+			return refreshExists[id]
+		})
+		if !orphaned {
+			continue
+		}
+		deleted, err := c.deleteKeyAtRevision(ctx, string(kv.Key), kv.ModRevision)
+		if err != nil {
+			c.logger.Errorf("failed to delete offline session %v", err)
+			delErr = fmt.Errorf("failed to delete offline session: %v", err)
+		}
+		if deleted {
+			result.OfflineSessions++
+		}
+	}
 	return result, delErr
 }
 
@@ -173,7 +263,25 @@ func (c *conn) DeleteAuthCode(id string) error {
 func (c *conn) CreateRefresh(r storage.RefreshToken) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
-	return c.txnCreate(ctx, keyID(refreshTokenPrefix, r.ID), fromStorageRefreshToken(r))
+	b, err := json.Marshal(fromStorageRefreshToken(r))
+	if err != nil {
+		return err
+	}
+	key := keyID(refreshTokenPrefix, r.ID)
+	res, err := c.db.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(
+			clientv3.OpPut(key, string(b)),
+			clientv3.OpPut(keyUserRefresh(r.Claims.UserID, r.ConnectorID, r.ID), r.ID),
+		).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !res.Succeeded {
+		return storage.ErrAlreadyExists
+	}
+	return nil
 }
 
 func (c *conn) GetRefresh(id string) (r storage.RefreshToken, err error) {
@@ -189,30 +297,161 @@ func (c *conn) GetRefresh(id string) (r storage.RefreshToken, err error) {
 func (c *conn) UpdateRefreshToken(id string, updater func(old storage.RefreshToken) (storage.RefreshToken, error)) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
-	return c.txnUpdate(ctx, keyID(refreshTokenPrefix, id), func(currentValue []byte) ([]byte, error) {
-		var current RefreshToken
-		if len(currentValue) > 0 {
-			if err := json.Unmarshal(currentValue, &current); err != nil {
-				return nil, err
-			}
-		}
-		updated, err := updater(toStorageRefreshToken(current))
-		if err != nil {
-			return nil, err
+	key := keyID(refreshTokenPrefix, id)
+	getResp, err := c.db.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	var (
+		current RefreshToken
+		modRev  int64
+	)
+	if len(getResp.Kvs) > 0 {
+		if err := json.Unmarshal(getResp.Kvs[0].Value, &current); err != nil {
+			return err
 		}
-		return json.Marshal(fromStorageRefreshToken(updated))
-	})
+		modRev = getResp.Kvs[0].ModRevision
+	}
+
+	updated, err := updater(toStorageRefreshToken(current))
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(fromStorageRefreshToken(updated))
+	if err != nil {
+		return err
+	}
+
+	// Always write the index entry, which also indexes tokens that were
+	// created before the index existed.
+	indexKey := keyUserRefresh(updated.Claims.UserID, updated.ConnectorID, id)
+	ops := []clientv3.Op{clientv3.OpPut(key, string(b)), clientv3.OpPut(indexKey, id)}
+	if oldKey := keyUserRefresh(current.Claims.UserID, current.ConnectorID, id); modRev != 0 && oldKey != indexKey {
+		ops = append(ops, clientv3.OpDelete(oldKey))
+	}
+	res, err := c.db.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", modRev)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !res.Succeeded {
+		return fmt.Errorf("failed to update key=%q: concurrent conflicting update happened", key)
+	}
+	return nil
 }
 
 func (c *conn) DeleteRefresh(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
-	return c.deleteKey(ctx, keyID(refreshTokenPrefix, id))
+	var token RefreshToken
+	if err := c.getKey(ctx, keyID(refreshTokenPrefix, id), &token); err != nil {
+		return err
+	}
+	return c.deleteRefresh(ctx, token)
+}
+
+// deleteRefresh deletes the refresh token and its index entry.
+func (c *conn) deleteRefresh(ctx context.Context, token RefreshToken) error {
+	res, err := c.db.Txn(ctx).
+		Then(
+			clientv3.OpDelete(keyID(refreshTokenPrefix, token.ID)),
+			clientv3.OpDelete(keyUserRefresh(token.Claims.UserID, token.ConnectorID, token.ID)),
+		).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if res.Responses[0].GetResponseDeleteRange().Deleted == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (c *conn) ListRefreshTokensForUser(userID string, connID string) (tokens []storage.RefreshToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	res, err := c.db.Get(ctx, keyUserRefresh(userID, connID, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range res.Kvs {
+		var token RefreshToken
+		if err := c.getKey(ctx, keyID(refreshTokenPrefix, string(kv.Value)), &token); err != nil {
+			if err == storage.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		// Keys are lowercased and the separators may be part of the IDs, the
+		// prefix can match the tokens of other users.
+		if token.Claims.UserID != userID || token.ConnectorID != connID {
+			continue
+		}
+		tokens = append(tokens, toStorageRefreshToken(token))
+	}
+	return tokens, nil
+}
+
+// indexRefreshTokens adds refresh tokens that were created before the user
+// index was introduced to the index. It only runs once per database.
+func (c *conn) indexRefreshTokens(ctx context.Context) error {
+	res, err := c.db.Get(ctx, refreshTokenUserIndexed)
+	if err != nil {
+		return err
+	}
+	if res.Count > 0 {
+		return nil
+	}
+	tokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if _, err := c.db.Put(ctx, keyUserRefresh(token.Claims.UserID, token.ConnectorID, token.ID), token.ID); err != nil {
+			return err
+		}
+	}
+	if _, err := c.db.Put(ctx, refreshTokenUserIndexed, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	c.logger.Infof("etcd: indexed %d refresh tokens by user", len(tokens))
+	return nil
 }
 
 func (c *conn) ListRefreshTokens() (tokens []storage.RefreshToken, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
+	refreshTokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return tokens, err
+	}
+	for _, token := range refreshTokens {
+		tokens = append(tokens, toStorageRefreshToken(token))
+	}
+	return tokens, nil
+}
+
+func (c *conn) ListRefreshTokensPage(opts storage.ListOptions) (tokens []storage.RefreshToken, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, refreshTokenPrefix, opts, func(value []byte) (bool, error) {
+		var token RefreshToken
+		if err := json.Unmarshal(value, &token); err != nil {
+			return false, err
+		}
+		r := toStorageRefreshToken(token)
+		if !opts.Filter.MatchRefreshToken(r) {
+			return false, nil
+		}
+		tokens = append(tokens, r)
+		return true, nil
+	})
+	return tokens, next, err
+}
+
+func (c *conn) listRefreshTokens(ctx context.Context) (tokens []RefreshToken, err error) {
 	res, err := c.db.Get(ctx, refreshTokenPrefix, clientv3.WithPrefix())
 	if err != nil {
 		return tokens, err
@@ -222,7 +461,7 @@ func (c *conn) ListRefreshTokens() (tokens []storage.RefreshToken, err error) {
 		if err = json.Unmarshal(v.Value, &token); err != nil {
 			return tokens, err
 		}
-		tokens = append(tokens, toStorageRefreshToken(token))
+		tokens = append(tokens, token)
 	}
 	return tokens, nil
 }
@@ -281,6 +520,20 @@ func (c *conn) ListClients() (clients []storage.Client, err error) {
 	return clients, nil
 }
 
+func (c *conn) ListClientsPage(opts storage.ListOptions) (clients []storage.Client, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, clientPrefix, opts, func(value []byte) (bool, error) {
+		var cli storage.Client
+		if err := json.Unmarshal(value, &cli); err != nil {
+			return false, err
+		}
+		clients = append(clients, cli)
+		return true, nil
+	})
+	return clients, next, err
+}
+
 func (c *conn) CreatePassword(p storage.Password) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
@@ -335,6 +588,23 @@ func (c *conn) ListPasswords() (passwords []storage.Password, err error) {
 	return passwords, nil
 }
 
+func (c *conn) ListPasswordsPage(opts storage.ListOptions) (passwords []storage.Password, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, passwordPrefix, opts, func(value []byte) (bool, error) {
+		var p storage.Password
+		if err := json.Unmarshal(value, &p); err != nil {
+			return false, err
+		}
+		if !opts.Filter.MatchPassword(p) {
+			return false, nil
+		}
+		passwords = append(passwords, p)
+		return true, nil
+	})
+	return passwords, next, err
+}
+
 func (c *conn) CreateOfflineSessions(s storage.OfflineSessions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
@@ -429,6 +699,67 @@ func (c *conn) ListConnectors() (connectors []storage.Connector, err error) {
 	return connectors, nil
 }
 
+func (c *conn) ListConnectorsPage(opts storage.ListOptions) (connectors []storage.Connector, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, connectorPrefix, opts, func(value []byte) (bool, error) {
+		var c storage.Connector
+		if err := json.Unmarshal(value, &c); err != nil {
+			return false, err
+		}
+		connectors = append(connectors, c)
+		return true, nil
+	})
+	return connectors, next, err
+}
+
+// listPage reads the keys below prefix in order, starting after the key in the
+// page token, and passes their values to add until add accepted PageSize
+// values. Keys are read in batches so a page only loads what it needs, values
+// rejected by add don't count towards the page.
+func (c *conn) listPage(ctx context.Context, prefix string, opts storage.ListOptions, add func(value []byte) (bool, error)) (string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return "", err
+	}
+	from := prefix
+	if after != "" {
+		from = prefix + after + "\x00"
+	}
+
+	end := clientv3.GetPrefixRangeEnd(prefix)
+	n := 0
+	for {
+		getOpts := []clientv3.OpOption{clientv3.WithRange(end)}
+		if opts.PageSize > 0 {
+			getOpts = append(getOpts, clientv3.WithLimit(int64(opts.PageSize-n)))
+		}
+		res, err := c.db.Get(ctx, from, getOpts...)
+		if err != nil {
+			return "", err
+		}
+		for i, kv := range res.Kvs {
+			ok, err := add(kv.Value)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				n++
+			}
+			if opts.PageSize > 0 && n == opts.PageSize {
+				if i == len(res.Kvs)-1 && !res.More {
+					return "", nil
+				}
+				return storage.NewPageToken(strings.TrimPrefix(string(kv.Key), prefix)), nil
+			}
+		}
+		if !res.More || len(res.Kvs) == 0 {
+			return "", nil
+		}
+		from = string(res.Kvs[len(res.Kvs)-1].Key) + "\x00"
+	}
+}
+
 func (c *conn) GetKeys() (keys storage.Keys, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
@@ -471,6 +802,19 @@ func (c *conn) deleteKey(ctx context.Context, key string) error {
 	return nil
 }
 
+// deleteKeyAtRevision deletes the key only if it wasn't modified since the
+// revision, and returns whether it was deleted.
+func (c *conn) deleteKeyAtRevision(ctx context.Context, key string, modRev int64) (bool, error) {
+	res, err := c.db.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", modRev)).
+		Then(clientv3.OpDelete(key)).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+	return res.Succeeded, nil
+}
+
 func (c *conn) getKey(ctx context.Context, key string, value interface{}) error {
 	r, err := c.db.Get(ctx, key)
 	if err != nil {
@@ -562,12 +906,66 @@ func (c *conn) txnUpdate(ctx context.Context, key string, update func(current []
 	return nil
 }
 
+func (c *conn) listOfflineSessions(ctx context.Context) (sessions []OfflineSessions, err error) {
+	res, err := c.db.Get(ctx, offlineSessionPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return sessions, err
+	}
+	for _, v := range res.Kvs {
+		var s OfflineSessions
+		if err = json.Unmarshal(v.Value, &s); err != nil {
+			return sessions, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+func (c *conn) ListOfflineSessions() ([]storage.OfflineSessions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	offlineSessions, err := c.listOfflineSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]storage.OfflineSessions, len(offlineSessions))
+	for i, s := range offlineSessions {
+		sessions[i] = toStorageOfflineSessions(s)
+	}
+	return sessions, nil
+}
+
+func (c *conn) ListOfflineSessionsForUser(userID string) (sessions []storage.OfflineSessions, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	// Offline session keys start with the user ID.
+	res, err := c.db.Get(ctx, offlineSessionPrefix+strings.ToLower(userID+"|"), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range res.Kvs {
+		var s OfflineSessions
+		if err := json.Unmarshal(kv.Value, &s); err != nil {
+			return nil, err
+		}
+		if s.UserID != userID {
+			continue
+		}
+		sessions = append(sessions, toStorageOfflineSessions(s))
+	}
+	return sessions, nil
+}
+
 func keyID(prefix, id string) string       { return prefix + id }
 func keyEmail(prefix, email string) string { return prefix + strings.ToLower(email) }
 func keySession(userID, connID string) string {
 	return offlineSessionPrefix + strings.ToLower(userID+"|"+connID)
 }
 
+func keyUserRefresh(userID, connID, id string) string {
+	return refreshTokenUserPrefix + strings.ToLower(userID+"|"+connID) + "/" + id
+}
+
 func (c *conn) CreateDeviceRequest(d storage.DeviceRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
 	defer cancel()
@@ -641,3 +1039,251 @@ func (c *conn) UpdateDeviceToken(deviceCode string, updater func(old storage.Dev
 		return json.Marshal(fromStorageDeviceToken(updated))
 	})
 }
+
+func (c *conn) CreateCIBARequest(r storage.CIBARequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnCreate(ctx, keyID(cibaRequestPrefix, r.ApprovalCode), fromStorageCIBARequest(r))
+}
+
+func (c *conn) GetCIBARequest(approvalCode string) (r storage.CIBARequest, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var req CIBARequest
+	if err = c.getKey(ctx, keyID(cibaRequestPrefix, approvalCode), &req); err != nil {
+		return r, err
+	}
+	return toStorageCIBARequest(req), nil
+}
+
+func (c *conn) listCIBARequests(ctx context.Context) (requests []CIBARequest, err error) {
+	res, err := c.db.Get(ctx, cibaRequestPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return requests, err
+	}
+	for _, v := range res.Kvs {
+		var r CIBARequest
+		if err = json.Unmarshal(v.Value, &r); err != nil {
+			return requests, err
+		}
+		requests = append(requests, r)
+	}
+	return requests, nil
+}
+
+func (c *conn) CreateCIBAToken(t storage.CIBAToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnCreate(ctx, keyID(cibaTokenPrefix, t.AuthReqID), fromStorageCIBAToken(t))
+}
+
+func (c *conn) GetCIBAToken(authReqID string) (t storage.CIBAToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var token CIBAToken
+	if err = c.getKey(ctx, keyID(cibaTokenPrefix, authReqID), &token); err != nil {
+		return t, err
+	}
+	return toStorageCIBAToken(token), nil
+}
+
+func (c *conn) listCIBATokens(ctx context.Context) (cibaTokens []CIBAToken, err error) {
+	res, err := c.db.Get(ctx, cibaTokenPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return cibaTokens, err
+	}
+	for _, v := range res.Kvs {
+		var t CIBAToken
+		if err = json.Unmarshal(v.Value, &t); err != nil {
+			return cibaTokens, err
+		}
+		cibaTokens = append(cibaTokens, t)
+	}
+	return cibaTokens, nil
+}
+
+func (c *conn) UpdateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, keyID(cibaTokenPrefix, authReqID), func(currentValue []byte) ([]byte, error) {
+		var current CIBAToken
+		if len(currentValue) > 0 {
+			if err := json.Unmarshal(currentValue, &current); err != nil {
+				return nil, err
+			}
+		}
+		updated, err := updater(toStorageCIBAToken(current))
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(fromStorageCIBAToken(updated))
+	})
+}
+
+// AcquireLease stores the holder in a key attached to an etcd lease, which
+// deletes the key unless the holder keeps the etcd lease alive.
+func (c *conn) AcquireLease(name, holder string, duration time.Duration) (storage.Lease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+
+	key := keyID(leasePrefix, name)
+	res, err := c.db.Get(ctx, key)
+	if err != nil {
+		return storage.Lease{}, err
+	}
+	if res.Count > 0 {
+		lease, err := c.renewLease(ctx, name, holder, res.Kvs[0])
+		if err != rpctypes.ErrLeaseNotFound {
+			return lease, err
+		}
+		// The etcd lease expired after the key was read, try to create it again.
+	}
+
+	ttl := int64((duration + time.Second - 1) / time.Second)
+	grant, err := c.db.Grant(ctx, ttl)
+	if err != nil {
+		return storage.Lease{}, fmt.Errorf("grant lease: %v", err)
+	}
+	b, err := json.Marshal(Lease{Holder: holder})
+	if err != nil {
+		return storage.Lease{}, err
+	}
+	txn, err := c.db.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(b), clientv3.WithLease(grant.ID))).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	if err != nil {
+		return storage.Lease{}, err
+	}
+	if txn.Succeeded {
+		return storage.Lease{Name: name, Holder: holder, Expiry: time.Now().Add(time.Duration(grant.TTL) * time.Second)}, nil
+	}
+
+	// Another instance created the lease first.
+	if _, err := c.db.Revoke(ctx, grant.ID); err != nil {
+		c.logger.Errorf("failed to revoke unused lease: %v", err)
+	}
+	kvs := txn.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return storage.Lease{Name: name}, nil
+	}
+	return c.renewLease(ctx, name, holder, kvs[0])
+}
+
+// renewLease keeps the etcd lease of the key alive if it's held by the holder,
+// otherwise it only looks up when the etcd lease expires.
+func (c *conn) renewLease(ctx context.Context, name, holder string, kv *mvccpb.KeyValue) (storage.Lease, error) {
+	var l Lease
+	if err := json.Unmarshal(kv.Value, &l); err != nil {
+		return storage.Lease{}, err
+	}
+
+	var ttl int64
+	if l.Holder == holder {
+		res, err := c.db.KeepAliveOnce(ctx, clientv3.LeaseID(kv.Lease))
+		if err != nil {
+			return storage.Lease{}, err
+		}
+		ttl = res.TTL
+	} else {
+		res, err := c.db.TimeToLive(ctx, clientv3.LeaseID(kv.Lease))
+		if err != nil {
+			return storage.Lease{}, err
+		}
+		ttl = res.TTL
+	}
+	if ttl <= 0 {
+		return storage.Lease{}, rpctypes.ErrLeaseNotFound
+	}
+	return storage.Lease{Name: name, Holder: l.Holder, Expiry: time.Now().Add(time.Duration(ttl) * time.Second)}, nil
+}
+
+// ReleaseLease revokes the etcd lease, which deletes the key.
+func (c *conn) ReleaseLease(name, holder string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+
+	res, err := c.db.Get(ctx, keyID(leasePrefix, name))
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return nil
+	}
+	var l Lease
+	if err := json.Unmarshal(res.Kvs[0].Value, &l); err != nil {
+		return err
+	}
+	if l.Holder != holder {
+		return nil
+	}
+	if _, err := c.db.Revoke(ctx, clientv3.LeaseID(res.Kvs[0].Lease)); err != nil && err != rpctypes.ErrLeaseNotFound {
+		return fmt.Errorf("revoke lease: %v", err)
+	}
+	return nil
+}
+
+// WatchChanges watches the prefixes of clients, connectors and passwords, and
+// returns once the watches were created. If a watch fails, e.g. because its
+// revision was compacted, it's created again at the current revision and all
+// objects of the kind are reported as changed.
+func (c *conn) WatchChanges(ctx context.Context, fn func(storage.Change)) error {
+	for _, w := range []struct {
+		kind   string
+		prefix string
+	}{
+		{storage.KindClient, clientPrefix},
+		{storage.KindConnector, connectorPrefix},
+		{storage.KindPassword, passwordPrefix},
+	} {
+		watch, cancel, err := c.watchPrefix(ctx, w.prefix)
+		if err != nil {
+			return err
+		}
+		go c.watchChanges(ctx, w.kind, w.prefix, watch, cancel, fn)
+	}
+	return nil
+}
+
+// watchPrefix starts watching the prefix and waits until the watch was created.
+func (c *conn) watchPrefix(ctx context.Context, prefix string) (clientv3.WatchChan, context.CancelFunc, error) {
+	watchCtx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+	watch := c.db.Watch(watchCtx, prefix, clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	res, ok := <-watch
+	if !ok || res.Err() != nil || !res.Created {
+		cancel()
+		return nil, nil, fmt.Errorf("watch %s: %v", prefix, res.Err())
+	}
+	return watch, cancel, nil
+}
+
+func (c *conn) watchChanges(ctx context.Context, kind, prefix string, watch clientv3.WatchChan, cancel context.CancelFunc, fn func(storage.Change)) {
+	for {
+		for res := range watch {
+			if err := res.Err(); err != nil {
+				c.logger.Errorf("watching %s failed: %v", prefix, err)
+				break
+			}
+			for _, event := range res.Events {
+				fn(storage.Change{Kind: kind, ID: strings.TrimPrefix(string(event.Kv.Key), prefix)})
+			}
+		}
+		cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			var err error
+			if watch, cancel, err = c.watchPrefix(ctx, prefix); err == nil {
+				break
+			}
+			c.logger.Errorf("failed to restart watching %s: %v", prefix, err)
+		}
+		// Changes made while the prefix wasn't watched are unknown.
+		fn(storage.Change{Kind: kind})
+	}
+}
@@ -46,6 +46,7 @@ func cleanDB(c *conn) error {
 		connectorPrefix,
 		deviceRequestPrefix,
 		deviceTokenPrefix,
+		leasePrefix,
 	} {
 		_, err := c.db.Delete(ctx, prefix, clientv3.WithPrefix())
 		if err != nil {
@@ -95,3 +96,42 @@ func TestEtcd(t *testing.T) {
 		conformance.RunTransactionTests(t, newStorage)
 	})
 }
+
+func TestEtcdWatchChanges(t *testing.T) {
+	testEtcdEnv := "DEX_ETCD_ENDPOINTS"
+	endpointsStr := os.Getenv(testEtcdEnv)
+	if endpointsStr == "" {
+		t.Skipf("test environment variable %q not set, skipping", testEtcdEnv)
+		return
+	}
+
+	s := &Etcd{
+		Endpoints: strings.Split(endpointsStr, ","),
+	}
+	conn, err := s.open(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := cleanDB(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan storage.Change, 10)
+	if err := conn.WatchChanges(ctx, func(c storage.Change) { changes <- c }); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.CreatePassword(storage.Password{Email: "Jane@example.com", Hash: []byte("hash"), UserID: "jane"}); err != nil {
+		t.Fatalf("create password: %v", err)
+	}
+	select {
+	case c := <-changes:
+		if want := (storage.Change{Kind: storage.KindPassword, ID: "jane@example.com"}); c != want {
+			t.Errorf("expected change %v, got %v", want, c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("change wasn't reported")
+	}
+}
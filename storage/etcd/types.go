@@ -304,3 +304,81 @@ func toStorageDeviceToken(t DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollIntervalSeconds,
 	}
 }
+
+// CIBARequest is a mirrored struct from storage with JSON struct tags
+type CIBARequest struct {
+	ApprovalCode   string    `json:"approval_code"`
+	AuthReqID      string    `json:"auth_req_id"`
+	ClientID       string    `json:"client_id"`
+	Scopes         []string  `json:"scopes"`
+	LoginHint      string    `json:"login_hint"`
+	BindingMessage string    `json:"binding_message"`
+	Expiry         time.Time `json:"expiry"`
+}
+
+func fromStorageCIBARequest(r storage.CIBARequest) CIBARequest {
+	return CIBARequest{
+		ApprovalCode:   r.ApprovalCode,
+		AuthReqID:      r.AuthReqID,
+		ClientID:       r.ClientID,
+		Scopes:         r.Scopes,
+		LoginHint:      r.LoginHint,
+		BindingMessage: r.BindingMessage,
+		Expiry:         r.Expiry,
+	}
+}
+
+func toStorageCIBARequest(r CIBARequest) storage.CIBARequest {
+	return storage.CIBARequest{
+		ApprovalCode:   r.ApprovalCode,
+		AuthReqID:      r.AuthReqID,
+		ClientID:       r.ClientID,
+		Scopes:         r.Scopes,
+		LoginHint:      r.LoginHint,
+		BindingMessage: r.BindingMessage,
+		Expiry:         r.Expiry,
+	}
+}
+
+// CIBAToken is a mirrored struct from storage with JSON struct tags
+type CIBAToken struct {
+	AuthReqID           string    `json:"auth_req_id"`
+	ClientID            string    `json:"client_id"`
+	Status              string    `json:"status"`
+	Token               string    `json:"token"`
+	NotificationToken   string    `json:"notification_token"`
+	Expiry              time.Time `json:"expiry"`
+	LastRequestTime     time.Time `json:"last_request"`
+	PollIntervalSeconds int       `json:"poll_interval"`
+}
+
+func fromStorageCIBAToken(t storage.CIBAToken) CIBAToken {
+	return CIBAToken{
+		AuthReqID:           t.AuthReqID,
+		ClientID:            t.ClientID,
+		Status:              t.Status,
+		Token:               t.Token,
+		NotificationToken:   t.NotificationToken,
+		Expiry:              t.Expiry,
+		LastRequestTime:     t.LastRequestTime,
+		PollIntervalSeconds: t.PollIntervalSeconds,
+	}
+}
+
+func toStorageCIBAToken(t CIBAToken) storage.CIBAToken {
+	return storage.CIBAToken{
+		AuthReqID:           t.AuthReqID,
+		ClientID:            t.ClientID,
+		Status:              t.Status,
+		Token:               t.Token,
+		NotificationToken:   t.NotificationToken,
+		Expiry:              t.Expiry,
+		LastRequestTime:     t.LastRequestTime,
+		PollIntervalSeconds: t.PollIntervalSeconds,
+	}
+}
+
+// Lease is the value of a key attached to an etcd lease, see AcquireLease.
+type Lease struct {
+	Holder string `json:"holder"`
+}
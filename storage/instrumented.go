@@ -0,0 +1,386 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tests for this code are in the "memory" package, since this package doesn't
+// define a concrete storage implementation.
+
+// The entity types recorded in spans.
+const (
+	entityAuthRequest     = "auth_request"
+	entityClient          = "client"
+	entityAuthCode        = "auth_code"
+	entityRefreshToken    = "refresh_token"
+	entityPassword        = "password"
+	entityOfflineSessions = "offline_session"
+	entityConnector       = "connector"
+	entityDeviceRequest   = "device_request"
+	entityDeviceToken     = "device_token"
+	entityCIBARequest     = "ciba_request"
+	entityCIBAToken       = "ciba_token"
+	entityKeys            = "keys"
+)
+
+// instrumentedStorage records the latency and errors of every storage method
+// in Prometheus metrics and OpenTelemetry spans.
+type instrumentedStorage struct {
+	Storage
+
+	backend  string
+	tracer   trace.Tracer
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	gc       *prometheus.GaugeVec
+}
+
+// WithInstrumentation records the latency of each method of the underlying
+// storage in a histogram, its errors in a counter, both labeled with the
+// method and the backend, and the objects deleted by the last garbage
+// collection in gauges. Metrics are registered with the registerer.
+//
+// Every call is also traced as a span of the tracer provider, with the type of
+// the entity as an attribute. As storage methods don't take a context, the
+// spans are the roots of their traces.
+func WithInstrumentation(s Storage, backend string, registerer prometheus.Registerer, tracerProvider trace.TracerProvider) (Storage, error) {
+	i := instrumentedStorage{
+		Storage: s,
+		backend: backend,
+		tracer:  tracerProvider.Tracer("github.com/dexidp/dex/storage"),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dex_storage_request_duration_seconds",
+			Help:    "Latency of storage methods.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"backend", "method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dex_storage_request_errors_total",
+			Help: "Count of storage methods which returned an error, by kind of error.",
+		}, []string{"backend", "method", "error"}),
+		gc: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dex_storage_gc_deleted_objects",
+			Help: "Number of objects deleted by the last garbage collection.",
+		}, []string{"backend", "entity"}),
+	}
+	for _, c := range []prometheus.Collector{i.duration, i.errors, i.gc} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// observe starts measuring a call of method. The returned function must be
+// deferred with the address of the error result.
+func (s instrumentedStorage) observe(method, entity string) func(err *error) {
+	start := time.Now()
+	_, span := s.tracer.Start(context.Background(), "storage."+method, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("dex.storage.backend", s.backend),
+			attribute.String("dex.storage.entity", entity),
+		))
+
+	return func(err *error) {
+		s.duration.WithLabelValues(s.backend, method).Observe(time.Since(start).Seconds())
+		if *err != nil {
+			kind := errorKind(*err)
+			s.errors.WithLabelValues(s.backend, method, kind).Inc()
+			span.SetAttributes(attribute.String("dex.storage.error", kind))
+			// Missing and existing objects are expected results of lookups
+			// and creations.
+			if kind == "error" {
+				span.RecordError(*err)
+				span.SetStatus(codes.Error, (*err).Error())
+			}
+		}
+		span.End()
+	}
+}
+
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrAlreadyExists):
+		return "already_exists"
+	default:
+		return "error"
+	}
+}
+
+func (s instrumentedStorage) CreateAuthRequest(a AuthRequest) (err error) {
+	defer s.observe("CreateAuthRequest", entityAuthRequest)(&err)
+	return s.Storage.CreateAuthRequest(a)
+}
+
+func (s instrumentedStorage) CreateClient(c Client) (err error) {
+	defer s.observe("CreateClient", entityClient)(&err)
+	return s.Storage.CreateClient(c)
+}
+
+func (s instrumentedStorage) CreateAuthCode(c AuthCode) (err error) {
+	defer s.observe("CreateAuthCode", entityAuthCode)(&err)
+	return s.Storage.CreateAuthCode(c)
+}
+
+func (s instrumentedStorage) CreateRefresh(r RefreshToken) (err error) {
+	defer s.observe("CreateRefresh", entityRefreshToken)(&err)
+	return s.Storage.CreateRefresh(r)
+}
+
+func (s instrumentedStorage) CreatePassword(p Password) (err error) {
+	defer s.observe("CreatePassword", entityPassword)(&err)
+	return s.Storage.CreatePassword(p)
+}
+
+func (s instrumentedStorage) CreateOfflineSessions(o OfflineSessions) (err error) {
+	defer s.observe("CreateOfflineSessions", entityOfflineSessions)(&err)
+	return s.Storage.CreateOfflineSessions(o)
+}
+
+func (s instrumentedStorage) CreateConnector(c Connector) (err error) {
+	defer s.observe("CreateConnector", entityConnector)(&err)
+	return s.Storage.CreateConnector(c)
+}
+
+func (s instrumentedStorage) CreateDeviceRequest(d DeviceRequest) (err error) {
+	defer s.observe("CreateDeviceRequest", entityDeviceRequest)(&err)
+	return s.Storage.CreateDeviceRequest(d)
+}
+
+func (s instrumentedStorage) CreateDeviceToken(d DeviceToken) (err error) {
+	defer s.observe("CreateDeviceToken", entityDeviceToken)(&err)
+	return s.Storage.CreateDeviceToken(d)
+}
+
+func (s instrumentedStorage) CreateCIBARequest(r CIBARequest) (err error) {
+	defer s.observe("CreateCIBARequest", entityCIBARequest)(&err)
+	return s.Storage.CreateCIBARequest(r)
+}
+
+func (s instrumentedStorage) CreateCIBAToken(t CIBAToken) (err error) {
+	defer s.observe("CreateCIBAToken", entityCIBAToken)(&err)
+	return s.Storage.CreateCIBAToken(t)
+}
+
+func (s instrumentedStorage) GetAuthRequest(id string) (_ AuthRequest, err error) {
+	defer s.observe("GetAuthRequest", entityAuthRequest)(&err)
+	return s.Storage.GetAuthRequest(id)
+}
+
+func (s instrumentedStorage) GetAuthCode(id string) (_ AuthCode, err error) {
+	defer s.observe("GetAuthCode", entityAuthCode)(&err)
+	return s.Storage.GetAuthCode(id)
+}
+
+func (s instrumentedStorage) GetClient(id string) (_ Client, err error) {
+	defer s.observe("GetClient", entityClient)(&err)
+	return s.Storage.GetClient(id)
+}
+
+func (s instrumentedStorage) GetKeys() (_ Keys, err error) {
+	defer s.observe("GetKeys", entityKeys)(&err)
+	return s.Storage.GetKeys()
+}
+
+func (s instrumentedStorage) GetRefresh(id string) (_ RefreshToken, err error) {
+	defer s.observe("GetRefresh", entityRefreshToken)(&err)
+	return s.Storage.GetRefresh(id)
+}
+
+func (s instrumentedStorage) GetPassword(email string) (_ Password, err error) {
+	defer s.observe("GetPassword", entityPassword)(&err)
+	return s.Storage.GetPassword(email)
+}
+
+func (s instrumentedStorage) GetOfflineSessions(userID string, connID string) (_ OfflineSessions, err error) {
+	defer s.observe("GetOfflineSessions", entityOfflineSessions)(&err)
+	return s.Storage.GetOfflineSessions(userID, connID)
+}
+
+func (s instrumentedStorage) GetConnector(id string) (_ Connector, err error) {
+	defer s.observe("GetConnector", entityConnector)(&err)
+	return s.Storage.GetConnector(id)
+}
+
+func (s instrumentedStorage) GetDeviceRequest(userCode string) (_ DeviceRequest, err error) {
+	defer s.observe("GetDeviceRequest", entityDeviceRequest)(&err)
+	return s.Storage.GetDeviceRequest(userCode)
+}
+
+func (s instrumentedStorage) GetDeviceToken(deviceCode string) (_ DeviceToken, err error) {
+	defer s.observe("GetDeviceToken", entityDeviceToken)(&err)
+	return s.Storage.GetDeviceToken(deviceCode)
+}
+
+func (s instrumentedStorage) GetCIBARequest(approvalCode string) (_ CIBARequest, err error) {
+	defer s.observe("GetCIBARequest", entityCIBARequest)(&err)
+	return s.Storage.GetCIBARequest(approvalCode)
+}
+
+func (s instrumentedStorage) GetCIBAToken(authReqID string) (_ CIBAToken, err error) {
+	defer s.observe("GetCIBAToken", entityCIBAToken)(&err)
+	return s.Storage.GetCIBAToken(authReqID)
+}
+
+func (s instrumentedStorage) ListClients() (_ []Client, err error) {
+	defer s.observe("ListClients", entityClient)(&err)
+	return s.Storage.ListClients()
+}
+
+func (s instrumentedStorage) ListRefreshTokens() (_ []RefreshToken, err error) {
+	defer s.observe("ListRefreshTokens", entityRefreshToken)(&err)
+	return s.Storage.ListRefreshTokens()
+}
+
+func (s instrumentedStorage) ListPasswords() (_ []Password, err error) {
+	defer s.observe("ListPasswords", entityPassword)(&err)
+	return s.Storage.ListPasswords()
+}
+
+func (s instrumentedStorage) ListConnectors() (_ []Connector, err error) {
+	defer s.observe("ListConnectors", entityConnector)(&err)
+	return s.Storage.ListConnectors()
+}
+
+func (s instrumentedStorage) ListClientsPage(opts ListOptions) (_ []Client, _ string, err error) {
+	defer s.observe("ListClientsPage", entityClient)(&err)
+	return s.Storage.ListClientsPage(opts)
+}
+
+func (s instrumentedStorage) ListRefreshTokensPage(opts ListOptions) (_ []RefreshToken, _ string, err error) {
+	defer s.observe("ListRefreshTokensPage", entityRefreshToken)(&err)
+	return s.Storage.ListRefreshTokensPage(opts)
+}
+
+func (s instrumentedStorage) ListPasswordsPage(opts ListOptions) (_ []Password, _ string, err error) {
+	defer s.observe("ListPasswordsPage", entityPassword)(&err)
+	return s.Storage.ListPasswordsPage(opts)
+}
+
+func (s instrumentedStorage) ListConnectorsPage(opts ListOptions) (_ []Connector, _ string, err error) {
+	defer s.observe("ListConnectorsPage", entityConnector)(&err)
+	return s.Storage.ListConnectorsPage(opts)
+}
+
+func (s instrumentedStorage) ListRefreshTokensForUser(userID string, connID string) (_ []RefreshToken, err error) {
+	defer s.observe("ListRefreshTokensForUser", entityRefreshToken)(&err)
+	return s.Storage.ListRefreshTokensForUser(userID, connID)
+}
+
+func (s instrumentedStorage) ListOfflineSessions() (_ []OfflineSessions, err error) {
+	defer s.observe("ListOfflineSessions", entityOfflineSessions)(&err)
+	return s.Storage.ListOfflineSessions()
+}
+
+func (s instrumentedStorage) ListOfflineSessionsForUser(userID string) (_ []OfflineSessions, err error) {
+	defer s.observe("ListOfflineSessionsForUser", entityOfflineSessions)(&err)
+	return s.Storage.ListOfflineSessionsForUser(userID)
+}
+
+func (s instrumentedStorage) DeleteAuthRequest(id string) (err error) {
+	defer s.observe("DeleteAuthRequest", entityAuthRequest)(&err)
+	return s.Storage.DeleteAuthRequest(id)
+}
+
+func (s instrumentedStorage) DeleteAuthCode(code string) (err error) {
+	defer s.observe("DeleteAuthCode", entityAuthCode)(&err)
+	return s.Storage.DeleteAuthCode(code)
+}
+
+func (s instrumentedStorage) DeleteClient(id string) (err error) {
+	defer s.observe("DeleteClient", entityClient)(&err)
+	return s.Storage.DeleteClient(id)
+}
+
+func (s instrumentedStorage) DeleteRefresh(id string) (err error) {
+	defer s.observe("DeleteRefresh", entityRefreshToken)(&err)
+	return s.Storage.DeleteRefresh(id)
+}
+
+func (s instrumentedStorage) DeletePassword(email string) (err error) {
+	defer s.observe("DeletePassword", entityPassword)(&err)
+	return s.Storage.DeletePassword(email)
+}
+
+func (s instrumentedStorage) DeleteOfflineSessions(userID string, connID string) (err error) {
+	defer s.observe("DeleteOfflineSessions", entityOfflineSessions)(&err)
+	return s.Storage.DeleteOfflineSessions(userID, connID)
+}
+
+func (s instrumentedStorage) DeleteConnector(id string) (err error) {
+	defer s.observe("DeleteConnector", entityConnector)(&err)
+	return s.Storage.DeleteConnector(id)
+}
+
+func (s instrumentedStorage) UpdateClient(id string, updater func(old Client) (Client, error)) (err error) {
+	defer s.observe("UpdateClient", entityClient)(&err)
+	return s.Storage.UpdateClient(id, updater)
+}
+
+func (s instrumentedStorage) UpdateKeys(updater func(old Keys) (Keys, error)) (err error) {
+	defer s.observe("UpdateKeys", entityKeys)(&err)
+	return s.Storage.UpdateKeys(updater)
+}
+
+func (s instrumentedStorage) UpdateAuthRequest(id string, updater func(a AuthRequest) (AuthRequest, error)) (err error) {
+	defer s.observe("UpdateAuthRequest", entityAuthRequest)(&err)
+	return s.Storage.UpdateAuthRequest(id, updater)
+}
+
+func (s instrumentedStorage) UpdateRefreshToken(id string, updater func(r RefreshToken) (RefreshToken, error)) (err error) {
+	defer s.observe("UpdateRefreshToken", entityRefreshToken)(&err)
+	return s.Storage.UpdateRefreshToken(id, updater)
+}
+
+func (s instrumentedStorage) UpdatePassword(email string, updater func(p Password) (Password, error)) (err error) {
+	defer s.observe("UpdatePassword", entityPassword)(&err)
+	return s.Storage.UpdatePassword(email, updater)
+}
+
+func (s instrumentedStorage) UpdateOfflineSessions(userID string, connID string, updater func(s OfflineSessions) (OfflineSessions, error)) (err error) {
+	defer s.observe("UpdateOfflineSessions", entityOfflineSessions)(&err)
+	return s.Storage.UpdateOfflineSessions(userID, connID, updater)
+}
+
+func (s instrumentedStorage) UpdateConnector(id string, updater func(c Connector) (Connector, error)) (err error) {
+	defer s.observe("UpdateConnector", entityConnector)(&err)
+	return s.Storage.UpdateConnector(id, updater)
+}
+
+func (s instrumentedStorage) UpdateDeviceToken(deviceCode string, updater func(t DeviceToken) (DeviceToken, error)) (err error) {
+	defer s.observe("UpdateDeviceToken", entityDeviceToken)(&err)
+	return s.Storage.UpdateDeviceToken(deviceCode, updater)
+}
+
+func (s instrumentedStorage) UpdateCIBAToken(authReqID string, updater func(t CIBAToken) (CIBAToken, error)) (err error) {
+	defer s.observe("UpdateCIBAToken", entityCIBAToken)(&err)
+	return s.Storage.UpdateCIBAToken(authReqID, updater)
+}
+
+func (s instrumentedStorage) GarbageCollect(now time.Time, opts GCOptions) (result GCResult, err error) {
+	defer s.observe("GarbageCollect", "all")(&err)
+	result, err = s.Storage.GarbageCollect(now, opts)
+	if err == nil {
+		for entity, n := range map[string]int64{
+			entityAuthRequest:     result.AuthRequests,
+			entityAuthCode:        result.AuthCodes,
+			entityDeviceRequest:   result.DeviceRequests,
+			entityDeviceToken:     result.DeviceTokens,
+			entityCIBARequest:     result.CIBARequests,
+			entityCIBAToken:       result.CIBATokens,
+			entityRefreshToken:    result.RefreshTokens,
+			entityOfflineSessions: result.OfflineSessions,
+		} {
+			s.gc.WithLabelValues(s.backend, entity).Set(float64(n))
+		}
+	}
+	return result, err
+}
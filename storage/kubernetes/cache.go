@@ -0,0 +1,483 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// cachedResources are the slowly changing resources which are read during
+// every login and served from the read cache if it's enabled.
+var cachedResources = []string{
+	resourceClient,
+	resourceConnector,
+	resourceKeys,
+	resourcePassword,
+}
+
+const (
+	// watchTimeout asks the API server to close watches after this time, the
+	// informer then resumes watching from the last resource version.
+	watchTimeout = 5 * time.Minute
+	// pendingTimeout limits how long reads of an object bypass the cache after
+	// dex wrote it, in case the watch never delivers the written version.
+	pendingTimeout = 30 * time.Second
+
+	minWatchBackoff = time.Second
+	maxWatchBackoff = 30 * time.Second
+)
+
+// errWatchExpired is returned if the resource version of a watch is too old
+// and the resource has to be listed again.
+var errWatchExpired = errors.New("watch expired")
+
+// informer keeps a copy of all objects of a resource. It lists the resource
+// once and watches the API server for changes afterwards. Objects are stored
+// as raw JSON and decoded on every read, so readers get their own copies.
+//
+// Writes never read from the informer, they keep reading the current object
+// from the API server and update it with its resource version. After dex wrote
+// an object reads bypass the informer until the watch delivered the written
+// version, so dex always reads its own writes.
+type informer struct {
+	cli      *client
+	resource string
+
+	// watchClient has no timeout, watches are closed by the API server.
+	watchClient *http.Client
+
+	mu              sync.RWMutex
+	objects         map[string]cachedObject
+	pending         map[string]pendingWrite
+	resourceVersion string
+	synced          bool
+	// lastSync is the last time the informer was known to be up to date,
+	// either by listing the resource or by receiving a watch event.
+	lastSync time.Time
+	restarts int
+
+	// onChange is called with every changed object, and with nil after the
+	// resource was listed again. Optional.
+	onChange func(raw json.RawMessage)
+}
+
+type cachedObject struct {
+	raw             json.RawMessage
+	resourceVersion string
+}
+
+type pendingWrite struct {
+	resourceVersion string
+	deleted         bool
+	at              time.Time
+}
+
+// objectMeta is the part of an object the informer needs to index it.
+type objectMeta struct {
+	Metadata struct {
+		Name            string `json:"name"`
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+}
+
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+func newInformer(cli *client, resource string) *informer {
+	return &informer{
+		cli:         cli,
+		resource:    resource,
+		watchClient: &http.Client{Transport: cli.client.Transport},
+		objects:     make(map[string]cachedObject),
+		pending:     make(map[string]pendingWrite),
+		lastSync:    time.Now(),
+	}
+}
+
+// startInformers starts an informer for each cached resource. They stop once
+// ctx is canceled.
+func (cli *client) startInformers(ctx context.Context) {
+	cache := make(map[string]*informer, len(cachedResources))
+	for _, resource := range cachedResources {
+		inf := newInformer(cli, resource)
+		cache[resource] = inf
+		go inf.run(ctx)
+	}
+	cli.cacheMu.Lock()
+	cli.cache = cache
+	cli.cacheMu.Unlock()
+}
+
+func (cli *client) informer(resource string) *informer {
+	cli.cacheMu.RLock()
+	defer cli.cacheMu.RUnlock()
+	return cli.cache[resource]
+}
+
+// read reads an object from the cache if possible and from the API server
+// otherwise. It must only be used for reads which aren't followed by writes.
+func (cli *client) read(resource, name string, v interface{}) error {
+	if inf := cli.informer(resource); inf != nil {
+		if ok, err := inf.get(name, v); ok {
+			return err
+		}
+	}
+	return cli.get(resource, name, v)
+}
+
+// readList lists all objects of a resource from the cache if possible. If the
+// cache can't serve the list it returns false.
+func (cli *client) readList(resource string, v interface{}) (bool, error) {
+	if inf := cli.informer(resource); inf != nil {
+		return inf.list(v)
+	}
+	return false, nil
+}
+
+// wrote tells the informer of a resource that dex wrote an object.
+func (cli *client) wrote(resource, name, resourceVersion string, deleted bool) {
+	if inf := cli.informer(resource); inf != nil {
+		inf.wrote(name, resourceVersion, deleted)
+	}
+}
+
+func (inf *informer) run(ctx context.Context) {
+	backoff := minWatchBackoff
+	for {
+		err := inf.listAndWatch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		inf.mu.Lock()
+		inf.restarts++
+		inf.mu.Unlock()
+		if err == errWatchExpired {
+			backoff = minWatchBackoff
+			continue
+		}
+
+		inf.cli.logger.Errorf("kubernetes cache: watching %s failed, retrying in %s: %v", inf.resource, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxWatchBackoff {
+			backoff = maxWatchBackoff
+		}
+	}
+}
+
+// listAndWatch lists the resource and watches it until the watch expires or
+// fails.
+func (inf *informer) listAndWatch(ctx context.Context) error {
+	if err := inf.relist(); err != nil {
+		inf.mu.Lock()
+		inf.synced = false
+		inf.mu.Unlock()
+		return fmt.Errorf("list: %v", err)
+	}
+	if inf.onChange != nil {
+		inf.onChange(nil)
+	}
+	for {
+		if err := inf.watch(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// relist replaces the cached objects with the objects listed by the API server.
+func (inf *informer) relist() error {
+	start := time.Now()
+	var list struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := inf.cli.list(inf.resource, &list); err != nil {
+		return err
+	}
+
+	objects := make(map[string]cachedObject, len(list.Items))
+	for _, raw := range list.Items {
+		var meta objectMeta
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return fmt.Errorf("decode object: %v", err)
+		}
+		objects[meta.Metadata.Name] = cachedObject{raw: raw, resourceVersion: meta.Metadata.ResourceVersion}
+	}
+
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+	inf.objects = objects
+	inf.resourceVersion = list.Metadata.ResourceVersion
+	inf.synced = true
+	inf.lastSync = time.Now()
+	// The list includes all writes which completed before it started.
+	for name, p := range inf.pending {
+		if p.at.Before(start) {
+			delete(inf.pending, name)
+		}
+	}
+	return nil
+}
+
+// watch applies the changes of the resource since the last seen resource
+// version until the API server closes the watch.
+func (inf *informer) watch(ctx context.Context) error {
+	inf.mu.RLock()
+	resourceVersion := inf.resourceVersion
+	inf.mu.RUnlock()
+
+	query := url.Values{
+		"watch":               {"true"},
+		"resourceVersion":     {resourceVersion},
+		"allowWatchBookmarks": {"true"},
+		"timeoutSeconds":      {fmt.Sprint(int(watchTimeout.Seconds()))},
+	}
+	u := inf.cli.urlFor(inf.cli.apiVersion, inf.cli.namespace, inf.resource, "") + "?" + query.Encode()
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := inf.watchClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer closeResp(resp)
+	if resp.StatusCode == http.StatusGone {
+		return errWatchExpired
+	}
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		return err
+	}
+	inf.touch()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event watchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The API server closed the watch, resume it.
+			return nil
+		}
+		if err := inf.apply(event); err != nil {
+			return err
+		}
+	}
+}
+
+func (inf *informer) touch() {
+	inf.mu.Lock()
+	inf.lastSync = time.Now()
+	inf.mu.Unlock()
+}
+
+func (inf *informer) apply(event watchEvent) error {
+	if event.Type == "ERROR" {
+		var status struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(event.Object, &status); err != nil {
+			return fmt.Errorf("decode watch error: %v", err)
+		}
+		if status.Code == http.StatusGone {
+			return errWatchExpired
+		}
+		return fmt.Errorf("watch error %d: %s", status.Code, status.Message)
+	}
+
+	var meta objectMeta
+	if err := json.Unmarshal(event.Object, &meta); err != nil {
+		return fmt.Errorf("decode %s event: %v", event.Type, err)
+	}
+	name, resourceVersion := meta.Metadata.Name, meta.Metadata.ResourceVersion
+
+	if inf.onChange != nil && event.Type != "BOOKMARK" {
+		// Deferred before the lock is taken so it runs after the unlock.
+		defer inf.onChange(event.Object)
+	}
+
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+	inf.resourceVersion = resourceVersion
+	inf.lastSync = time.Now()
+
+	p, isPending := inf.pending[name]
+	switch event.Type {
+	case "ADDED", "MODIFIED":
+		inf.objects[name] = cachedObject{raw: event.Object, resourceVersion: resourceVersion}
+		if isPending && !p.deleted && p.resourceVersion == resourceVersion {
+			delete(inf.pending, name)
+		}
+	case "DELETED":
+		delete(inf.objects, name)
+		if isPending && p.deleted {
+			delete(inf.pending, name)
+		}
+	case "BOOKMARK":
+	default:
+		return fmt.Errorf("unknown watch event type %q", event.Type)
+	}
+	return nil
+}
+
+// usable reports whether reads of the named object may be served from the
+// cache. An empty name checks all objects. The caller must hold the lock.
+func (inf *informer) usable(name string) bool {
+	if !inf.synced {
+		return false
+	}
+	for n, p := range inf.pending {
+		if (name == "" || n == name) && time.Since(p.at) < pendingTimeout {
+			return false
+		}
+	}
+	return true
+}
+
+// get decodes the cached object into v. It returns false if the object must be
+// read from the API server.
+func (inf *informer) get(name string, v interface{}) (bool, error) {
+	inf.mu.RLock()
+	defer inf.mu.RUnlock()
+	if !inf.usable(name) {
+		return false, nil
+	}
+	obj, ok := inf.objects[name]
+	if !ok {
+		return true, storage.ErrNotFound
+	}
+	return true, json.Unmarshal(obj.raw, v)
+}
+
+// list decodes all cached objects into the list v. It returns false if the
+// list must be read from the API server.
+func (inf *informer) list(v interface{}) (bool, error) {
+	inf.mu.RLock()
+	defer inf.mu.RUnlock()
+	if !inf.usable("") {
+		return false, nil
+	}
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	list.Items = make([]json.RawMessage, 0, len(inf.objects))
+	for _, obj := range inf.objects {
+		list.Items = append(list.Items, obj.raw)
+	}
+	b, err := json.Marshal(list)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+func (inf *informer) wrote(name, resourceVersion string, deleted bool) {
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+	inf.pending[name] = pendingWrite{resourceVersion: resourceVersion, deleted: deleted, at: time.Now()}
+}
+
+var (
+	cacheStalenessDesc = prometheus.NewDesc(
+		"dex_kubernetes_cache_staleness_seconds",
+		"Seconds since the read cache of a resource was last known to be up to date.",
+		[]string{"resource"}, nil,
+	)
+	cacheSyncedDesc = prometheus.NewDesc(
+		"dex_kubernetes_cache_synced",
+		"Whether the read cache of a resource is synced and serves reads.",
+		[]string{"resource"}, nil,
+	)
+	cacheObjectsDesc = prometheus.NewDesc(
+		"dex_kubernetes_cache_objects",
+		"Number of objects in the read cache of a resource.",
+		[]string{"resource"}, nil,
+	)
+	cacheRestartsDesc = prometheus.NewDesc(
+		"dex_kubernetes_cache_watch_restarts_total",
+		"Number of times the watch of a cached resource was restarted.",
+		[]string{"resource"}, nil,
+	)
+)
+
+// Describe implements prometheus.Collector. The metrics are only collected if
+// the read cache is enabled.
+func (cli *client) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheStalenessDesc
+	ch <- cacheSyncedDesc
+	ch <- cacheObjectsDesc
+	ch <- cacheRestartsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (cli *client) Collect(ch chan<- prometheus.Metric) {
+	cli.cacheMu.RLock()
+	defer cli.cacheMu.RUnlock()
+	for resource, inf := range cli.cache {
+		inf.mu.RLock()
+		synced := 0.0
+		if inf.synced {
+			synced = 1
+		}
+		ch <- prometheus.MustNewConstMetric(cacheStalenessDesc, prometheus.GaugeValue, time.Since(inf.lastSync).Seconds(), resource)
+		ch <- prometheus.MustNewConstMetric(cacheSyncedDesc, prometheus.GaugeValue, synced, resource)
+		ch <- prometheus.MustNewConstMetric(cacheObjectsDesc, prometheus.GaugeValue, float64(len(inf.objects)), resource)
+		ch <- prometheus.MustNewConstMetric(cacheRestartsDesc, prometheus.CounterValue, float64(inf.restarts), resource)
+		inf.mu.RUnlock()
+	}
+}
+
+// WatchChanges runs informers for clients, connectors and passwords, which
+// report the objects of their watch events. Listing a resource again reports
+// all its objects as changed. The informers are independent of the read cache.
+func (cli *client) WatchChanges(ctx context.Context, fn func(storage.Change)) error {
+	for resource, kind := range map[string]string{
+		resourceClient:    storage.KindClient,
+		resourceConnector: storage.KindConnector,
+		resourcePassword:  storage.KindPassword,
+	} {
+		kind := kind
+		inf := newInformer(cli, resource)
+		inf.onChange = func(raw json.RawMessage) {
+			if raw == nil {
+				fn(storage.Change{Kind: kind})
+				return
+			}
+			// Object names are hashes, the IDs are read from the objects.
+			var object struct {
+				ID    string `json:"id"`
+				Email string `json:"email"`
+			}
+			if err := json.Unmarshal(raw, &object); err != nil {
+				cli.logger.Errorf("kubernetes: decode changed %s: %v", kind, err)
+				fn(storage.Change{Kind: kind})
+				return
+			}
+			id := object.ID
+			if kind == storage.KindPassword {
+				id = object.Email
+			}
+			fn(storage.Change{Kind: kind, ID: id})
+		}
+		go inf.run(ctx)
+	}
+	return nil
+}
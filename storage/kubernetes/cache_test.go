@@ -0,0 +1,226 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"hash"
+	"hash/fnv"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/kubernetes/k8sapi"
+)
+
+// fakeClientAPI serves OAuth2 clients like the API server, including watches
+// streaming the events sent to its events channel. Other resources are empty.
+type fakeClientAPI struct {
+	mu              sync.Mutex
+	clients         map[string]Client
+	resourceVersion int
+	gets            int
+
+	events chan watchEvent
+}
+
+func (f *fakeClientAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dir, name := path.Split(r.URL.Path)
+	switch {
+	case r.URL.Query().Get("watch") == "true":
+		if name != resourceClient {
+			f.mu.Unlock()
+			<-r.Context().Done()
+			f.mu.Lock()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		f.mu.Unlock()
+		defer f.mu.Lock()
+		for {
+			select {
+			case event := <-f.events:
+				json.NewEncoder(w).Encode(event)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	case name == resourceClient:
+		list := ClientList{ListMeta: k8sapi.ListMeta{ResourceVersion: strconv.Itoa(f.resourceVersion)}}
+		for _, c := range f.clients {
+			list.Clients = append(list.Clients, c)
+		}
+		json.NewEncoder(w).Encode(list)
+	case path.Base(dir) == resourceClient && r.Method == http.MethodGet:
+		f.gets++
+		c, ok := f.clients[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(c)
+	case path.Base(dir) == resourceClient && r.Method == http.MethodPut:
+		var c Client
+		json.NewDecoder(r.Body).Decode(&c)
+		f.resourceVersion++
+		c.ObjectMeta.ResourceVersion = strconv.Itoa(f.resourceVersion)
+		f.clients[name] = c
+		json.NewEncoder(w).Encode(c)
+	default:
+		w.Write([]byte(`{"items":[]}`))
+	}
+}
+
+func (f *fakeClientAPI) liveGets() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.gets
+}
+
+func (f *fakeClientAPI) client(name string) Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.clients[name]
+}
+
+func TestReadCache(t *testing.T) {
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.hash = func() hash.Hash { return fnv.New64() }
+	cli.apiVersion = "dex.coreos.com/v1"
+	cli.namespace = "dex"
+
+	c := cli.fromStorageClient(storage.Client{ID: "client", Secret: "secret"})
+	c.ObjectMeta.ResourceVersion = "1"
+	api := &fakeClientAPI{
+		clients:         map[string]Client{c.ObjectMeta.Name: c},
+		resourceVersion: 1,
+		events:          make(chan watchEvent),
+	}
+	s := httptest.NewTLSServer(api)
+	defer s.Close()
+	cli.baseURL = s.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli.startInformers(ctx)
+
+	getSecret := func() string {
+		t.Helper()
+		c, err := cli.GetClient("client")
+		require.NoError(t, err)
+		return c.Secret
+	}
+
+	require.Eventually(t, func() bool {
+		inf := cli.informer(resourceClient)
+		inf.mu.RLock()
+		defer inf.mu.RUnlock()
+		return inf.synced
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, "secret", getSecret())
+	_, err := cli.GetClient("missing")
+	require.Equal(t, storage.ErrNotFound, err)
+	require.Equal(t, 0, api.liveGets(), "reads should be served from the cache")
+
+	// Changes made by others arrive through the watch.
+	changed := api.client(c.ObjectMeta.Name)
+	changed.Secret = "changed"
+	changed.ObjectMeta.ResourceVersion = "changed"
+	raw, err := json.Marshal(changed)
+	require.NoError(t, err)
+	api.events <- watchEvent{Type: "MODIFIED", Object: raw}
+	require.Eventually(t, func() bool { return getSecret() == "changed" }, 5*time.Second, 10*time.Millisecond)
+
+	clients, err := cli.ListClients()
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+
+	// Updates read the current object from the API server, and reads bypass
+	// the cache until the watch delivered the written version.
+	err = cli.UpdateClient("client", func(old storage.Client) (storage.Client, error) {
+		old.Secret = "updated"
+		return old, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, api.liveGets())
+	require.Equal(t, "updated", getSecret())
+	require.Equal(t, 2, api.liveGets())
+
+	raw, err = json.Marshal(api.client(c.ObjectMeta.Name))
+	require.NoError(t, err)
+	api.events <- watchEvent{Type: "MODIFIED", Object: raw}
+	inf := cli.informer(resourceClient)
+	require.Eventually(t, func() bool {
+		inf.mu.RLock()
+		defer inf.mu.RUnlock()
+		return len(inf.pending) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "updated", getSecret())
+	require.Equal(t, 2, api.liveGets(), "reads should be served from the cache again")
+
+	require.Equal(t, len(cachedResources)*4, testutil.CollectAndCount(cli))
+}
+
+func TestWatchChanges(t *testing.T) {
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.hash = func() hash.Hash { return fnv.New64() }
+	cli.apiVersion = "dex.coreos.com/v1"
+	cli.namespace = "dex"
+
+	c := cli.fromStorageClient(storage.Client{ID: "client", Secret: "secret"})
+	c.ObjectMeta.ResourceVersion = "1"
+	api := &fakeClientAPI{
+		clients:         map[string]Client{c.ObjectMeta.Name: c},
+		resourceVersion: 1,
+		events:          make(chan watchEvent),
+	}
+	s := httptest.NewTLSServer(api)
+	defer s.Close()
+	cli.baseURL = s.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan storage.Change, 10)
+	require.NoError(t, cli.WatchChanges(ctx, func(c storage.Change) { changes <- c }))
+
+	// Listing reports all objects of each kind as changed.
+	listed := map[storage.Change]bool{}
+	for i := 0; i < 3; i++ {
+		select {
+		case c := <-changes:
+			listed[c] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("resources weren't listed")
+		}
+	}
+	require.Equal(t, map[storage.Change]bool{
+		{Kind: storage.KindClient}:    true,
+		{Kind: storage.KindConnector}: true,
+		{Kind: storage.KindPassword}:  true,
+	}, listed)
+
+	c.Secret = "changed"
+	c.ObjectMeta.ResourceVersion = "2"
+	raw, err := json.Marshal(c)
+	require.NoError(t, err)
+	api.events <- watchEvent{Type: "MODIFIED", Object: raw}
+	select {
+	case change := <-changes:
+		require.Equal(t, storage.Change{Kind: storage.KindClient, ID: "client"}, change)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change wasn't reported")
+	}
+}
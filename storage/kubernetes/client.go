@@ -15,10 +15,12 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -55,6 +57,11 @@ type client struct {
 	// This is called once the client's Close method is called to signal goroutines,
 	// such as the one creating third party resources, to stop.
 	cancel context.CancelFunc
+
+	// Informers of the cached resources by resource name, nil if the read
+	// cache is disabled.
+	cacheMu sync.RWMutex
+	cache   map[string]*informer
 }
 
 // idToName maps an arbitrary ID, such as an email or client ID to a Kubernetes object name.
@@ -180,6 +187,56 @@ func (cli *client) list(resource string, v interface{}) error {
 	return cli.get(resource, "", v)
 }
 
+// listSelector lists the resources matching the label selector.
+func (cli *client) listSelector(resource, selector string, v interface{}) error {
+	u := cli.urlFor(cli.apiVersion, cli.namespace, resource, "")
+	u += "?" + url.Values{"labelSelector": {selector}}.Encode()
+
+	resp, err := cli.client.Get(u)
+	if err != nil {
+		return err
+	}
+	defer closeResp(resp)
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// listChunk lists at most limit resources, continuing a previous listing if
+// continueToken is set. A limit of zero lists all resources.
+func (cli *client) listChunk(resource string, limit int, continueToken string, v interface{}) error {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if continueToken != "" {
+		query.Set("continue", continueToken)
+	}
+	u := cli.urlFor(cli.apiVersion, cli.namespace, resource, "")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	resp, err := cli.client.Get(u)
+	if err != nil {
+		return err
+	}
+	defer closeResp(resp)
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		if e, ok := err.(httpError); ok && continueToken != "" {
+			// Malformed continue tokens are rejected as bad requests, expired
+			// ones are gone.
+			switch e.StatusCode() {
+			case http.StatusBadRequest, http.StatusGone:
+				return storage.ErrInvalidPageToken
+			}
+		}
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
 func (cli *client) post(resource string, v interface{}) error {
 	return cli.postResource(cli.apiVersion, cli.namespace, resource, v)
 }
@@ -196,7 +253,27 @@ func (cli *client) postResource(apiVersion, namespace, resource string, v interf
 		return err
 	}
 	defer closeResp(resp)
-	return checkHTTPErr(resp, http.StatusCreated)
+	if err := checkHTTPErr(resp, http.StatusCreated); err != nil {
+		return err
+	}
+	if apiVersion == cli.apiVersion && namespace == cli.namespace {
+		cli.wroteResponse(resource, resp)
+	}
+	return nil
+}
+
+// wroteResponse tells the read cache about an object dex created or replaced,
+// using the object returned by the API server.
+func (cli *client) wroteResponse(resource string, resp *http.Response) {
+	if cli.informer(resource) == nil {
+		return
+	}
+	var meta objectMeta
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		cli.logger.Errorf("kubernetes cache: decode written %s: %v", resource, err)
+		return
+	}
+	cli.wrote(resource, meta.Metadata.Name, meta.Metadata.ResourceVersion, false)
 }
 
 func (cli *client) detectKubernetesVersion() error {
@@ -241,7 +318,43 @@ func (cli *client) delete(resource, name string) error {
 		return fmt.Errorf("delete request: %v", err)
 	}
 	defer closeResp(resp)
-	return checkHTTPErr(resp, http.StatusOK)
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		return err
+	}
+	cli.wrote(resource, name, "", true)
+	return nil
+}
+
+// deleteAtVersion deletes the object only if it wasn't modified since the
+// resource version, and returns whether it was deleted.
+func (cli *client) deleteAtVersion(resource, name, resourceVersion string) (bool, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion":    "v1",
+		"kind":          "DeleteOptions",
+		"preconditions": map[string]string{"resourceVersion": resourceVersion},
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshal delete options: %v", err)
+	}
+	url := cli.urlFor(cli.apiVersion, cli.namespace, resource, name)
+	req, err := http.NewRequest("DELETE", url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("create delete request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cli.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("delete request: %v", err)
+	}
+	defer closeResp(resp)
+	if resp.StatusCode == http.StatusConflict {
+		return false, nil
+	}
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		return false, err
+	}
+	cli.wrote(resource, name, "", true)
+	return true, nil
 }
 
 func (cli *client) deleteAll(resource string) error {
@@ -265,12 +378,16 @@ func (cli *client) deleteAll(resource string) error {
 }
 
 func (cli *client) put(resource, name string, v interface{}) error {
+	return cli.putResource(cli.apiVersion, cli.namespace, resource, name, v)
+}
+
+func (cli *client) putResource(apiVersion, namespace, resource, name string, v interface{}) error {
 	body, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("marshal object: %v", err)
 	}
 
-	url := cli.urlFor(cli.apiVersion, cli.namespace, resource, name)
+	url := cli.urlFor(apiVersion, namespace, resource, name)
 	req, err := http.NewRequest("PUT", url, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("create patch request: %v", err)
@@ -284,7 +401,13 @@ func (cli *client) put(resource, name string, v interface{}) error {
 	}
 	defer closeResp(resp)
 
-	return checkHTTPErr(resp, http.StatusOK)
+	if err := checkHTTPErr(resp, http.StatusOK); err != nil {
+		return err
+	}
+	if apiVersion == cli.apiVersion && namespace == cli.namespace {
+		cli.wroteResponse(resource, resp)
+	}
+	return nil
 }
 
 // Copied from https://github.com/gtank/cryptopasta
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaseAPI stores Lease objects like the API server, rejecting writes of
+// outdated resource versions.
+type fakeLeaseAPI struct {
+	mu              sync.Mutex
+	leases          map[string]Lease
+	resourceVersion int
+}
+
+func (f *fakeLeaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := path.Base(r.URL.Path)
+	current, exists := f.leases[name]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(current)
+		return
+	case http.MethodPost:
+		if exists {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	case http.MethodPut:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
+
+	var l Lease
+	json.NewDecoder(r.Body).Decode(&l)
+	if exists && l.ObjectMeta.ResourceVersion != current.ObjectMeta.ResourceVersion {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	f.resourceVersion++
+	l.ObjectMeta.ResourceVersion = strconv.Itoa(f.resourceVersion)
+	f.leases[l.ObjectMeta.Name] = l
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(l)
+}
+
+func TestLease(t *testing.T) {
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.namespace = "dex"
+	api := &fakeLeaseAPI{leases: map[string]Lease{}}
+	s := httptest.NewTLSServer(api)
+	defer s.Close()
+	cli.baseURL = s.URL
+
+	lease, err := cli.AcquireLease("dex", "a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "a", lease.Holder)
+	require.True(t, lease.Held(time.Now()))
+
+	lease, err = cli.AcquireLease("dex", "b", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "a", lease.Holder)
+
+	// Renewals keep the acquire time.
+	acquired := api.leases["dex"].Spec.AcquireTime
+	_, err = cli.AcquireLease("dex", "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, api.leases["dex"].Spec.AcquireTime.Equal(acquired.Time))
+
+	require.NoError(t, cli.ReleaseLease("dex", "b"))
+	require.Equal(t, "a", api.leases["dex"].Spec.HolderIdentity)
+	require.NoError(t, cli.ReleaseLease("dex", "a"))
+	require.Equal(t, "", api.leases["dex"].Spec.HolderIdentity)
+
+	lease, err = cli.AcquireLease("dex", "b", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "b", lease.Holder)
+	require.Equal(t, int32(1), api.leases["dex"].Spec.LeaseTransitions)
+	require.Equal(t, "coordination.k8s.io/v1", api.leases["dex"].TypeMeta.APIVersion)
+}
@@ -25,6 +25,8 @@ const (
 	kindConnector       = "Connector"
 	kindDeviceRequest   = "DeviceRequest"
 	kindDeviceToken     = "DeviceToken"
+	kindCIBARequest     = "CIBARequest"
+	kindCIBAToken       = "CIBAToken"
 )
 
 const (
@@ -38,12 +40,23 @@ const (
 	resourceConnector       = "connectors"
 	resourceDeviceRequest   = "devicerequests"
 	resourceDeviceToken     = "devicetokens"
+	resourceCIBARequest     = "cibarequests"
+	resourceCIBAToken       = "cibatokens"
+
+	// Leases are the built-in Lease objects, not a custom resource.
+	leaseAPIVersion = "coordination.k8s.io/v1"
+	kindLease       = "Lease"
+	resourceLease   = "leases"
 )
 
 // Config values for the Kubernetes storage type.
 type Config struct {
 	InCluster      bool   `json:"inCluster"`
 	KubeConfigFile string `json:"kubeConfigFile"`
+
+	// ReadCache serves reads of clients, connectors, signing keys and passwords
+	// from a cache kept up to date by watching the API server.
+	ReadCache bool `json:"readCache"`
 }
 
 // Open returns a storage using Kubernetes third party resource.
@@ -95,7 +108,8 @@ func (c *Config) open(logger log.Logger, waitForResources bool) (*client, error)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	logger.Info("creating custom Kubernetes resources")
-	if !cli.registerCustomResources() {
+	registered := cli.registerCustomResources()
+	if !registered {
 		if waitForResources {
 			cancel()
 			return nil, fmt.Errorf("failed creating custom resources")
@@ -107,6 +121,10 @@ func (c *Config) open(logger log.Logger, waitForResources bool) (*client, error)
 		go func() {
 			for {
 				if cli.registerCustomResources() {
+					cli.indexObjects()
+					if c.ReadCache {
+						cli.startInformers(ctx)
+					}
 					return
 				}
 
@@ -126,11 +144,45 @@ func (c *Config) open(logger log.Logger, waitForResources bool) (*client, error)
 		}
 	}
 
+	if registered {
+		cli.indexObjects()
+		if c.ReadCache {
+			cli.startInformers(ctx)
+		}
+	}
+
 	// If the client is closed, stop trying to create resources.
 	cli.cancel = cancel
 	return cli, nil
 }
 
+// indexObjects labels refresh tokens and offline sessions which were created
+// before they were indexed by user. Failures are logged, objects which aren't
+// labeled yet are only missing from the lists by user.
+func (cli *client) indexObjects() {
+	keep := func(old storage.RefreshToken) (storage.RefreshToken, error) { return old, nil }
+	var tokens RefreshList
+	if err := cli.listSelector(resourceRefreshToken, "!"+labelOfflineSession, &tokens); err != nil {
+		cli.logger.Errorf("failed to list refresh tokens to index: %v", err)
+	}
+	for _, token := range tokens.RefreshTokens {
+		if err := cli.UpdateRefreshToken(token.ObjectMeta.Name, keep); err != nil && err != storage.ErrNotFound {
+			cli.logger.Errorf("failed to index refresh token: %v", err)
+		}
+	}
+
+	keepSession := func(old storage.OfflineSessions) (storage.OfflineSessions, error) { return old, nil }
+	var sessions OfflineSessionsList
+	if err := cli.listSelector(resourceOfflineSessions, "!"+labelUser, &sessions); err != nil {
+		cli.logger.Errorf("failed to list offline sessions to index: %v", err)
+	}
+	for _, o := range sessions.OfflineSessions {
+		if err := cli.UpdateOfflineSessions(o.UserID, o.ConnID, keepSession); err != nil && err != storage.ErrNotFound {
+			cli.logger.Errorf("failed to index offline session: %v", err)
+		}
+	}
+}
+
 // registerCustomResources attempts to create the custom resources dex
 // requires or identifies that they're already enabled. This function creates
 // custom resource definitions(CRDs)
@@ -273,17 +325,21 @@ func (cli *client) GetAuthCode(id string) (storage.AuthCode, error) {
 }
 
 func (cli *client) GetClient(id string) (storage.Client, error) {
-	c, err := cli.getClient(id)
+	c, err := cli.getClient(cli.read, id)
 	if err != nil {
 		return storage.Client{}, err
 	}
 	return toStorageClient(c), nil
 }
 
-func (cli *client) getClient(id string) (Client, error) {
+// getter reads an object, cli.get reads it from the API server and cli.read
+// from the read cache if it's enabled.
+type getter func(resource, name string, v interface{}) error
+
+func (cli *client) getClient(get getter, id string) (Client, error) {
 	var c Client
 	name := cli.idToName(id)
-	if err := cli.get(resourceClient, name, &c); err != nil {
+	if err := get(resourceClient, name, &c); err != nil {
 		return Client{}, err
 	}
 	if c.ID != id {
@@ -293,19 +349,19 @@ func (cli *client) getClient(id string) (Client, error) {
 }
 
 func (cli *client) GetPassword(email string) (storage.Password, error) {
-	p, err := cli.getPassword(email)
+	p, err := cli.getPassword(cli.read, email)
 	if err != nil {
 		return storage.Password{}, err
 	}
 	return toStoragePassword(p), nil
 }
 
-func (cli *client) getPassword(email string) (Password, error) {
+func (cli *client) getPassword(get getter, email string) (Password, error) {
 	// TODO(ericchiang): Figure out whose job it is to lowercase emails.
 	email = strings.ToLower(email)
 	var p Password
 	name := cli.idToName(email)
-	if err := cli.get(resourcePassword, name, &p); err != nil {
+	if err := get(resourcePassword, name, &p); err != nil {
 		return Password{}, err
 	}
 	if email != p.Email {
@@ -316,7 +372,7 @@ func (cli *client) getPassword(email string) (Password, error) {
 
 func (cli *client) GetKeys() (storage.Keys, error) {
 	var keys Keys
-	if err := cli.get(resourceKeys, keysName, &keys); err != nil {
+	if err := cli.read(resourceKeys, keysName, &keys); err != nil {
 		return storage.Keys{}, err
 	}
 	return toStorageKeys(keys), nil
@@ -356,23 +412,198 @@ func (cli *client) getOfflineSessions(userID string, connID string) (o OfflineSe
 
 func (cli *client) GetConnector(id string) (storage.Connector, error) {
 	var c Connector
-	if err := cli.get(resourceConnector, id, &c); err != nil {
+	if err := cli.read(resourceConnector, id, &c); err != nil {
 		return storage.Connector{}, err
 	}
 	return toStorageConnector(c), nil
 }
 
-func (cli *client) ListClients() ([]storage.Client, error) {
-	return nil, errors.New("not implemented")
+// listChunkSize is the number of objects requested at once when listing all
+// objects of a kind.
+const listChunkSize = 500
+
+func (cli *client) ListClients() (clients []storage.Client, err error) {
+	var clientList ClientList
+	if ok, err := cli.readList(resourceClient, &clientList); ok {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clients: %v", err)
+		}
+		for _, c := range clientList.Clients {
+			clients = append(clients, toStorageClient(c))
+		}
+		return clients, nil
+	}
+
+	opts := storage.ListOptions{PageSize: listChunkSize}
+	for {
+		page, next, err := cli.ListClientsPage(opts)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, page...)
+		if next == "" {
+			return clients, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+func (cli *client) ListRefreshTokens() (tokens []storage.RefreshToken, err error) {
+	opts := storage.ListOptions{PageSize: listChunkSize}
+	for {
+		page, next, err := cli.ListRefreshTokensPage(opts)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, page...)
+		if next == "" {
+			return tokens, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// The paginated list methods use the continue tokens of the Kubernetes API as
+// page tokens. Filters are applied to the chunks returned by the API server,
+// chunks are requested until a page is complete.
+
+// chunkLimit returns the number of objects to request for a page which already
+// holds n objects.
+func chunkLimit(opts storage.ListOptions, n int) int {
+	if opts.PageSize == 0 {
+		return 0
+	}
+	return opts.PageSize - n
 }
 
-func (cli *client) ListRefreshTokens() ([]storage.RefreshToken, error) {
-	return nil, errors.New("not implemented")
+func (cli *client) ListClientsPage(opts storage.ListOptions) (clients []storage.Client, next string, err error) {
+	next = opts.PageToken
+	for {
+		var clientList ClientList
+		if err = cli.listChunk(resourceClient, chunkLimit(opts, len(clients)), next, &clientList); err != nil {
+			return nil, "", fmt.Errorf("failed to list clients: %w", err)
+		}
+		for _, client := range clientList.Clients {
+			clients = append(clients, toStorageClient(client))
+		}
+		next = clientList.Continue
+		if next == "" || opts.PageSize == 0 || len(clients) >= opts.PageSize {
+			return clients, next, nil
+		}
+	}
+}
+
+func (cli *client) ListRefreshTokensPage(opts storage.ListOptions) (tokens []storage.RefreshToken, next string, err error) {
+	next = opts.PageToken
+	for {
+		var refreshList RefreshList
+		if err = cli.listChunk(resourceRefreshToken, chunkLimit(opts, len(tokens)), next, &refreshList); err != nil {
+			return nil, "", fmt.Errorf("failed to list refresh tokens: %w", err)
+		}
+		for _, token := range refreshList.RefreshTokens {
+			if r := toStorageRefreshToken(token); opts.Filter.MatchRefreshToken(r) {
+				tokens = append(tokens, r)
+			}
+		}
+		next = refreshList.Continue
+		if next == "" || opts.PageSize == 0 || len(tokens) >= opts.PageSize {
+			return tokens, next, nil
+		}
+	}
+}
+
+func (cli *client) ListRefreshTokensForUser(userID string, connID string) ([]storage.RefreshToken, error) {
+	var refreshList RefreshList
+	selector := labelOfflineSession + "=" + cli.offlineTokenName(userID, connID)
+	if err := cli.listSelector(resourceRefreshToken, selector, &refreshList); err != nil {
+		return nil, fmt.Errorf("failed to list refresh tokens: %v", err)
+	}
+
+	var tokens []storage.RefreshToken
+	for _, token := range refreshList.RefreshTokens {
+		// Check for hash collisions.
+		if token.Claims.UserID != userID || token.ConnectorID != connID {
+			continue
+		}
+		tokens = append(tokens, toStorageRefreshToken(token))
+	}
+	return tokens, nil
+}
+
+func (cli *client) ListOfflineSessions() ([]storage.OfflineSessions, error) {
+	var sessionsList OfflineSessionsList
+	if err := cli.list(resourceOfflineSessions, &sessionsList); err != nil {
+		return nil, fmt.Errorf("failed to list offline sessions: %v", err)
+	}
+
+	sessions := make([]storage.OfflineSessions, len(sessionsList.OfflineSessions))
+	for i, o := range sessionsList.OfflineSessions {
+		sessions[i] = toStorageOfflineSessions(o)
+	}
+	return sessions, nil
+}
+
+func (cli *client) ListOfflineSessionsForUser(userID string) ([]storage.OfflineSessions, error) {
+	var sessionsList OfflineSessionsList
+	selector := labelUser + "=" + cli.offlineTokenName(userID, "")
+	if err := cli.listSelector(resourceOfflineSessions, selector, &sessionsList); err != nil {
+		return nil, fmt.Errorf("failed to list offline sessions: %v", err)
+	}
+
+	var sessions []storage.OfflineSessions
+	for _, o := range sessionsList.OfflineSessions {
+		// Check for hash collisions.
+		if o.UserID != userID {
+			continue
+		}
+		sessions = append(sessions, toStorageOfflineSessions(o))
+	}
+	return sessions, nil
+}
+
+func (cli *client) ListPasswordsPage(opts storage.ListOptions) (passwords []storage.Password, next string, err error) {
+	next = opts.PageToken
+	for {
+		var passwordList PasswordList
+		if err = cli.listChunk(resourcePassword, chunkLimit(opts, len(passwords)), next, &passwordList); err != nil {
+			return nil, "", fmt.Errorf("failed to list passwords: %w", err)
+		}
+		for _, password := range passwordList.Passwords {
+			if p := toStoragePassword(password); opts.Filter.MatchPassword(p) {
+				passwords = append(passwords, p)
+			}
+		}
+		next = passwordList.Continue
+		if next == "" || opts.PageSize == 0 || len(passwords) >= opts.PageSize {
+			return passwords, next, nil
+		}
+	}
+}
+
+func (cli *client) ListConnectorsPage(opts storage.ListOptions) (connectors []storage.Connector, next string, err error) {
+	next = opts.PageToken
+	for {
+		var connectorList ConnectorList
+		if err = cli.listChunk(resourceConnector, chunkLimit(opts, len(connectors)), next, &connectorList); err != nil {
+			return nil, "", fmt.Errorf("failed to list connectors: %w", err)
+		}
+		for _, connector := range connectorList.Connectors {
+			connectors = append(connectors, toStorageConnector(connector))
+		}
+		next = connectorList.Continue
+		if next == "" || opts.PageSize == 0 || len(connectors) >= opts.PageSize {
+			return connectors, next, nil
+		}
+	}
 }
 
 func (cli *client) ListPasswords() (passwords []storage.Password, err error) {
 	var passwordList PasswordList
-	if err = cli.list(resourcePassword, &passwordList); err != nil {
+	ok, err := cli.readList(resourcePassword, &passwordList)
+	if !ok {
+		err = cli.list(resourcePassword, &passwordList)
+	}
+	if err != nil {
 		return passwords, fmt.Errorf("failed to list passwords: %v", err)
 	}
 
@@ -391,7 +622,11 @@ func (cli *client) ListPasswords() (passwords []storage.Password, err error) {
 
 func (cli *client) ListConnectors() (connectors []storage.Connector, err error) {
 	var connectorList ConnectorList
-	if err = cli.list(resourceConnector, &connectorList); err != nil {
+	ok, err := cli.readList(resourceConnector, &connectorList)
+	if !ok {
+		err = cli.list(resourceConnector, &connectorList)
+	}
+	if err != nil {
 		return connectors, fmt.Errorf("failed to list connectors: %v", err)
 	}
 
@@ -413,7 +648,7 @@ func (cli *client) DeleteAuthCode(code string) error {
 
 func (cli *client) DeleteClient(id string) error {
 	// Check for hash collision.
-	c, err := cli.getClient(id)
+	c, err := cli.getClient(cli.get, id)
 	if err != nil {
 		return err
 	}
@@ -426,7 +661,7 @@ func (cli *client) DeleteRefresh(id string) error {
 
 func (cli *client) DeletePassword(email string) error {
 	// Check for hash collision.
-	p, err := cli.getPassword(email)
+	p, err := cli.getPassword(cli.get, email)
 	if err != nil {
 		return err
 	}
@@ -459,13 +694,15 @@ func (cli *client) UpdateRefreshToken(id string, updater func(old storage.Refres
 		updated.ID = id
 
 		newToken := cli.fromStorageRefreshToken(updated)
+		labels := mergeLabels(r.ObjectMeta.Labels, newToken.ObjectMeta.Labels)
 		newToken.ObjectMeta = r.ObjectMeta
+		newToken.ObjectMeta.Labels = labels
 		return cli.put(resourceRefreshToken, r.ObjectMeta.Name, newToken)
 	})
 }
 
 func (cli *client) UpdateClient(id string, updater func(old storage.Client) (storage.Client, error)) error {
-	c, err := cli.getClient(id)
+	c, err := cli.getClient(cli.get, id)
 	if err != nil {
 		return err
 	}
@@ -482,7 +719,7 @@ func (cli *client) UpdateClient(id string, updater func(old storage.Client) (sto
 }
 
 func (cli *client) UpdatePassword(email string, updater func(old storage.Password) (storage.Password, error)) error {
-	p, err := cli.getPassword(email)
+	p, err := cli.getPassword(cli.get, email)
 	if err != nil {
 		return err
 	}
@@ -511,7 +748,9 @@ func (cli *client) UpdateOfflineSessions(userID string, connID string, updater f
 		}
 
 		newOfflineSessions := cli.fromStorageOfflineSessions(updated)
+		labels := mergeLabels(o.ObjectMeta.Labels, newOfflineSessions.ObjectMeta.Labels)
 		newOfflineSessions.ObjectMeta = o.ObjectMeta
+		newOfflineSessions.ObjectMeta.Labels = labels
 		return cli.put(resourceOfflineSessions, o.ObjectMeta.Name, newOfflineSessions)
 	})
 }
@@ -597,7 +836,7 @@ func (cli *client) UpdateConnector(id string, updater func(a storage.Connector)
 	})
 }
 
-func (cli *client) GarbageCollect(now time.Time) (result storage.GCResult, err error) {
+func (cli *client) GarbageCollect(now time.Time, opts storage.GCOptions) (result storage.GCResult, err error) {
 	var authRequests AuthRequestList
 	if err := cli.list(resourceAuthRequest, &authRequests); err != nil {
 		return result, fmt.Errorf("failed to list auth requests: %v", err)
@@ -662,6 +901,80 @@ func (cli *client) GarbageCollect(now time.Time) (result storage.GCResult, err e
 		}
 	}
 
+	var cibaRequests CIBARequestList
+	if err := cli.list(resourceCIBARequest, &cibaRequests); err != nil {
+		return result, fmt.Errorf("failed to list ciba requests: %v", err)
+	}
+
+	for _, cibaRequest := range cibaRequests.CIBARequests {
+		if now.After(cibaRequest.Expiry) {
+			if err := cli.delete(resourceCIBARequest, cibaRequest.ObjectMeta.Name); err != nil {
+				cli.logger.Errorf("failed to delete ciba request: %v", err)
+				delErr = fmt.Errorf("failed to delete ciba request: %v", err)
+			}
+			result.CIBARequests++
+		}
+	}
+
+	var cibaTokens CIBATokenList
+	if err := cli.list(resourceCIBAToken, &cibaTokens); err != nil {
+		return result, fmt.Errorf("failed to list ciba tokens: %v", err)
+	}
+
+	for _, cibaToken := range cibaTokens.CIBATokens {
+		if now.After(cibaToken.Expiry) {
+			if err := cli.delete(resourceCIBAToken, cibaToken.ObjectMeta.Name); err != nil {
+				cli.logger.Errorf("failed to delete ciba token: %v", err)
+				delErr = fmt.Errorf("failed to delete ciba token: %v", err)
+			}
+			result.CIBATokens++
+		}
+	}
+
+	// Offline sessions are listed before the refresh tokens. A session which
+	// references a refresh token created after the listing has been updated
+	// since, and is left alone by the conditional delete below.
+	var offlineSessions OfflineSessionsList
+	if err := cli.list(resourceOfflineSessions, &offlineSessions); err != nil {
+		return result, fmt.Errorf("failed to list offline sessions: %v", err)
+	}
+
+	var refreshTokens RefreshList
+	if err := cli.list(resourceRefreshToken, &refreshTokens); err != nil {
+		return result, fmt.Errorf("failed to list refresh tokens: %v", err)
+	}
+
+	refreshExists := make(map[string]bool, len(refreshTokens.RefreshTokens))
+	for _, refreshToken := range refreshTokens.RefreshTokens {
+		if opts.RefreshTokenExpired(toStorageRefreshToken(refreshToken), now) {
+			if err := cli.delete(resourceRefreshToken, refreshToken.ObjectMeta.Name); err != nil {
+				cli.logger.Errorf("failed to delete refresh token: %v", err)
+				delErr = fmt.Errorf("failed to delete refresh token: %v", err)
+				refreshExists[refreshToken.ObjectMeta.Name] = true
+			}
+			result.RefreshTokens++
+			continue
+		}
+		refreshExists[refreshToken.ObjectMeta.Name] = true
+	}
+
+	for _, offlineSession := range offlineSessions.OfflineSessions {
+		orphaned := storage.OfflineSessionOrphaned(toStorageOfflineSessions(offlineSession), func(id string) bool {
+			return refreshExists[id]
+		})
+		if !orphaned {
+			continue
+		}
+		deleted, err := cli.deleteAtVersion(resourceOfflineSessions, offlineSession.ObjectMeta.Name, offlineSession.ObjectMeta.ResourceVersion)
+		if err != nil {
+			cli.logger.Errorf("failed to delete offline session: %v", err)
+			delErr = fmt.Errorf("failed to delete offline session: %v", err)
+		}
+		if deleted {
+			result.OfflineSessions++
+		}
+	}
+
 	if delErr != nil {
 		return result, delErr
 	}
@@ -715,6 +1028,48 @@ func (cli *client) UpdateDeviceToken(deviceCode string, updater func(old storage
 	})
 }
 
+func (cli *client) CreateCIBARequest(r storage.CIBARequest) error {
+	return cli.post(resourceCIBARequest, cli.fromStorageCIBARequest(r))
+}
+
+func (cli *client) GetCIBARequest(approvalCode string) (storage.CIBARequest, error) {
+	var req CIBARequest
+	if err := cli.get(resourceCIBARequest, approvalCode, &req); err != nil {
+		return storage.CIBARequest{}, err
+	}
+	return toStorageCIBARequest(req), nil
+}
+
+func (cli *client) CreateCIBAToken(t storage.CIBAToken) error {
+	return cli.post(resourceCIBAToken, cli.fromStorageCIBAToken(t))
+}
+
+func (cli *client) GetCIBAToken(authReqID string) (storage.CIBAToken, error) {
+	var token CIBAToken
+	if err := cli.get(resourceCIBAToken, authReqID, &token); err != nil {
+		return storage.CIBAToken{}, err
+	}
+	return toStorageCIBAToken(token), nil
+}
+
+func (cli *client) UpdateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	return retryOnConflict(context.TODO(), func() error {
+		var r CIBAToken
+		if err := cli.get(resourceCIBAToken, authReqID, &r); err != nil {
+			return err
+		}
+		updated, err := updater(toStorageCIBAToken(r))
+		if err != nil {
+			return err
+		}
+		updated.AuthReqID = authReqID
+
+		newToken := cli.fromStorageCIBAToken(updated)
+		newToken.ObjectMeta = r.ObjectMeta
+		return cli.put(resourceCIBAToken, r.ObjectMeta.Name, newToken)
+	})
+}
+
 func isKubernetesAPIConflictError(err error) bool {
 	if httpErr, ok := err.(httpError); ok {
 		if httpErr.StatusCode() == http.StatusConflict {
@@ -754,3 +1109,92 @@ func retryOnConflict(ctx context.Context, action func() error) error {
 		}
 	}
 }
+
+// AcquireLease acquires or renews the named Lease object, which is shared with
+// other instances through the API server's optimistic concurrency.
+func (cli *client) AcquireLease(name, holder string, duration time.Duration) (lease storage.Lease, err error) {
+	seconds := int32((duration + time.Second - 1) / time.Second)
+	err = retryOnConflict(context.TODO(), func() error {
+		now := time.Now()
+		renewTime := k8sapi.NewMicroTime(now)
+		acquired := LeaseSpec{
+			HolderIdentity:       holder,
+			LeaseDurationSeconds: seconds,
+			AcquireTime:          &renewTime,
+			RenewTime:            &renewTime,
+		}
+
+		var l Lease
+		err := cli.getResource(leaseAPIVersion, cli.namespace, resourceLease, name, &l)
+		if err == storage.ErrNotFound {
+			l = Lease{
+				TypeMeta: k8sapi.TypeMeta{
+					Kind:       kindLease,
+					APIVersion: leaseAPIVersion,
+				},
+				ObjectMeta: k8sapi.ObjectMeta{
+					Name:      name,
+					Namespace: cli.namespace,
+				},
+				Spec: acquired,
+			}
+			err = cli.postResource(leaseAPIVersion, cli.namespace, resourceLease, l)
+			if err == nil {
+				lease = toStorageLease(l)
+				return nil
+			}
+			if err != storage.ErrAlreadyExists {
+				return err
+			}
+			// Another instance created the Lease first.
+			err = cli.getResource(leaseAPIVersion, cli.namespace, resourceLease, name, &l)
+		}
+		if err != nil {
+			return err
+		}
+
+		current := toStorageLease(l)
+		if current.Holder != holder && current.Held(now) {
+			lease = current
+			return nil
+		}
+		if current.Holder == holder {
+			acquired.AcquireTime = l.Spec.AcquireTime
+		} else {
+			acquired.LeaseTransitions++
+		}
+		acquired.LeaseTransitions += l.Spec.LeaseTransitions
+		l.Spec = acquired
+		if err := cli.putResource(leaseAPIVersion, cli.namespace, resourceLease, name, l); err != nil {
+			return err
+		}
+		lease = toStorageLease(l)
+		return nil
+	})
+	return lease, err
+}
+
+// ReleaseLease clears the holder of the Lease object, like client-go does,
+// rather than deleting it.
+func (cli *client) ReleaseLease(name, holder string) error {
+	return retryOnConflict(context.TODO(), func() error {
+		var l Lease
+		err := cli.getResource(leaseAPIVersion, cli.namespace, resourceLease, name, &l)
+		if err == storage.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if l.Spec.HolderIdentity != holder {
+			return nil
+		}
+
+		renewTime := k8sapi.NewMicroTime(time.Now())
+		l.Spec.HolderIdentity = ""
+		l.Spec.LeaseDurationSeconds = 1
+		l.Spec.AcquireTime = &renewTime
+		l.Spec.RenewTime = &renewTime
+		return cli.putResource(leaseAPIVersion, cli.namespace, resourceLease, name, l)
+	})
+}
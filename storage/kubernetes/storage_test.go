@@ -3,14 +3,19 @@ package kubernetes
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/fnv"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -18,6 +23,7 @@ import (
 
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/conformance"
+	"github.com/dexidp/dex/storage/kubernetes/k8sapi"
 )
 
 const kubeconfigPathVariableName = "DEX_KUBERNETES_CONFIG_PATH"
@@ -253,6 +259,113 @@ func newStatusCodesResponseTestClient(getResponseCode, actionResponseCode int) *
 	}
 }
 
+func TestListRefreshTokensPage(t *testing.T) {
+	var items []RefreshToken
+	for i := 0; i < 5; i++ {
+		items = append(items, RefreshToken{
+			ObjectMeta: k8sapi.ObjectMeta{Name: fmt.Sprintf("token%d", i)},
+			ClientID:   fmt.Sprintf("client%d", i%2),
+		})
+	}
+
+	// The fake API server returns chunks of at most limit items and uses the
+	// offset of the next item as continue token.
+	var limits []string
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		start := 0
+		if token := r.URL.Query().Get("continue"); token != "" {
+			var err error
+			if start, err = strconv.Atoi(token); err != nil {
+				w.WriteHeader(http.StatusGone)
+				return
+			}
+		}
+		end := len(items)
+		if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && start+limit < end {
+			end = start + limit
+		}
+		list := RefreshList{RefreshTokens: items[start:end]}
+		if end < len(items) {
+			list.Continue = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer s.Close()
+
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.baseURL = s.URL
+
+	opts := storage.ListOptions{PageSize: 2, Filter: storage.ListFilter{ClientID: "client0"}}
+	var names []string
+	for {
+		page, next, err := cli.ListRefreshTokensPage(opts)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), opts.PageSize)
+		for _, r := range page {
+			names = append(names, r.ID)
+		}
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+	require.Equal(t, []string{"token0", "token2", "token4"}, names)
+	// The second chunk only asks for the object missing from the first page.
+	require.Equal(t, []string{"2", "1", "2"}, limits)
+
+	_, _, err := cli.ListRefreshTokensPage(storage.ListOptions{PageSize: 2, PageToken: "expired"})
+	require.True(t, errors.Is(err, storage.ErrInvalidPageToken), "expected invalid page token, got %v", err)
+}
+
+func TestListRefreshTokensForUser(t *testing.T) {
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.hash = func() hash.Hash { return fnv.New64() }
+	label := cli.offlineTokenName("alice", "conn1")
+	items := []RefreshToken{
+		{
+			ObjectMeta:  k8sapi.ObjectMeta{Name: "token0", Labels: map[string]string{labelOfflineSession: label}},
+			ConnectorID: "conn1",
+			Claims:      Claims{UserID: "alice"},
+		},
+		{
+			// Hash collision with a different user.
+			ObjectMeta:  k8sapi.ObjectMeta{Name: "token1", Labels: map[string]string{labelOfflineSession: label}},
+			ConnectorID: "conn1",
+			Claims:      Claims{UserID: "mallory"},
+		},
+		{
+			ObjectMeta:  k8sapi.ObjectMeta{Name: "token2", Labels: map[string]string{labelOfflineSession: "other"}},
+			ConnectorID: "conn1",
+			Claims:      Claims{UserID: "bob"},
+		},
+	}
+
+	// The fake API server filters by a single equality label selector.
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selector := strings.SplitN(r.URL.Query().Get("labelSelector"), "=", 2)
+		require.Len(t, selector, 2)
+		var list RefreshList
+		for _, item := range items {
+			if item.ObjectMeta.Labels[selector[0]] == selector[1] {
+				list.RefreshTokens = append(list.RefreshTokens, item)
+			}
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer s.Close()
+	cli.baseURL = s.URL
+
+	tokens, err := cli.ListRefreshTokensForUser("alice", "conn1")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "token0", tokens[0].ID)
+
+	// New tokens are labeled.
+	r := cli.fromStorageRefreshToken(storage.RefreshToken{ID: "token3", ConnectorID: "conn1", Claims: storage.Claims{UserID: "alice"}})
+	require.Equal(t, label, r.ObjectMeta.Labels[labelOfflineSession])
+}
+
 func TestRetryOnConflict(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -292,3 +405,57 @@ func TestRetryOnConflict(t *testing.T) {
 		})
 	}
 }
+
+func TestGarbageCollectOfflineSessionsAtVersion(t *testing.T) {
+	session := OfflineSessions{
+		ObjectMeta: k8sapi.ObjectMeta{Name: "session", ResourceVersion: "1"},
+		UserID:     "alice",
+		ConnID:     "conn1",
+		Refresh:    map[string]*storage.RefreshTokenRef{"client": {ID: "deleted", ClientID: "client"}},
+	}
+
+	// The fake API server deletes the session only if the precondition matches
+	// its current resource version.
+	currentVersion := "1"
+	deleted := false
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			var opts struct {
+				Preconditions struct {
+					ResourceVersion string `json:"resourceVersion"`
+				} `json:"preconditions"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			require.True(t, strings.HasSuffix(r.URL.Path, "/"+resourceOfflineSessions+"/session"), r.URL.Path)
+			if opts.Preconditions.ResourceVersion != currentVersion {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			deleted = true
+			w.Write([]byte(`{}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/"+resourceOfflineSessions) {
+			json.NewEncoder(w).Encode(OfflineSessionsList{OfflineSessions: []OfflineSessions{session}})
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	cli := newStatusCodesResponseTestClient(0, 0)
+	cli.baseURL = s.URL
+
+	// The session got a new refresh token after it was listed.
+	currentVersion = "2"
+	result, err := cli.GarbageCollect(time.Now(), storage.GCOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), result.OfflineSessions)
+	require.False(t, deleted, "expected modified offline session to be kept")
+
+	currentVersion = "1"
+	result, err = cli.GarbageCollect(time.Now(), storage.GCOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.OfflineSessions)
+	require.True(t, deleted, "expected orphaned offline session to be deleted")
+}
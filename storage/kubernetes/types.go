@@ -13,6 +13,11 @@ import (
 const (
 	apiGroup = "dex.coreos.com"
 
+	// Labels indexing refresh tokens and offline sessions by user. Their values
+	// are hashes, lists filtered by them are always checked for collisions.
+	labelUser           = apiGroup + "/user"
+	labelOfflineSession = apiGroup + "/offline-session"
+
 	legacyCRDAPIVersion = "apiextensions.k8s.io/v1beta1"
 	crdAPIVersion       = "apiextensions.k8s.io/v1"
 )
@@ -226,6 +231,40 @@ func customResourceDefinitions(apiVersion string) []k8sapi.CustomResourceDefinit
 				},
 			},
 		},
+		{
+			ObjectMeta: k8sapi.ObjectMeta{
+				Name: "cibarequests.dex.coreos.com",
+			},
+			TypeMeta: crdMeta,
+			Spec: k8sapi.CustomResourceDefinitionSpec{
+				Group:    apiGroup,
+				Version:  version,
+				Versions: versions,
+				Scope:    scope,
+				Names: k8sapi.CustomResourceDefinitionNames{
+					Plural:   "cibarequests",
+					Singular: "cibarequest",
+					Kind:     "CIBARequest",
+				},
+			},
+		},
+		{
+			ObjectMeta: k8sapi.ObjectMeta{
+				Name: "cibatokens.dex.coreos.com",
+			},
+			TypeMeta: crdMeta,
+			Spec: k8sapi.CustomResourceDefinitionSpec{
+				Group:    apiGroup,
+				Version:  version,
+				Versions: versions,
+				Scope:    scope,
+				Names: k8sapi.CustomResourceDefinitionNames{
+					Plural:   "cibatokens",
+					Singular: "cibatoken",
+					Kind:     "CIBAToken",
+				},
+			},
+		},
 	}
 }
 
@@ -251,6 +290,15 @@ type Client struct {
 
 	Name    string `json:"name,omitempty"`
 	LogoURL string `json:"logoURL,omitempty"`
+
+	BackchannelNotificationEndpoint string `json:"backchannelNotificationEndpoint,omitempty"`
+
+	TokenPolicy storage.ClientTokenPolicy `json:"tokenPolicy,omitempty"`
+
+	AllowedConnectors    []string `json:"allowedConnectors,omitempty"`
+	AllowedGrantTypes    []string `json:"allowedGrantTypes,omitempty"`
+	AllowedScopes        []string `json:"allowedScopes,omitempty"`
+	AllowedResponseTypes []string `json:"allowedResponseTypes,omitempty"`
 }
 
 // ClientList is a list of Clients.
@@ -277,6 +325,14 @@ func (cli *client) fromStorageClient(c storage.Client) Client {
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+
+		BackchannelNotificationEndpoint: c.BackchannelNotificationEndpoint,
+		TokenPolicy:                     c.TokenPolicy,
+
+		AllowedConnectors:    c.AllowedConnectors,
+		AllowedGrantTypes:    c.AllowedGrantTypes,
+		AllowedScopes:        c.AllowedScopes,
+		AllowedResponseTypes: c.AllowedResponseTypes,
 	}
 }
 
@@ -289,6 +345,14 @@ func toStorageClient(c Client) storage.Client {
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+
+		BackchannelNotificationEndpoint: c.BackchannelNotificationEndpoint,
+		TokenPolicy:                     c.TokenPolicy,
+
+		AllowedConnectors:    c.AllowedConnectors,
+		AllowedGrantTypes:    c.AllowedGrantTypes,
+		AllowedScopes:        c.AllowedScopes,
+		AllowedResponseTypes: c.AllowedResponseTypes,
 	}
 }
 
@@ -592,6 +656,7 @@ func (cli *client) fromStorageRefreshToken(r storage.RefreshToken) RefreshToken
 		ObjectMeta: k8sapi.ObjectMeta{
 			Name:      r.ID,
 			Namespace: cli.namespace,
+			Labels:    cli.refreshTokenLabels(r),
 		},
 		Token:         r.Token,
 		ObsoleteToken: r.ObsoleteToken,
@@ -663,6 +728,13 @@ type OfflineSessions struct {
 	ConnectorData []byte                              `json:"connectorData,omitempty"`
 }
 
+// OfflineSessionsList is a list of OfflineSessions.
+type OfflineSessionsList struct {
+	k8sapi.TypeMeta `json:",inline"`
+	k8sapi.ListMeta `json:"metadata,omitempty"`
+	OfflineSessions []OfflineSessions `json:"items"`
+}
+
 func (cli *client) fromStorageOfflineSessions(o storage.OfflineSessions) OfflineSessions {
 	return OfflineSessions{
 		TypeMeta: k8sapi.TypeMeta{
@@ -672,6 +744,7 @@ func (cli *client) fromStorageOfflineSessions(o storage.OfflineSessions) Offline
 		ObjectMeta: k8sapi.ObjectMeta{
 			Name:      cli.offlineTokenName(o.UserID, o.ConnID),
 			Namespace: cli.namespace,
+			Labels:    cli.offlineSessionsLabels(o),
 		},
 		UserID:        o.UserID,
 		ConnID:        o.ConnID,
@@ -680,6 +753,32 @@ func (cli *client) fromStorageOfflineSessions(o storage.OfflineSessions) Offline
 	}
 }
 
+func (cli *client) refreshTokenLabels(r storage.RefreshToken) map[string]string {
+	return map[string]string{
+		labelUser:           cli.offlineTokenName(r.Claims.UserID, ""),
+		labelOfflineSession: cli.offlineTokenName(r.Claims.UserID, r.ConnectorID),
+	}
+}
+
+func (cli *client) offlineSessionsLabels(o storage.OfflineSessions) map[string]string {
+	return map[string]string{
+		labelUser: cli.offlineTokenName(o.UserID, ""),
+	}
+}
+
+// mergeLabels returns the labels of an existing object with the index labels
+// set, keeping labels which were added by others.
+func mergeLabels(current, index map[string]string) map[string]string {
+	labels := make(map[string]string, len(current)+len(index))
+	for k, v := range current {
+		labels[k] = v
+	}
+	for k, v := range index {
+		labels[k] = v
+	}
+	return labels
+}
+
 func toStorageOfflineSessions(o OfflineSessions) storage.OfflineSessions {
 	s := storage.OfflineSessions{
 		UserID:        o.UserID,
@@ -840,3 +939,139 @@ func toStorageDeviceToken(t DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollIntervalSeconds,
 	}
 }
+
+// CIBARequest is a mirrored struct from storage with JSON struct tags and
+// Kubernetes type metadata.
+type CIBARequest struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	AuthReqID      string    `json:"auth_req_id,omitempty"`
+	ClientID       string    `json:"client_id,omitempty"`
+	Scopes         []string  `json:"scopes,omitempty"`
+	LoginHint      string    `json:"login_hint,omitempty"`
+	BindingMessage string    `json:"binding_message,omitempty"`
+	Expiry         time.Time `json:"expiry"`
+}
+
+// CIBARequestList is a list of CIBARequests.
+type CIBARequestList struct {
+	k8sapi.TypeMeta `json:",inline"`
+	k8sapi.ListMeta `json:"metadata,omitempty"`
+	CIBARequests    []CIBARequest `json:"items"`
+}
+
+func (cli *client) fromStorageCIBARequest(r storage.CIBARequest) CIBARequest {
+	return CIBARequest{
+		TypeMeta: k8sapi.TypeMeta{
+			Kind:       kindCIBARequest,
+			APIVersion: cli.apiVersion,
+		},
+		ObjectMeta: k8sapi.ObjectMeta{
+			Name:      r.ApprovalCode,
+			Namespace: cli.namespace,
+		},
+		AuthReqID:      r.AuthReqID,
+		ClientID:       r.ClientID,
+		Scopes:         r.Scopes,
+		LoginHint:      r.LoginHint,
+		BindingMessage: r.BindingMessage,
+		Expiry:         r.Expiry,
+	}
+}
+
+func toStorageCIBARequest(r CIBARequest) storage.CIBARequest {
+	return storage.CIBARequest{
+		ApprovalCode:   r.ObjectMeta.Name,
+		AuthReqID:      r.AuthReqID,
+		ClientID:       r.ClientID,
+		Scopes:         r.Scopes,
+		LoginHint:      r.LoginHint,
+		BindingMessage: r.BindingMessage,
+		Expiry:         r.Expiry,
+	}
+}
+
+// CIBAToken is a mirrored struct from storage with JSON struct tags and
+// Kubernetes type metadata.
+type CIBAToken struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	ClientID            string    `json:"client_id,omitempty"`
+	Status              string    `json:"status,omitempty"`
+	Token               string    `json:"token,omitempty"`
+	NotificationToken   string    `json:"notification_token,omitempty"`
+	Expiry              time.Time `json:"expiry"`
+	LastRequestTime     time.Time `json:"last_request"`
+	PollIntervalSeconds int       `json:"poll_interval"`
+}
+
+// CIBATokenList is a list of CIBATokens.
+type CIBATokenList struct {
+	k8sapi.TypeMeta `json:",inline"`
+	k8sapi.ListMeta `json:"metadata,omitempty"`
+	CIBATokens      []CIBAToken `json:"items"`
+}
+
+func (cli *client) fromStorageCIBAToken(t storage.CIBAToken) CIBAToken {
+	return CIBAToken{
+		TypeMeta: k8sapi.TypeMeta{
+			Kind:       kindCIBAToken,
+			APIVersion: cli.apiVersion,
+		},
+		ObjectMeta: k8sapi.ObjectMeta{
+			Name:      t.AuthReqID,
+			Namespace: cli.namespace,
+		},
+		ClientID:            t.ClientID,
+		Status:              t.Status,
+		Token:               t.Token,
+		NotificationToken:   t.NotificationToken,
+		Expiry:              t.Expiry,
+		LastRequestTime:     t.LastRequestTime,
+		PollIntervalSeconds: t.PollIntervalSeconds,
+	}
+}
+
+func toStorageCIBAToken(t CIBAToken) storage.CIBAToken {
+	return storage.CIBAToken{
+		AuthReqID:           t.ObjectMeta.Name,
+		ClientID:            t.ClientID,
+		Status:              t.Status,
+		Token:               t.Token,
+		NotificationToken:   t.NotificationToken,
+		Expiry:              t.Expiry,
+		LastRequestTime:     t.LastRequestTime,
+		PollIntervalSeconds: t.PollIntervalSeconds,
+	}
+}
+
+// Lease is a mirrored struct from coordination.k8s.io/v1 Lease, used to elect
+// a leader among the dex instances.
+type Lease struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LeaseSpec `json:"spec"`
+}
+
+// LeaseSpec is a mirrored struct from coordination.k8s.io/v1 LeaseSpec.
+type LeaseSpec struct {
+	HolderIdentity       string            `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32             `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *k8sapi.MicroTime `json:"acquireTime,omitempty"`
+	RenewTime            *k8sapi.MicroTime `json:"renewTime,omitempty"`
+	LeaseTransitions     int32             `json:"leaseTransitions,omitempty"`
+}
+
+func toStorageLease(l Lease) storage.Lease {
+	lease := storage.Lease{
+		Name:   l.ObjectMeta.Name,
+		Holder: l.Spec.HolderIdentity,
+	}
+	if l.Spec.RenewTime != nil {
+		lease.Expiry = l.Spec.RenewTime.Add(time.Duration(l.Spec.LeaseDurationSeconds) * time.Second)
+	}
+	return lease
+}
@@ -0,0 +1,29 @@
+package storage
+
+import "time"
+
+// Lease is a lock held by one dex instance at a time. It expires unless the
+// holder renews it.
+type Lease struct {
+	Name   string
+	Holder string
+	Expiry time.Time
+}
+
+// Held reports whether the lease is held by anyone at the given time.
+func (l Lease) Held(now time.Time) bool {
+	return l.Holder != "" && now.Before(l.Expiry)
+}
+
+// LeaseStorage is implemented by storages which can hold leases, used to elect
+// a leader among the dex instances sharing the storage.
+type LeaseStorage interface {
+	// AcquireLease acquires or renews the named lease for holder until now plus
+	// duration if the lease is free, expired or already held by holder. It
+	// returns the lease as stored afterwards, which has another holder if the
+	// lease couldn't be acquired.
+	AcquireLease(name, holder string, duration time.Duration) (Lease, error)
+
+	// ReleaseLease gives up the named lease if it's held by holder.
+	ReleaseLease(name, holder string) error
+}
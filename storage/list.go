@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPageToken is the error returned by storages if a page token
+// can't be decoded or has expired.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ListOptions control the paginated list methods.
+type ListOptions struct {
+	// PageSize is the maximum number of objects to return. Zero returns all
+	// remaining objects.
+	PageSize int
+	// PageToken continues a previous listing, it's the token returned with
+	// the previous page. The empty token starts at the first page.
+	PageToken string
+
+	Filter ListFilter
+}
+
+// ListFilter restricts the objects returned by the paginated list methods. Zero
+// values don't restrict the results, time ranges are half-open intervals that
+// include the lower bound.
+//
+// Fields which don't apply to a kind of object are ignored. Passwords can be
+// filtered by UserID, refresh tokens by all fields.
+type ListFilter struct {
+	ClientID    string
+	UserID      string
+	ConnectorID string
+
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	LastUsedAfter  time.Time
+	LastUsedBefore time.Time
+}
+
+func inRange(t, after, before time.Time) bool {
+	return (after.IsZero() || !t.Before(after)) && (before.IsZero() || t.Before(before))
+}
+
+// MatchRefreshToken reports whether the refresh token passes the filter.
+func (f ListFilter) MatchRefreshToken(r RefreshToken) bool {
+	return (f.ClientID == "" || r.ClientID == f.ClientID) &&
+		(f.UserID == "" || r.Claims.UserID == f.UserID) &&
+		(f.ConnectorID == "" || r.ConnectorID == f.ConnectorID) &&
+		inRange(r.CreatedAt, f.CreatedAfter, f.CreatedBefore) &&
+		inRange(r.LastUsed, f.LastUsedAfter, f.LastUsedBefore)
+}
+
+// MatchPassword reports whether the password passes the filter.
+func (f ListFilter) MatchPassword(p Password) bool {
+	return f.UserID == "" || p.UserID == f.UserID
+}
+
+// NewPageToken returns a page token for storages which list objects ordered by
+// key. The token continues after the object with the given key.
+func NewPageToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// ParsePageToken returns the key encoded in a token created by NewPageToken.
+// The empty token returns the empty key.
+func ParsePageToken(token string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", ErrInvalidPageToken
+	}
+	return string(key), nil
+}
+
+// Page tokens of the static storages either continue the listing of the
+// backing storage or point at an offset into the static objects, which are
+// listed after all objects of the backing storage.
+const (
+	staticTokenStorage = "storage:"
+	staticTokenStatic  = "static:"
+)
+
+type staticCursor struct {
+	token    string
+	static   bool
+	position int
+}
+
+func parseStaticCursor(token string) (staticCursor, error) {
+	if token == "" {
+		return staticCursor{}, nil
+	}
+	s, err := ParsePageToken(token)
+	if err != nil {
+		return staticCursor{}, err
+	}
+	switch {
+	case strings.HasPrefix(s, staticTokenStorage):
+		return staticCursor{token: strings.TrimPrefix(s, staticTokenStorage)}, nil
+	case strings.HasPrefix(s, staticTokenStatic):
+		n, err := strconv.Atoi(strings.TrimPrefix(s, staticTokenStatic))
+		if err != nil || n < 0 {
+			return staticCursor{}, ErrInvalidPageToken
+		}
+		return staticCursor{static: true, position: n}, nil
+	default:
+		return staticCursor{}, ErrInvalidPageToken
+	}
+}
+
+func storagePageToken(token string) string {
+	return NewPageToken(staticTokenStorage + token)
+}
+
+// staticPage returns the range of the static objects to add to a page which
+// already holds n objects, and the token of the following page.
+func staticPage(opts ListOptions, cursor staticCursor, n, total int) (start, end int, next string) {
+	start = cursor.position
+	if start > total {
+		start = total
+	}
+	end = total
+	if opts.PageSize > 0 {
+		room := opts.PageSize - n
+		if room < 0 {
+			room = 0
+		}
+		if end-start > room {
+			end = start + room
+		}
+	}
+	if end < total {
+		next = NewPageToken(staticTokenStatic + strconv.Itoa(end))
+	}
+	return start, end, next
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/conformance"
+)
+
+// fakeWatcher passes the changes sent to it to the cache.
+type fakeWatcher struct {
+	fn func(storage.Change)
+}
+
+func (w *fakeWatcher) WatchChanges(ctx context.Context, fn func(storage.Change)) error {
+	w.fn = fn
+	return nil
+}
+
+func TestCachedStorage(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := storage.CacheConfig{
+		Clients:    time.Minute,
+		Connectors: time.Minute,
+		Passwords:  time.Minute,
+	}
+
+	// Reads through the cache see the writes through it.
+	conformance.RunTests(t, func() storage.Storage {
+		return storage.WithCache(ctx, New(logger), config, nil, logger)
+	})
+
+	backing := New(logger)
+	watcher := &fakeWatcher{}
+	s := storage.WithCache(ctx, backing, config, watcher, logger)
+
+	require.NoError(t, s.CreateClient(storage.Client{ID: "client", Secret: "secret"}))
+	client, err := s.GetClient("client")
+	require.NoError(t, err)
+	require.Equal(t, "secret", client.Secret)
+	connectors, err := s.ListConnectors()
+	require.NoError(t, err)
+	require.Empty(t, connectors)
+
+	// Writes made by others are only seen once they're reported.
+	require.NoError(t, backing.UpdateClient("client", func(old storage.Client) (storage.Client, error) {
+		old.Secret = "changed"
+		return old, nil
+	}))
+	require.NoError(t, backing.CreateConnector(storage.Connector{ID: "connector"}))
+	client, err = s.GetClient("client")
+	require.NoError(t, err)
+	require.Equal(t, "secret", client.Secret)
+	connectors, err = s.ListConnectors()
+	require.NoError(t, err)
+	require.Empty(t, connectors)
+
+	watcher.fn(storage.Change{Kind: storage.KindClient, ID: "client"})
+	watcher.fn(storage.Change{Kind: storage.KindConnector})
+	client, err = s.GetClient("client")
+	require.NoError(t, err)
+	require.Equal(t, "changed", client.Secret)
+	connectors, err = s.ListConnectors()
+	require.NoError(t, err)
+	require.Len(t, connectors, 1)
+
+	// Local writes invalidate the cache.
+	require.NoError(t, s.CreatePassword(storage.Password{Email: "Jane@example.com", Username: "jane"}))
+	_, err = s.GetPassword("jane@example.com")
+	require.NoError(t, err)
+	require.NoError(t, s.UpdatePassword("JANE@example.com", func(old storage.Password) (storage.Password, error) {
+		old.Username = "janet"
+		return old, nil
+	}))
+	p, err := s.GetPassword("jane@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "janet", p.Username)
+	require.NoError(t, s.DeleteClient("client"))
+	_, err = s.GetClient("client")
+	require.Equal(t, storage.ErrNotFound, err)
+
+	// Kinds without a TTL aren't cached.
+	s = storage.WithCache(ctx, backing, storage.CacheConfig{Clients: time.Minute}, nil, logger)
+	_, err = s.GetConnector("connector")
+	require.NoError(t, err)
+	require.NoError(t, backing.DeleteConnector("connector"))
+	_, err = s.GetConnector("connector")
+	require.Equal(t, storage.ErrNotFound, err)
+}
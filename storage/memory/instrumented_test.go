@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/conformance"
+)
+
+func TestInstrumentedStorage(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	// The decorator passes every call through.
+	conformance.RunTests(t, func() storage.Storage {
+		s, err := storage.WithInstrumentation(New(logger), "memory", prometheus.NewRegistry(), trace.NewNoopTracerProvider())
+		require.NoError(t, err)
+		return s
+	})
+
+	registry := prometheus.NewRegistry()
+	recorder := tracetest.NewSpanRecorder()
+	s, err := storage.WithInstrumentation(New(logger), "memory", registry,
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	require.NoError(t, err)
+
+	require.NoError(t, s.CreateClient(storage.Client{ID: "client"}))
+	_, err = s.GetClient("missing")
+	require.Equal(t, storage.ErrNotFound, err)
+	err = s.UpdateClient("client", func(old storage.Client) (storage.Client, error) {
+		return old, os.ErrPermission
+	})
+	require.Error(t, err)
+
+	expiry := time.Now().Add(-time.Minute)
+	require.NoError(t, s.CreateAuthCode(storage.AuthCode{ID: "code", Expiry: expiry}))
+	_, err = s.GarbageCollect(time.Now(), storage.GCOptions{})
+	require.NoError(t, err)
+
+	n, err := testutil.GatherAndCount(registry, "dex_storage_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	expected := `
+# HELP dex_storage_request_errors_total Count of storage methods which returned an error, by kind of error.
+# TYPE dex_storage_request_errors_total counter
+dex_storage_request_errors_total{backend="memory",error="error",method="UpdateClient"} 1
+dex_storage_request_errors_total{backend="memory",error="not_found",method="GetClient"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "dex_storage_request_errors_total"))
+	n, err = testutil.GatherAndCount(registry, "dex_storage_gc_deleted_objects")
+	require.NoError(t, err)
+	require.Equal(t, 8, n)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	require.Equal(t, "storage.GetClient", spans[1].Name())
+	require.Contains(t, spans[1].Attributes(), attribute.String("dex.storage.entity", "client"))
+	require.Contains(t, spans[1].Attributes(), attribute.String("dex.storage.error", "not_found"))
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+	require.Equal(t, codes.Error, spans[2].Status().Code)
+	require.Contains(t, spans[3].Attributes(), attribute.String("dex.storage.entity", "auth_code"))
+}
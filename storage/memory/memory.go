@@ -2,6 +2,7 @@
 package memory
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,9 @@ func New(logger log.Logger) storage.Storage {
 		connectors:      make(map[string]storage.Connector),
 		deviceRequests:  make(map[string]storage.DeviceRequest),
 		deviceTokens:    make(map[string]storage.DeviceToken),
+		cibaRequests:    make(map[string]storage.CIBARequest),
+		cibaTokens:      make(map[string]storage.CIBAToken),
+		leases:          make(map[string]storage.Lease),
 		logger:          logger,
 	}
 }
@@ -49,6 +53,9 @@ type memStorage struct {
 	connectors      map[string]storage.Connector
 	deviceRequests  map[string]storage.DeviceRequest
 	deviceTokens    map[string]storage.DeviceToken
+	cibaRequests    map[string]storage.CIBARequest
+	cibaTokens      map[string]storage.CIBAToken
+	leases          map[string]storage.Lease
 
 	keys storage.Keys
 
@@ -68,7 +75,7 @@ func (s *memStorage) tx(f func()) {
 
 func (s *memStorage) Close() error { return nil }
 
-func (s *memStorage) GarbageCollect(now time.Time) (result storage.GCResult, err error) {
+func (s *memStorage) GarbageCollect(now time.Time, opts storage.GCOptions) (result storage.GCResult, err error) {
 	s.tx(func() {
 		for id, a := range s.authCodes {
 			if now.After(a.Expiry) {
@@ -94,6 +101,34 @@ func (s *memStorage) GarbageCollect(now time.Time) (result storage.GCResult, err
 				result.DeviceTokens++
 			}
 		}
+		for id, a := range s.cibaRequests {
+			if now.After(a.Expiry) {
+				delete(s.cibaRequests, id)
+				result.CIBARequests++
+			}
+		}
+		for id, a := range s.cibaTokens {
+			if now.After(a.Expiry) {
+				delete(s.cibaTokens, id)
+				result.CIBATokens++
+			}
+		}
+		for id, r := range s.refreshTokens {
+			if opts.RefreshTokenExpired(r, now) {
+				delete(s.refreshTokens, id)
+				result.RefreshTokens++
+			}
+		}
+		for id, o := range s.offlineSessions {
+			orphaned := storage.OfflineSessionOrphaned(o, func(id string) bool {
+				_, ok := s.refreshTokens[id]
+				return ok
+			})
+			if orphaned {
+				delete(s.offlineSessions, id)
+				result.OfflineSessions++
+			}
+		}
 	})
 	return result, nil
 }
@@ -300,6 +335,132 @@ func (s *memStorage) ListConnectors() (conns []storage.Connector, err error) {
 	return
 }
 
+// pageKeys sorts the keys following after and returns the keys of the page
+// and the token of the next page.
+func pageKeys(keys []string, after string, pageSize int) ([]string, string) {
+	n := 0
+	for _, key := range keys {
+		if key > after {
+			keys[n] = key
+			n++
+		}
+	}
+	keys = keys[:n]
+	sort.Strings(keys)
+	if pageSize > 0 && len(keys) > pageSize {
+		keys = keys[:pageSize]
+		return keys, storage.NewPageToken(keys[pageSize-1])
+	}
+	return keys, ""
+}
+
+func (s *memStorage) ListClientsPage(opts storage.ListOptions) (clients []storage.Client, next string, err error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	s.tx(func() {
+		keys := make([]string, 0, len(s.clients))
+		for id := range s.clients {
+			keys = append(keys, id)
+		}
+		keys, next = pageKeys(keys, after, opts.PageSize)
+		for _, id := range keys {
+			clients = append(clients, s.clients[id])
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListRefreshTokensPage(opts storage.ListOptions) (tokens []storage.RefreshToken, next string, err error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	s.tx(func() {
+		var keys []string
+		for id, refresh := range s.refreshTokens {
+			if opts.Filter.MatchRefreshToken(refresh) {
+				keys = append(keys, id)
+			}
+		}
+		keys, next = pageKeys(keys, after, opts.PageSize)
+		for _, id := range keys {
+			tokens = append(tokens, s.refreshTokens[id])
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListPasswordsPage(opts storage.ListOptions) (passwords []storage.Password, next string, err error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	s.tx(func() {
+		var keys []string
+		for email, password := range s.passwords {
+			if opts.Filter.MatchPassword(password) {
+				keys = append(keys, email)
+			}
+		}
+		keys, next = pageKeys(keys, after, opts.PageSize)
+		for _, email := range keys {
+			passwords = append(passwords, s.passwords[email])
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListConnectorsPage(opts storage.ListOptions) (conns []storage.Connector, next string, err error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	s.tx(func() {
+		keys := make([]string, 0, len(s.connectors))
+		for id := range s.connectors {
+			keys = append(keys, id)
+		}
+		keys, next = pageKeys(keys, after, opts.PageSize)
+		for _, id := range keys {
+			conns = append(conns, s.connectors[id])
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListRefreshTokensForUser(userID string, connID string) (tokens []storage.RefreshToken, err error) {
+	s.tx(func() {
+		for _, refresh := range s.refreshTokens {
+			if refresh.Claims.UserID == userID && refresh.ConnectorID == connID {
+				tokens = append(tokens, refresh)
+			}
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListOfflineSessions() (sessions []storage.OfflineSessions, err error) {
+	s.tx(func() {
+		for _, o := range s.offlineSessions {
+			sessions = append(sessions, o)
+		}
+	})
+	return
+}
+
+func (s *memStorage) ListOfflineSessionsForUser(userID string) (sessions []storage.OfflineSessions, err error) {
+	s.tx(func() {
+		for id, o := range s.offlineSessions {
+			if id.userID == userID {
+				sessions = append(sessions, o)
+			}
+		}
+	})
+	return
+}
+
 func (s *memStorage) DeletePassword(email string) (err error) {
 	email = strings.ToLower(email)
 	s.tx(func() {
@@ -538,3 +699,82 @@ func (s *memStorage) UpdateDeviceToken(deviceCode string, updater func(p storage
 	})
 	return
 }
+
+func (s *memStorage) CreateCIBARequest(r storage.CIBARequest) (err error) {
+	s.tx(func() {
+		if _, ok := s.cibaRequests[r.ApprovalCode]; ok {
+			err = storage.ErrAlreadyExists
+		} else {
+			s.cibaRequests[r.ApprovalCode] = r
+		}
+	})
+	return
+}
+
+func (s *memStorage) GetCIBARequest(approvalCode string) (req storage.CIBARequest, err error) {
+	s.tx(func() {
+		var ok bool
+		if req, ok = s.cibaRequests[approvalCode]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+	})
+	return
+}
+
+func (s *memStorage) CreateCIBAToken(t storage.CIBAToken) (err error) {
+	s.tx(func() {
+		if _, ok := s.cibaTokens[t.AuthReqID]; ok {
+			err = storage.ErrAlreadyExists
+		} else {
+			s.cibaTokens[t.AuthReqID] = t
+		}
+	})
+	return
+}
+
+func (s *memStorage) GetCIBAToken(authReqID string) (t storage.CIBAToken, err error) {
+	s.tx(func() {
+		var ok bool
+		if t, ok = s.cibaTokens[authReqID]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+	})
+	return
+}
+
+func (s *memStorage) UpdateCIBAToken(authReqID string, updater func(t storage.CIBAToken) (storage.CIBAToken, error)) (err error) {
+	s.tx(func() {
+		r, ok := s.cibaTokens[authReqID]
+		if !ok {
+			err = storage.ErrNotFound
+			return
+		}
+		if r, err = updater(r); err == nil {
+			s.cibaTokens[authReqID] = r
+		}
+	})
+	return
+}
+
+func (s *memStorage) AcquireLease(name, holder string, duration time.Duration) (lease storage.Lease, err error) {
+	s.tx(func() {
+		now := time.Now()
+		lease = s.leases[name]
+		if lease.Holder == holder || !lease.Held(now) {
+			lease = storage.Lease{Name: name, Holder: holder, Expiry: now.Add(duration)}
+			s.leases[name] = lease
+		}
+	})
+	return
+}
+
+func (s *memStorage) ReleaseLease(name, holder string) error {
+	s.tx(func() {
+		if s.leases[name].Holder == holder {
+			delete(s.leases, name)
+		}
+	})
+	return nil
+}
@@ -300,3 +300,61 @@ func TestStaticConnectors(t *testing.T) {
 		}
 	}
 }
+
+func TestStaticPasswordsPagination(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+	backing := New(logger)
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		backing.CreatePassword(storage.Password{Email: email, UserID: "backing"})
+	}
+	s := storage.WithStaticPasswords(backing, []storage.Password{
+		{Email: "b@example.com", UserID: "static"},
+		{Email: "x@example.com", UserID: "static"},
+		{Email: "y@example.com", UserID: "static"},
+		{Email: "z@example.com", UserID: "other"},
+	}, logger)
+
+	list := func(filter storage.ListFilter) []string {
+		opts := storage.ListOptions{PageSize: 2, Filter: filter}
+		var got []string
+		for i := 0; ; i++ {
+			if i > 10 {
+				t.Fatalf("listing doesn't terminate")
+			}
+			page, next, err := s.ListPasswordsPage(opts)
+			if err != nil {
+				t.Fatalf("list passwords: %v", err)
+			}
+			if len(page) > opts.PageSize {
+				t.Fatalf("page of %d exceeds page size", len(page))
+			}
+			for _, p := range page {
+				got = append(got, p.Email+"/"+p.UserID)
+			}
+			if next == "" {
+				return got
+			}
+			opts.PageToken = next
+		}
+	}
+
+	// Static passwords follow the backing storage and hide its passwords with
+	// the same email.
+	want := "a@example.com/backing c@example.com/backing b@example.com/static x@example.com/static y@example.com/static z@example.com/other"
+	if got := strings.Join(list(storage.ListFilter{}), " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	want = "b@example.com/static x@example.com/static y@example.com/static"
+	if got := strings.Join(list(storage.ListFilter{UserID: "static"}), " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, _, err := s.ListPasswordsPage(storage.ListOptions{PageToken: "not a page token"}); err == nil {
+		t.Errorf("expected an error for an invalid page token")
+	}
+}
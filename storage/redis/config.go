@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+)
+
+const (
+	modeStandalone = "standalone"
+	modeSentinel   = "sentinel"
+	modeCluster    = "cluster"
+
+	defaultKeyPrefix   = "dex:"
+	defaultDialTimeout = 2 * time.Second
+)
+
+// SSL represents SSL options for Redis servers.
+type SSL struct {
+	ServerName string `json:"serverName" yaml:"serverName"`
+	CAFile     string `json:"caFile" yaml:"caFile"`
+	KeyFile    string `json:"keyFile" yaml:"keyFile"`
+	CertFile   string `json:"certFile" yaml:"certFile"`
+}
+
+// Redis options for connecting to Redis servers.
+//
+// Mode selects how Addrs are used: "standalone" (the default) connects to a
+// single server, "sentinel" asks the Sentinels in Addrs for the master named
+// MasterName, and "cluster" treats Addrs as seed nodes of a Redis Cluster.
+//
+// Objects of the same kind share a hash slot so they can be updated and listed
+// together, in cluster mode every kind is therefore served by a single node.
+type Redis struct {
+	Mode  string   `json:"mode" yaml:"mode"`
+	Addrs []string `json:"addrs" yaml:"addrs"`
+
+	// MasterName and SentinelPassword are only used in sentinel mode.
+	MasterName       string `json:"masterName" yaml:"masterName"`
+	SentinelPassword string `json:"sentinelPassword" yaml:"sentinelPassword"`
+
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	// DB selects the database, it must be 0 in cluster mode.
+	DB int `json:"db" yaml:"db"`
+
+	// KeyPrefix is prepended to all keys, defaults to "dex:". Use different
+	// prefixes to share a Redis deployment between several dex instances.
+	KeyPrefix string `json:"keyPrefix" yaml:"keyPrefix"`
+
+	SSL SSL `json:"ssl" yaml:"ssl"`
+}
+
+// Open creates a new storage implementation backed by Redis.
+func (p *Redis) Open(logger log.Logger) (storage.Storage, error) {
+	return p.open(logger)
+}
+
+func (p *Redis) open(logger log.Logger) (*conn, error) {
+	if len(p.Addrs) == 0 {
+		return nil, fmt.Errorf("redis: no addrs specified")
+	}
+
+	tlsConfig, err := p.SSL.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("redis: %v", err)
+	}
+
+	var db redis.UniversalClient
+	switch p.Mode {
+	case "", modeStandalone:
+		if len(p.Addrs) != 1 {
+			return nil, fmt.Errorf("redis: standalone mode requires exactly one addr, got %d", len(p.Addrs))
+		}
+		db = redis.NewClient(&redis.Options{
+			Addr:        p.Addrs[0],
+			Username:    p.Username,
+			Password:    p.Password,
+			DB:          p.DB,
+			DialTimeout: defaultDialTimeout,
+			TLSConfig:   tlsConfig,
+		})
+	case modeSentinel:
+		if p.MasterName == "" {
+			return nil, fmt.Errorf("redis: sentinel mode requires a masterName")
+		}
+		db = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       p.MasterName,
+			SentinelAddrs:    p.Addrs,
+			SentinelPassword: p.SentinelPassword,
+			Username:         p.Username,
+			Password:         p.Password,
+			DB:               p.DB,
+			DialTimeout:      defaultDialTimeout,
+			TLSConfig:        tlsConfig,
+		})
+	case modeCluster:
+		if p.DB != 0 {
+			return nil, fmt.Errorf("redis: cluster mode only supports db 0")
+		}
+		db = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:       p.Addrs,
+			Username:    p.Username,
+			Password:    p.Password,
+			DialTimeout: defaultDialTimeout,
+			TLSConfig:   tlsConfig,
+		})
+	default:
+		return nil, fmt.Errorf("redis: unknown mode %q", p.Mode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	if err := db.Ping(ctx).Err(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("redis: ping: %v", err)
+	}
+
+	prefix := p.KeyPrefix
+	if prefix == "" {
+		prefix = defaultKeyPrefix
+	}
+	c := &conn{
+		db:     db,
+		prefix: prefix,
+		logger: logger,
+	}
+	if err := c.indexUsers(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("redis: index users: %v", err)
+	}
+	return c, nil
+}
+
+func (s SSL) tlsConfig() (*tls.Config, error) {
+	if s == (SSL{}) {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		ServerName: s.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if s.CAFile != "" {
+		data, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", s.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if s.CertFile != "" || s.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,1123 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dexidp/dex/pkg/log"
+	"github.com/dexidp/dex/storage"
+)
+
+const (
+	clientKind         = "client"
+	authCodeKind       = "auth_code"
+	refreshTokenKind   = "refresh_token"
+	authRequestKind    = "auth_req"
+	passwordKind       = "password"
+	offlineSessionKind = "offline_session"
+	connectorKind      = "connector"
+	deviceRequestKind  = "device_req"
+	deviceTokenKind    = "device_token"
+	cibaRequestKind    = "ciba_req"
+	cibaTokenKind      = "ciba_token"
+	leaseKind          = "lease"
+	keysName           = "openid-connect-keys"
+
+	// defaultStorageTimeout will be applied to all storage's operations.
+	defaultStorageTimeout = 5 * time.Second
+
+	// expiryGracePeriod is added to the expiry of short-lived objects before
+	// it's used as their TTL. Redis drops objects garbage collection missed,
+	// while expired objects are still reported by garbage collection and
+	// clock skew between dex and Redis doesn't remove objects early.
+	expiryGracePeriod = 10 * time.Minute
+
+	// listBatchSize limits the number of keys fetched per MGET.
+	listBatchSize = 500
+
+	// userIndexed marks that refresh tokens and offline sessions created
+	// before they were indexed by user have been added to the user indexes.
+	userIndexed = "user_indexed"
+)
+
+// pruneIndex removes an ID from the index of its kind unless the object was
+// recreated in the meantime.
+var pruneIndex = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return redis.call("SREM", KEYS[2], ARGV[1])
+end
+return 0
+`)
+
+// acquireLease sets the holder of a lease unless it's held by another holder,
+// and returns the holder with the milliseconds until the lease expires.
+var acquireLease = redis.NewScript(`
+local holder = redis.call("GET", KEYS[1])
+if not holder or holder == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return {ARGV[1], tonumber(ARGV[2])}
+end
+return {holder, redis.call("PTTL", KEYS[1])}
+`)
+
+// releaseLease deletes a lease if it's held by the holder.
+var releaseLease = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// conn stores every object as JSON under "<prefix>{<kind>}:<id>" and keeps the
+// IDs of each kind in the set "<prefix>{<kind>}" to list them. Refresh tokens
+// and offline sessions are also indexed by user in the sets
+// "<prefix>{<kind>}/user:<user>". The hash tag keeps an object and its indexes
+// on the same cluster slot.
+type conn struct {
+	db     redis.UniversalClient
+	prefix string
+	logger log.Logger
+}
+
+func (c *conn) Close() error {
+	return c.db.Close()
+}
+
+func (c *conn) key(kind, id string) string  { return c.prefix + "{" + kind + "}:" + id }
+func (c *conn) indexKey(kind string) string { return c.prefix + "{" + kind + "}" }
+func (c *conn) userIndexKey(kind, user string) string {
+	return c.prefix + "{" + kind + "}/user:" + strings.ToLower(user)
+}
+
+func (c *conn) refreshUserIndexKey(r RefreshToken) string {
+	return c.userIndexKey(refreshTokenKind, sessionID(r.Claims.UserID, r.ConnectorID))
+}
+
+func emailID(email string) string { return strings.ToLower(email) }
+func sessionID(userID, connID string) string {
+	return strings.ToLower(userID + "|" + connID)
+}
+
+// ttl returns the TTL of an object expiring at expiry, zero never expires.
+func ttl(expiry time.Time) time.Duration {
+	if expiry.IsZero() {
+		return 0
+	}
+	d := time.Until(expiry) + expiryGracePeriod
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
+func (c *conn) GarbageCollect(now time.Time, opts storage.GCOptions) (result storage.GCResult, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+
+	var delErr error
+	gcExpired := func(kind string, newObject func() interface{}, expiry func(v interface{}) time.Time) (int64, error) {
+		var expired []string
+		err := c.list(ctx, kind, func(id string, value []byte) error {
+			v := newObject()
+			if err := json.Unmarshal(value, v); err != nil {
+				return err
+			}
+			if now.After(expiry(v)) {
+				expired = append(expired, id)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		var n int64
+		for _, id := range expired {
+			if err := c.delete(ctx, kind, id); err != nil && err != storage.ErrNotFound {
+				c.logger.Errorf("failed to delete %s: %v", kind, err)
+				delErr = fmt.Errorf("failed to delete %s: %v", kind, err)
+				continue
+			}
+			n++
+		}
+		return n, nil
+	}
+
+	if result.AuthRequests, err = gcExpired(authRequestKind,
+		func() interface{} { return new(AuthRequest) },
+		func(v interface{}) time.Time { return v.(*AuthRequest).Expiry },
+	); err != nil {
+		return result, err
+	}
+	if result.AuthCodes, err = gcExpired(authCodeKind,
+		func() interface{} { return new(AuthCode) },
+		func(v interface{}) time.Time { return v.(*AuthCode).Expiry },
+	); err != nil {
+		return result, err
+	}
+	if result.DeviceRequests, err = gcExpired(deviceRequestKind,
+		func() interface{} { return new(DeviceRequest) },
+		func(v interface{}) time.Time { return v.(*DeviceRequest).Expiry },
+	); err != nil {
+		return result, err
+	}
+	if result.DeviceTokens, err = gcExpired(deviceTokenKind,
+		func() interface{} { return new(DeviceToken) },
+		func(v interface{}) time.Time { return v.(*DeviceToken).Expiry },
+	); err != nil {
+		return result, err
+	}
+	if result.CIBARequests, err = gcExpired(cibaRequestKind,
+		func() interface{} { return new(CIBARequest) },
+		func(v interface{}) time.Time { return v.(*CIBARequest).Expiry },
+	); err != nil {
+		return result, err
+	}
+	if result.CIBATokens, err = gcExpired(cibaTokenKind,
+		func() interface{} { return new(CIBAToken) },
+		func(v interface{}) time.Time { return v.(*CIBAToken).Expiry },
+	); err != nil {
+		return result, err
+	}
+
+	// Offline sessions are listed before the refresh tokens. A session which
+	// references a refresh token created after the listing has been updated
+	// since, and is left alone by the conditional delete below.
+	offlineSessions := make(map[string][]byte)
+	err = c.list(ctx, offlineSessionKind, func(id string, value []byte) error {
+		offlineSessions[id] = value
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	refreshTokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	refreshExists := make(map[string]bool, len(refreshTokens))
+	for _, refreshToken := range refreshTokens {
+		if opts.RefreshTokenExpired(toStorageRefreshToken(refreshToken), now) {
+			if err := c.delete(ctx, refreshTokenKind, refreshToken.ID, c.refreshUserIndexKey(refreshToken)); err != nil && err != storage.ErrNotFound {
+				c.logger.Errorf("failed to delete refresh token %v", err)
+				delErr = fmt.Errorf("failed to delete refresh token: %v", err)
+				refreshExists[refreshToken.ID] = true
+			}
+			result.RefreshTokens++
+			continue
+		}
+		refreshExists[refreshToken.ID] = true
+	}
+
+	for id, value := range offlineSessions {
+		var offlineSession OfflineSessions
+		if err := json.Unmarshal(value, &offlineSession); err != nil {
+			return result, err
+		}
+		orphaned := storage.OfflineSessionOrphaned(toStorageOfflineSessions(offlineSession), func(id string) bool {
+			return refreshExists[id]
+		})
+		if !orphaned {
+			continue
+		}
+		deleted, err := c.deleteUnchanged(ctx, offlineSessionKind, id, value, c.userIndexKey(offlineSessionKind, offlineSession.UserID))
+		if err != nil {
+			c.logger.Errorf("failed to delete offline session %v", err)
+			delErr = fmt.Errorf("failed to delete offline session: %v", err)
+		}
+		if deleted {
+			result.OfflineSessions++
+		}
+	}
+	return result, delErr
+}
+
+func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, authRequestKind, a.ID, fromStorageAuthRequest(a), a.Expiry)
+}
+
+func (c *conn) GetAuthRequest(id string) (a storage.AuthRequest, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var req AuthRequest
+	if err = c.get(ctx, c.key(authRequestKind, id), &req); err != nil {
+		return
+	}
+	return toStorageAuthRequest(req), nil
+}
+
+func (c *conn) UpdateAuthRequest(id string, updater func(a storage.AuthRequest) (storage.AuthRequest, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(authRequestKind, id), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current AuthRequest
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(toStorageAuthRequest(current))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(fromStorageAuthRequest(updated))
+		return b, updated.Expiry, err
+	})
+}
+
+func (c *conn) DeleteAuthRequest(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, authRequestKind, id)
+}
+
+func (c *conn) CreateAuthCode(a storage.AuthCode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, authCodeKind, a.ID, fromStorageAuthCode(a), a.Expiry)
+}
+
+func (c *conn) GetAuthCode(id string) (a storage.AuthCode, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var ac AuthCode
+	if err = c.get(ctx, c.key(authCodeKind, id), &ac); err != nil {
+		return
+	}
+	return toStorageAuthCode(ac), nil
+}
+
+func (c *conn) DeleteAuthCode(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, authCodeKind, id)
+}
+
+func (c *conn) CreateRefresh(r storage.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	token := fromStorageRefreshToken(r)
+	return c.create(ctx, refreshTokenKind, r.ID, token, time.Time{}, c.refreshUserIndexKey(token))
+}
+
+func (c *conn) GetRefresh(id string) (r storage.RefreshToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var token RefreshToken
+	if err = c.get(ctx, c.key(refreshTokenKind, id), &token); err != nil {
+		return
+	}
+	return toStorageRefreshToken(token), nil
+}
+
+func (c *conn) UpdateRefreshToken(id string, updater func(old storage.RefreshToken) (storage.RefreshToken, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var oldIndex, newIndex string
+	update := func(currentValue []byte) ([]byte, time.Time, error) {
+		var current RefreshToken
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(toStorageRefreshToken(current))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		token := fromStorageRefreshToken(updated)
+		oldIndex, newIndex = c.refreshUserIndexKey(current), c.refreshUserIndexKey(token)
+		b, err := json.Marshal(token)
+		return b, time.Time{}, err
+	}
+	// Always add the token to the user index, which also indexes tokens that
+	// were created before the index existed.
+	return c.watch(ctx, c.key(refreshTokenKind, id), false, update, func(pipe redis.Pipeliner) {
+		if oldIndex != newIndex {
+			pipe.SRem(ctx, oldIndex, id)
+		}
+		pipe.SAdd(ctx, newIndex, id)
+	})
+}
+
+func (c *conn) DeleteRefresh(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var token RefreshToken
+	if err := c.get(ctx, c.key(refreshTokenKind, id), &token); err != nil {
+		return err
+	}
+	return c.delete(ctx, refreshTokenKind, id, c.refreshUserIndexKey(token))
+}
+
+func (c *conn) ListRefreshTokensForUser(userID string, connID string) (tokens []storage.RefreshToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	index := c.userIndexKey(refreshTokenKind, sessionID(userID, connID))
+	ids, err := c.db.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+	err = c.load(ctx, refreshTokenKind, index, ids, func(_ string, value []byte) error {
+		var token RefreshToken
+		if err := json.Unmarshal(value, &token); err != nil {
+			return err
+		}
+		// The index is case insensitive.
+		if token.Claims.UserID == userID && token.ConnectorID == connID {
+			tokens = append(tokens, toStorageRefreshToken(token))
+		}
+		return nil
+	})
+	return tokens, err
+}
+
+func (c *conn) ListRefreshTokens() (tokens []storage.RefreshToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	refreshTokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return tokens, err
+	}
+	for _, token := range refreshTokens {
+		tokens = append(tokens, toStorageRefreshToken(token))
+	}
+	return tokens, nil
+}
+
+func (c *conn) ListRefreshTokensPage(opts storage.ListOptions) (tokens []storage.RefreshToken, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, refreshTokenKind, opts, func(value []byte) (bool, error) {
+		var token RefreshToken
+		if err := json.Unmarshal(value, &token); err != nil {
+			return false, err
+		}
+		r := toStorageRefreshToken(token)
+		if !opts.Filter.MatchRefreshToken(r) {
+			return false, nil
+		}
+		tokens = append(tokens, r)
+		return true, nil
+	})
+	return tokens, next, err
+}
+
+func (c *conn) listRefreshTokens(ctx context.Context) (tokens []RefreshToken, err error) {
+	err = c.list(ctx, refreshTokenKind, func(_ string, value []byte) error {
+		var token RefreshToken
+		if err := json.Unmarshal(value, &token); err != nil {
+			return err
+		}
+		tokens = append(tokens, token)
+		return nil
+	})
+	return tokens, err
+}
+
+func (c *conn) CreateClient(cli storage.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, clientKind, cli.ID, cli, time.Time{})
+}
+
+func (c *conn) GetClient(id string) (cli storage.Client, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.get(ctx, c.key(clientKind, id), &cli)
+	return cli, err
+}
+
+func (c *conn) UpdateClient(id string, updater func(old storage.Client) (storage.Client, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(clientKind, id), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current storage.Client
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(current)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(updated)
+		return b, time.Time{}, err
+	})
+}
+
+func (c *conn) DeleteClient(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, clientKind, id)
+}
+
+func (c *conn) ListClients() (clients []storage.Client, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.list(ctx, clientKind, func(_ string, value []byte) error {
+		var cli storage.Client
+		if err := json.Unmarshal(value, &cli); err != nil {
+			return err
+		}
+		clients = append(clients, cli)
+		return nil
+	})
+	return clients, err
+}
+
+func (c *conn) ListClientsPage(opts storage.ListOptions) (clients []storage.Client, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, clientKind, opts, func(value []byte) (bool, error) {
+		var cli storage.Client
+		if err := json.Unmarshal(value, &cli); err != nil {
+			return false, err
+		}
+		clients = append(clients, cli)
+		return true, nil
+	})
+	return clients, next, err
+}
+
+func (c *conn) CreatePassword(p storage.Password) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	p.Email = strings.ToLower(p.Email)
+	return c.create(ctx, passwordKind, emailID(p.Email), p, time.Time{})
+}
+
+func (c *conn) GetPassword(email string) (p storage.Password, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.get(ctx, c.key(passwordKind, emailID(email)), &p)
+	return p, err
+}
+
+func (c *conn) UpdatePassword(email string, updater func(p storage.Password) (storage.Password, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(passwordKind, emailID(email)), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current storage.Password
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(current)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(updated)
+		return b, time.Time{}, err
+	})
+}
+
+func (c *conn) DeletePassword(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, passwordKind, emailID(email))
+}
+
+func (c *conn) ListPasswords() (passwords []storage.Password, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.list(ctx, passwordKind, func(_ string, value []byte) error {
+		var p storage.Password
+		if err := json.Unmarshal(value, &p); err != nil {
+			return err
+		}
+		passwords = append(passwords, p)
+		return nil
+	})
+	return passwords, err
+}
+
+func (c *conn) ListPasswordsPage(opts storage.ListOptions) (passwords []storage.Password, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, passwordKind, opts, func(value []byte) (bool, error) {
+		var p storage.Password
+		if err := json.Unmarshal(value, &p); err != nil {
+			return false, err
+		}
+		if !opts.Filter.MatchPassword(p) {
+			return false, nil
+		}
+		passwords = append(passwords, p)
+		return true, nil
+	})
+	return passwords, next, err
+}
+
+func (c *conn) CreateOfflineSessions(s storage.OfflineSessions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, offlineSessionKind, sessionID(s.UserID, s.ConnID), fromStorageOfflineSessions(s), time.Time{},
+		c.userIndexKey(offlineSessionKind, s.UserID))
+}
+
+func (c *conn) UpdateOfflineSessions(userID string, connID string, updater func(s storage.OfflineSessions) (storage.OfflineSessions, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	id := sessionID(userID, connID)
+	return c.watch(ctx, c.key(offlineSessionKind, id), false, func(currentValue []byte) ([]byte, time.Time, error) {
+		var current OfflineSessions
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(toStorageOfflineSessions(current))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(fromStorageOfflineSessions(updated))
+		return b, time.Time{}, err
+	}, func(pipe redis.Pipeliner) {
+		pipe.SAdd(ctx, c.userIndexKey(offlineSessionKind, userID), id)
+	})
+}
+
+func (c *conn) GetOfflineSessions(userID string, connID string) (s storage.OfflineSessions, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var os OfflineSessions
+	if err = c.get(ctx, c.key(offlineSessionKind, sessionID(userID, connID)), &os); err != nil {
+		return
+	}
+	return toStorageOfflineSessions(os), nil
+}
+
+func (c *conn) DeleteOfflineSessions(userID string, connID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, offlineSessionKind, sessionID(userID, connID), c.userIndexKey(offlineSessionKind, userID))
+}
+
+func (c *conn) ListOfflineSessions() ([]storage.OfflineSessions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	offlineSessions, err := c.listOfflineSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]storage.OfflineSessions, len(offlineSessions))
+	for i, s := range offlineSessions {
+		sessions[i] = toStorageOfflineSessions(s)
+	}
+	return sessions, nil
+}
+
+func (c *conn) ListOfflineSessionsForUser(userID string) (sessions []storage.OfflineSessions, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	index := c.userIndexKey(offlineSessionKind, userID)
+	ids, err := c.db.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+	err = c.load(ctx, offlineSessionKind, index, ids, func(_ string, value []byte) error {
+		var s OfflineSessions
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		// The index is case insensitive.
+		if s.UserID == userID {
+			sessions = append(sessions, toStorageOfflineSessions(s))
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+func (c *conn) listOfflineSessions(ctx context.Context) (sessions []OfflineSessions, err error) {
+	err = c.list(ctx, offlineSessionKind, func(_ string, value []byte) error {
+		var s OfflineSessions
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		sessions = append(sessions, s)
+		return nil
+	})
+	return sessions, err
+}
+
+func (c *conn) CreateConnector(connector storage.Connector) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, connectorKind, connector.ID, connector, time.Time{})
+}
+
+func (c *conn) GetConnector(id string) (conn storage.Connector, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.get(ctx, c.key(connectorKind, id), &conn)
+	return conn, err
+}
+
+func (c *conn) UpdateConnector(id string, updater func(s storage.Connector) (storage.Connector, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(connectorKind, id), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current storage.Connector
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(current)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(updated)
+		return b, time.Time{}, err
+	})
+}
+
+func (c *conn) DeleteConnector(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.delete(ctx, connectorKind, id)
+}
+
+func (c *conn) ListConnectors() (connectors []storage.Connector, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.list(ctx, connectorKind, func(_ string, value []byte) error {
+		var c storage.Connector
+		if err := json.Unmarshal(value, &c); err != nil {
+			return err
+		}
+		connectors = append(connectors, c)
+		return nil
+	})
+	return connectors, err
+}
+
+func (c *conn) ListConnectorsPage(opts storage.ListOptions) (connectors []storage.Connector, next string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	next, err = c.listPage(ctx, connectorKind, opts, func(value []byte) (bool, error) {
+		var c storage.Connector
+		if err := json.Unmarshal(value, &c); err != nil {
+			return false, err
+		}
+		connectors = append(connectors, c)
+		return true, nil
+	})
+	return connectors, next, err
+}
+
+func (c *conn) GetKeys() (keys storage.Keys, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	err = c.get(ctx, c.prefix+keysName, &keys)
+	return keys, err
+}
+
+func (c *conn) UpdateKeys(updater func(old storage.Keys) (storage.Keys, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	// The keys are created by the first update, so a missing value is passed
+	// to the updater as empty keys.
+	return c.watch(ctx, c.prefix+keysName, true, func(currentValue []byte) ([]byte, time.Time, error) {
+		var current storage.Keys
+		if len(currentValue) > 0 {
+			if err := json.Unmarshal(currentValue, &current); err != nil {
+				return nil, time.Time{}, err
+			}
+		}
+		updated, err := updater(current)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(updated)
+		return b, time.Time{}, err
+	}, nil)
+}
+
+func (c *conn) CreateDeviceRequest(d storage.DeviceRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, deviceRequestKind, d.UserCode, fromStorageDeviceRequest(d), d.Expiry)
+}
+
+func (c *conn) GetDeviceRequest(userCode string) (r storage.DeviceRequest, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var req DeviceRequest
+	if err = c.get(ctx, c.key(deviceRequestKind, userCode), &req); err != nil {
+		return
+	}
+	return toStorageDeviceRequest(req), nil
+}
+
+func (c *conn) CreateDeviceToken(t storage.DeviceToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, deviceTokenKind, t.DeviceCode, fromStorageDeviceToken(t), t.Expiry)
+}
+
+func (c *conn) GetDeviceToken(deviceCode string) (t storage.DeviceToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var token DeviceToken
+	if err = c.get(ctx, c.key(deviceTokenKind, deviceCode), &token); err != nil {
+		return
+	}
+	return toStorageDeviceToken(token), nil
+}
+
+func (c *conn) UpdateDeviceToken(deviceCode string, updater func(old storage.DeviceToken) (storage.DeviceToken, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(deviceTokenKind, deviceCode), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current DeviceToken
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(toStorageDeviceToken(current))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(fromStorageDeviceToken(updated))
+		return b, updated.Expiry, err
+	})
+}
+
+func (c *conn) CreateCIBARequest(r storage.CIBARequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, cibaRequestKind, r.ApprovalCode, fromStorageCIBARequest(r), r.Expiry)
+}
+
+func (c *conn) GetCIBARequest(approvalCode string) (r storage.CIBARequest, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var req CIBARequest
+	if err = c.get(ctx, c.key(cibaRequestKind, approvalCode), &req); err != nil {
+		return
+	}
+	return toStorageCIBARequest(req), nil
+}
+
+func (c *conn) CreateCIBAToken(t storage.CIBAToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.create(ctx, cibaTokenKind, t.AuthReqID, fromStorageCIBAToken(t), t.Expiry)
+}
+
+func (c *conn) GetCIBAToken(authReqID string) (t storage.CIBAToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var token CIBAToken
+	if err = c.get(ctx, c.key(cibaTokenKind, authReqID), &token); err != nil {
+		return
+	}
+	return toStorageCIBAToken(token), nil
+}
+
+func (c *conn) UpdateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, c.key(cibaTokenKind, authReqID), func(currentValue []byte) ([]byte, time.Time, error) {
+		var current CIBAToken
+		if err := json.Unmarshal(currentValue, &current); err != nil {
+			return nil, time.Time{}, err
+		}
+		updated, err := updater(toStorageCIBAToken(current))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		b, err := json.Marshal(fromStorageCIBAToken(updated))
+		return b, updated.Expiry, err
+	})
+}
+
+// create stores a new object and adds it to the index of its kind and to the
+// additional indexes. A zero expiry stores the object without a TTL.
+func (c *conn) create(ctx context.Context, kind, id string, value interface{}, expiry time.Time, indexes ...string) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var set *redis.BoolCmd
+	_, err = c.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		set = pipe.SetNX(ctx, c.key(kind, id), b, ttl(expiry))
+		pipe.SAdd(ctx, c.indexKey(kind), id)
+		for _, index := range indexes {
+			pipe.SAdd(ctx, index, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !set.Val() {
+		return storage.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (c *conn) get(ctx context.Context, key string, value interface{}) error {
+	b, err := c.db.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return storage.ErrNotFound
+		}
+		return err
+	}
+	return json.Unmarshal(b, value)
+}
+
+// delete removes an object and its ID from the index of its kind and from the
+// additional indexes.
+func (c *conn) delete(ctx context.Context, kind, id string, indexes ...string) error {
+	var del *redis.IntCmd
+	_, err := c.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		del = pipe.Del(ctx, c.key(kind, id))
+		pipe.SRem(ctx, c.indexKey(kind), id)
+		for _, index := range indexes {
+			pipe.SRem(ctx, index, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if del.Val() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// deleteUnchanged is like delete, but only removes the object if its value is
+// still the listed one, and returns whether it was removed.
+func (c *conn) deleteUnchanged(ctx context.Context, kind, id string, value []byte, indexes ...string) (bool, error) {
+	key := c.key(kind, id)
+	deleted := false
+	err := c.db.Watch(ctx, func(tx *redis.Tx) error {
+		currentValue, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil || (err == nil && !bytes.Equal(currentValue, value)) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			pipe.SRem(ctx, c.indexKey(kind), id)
+			for _, index := range indexes {
+				pipe.SRem(ctx, index, id)
+			}
+			return nil
+		})
+		deleted = err == nil
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return false, nil
+	}
+	return deleted, err
+}
+
+// txnUpdate replaces an existing object, failing if it was changed between
+// reading and writing it.
+func (c *conn) txnUpdate(ctx context.Context, key string, update func(current []byte) ([]byte, time.Time, error)) error {
+	return c.watch(ctx, key, false, update, nil)
+}
+
+// watch replaces an object, failing if it was changed between reading and
+// writing it. If indexes is set, it's called to update indexes in the same
+// transaction after update returned.
+func (c *conn) watch(ctx context.Context, key string, createMissing bool, update func(current []byte) ([]byte, time.Time, error), indexes func(pipe redis.Pipeliner)) error {
+	err := c.db.Watch(ctx, func(tx *redis.Tx) error {
+		currentValue, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if err != redis.Nil {
+				return err
+			}
+			if !createMissing {
+				return storage.ErrNotFound
+			}
+		}
+
+		updatedValue, expiry, err := update(currentValue)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, updatedValue, ttl(expiry))
+			if indexes != nil {
+				indexes(pipe)
+			}
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return fmt.Errorf("failed to update key=%q: concurrent conflicting update happened", key)
+	}
+	return err
+}
+
+// list calls fn for every object of a kind. IDs of objects which expired are
+// removed from the index.
+func (c *conn) list(ctx context.Context, kind string, fn func(id string, value []byte) error) error {
+	ids, err := c.db.SMembers(ctx, c.indexKey(kind)).Result()
+	if err != nil {
+		return err
+	}
+	return c.load(ctx, kind, c.indexKey(kind), ids, fn)
+}
+
+// errPageFull stops loading objects once a page is complete.
+var errPageFull = errors.New("page full")
+
+// listPage loads the objects of kind in ID order, starting after the ID in the
+// page token, and passes them to add until add accepted PageSize objects.
+// Objects rejected by add don't count towards the page.
+func (c *conn) listPage(ctx context.Context, kind string, opts storage.ListOptions, add func(value []byte) (bool, error)) (string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return "", err
+	}
+	ids, err := c.db.SMembers(ctx, c.indexKey(kind)).Result()
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(ids)
+	ids = ids[sort.Search(len(ids), func(i int) bool { return ids[i] > after }):]
+
+	var (
+		n    int
+		last string
+	)
+	err = c.load(ctx, kind, c.indexKey(kind), ids, func(id string, value []byte) error {
+		ok, err := add(value)
+		if err != nil {
+			return err
+		}
+		if ok {
+			n++
+		}
+		if opts.PageSize > 0 && n == opts.PageSize {
+			last = id
+			return errPageFull
+		}
+		return nil
+	})
+	switch {
+	case err == errPageFull:
+		if last == ids[len(ids)-1] {
+			return "", nil
+		}
+		return storage.NewPageToken(last), nil
+	case err != nil:
+		return "", err
+	}
+	return "", nil
+}
+
+// load reads the objects with the given IDs in batches and passes them to fn.
+// IDs of objects which expired are removed from index.
+func (c *conn) load(ctx context.Context, kind, index string, ids []string, fn func(id string, value []byte) error) error {
+	var stale []string
+	defer func() {
+		for _, id := range stale {
+			if err := pruneIndex.Run(ctx, c.db, []string{c.key(kind, id), index}, id).Err(); err != nil {
+				c.logger.Errorf("failed to prune %s %q from index: %v", kind, id, err)
+			}
+		}
+	}()
+
+	for start := 0; start < len(ids); start += listBatchSize {
+		end := start + listBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		keys := make([]string, len(batch))
+		for i, id := range batch {
+			keys[i] = c.key(kind, id)
+		}
+		values, err := c.db.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				stale = append(stale, batch[i])
+				continue
+			}
+			if err := fn(batch[i], []byte(s)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexUsers adds refresh tokens and offline sessions which were created
+// before they were indexed by user to the user indexes. It only runs once per
+// key prefix.
+func (c *conn) indexUsers(ctx context.Context) error {
+	marker := c.prefix + userIndexed
+	n, err := c.db.Exists(ctx, marker).Result()
+	if err != nil || n > 0 {
+		return err
+	}
+	tokens, err := c.listRefreshTokens(ctx)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := c.db.SAdd(ctx, c.refreshUserIndexKey(token), token.ID).Err(); err != nil {
+			return err
+		}
+	}
+	sessions, err := c.listOfflineSessions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if err := c.db.SAdd(ctx, c.userIndexKey(offlineSessionKind, s.UserID), sessionID(s.UserID, s.ConnID)).Err(); err != nil {
+			return err
+		}
+	}
+	return c.db.Set(ctx, marker, time.Now().UTC().Format(time.RFC3339), 0).Err()
+}
+
+// AcquireLease stores the holder of a lease as a plain string whose TTL is the
+// remaining lease duration.
+func (c *conn) AcquireLease(name, holder string, duration time.Duration) (storage.Lease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+
+	now := time.Now()
+	res, err := acquireLease.Run(ctx, c.db, []string{c.key(leaseKind, name)}, holder, duration.Milliseconds()).Slice()
+	if err != nil {
+		return storage.Lease{}, fmt.Errorf("acquire lease: %v", err)
+	}
+	if len(res) != 2 {
+		return storage.Lease{}, fmt.Errorf("acquire lease: unexpected result %v", res)
+	}
+	current, _ := res[0].(string)
+	ttl, _ := res[1].(int64)
+	return storage.Lease{Name: name, Holder: current, Expiry: now.Add(time.Duration(ttl) * time.Millisecond)}, nil
+}
+
+func (c *conn) ReleaseLease(name, holder string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+
+	if err := releaseLease.Run(ctx, c.db, []string{c.key(leaseKind, name)}, holder).Err(); err != nil {
+		return fmt.Errorf("release lease: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/conformance"
+)
+
+var logger = &logrus.Logger{
+	Out:       os.Stderr,
+	Formatter: &logrus.TextFormatter{DisableColors: true},
+	Level:     logrus.DebugLevel,
+}
+
+func TestRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	// miniredis only expires keys when its clock is advanced.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mr.FastForward(100 * time.Millisecond)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	newStorage := func() storage.Storage {
+		mr.FlushAll()
+		s := &Redis{
+			Addrs: []string{mr.Addr()},
+		}
+		conn, err := s.open(logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	conformance.RunTests(t, newStorage)
+	conformance.RunTransactionTests(t, newStorage)
+}
+
+func TestRedisExternal(t *testing.T) {
+	testRedisEnv := "DEX_REDIS_ADDRS"
+	addrs := os.Getenv(testRedisEnv)
+	if addrs == "" {
+		t.Skipf("test environment variable %q not set, skipping", testRedisEnv)
+		return
+	}
+
+	newStorage := func() storage.Storage {
+		s := &Redis{
+			Mode:      os.Getenv("DEX_REDIS_MODE"),
+			Addrs:     strings.Split(addrs, ","),
+			KeyPrefix: "dex-test:" + storage.NewID() + ":",
+		}
+		conn, err := s.open(logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	conformance.RunTests(t, newStorage)
+	conformance.RunTransactionTests(t, newStorage)
+}
+
+func TestExpiringObjectsUseTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	s := &Redis{Addrs: []string{mr.Addr()}}
+	c, err := s.open(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	expiry := time.Now().Add(time.Hour)
+	if err := c.CreateAuthCode(storage.AuthCode{ID: "code", Expiry: expiry}); err != nil {
+		t.Fatalf("create auth code: %v", err)
+	}
+	if err := c.CreateClient(storage.Client{ID: "client"}); err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	if got := mr.TTL(c.key(authCodeKind, "code")); got <= time.Hour || got > time.Hour+expiryGracePeriod {
+		t.Errorf("expected auth code TTL to cover its expiry, got %v", got)
+	}
+	if got := mr.TTL(c.key(clientKind, "client")); got != 0 {
+		t.Errorf("expected client without TTL, got %v", got)
+	}
+
+	// Once Redis drops the auth code its ID is removed from the index.
+	mr.FastForward(time.Hour + expiryGracePeriod + time.Second)
+	if _, err := c.GetAuthCode("code"); err != storage.ErrNotFound {
+		t.Errorf("expected expired auth code to be gone, got %v", err)
+	}
+	if _, err := c.GarbageCollect(time.Now(), storage.GCOptions{}); err != nil {
+		t.Fatalf("garbage collection failed: %v", err)
+	}
+	if mr.Exists(c.indexKey(authCodeKind)) {
+		members, _ := mr.Members(c.indexKey(authCodeKind))
+		t.Errorf("expected auth code index to be pruned, got %v", members)
+	}
+
+	ctx := context.Background()
+	if n, err := c.db.SCard(ctx, c.indexKey(clientKind)).Result(); err != nil || n != 1 {
+		t.Errorf("expected client to stay indexed, got %d (%v)", n, err)
+	}
+}
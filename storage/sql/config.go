@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -21,7 +22,8 @@ import (
 
 const (
 	// postgres error codes
-	pgErrUniqueViolation = "23505" // unique_violation
+	pgErrUniqueViolation      = "23505" // unique_violation
+	pgErrSerializationFailure = "40001" // serialization_failure
 )
 
 const (
@@ -29,6 +31,7 @@ const (
 	mysqlErrDupEntry            = 1062
 	mysqlErrDupEntryWithKeyName = 1586
 	mysqlErrUnknownSysVar       = 1193
+	mysqlErrLockDeadlock        = 1213
 )
 
 // nolint
@@ -89,7 +92,22 @@ func (p *Postgres) Open(logger log.Logger) (storage.Storage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
+	return &pgConn{conn, p.createDataSourceName()}, nil
+}
+
+// isSerializationFailure reports whether a transaction was aborted because it
+// conflicted with a concurrent transaction, and can be retried. MySQL and
+// MariaDB report these conflicts as deadlocks (SQLSTATE 40001 as well).
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pgErrSerializationFailure
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrLockDeadlock
+	}
+	return false
 }
 
 var strEsc = regexp.MustCompile(`([\\'])`)
@@ -165,6 +183,10 @@ func (p *Postgres) createDataSourceName() string {
 }
 
 func (p *Postgres) open(logger log.Logger) (*conn, error) {
+	return p.openFlavor(logger, &flavorPostgres)
+}
+
+func (p *Postgres) openFlavor(logger log.Logger, flavor *flavor) (*conn, error) {
 	dataSourceName := p.createDataSourceName()
 
 	db, err := sql.Open("postgres", dataSourceName)
@@ -197,13 +219,36 @@ func (p *Postgres) open(logger log.Logger) (*conn, error) {
 		return sqlErr.Code == pgErrUniqueViolation
 	}
 
-	c := &conn{db, &flavorPostgres, logger, errCheck}
+	c := &conn{db, flavor, logger, errCheck}
 	if _, err := c.migrate(); err != nil {
 		return nil, fmt.Errorf("failed to perform migrations: %v", err)
 	}
 	return c, nil
 }
 
+// CockroachDB options for creating a CockroachDB db. CockroachDB takes the
+// same options as Postgres, but defaults to its own port.
+type CockroachDB struct {
+	Postgres
+}
+
+// Open creates a new storage implementation backed by CockroachDB.
+func (p *CockroachDB) Open(logger log.Logger) (storage.Storage, error) {
+	conn, err := p.open(logger)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (p *CockroachDB) open(logger log.Logger) (*conn, error) {
+	cfg := p.Postgres
+	if cfg.Port == 0 {
+		cfg.Port = 26257
+	}
+	return cfg.openFlavor(logger, &flavorCockroachDB)
+}
+
 // MySQL options for creating a MySQL db.
 type MySQL struct {
 	NetworkDB
@@ -225,6 +270,10 @@ func (s *MySQL) Open(logger log.Logger) (storage.Storage, error) {
 }
 
 func (s *MySQL) open(logger log.Logger) (*conn, error) {
+	return s.openFlavor(logger, &flavorMySQL)
+}
+
+func (s *MySQL) openFlavor(logger log.Logger, flavor *flavor) (*conn, error) {
 	cfg := mysql.Config{
 		User:                 s.User,
 		Passwd:               s.Password,
@@ -309,13 +358,32 @@ func (s *MySQL) open(logger log.Logger) (*conn, error) {
 			sqlErr.Number == mysqlErrDupEntryWithKeyName
 	}
 
-	c := &conn{db, &flavorMySQL, logger, errCheck}
+	c := &conn{db, flavor, logger, errCheck}
 	if _, err := c.migrate(); err != nil {
 		return nil, fmt.Errorf("failed to perform migrations: %v", err)
 	}
 	return c, nil
 }
 
+// MariaDB options for creating a MariaDB db. MariaDB takes the same options
+// as MySQL.
+type MariaDB struct {
+	MySQL
+}
+
+// Open creates a new storage implementation backed by MariaDB.
+func (s *MariaDB) Open(logger log.Logger) (storage.Storage, error) {
+	conn, err := s.open(logger)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (s *MariaDB) open(logger log.Logger) (*conn, error) {
+	return s.openFlavor(logger, &flavorMariaDB)
+}
+
 func (s *MySQL) makeTLSConfig() error {
 	cfg := &tls.Config{}
 	if s.SSL.CAFile != "" {
@@ -75,6 +75,14 @@ func testDB(t *testing.T, o opener, withTransactions bool) {
 		}
 		return conn
 	}
+	t.Run("MigrationReplay", func(t *testing.T) {
+		conn, err := o.open(logger)
+		if err != nil {
+			fatal(err)
+		}
+		defer conn.Close()
+		testMigrationReplay(t, conn)
+	})
 	withTimeout(time.Minute*1, func() {
 		conformance.RunTests(t, newStorage)
 	})
@@ -282,3 +290,78 @@ func TestMySQL(t *testing.T) {
 	}
 	testDB(t, s, true)
 }
+
+const testCockroachDBEnv = "DEX_COCKROACHDB_HOST"
+
+func TestCockroachDB(t *testing.T) {
+	host := os.Getenv(testCockroachDBEnv)
+	if host == "" {
+		t.Skipf("test environment variable %q not set, skipping", testCockroachDBEnv)
+	}
+
+	port := uint64(26257)
+	if rawPort := os.Getenv("DEX_COCKROACHDB_PORT"); rawPort != "" {
+		var err error
+
+		port, err = strconv.ParseUint(rawPort, 10, 32)
+		if err != nil {
+			t.Fatalf("invalid cockroachdb port %q: %s", rawPort, err)
+		}
+	}
+
+	p := &CockroachDB{
+		Postgres: Postgres{
+			NetworkDB: NetworkDB{
+				Database:          getenv("DEX_COCKROACHDB_DATABASE", "defaultdb"),
+				User:              getenv("DEX_COCKROACHDB_USER", "root"),
+				Password:          getenv("DEX_COCKROACHDB_PASSWORD", ""),
+				Host:              host,
+				Port:              uint16(port),
+				ConnectionTimeout: 5,
+			},
+			SSL: SSL{
+				Mode: pgSSLDisable, // Insecure CockroachDB nodes don't support SSL.
+			},
+		},
+	}
+	testDB(t, p, true)
+}
+
+const testMariaDBEnv = "DEX_MARIADB_HOST"
+
+func TestMariaDB(t *testing.T) {
+	host := os.Getenv(testMariaDBEnv)
+	if host == "" {
+		t.Skipf("test environment variable %q not set, skipping", testMariaDBEnv)
+	}
+
+	port := uint64(3306)
+	if rawPort := os.Getenv("DEX_MARIADB_PORT"); rawPort != "" {
+		var err error
+
+		port, err = strconv.ParseUint(rawPort, 10, 32)
+		if err != nil {
+			t.Fatalf("invalid mariadb port %q: %s", rawPort, err)
+		}
+	}
+
+	s := &MariaDB{
+		MySQL: MySQL{
+			NetworkDB: NetworkDB{
+				Database:          getenv("DEX_MARIADB_DATABASE", "mysql"),
+				User:              getenv("DEX_MARIADB_USER", "mysql"),
+				Password:          getenv("DEX_MARIADB_PASSWORD", "mysql"),
+				Host:              host,
+				Port:              uint16(port),
+				ConnectionTimeout: 5,
+			},
+			SSL: SSL{
+				Mode: mysqlSSLFalse,
+			},
+			params: map[string]string{
+				"innodb_lock_wait_timeout": "3",
+			},
+		},
+	}
+	testDB(t, s, true)
+}
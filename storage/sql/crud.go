@@ -41,7 +41,13 @@ func encoder(i interface{}) driver.Valuer {
 // decoder wraps the underlying value in a JSON unmarshaler which can then be passed
 // to a database Scan() method.
 func decoder(i interface{}) sql.Scanner {
-	return jsonDecoder{i}
+	return jsonDecoder{i: i}
+}
+
+// nullableDecoder is like decoder but leaves the underlying value untouched
+// when the column is NULL, for columns added after rows may already exist.
+func nullableDecoder(i interface{}) sql.Scanner {
+	return jsonDecoder{i: i, nullable: true}
 }
 
 type jsonEncoder struct {
@@ -51,17 +57,21 @@ type jsonEncoder struct {
 func (j jsonEncoder) Value() (driver.Value, error) {
 	b, err := json.Marshal(j.i)
 	if err != nil {
-		return nil, fmt.Errorf("marshal: %v", err)
+		return nil, fmt.Errorf("marshal: %w", err)
 	}
 	return b, nil
 }
 
 type jsonDecoder struct {
-	i interface{}
+	i        interface{}
+	nullable bool
 }
 
 func (j jsonDecoder) Scan(dest interface{}) error {
 	if dest == nil {
+		if j.nullable {
+			return nil
+		}
 		return errors.New("nil value")
 	}
 	b, ok := dest.([]byte)
@@ -69,7 +79,7 @@ func (j jsonDecoder) Scan(dest interface{}) error {
 		return fmt.Errorf("expected []byte got %T", dest)
 	}
 	if err := json.Unmarshal(b, &j.i); err != nil {
-		return fmt.Errorf("unmarshal: %v", err)
+		return fmt.Errorf("unmarshal: %w", err)
 	}
 	return nil
 }
@@ -84,12 +94,12 @@ type scanner interface {
 	Scan(dest ...interface{}) error
 }
 
-func (c *conn) GarbageCollect(now time.Time) (storage.GCResult, error) {
+func (c *conn) GarbageCollect(now time.Time, opts storage.GCOptions) (storage.GCResult, error) {
 	result := storage.GCResult{}
 
 	r, err := c.Exec(`delete from auth_request where expiry < $1`, now)
 	if err != nil {
-		return result, fmt.Errorf("gc auth_request: %v", err)
+		return result, fmt.Errorf("gc auth_request: %w", err)
 	}
 	if n, err := r.RowsAffected(); err == nil {
 		result.AuthRequests = n
@@ -97,7 +107,7 @@ func (c *conn) GarbageCollect(now time.Time) (storage.GCResult, error) {
 
 	r, err = c.Exec(`delete from auth_code where expiry < $1`, now)
 	if err != nil {
-		return result, fmt.Errorf("gc auth_code: %v", err)
+		return result, fmt.Errorf("gc auth_code: %w", err)
 	}
 	if n, err := r.RowsAffected(); err == nil {
 		result.AuthCodes = n
@@ -105,7 +115,7 @@ func (c *conn) GarbageCollect(now time.Time) (storage.GCResult, error) {
 
 	r, err = c.Exec(`delete from device_request where expiry < $1`, now)
 	if err != nil {
-		return result, fmt.Errorf("gc device_request: %v", err)
+		return result, fmt.Errorf("gc device_request: %w", err)
 	}
 	if n, err := r.RowsAffected(); err == nil {
 		result.DeviceRequests = n
@@ -113,15 +123,116 @@ func (c *conn) GarbageCollect(now time.Time) (storage.GCResult, error) {
 
 	r, err = c.Exec(`delete from device_token where expiry < $1`, now)
 	if err != nil {
-		return result, fmt.Errorf("gc device_token: %v", err)
+		return result, fmt.Errorf("gc device_token: %w", err)
 	}
 	if n, err := r.RowsAffected(); err == nil {
 		result.DeviceTokens = n
 	}
 
+	r, err = c.Exec(`delete from ciba_request where expiry < $1`, now)
+	if err != nil {
+		return result, fmt.Errorf("gc ciba_request: %w", err)
+	}
+	if n, err := r.RowsAffected(); err == nil {
+		result.CIBARequests = n
+	}
+
+	r, err = c.Exec(`delete from ciba_token where expiry < $1`, now)
+	if err != nil {
+		return result, fmt.Errorf("gc ciba_token: %w", err)
+	}
+	if n, err := r.RowsAffected(); err == nil {
+		result.CIBATokens = n
+	}
+
+	if opts.RefreshTokenAbsoluteLifetime != 0 {
+		r, err = c.Exec(`delete from refresh_token where created_at < $1`, now.Add(-opts.RefreshTokenAbsoluteLifetime))
+		if err != nil {
+			return result, fmt.Errorf("gc refresh_token: %w", err)
+		}
+		if n, err := r.RowsAffected(); err == nil {
+			result.RefreshTokens += n
+		}
+	}
+
+	if opts.RefreshTokenValidIfNotUsedFor != 0 {
+		r, err = c.Exec(`delete from refresh_token where last_used < $1`, now.Add(-opts.RefreshTokenValidIfNotUsedFor))
+		if err != nil {
+			return result, fmt.Errorf("gc refresh_token: %w", err)
+		}
+		if n, err := r.RowsAffected(); err == nil {
+			result.RefreshTokens += n
+		}
+	}
+
+	err = c.ExecTx(func(tx *trans) error {
+		n, err := gcOfflineSessions(tx)
+		result.OfflineSessions = n
+		return err
+	})
+	if err != nil {
+		return result, fmt.Errorf("gc offline_session: %w", err)
+	}
+
 	return result, err
 }
 
+// gcOfflineSessions deletes the offline sessions which only reference refresh
+// tokens that no longer exist. The sessions and refresh tokens are read and the
+// sessions deleted in one serializable transaction, which conflicts with any
+// concurrent transaction adding a refresh token to a session.
+func gcOfflineSessions(tx *trans) (int64, error) {
+	rows, err := tx.Query(`select id from refresh_token;`)
+	if err != nil {
+		return 0, fmt.Errorf("query refresh tokens: %w", err)
+	}
+	refreshIDs := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan refresh token: %w", err)
+		}
+		refreshIDs[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("scan refresh token: %w", err)
+	}
+
+	rows, err = tx.Query(`select user_id, conn_id, refresh, connector_data from offline_session;`)
+	if err != nil {
+		return 0, fmt.Errorf("query offline sessions: %w", err)
+	}
+	var orphaned []storage.OfflineSessions
+	for rows.Next() {
+		o, err := scanOfflineSessions(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if storage.OfflineSessionOrphaned(o, func(id string) bool { return refreshIDs[id] }) {
+			orphaned = append(orphaned, o)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("scan offline session: %w", err)
+	}
+
+	var deleted int64
+	for _, o := range orphaned {
+		r, err := tx.Exec(`delete from offline_session where user_id = $1 AND conn_id = $2`, o.UserID, o.ConnID)
+		if err != nil {
+			return deleted, fmt.Errorf("delete offline session: %w", err)
+		}
+		if n, err := r.RowsAffected(); err == nil {
+			deleted += n
+		}
+	}
+	return deleted, nil
+}
+
 func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
 	_, err := c.Exec(`
 		insert into auth_request (
@@ -149,7 +260,7 @@ func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert auth request: %v", err)
+		return fmt.Errorf("insert auth request: %w", err)
 	}
 	return nil
 }
@@ -189,7 +300,7 @@ func (c *conn) UpdateAuthRequest(id string, updater func(a storage.AuthRequest)
 			r.ID,
 		)
 		if err != nil {
-			return fmt.Errorf("update auth request: %v", err)
+			return fmt.Errorf("update auth request: %w", err)
 		}
 		return nil
 	})
@@ -222,7 +333,7 @@ func getAuthRequest(q querier, id string) (a storage.AuthRequest, err error) {
 		if err == sql.ErrNoRows {
 			return a, storage.ErrNotFound
 		}
-		return a, fmt.Errorf("select auth request: %v", err)
+		return a, fmt.Errorf("select auth request: %w", err)
 	}
 	return a, nil
 }
@@ -248,7 +359,7 @@ func (c *conn) CreateAuthCode(a storage.AuthCode) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert auth code: %v", err)
+		return fmt.Errorf("insert auth code: %w", err)
 	}
 	return nil
 }
@@ -273,7 +384,7 @@ func (c *conn) GetAuthCode(id string) (a storage.AuthCode, err error) {
 		if err == sql.ErrNoRows {
 			return a, storage.ErrNotFound
 		}
-		return a, fmt.Errorf("select auth code: %v", err)
+		return a, fmt.Errorf("select auth code: %w", err)
 	}
 	return a, nil
 }
@@ -300,7 +411,7 @@ func (c *conn) CreateRefresh(r storage.RefreshToken) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert refresh_token: %v", err)
+		return fmt.Errorf("insert refresh_token: %w", err)
 	}
 	return nil
 }
@@ -343,7 +454,7 @@ func (c *conn) UpdateRefreshToken(id string, updater func(old storage.RefreshTok
 			r.Token, r.ObsoleteToken, r.CreatedAt, r.LastUsed, id,
 		)
 		if err != nil {
-			return fmt.Errorf("update refresh token: %v", err)
+			return fmt.Errorf("update refresh token: %w", err)
 		}
 		return nil
 	})
@@ -377,7 +488,7 @@ func (c *conn) ListRefreshTokens() ([]storage.RefreshToken, error) {
 		from refresh_token;
 	`)
 	if err != nil {
-		return nil, fmt.Errorf("query: %v", err)
+		return nil, fmt.Errorf("query: %w", err)
 	}
 	defer rows.Close()
 
@@ -390,11 +501,106 @@ func (c *conn) ListRefreshTokens() ([]storage.RefreshToken, error) {
 		tokens = append(tokens, r)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("scan: %v", err)
+		return nil, fmt.Errorf("scan: %w", err)
 	}
 	return tokens, nil
 }
 
+func (c *conn) ListRefreshTokensForUser(userID string, connID string) ([]storage.RefreshToken, error) {
+	rows, err := c.Query(`
+		select
+			id, client_id, scopes, nonce,
+			claims_user_id, claims_username, claims_preferred_username,
+			claims_email, claims_email_verified, claims_groups,
+			connector_id, connector_data,
+			token, obsolete_token, created_at, last_used
+		from refresh_token
+		where claims_user_id = $1 AND connector_id = $2;
+	`, userID, connID)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []storage.RefreshToken
+	for rows.Next() {
+		r, err := scanRefresh(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return tokens, nil
+}
+
+func (c *conn) ListRefreshTokensPage(opts storage.ListOptions) ([]storage.RefreshToken, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var q listQuery
+	f := opts.Filter
+	if f.ClientID != "" {
+		q.where("client_id = %s", f.ClientID)
+	}
+	if f.UserID != "" {
+		q.where("claims_user_id = %s", f.UserID)
+	}
+	if f.ConnectorID != "" {
+		q.where("connector_id = %s", f.ConnectorID)
+	}
+	if !f.CreatedAfter.IsZero() {
+		q.where("created_at >= %s", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		q.where("created_at < %s", f.CreatedBefore)
+	}
+	if !f.LastUsedAfter.IsZero() {
+		q.where("last_used >= %s", f.LastUsedAfter)
+	}
+	if !f.LastUsedBefore.IsZero() {
+		q.where("last_used < %s", f.LastUsedBefore)
+	}
+
+	rows, err := c.Query(`
+		select
+			id, client_id, scopes, nonce,
+			claims_user_id, claims_username, claims_preferred_username,
+			claims_email, claims_email_verified, claims_groups,
+			connector_id, connector_data,
+			token, obsolete_token, created_at, last_used
+		from refresh_token`+q.page("id", after, opts.PageSize)+`;`,
+		q.args...,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []storage.RefreshToken
+	for rows.Next() {
+		r, err := scanRefresh(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		tokens = append(tokens, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("scan: %w", err)
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(tokens) > opts.PageSize {
+		tokens = tokens[:opts.PageSize]
+		next = storage.NewPageToken(tokens[opts.PageSize-1].ID)
+	}
+	return tokens, next, nil
+}
+
 func scanRefresh(s scanner) (r storage.RefreshToken, err error) {
 	err = s.Scan(
 		&r.ID, &r.ClientID, decoder(&r.Scopes), &r.Nonce,
@@ -408,7 +614,7 @@ func scanRefresh(s scanner) (r storage.RefreshToken, err error) {
 		if err == sql.ErrNoRows {
 			return r, storage.ErrNotFound
 		}
-		return r, fmt.Errorf("scan refresh_token: %v", err)
+		return r, fmt.Errorf("scan refresh_token: %w", err)
 	}
 	return r, nil
 }
@@ -421,7 +627,7 @@ func (c *conn) UpdateKeys(updater func(old storage.Keys) (storage.Keys, error))
 		old, err := getKeys(tx)
 		if err != nil {
 			if err != storage.ErrNotFound {
-				return fmt.Errorf("get keys: %v", err)
+				return fmt.Errorf("get keys: %w", err)
 			}
 			firstUpdate = true
 			old = storage.Keys{}
@@ -443,7 +649,7 @@ func (c *conn) UpdateKeys(updater func(old storage.Keys) (storage.Keys, error))
 				encoder(nk.SigningKeyPub), nk.NextRotation,
 			)
 			if err != nil {
-				return fmt.Errorf("insert: %v", err)
+				return fmt.Errorf("insert: %w", err)
 			}
 		} else {
 			_, err = tx.Exec(`
@@ -459,7 +665,7 @@ func (c *conn) UpdateKeys(updater func(old storage.Keys) (storage.Keys, error))
 				encoder(nk.SigningKeyPub), nk.NextRotation, keysRowID,
 			)
 			if err != nil {
-				return fmt.Errorf("update: %v", err)
+				return fmt.Errorf("update: %w", err)
 			}
 		}
 		return nil
@@ -484,7 +690,7 @@ func getKeys(q querier) (keys storage.Keys, err error) {
 		if err == sql.ErrNoRows {
 			return keys, storage.ErrNotFound
 		}
-		return keys, fmt.Errorf("query keys: %v", err)
+		return keys, fmt.Errorf("query keys: %w", err)
 	}
 	return keys, nil
 }
@@ -508,12 +714,29 @@ func (c *conn) UpdateClient(id string, updater func(old storage.Client) (storage
 				trusted_peers = $3,
 				public = $4,
 				name = $5,
-				logo_url = $6
-			where id = $7;
-		`, nc.Secret, encoder(nc.RedirectURIs), encoder(nc.TrustedPeers), nc.Public, nc.Name, nc.LogoURL, id,
+				logo_url = $6,
+				backchannel_notification_endpoint = $7,
+				id_tokens_valid_for = $8,
+				access_tokens_valid_for = $9,
+				auth_codes_valid_for = $10,
+				disable_refresh_token_rotation = $11,
+				refresh_token_absolute_lifetime = $12,
+				refresh_token_valid_if_not_used_for = $13,
+				allowed_connectors = $14,
+				allowed_grant_types = $15,
+				allowed_scopes = $16,
+				allowed_response_types = $17
+			where id = $18;
+		`, nc.Secret, encoder(nc.RedirectURIs), encoder(nc.TrustedPeers), nc.Public, nc.Name, nc.LogoURL,
+			nc.BackchannelNotificationEndpoint,
+			nc.TokenPolicy.IDTokensValidFor, nc.TokenPolicy.AccessTokensValidFor, nc.TokenPolicy.AuthCodesValidFor,
+			nc.TokenPolicy.DisableRefreshTokenRotation, nc.TokenPolicy.RefreshTokenAbsoluteLifetime,
+			nc.TokenPolicy.RefreshTokenValidIfNotUsedFor,
+			encoder(nc.AllowedConnectors), encoder(nc.AllowedGrantTypes),
+			encoder(nc.AllowedScopes), encoder(nc.AllowedResponseTypes), id,
 		)
 		if err != nil {
-			return fmt.Errorf("update client: %v", err)
+			return fmt.Errorf("update client: %w", err)
 		}
 		return nil
 	})
@@ -522,18 +745,28 @@ func (c *conn) UpdateClient(id string, updater func(old storage.Client) (storage
 func (c *conn) CreateClient(cli storage.Client) error {
 	_, err := c.Exec(`
 		insert into client (
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			backchannel_notification_endpoint,
+			id_tokens_valid_for, access_tokens_valid_for, auth_codes_valid_for,
+			disable_refresh_token_rotation, refresh_token_absolute_lifetime,
+			refresh_token_valid_if_not_used_for,
+			allowed_connectors, allowed_grant_types, allowed_scopes, allowed_response_types
 		)
-		values ($1, $2, $3, $4, $5, $6, $7);
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18);
 	`,
 		cli.ID, cli.Secret, encoder(cli.RedirectURIs), encoder(cli.TrustedPeers),
-		cli.Public, cli.Name, cli.LogoURL,
+		cli.Public, cli.Name, cli.LogoURL, cli.BackchannelNotificationEndpoint,
+		cli.TokenPolicy.IDTokensValidFor, cli.TokenPolicy.AccessTokensValidFor, cli.TokenPolicy.AuthCodesValidFor,
+		cli.TokenPolicy.DisableRefreshTokenRotation, cli.TokenPolicy.RefreshTokenAbsoluteLifetime,
+		cli.TokenPolicy.RefreshTokenValidIfNotUsedFor,
+		encoder(cli.AllowedConnectors), encoder(cli.AllowedGrantTypes),
+		encoder(cli.AllowedScopes), encoder(cli.AllowedResponseTypes),
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert client: %v", err)
+		return fmt.Errorf("insert client: %w", err)
 	}
 	return nil
 }
@@ -541,7 +774,12 @@ func (c *conn) CreateClient(cli storage.Client) error {
 func getClient(q querier, id string) (storage.Client, error) {
 	return scanClient(q.QueryRow(`
 		select
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			backchannel_notification_endpoint,
+			id_tokens_valid_for, access_tokens_valid_for, auth_codes_valid_for,
+			disable_refresh_token_rotation, refresh_token_absolute_lifetime,
+			refresh_token_valid_if_not_used_for,
+			allowed_connectors, allowed_grant_types, allowed_scopes, allowed_response_types
 	    from client where id = $1;
 	`, id))
 }
@@ -553,7 +791,12 @@ func (c *conn) GetClient(id string) (storage.Client, error) {
 func (c *conn) ListClients() ([]storage.Client, error) {
 	rows, err := c.Query(`
 		select
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			backchannel_notification_endpoint,
+			id_tokens_valid_for, access_tokens_valid_for, auth_codes_valid_for,
+			disable_refresh_token_rotation, refresh_token_absolute_lifetime,
+			refresh_token_valid_if_not_used_for,
+			allowed_connectors, allowed_grant_types, allowed_scopes, allowed_response_types
 		from client;
 	`)
 	if err != nil {
@@ -575,16 +818,64 @@ func (c *conn) ListClients() ([]storage.Client, error) {
 	return clients, nil
 }
 
+func (c *conn) ListClientsPage(opts storage.ListOptions) ([]storage.Client, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var q listQuery
+	rows, err := c.Query(`
+		select
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			backchannel_notification_endpoint,
+			id_tokens_valid_for, access_tokens_valid_for, auth_codes_valid_for,
+			disable_refresh_token_rotation, refresh_token_absolute_lifetime,
+			refresh_token_valid_if_not_used_for,
+			allowed_connectors, allowed_grant_types, allowed_scopes, allowed_response_types
+		from client`+q.page("id", after, opts.PageSize)+`;`,
+		q.args...,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var clients []storage.Client
+	for rows.Next() {
+		cli, err := scanClient(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		clients = append(clients, cli)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(clients) > opts.PageSize {
+		clients = clients[:opts.PageSize]
+		next = storage.NewPageToken(clients[opts.PageSize-1].ID)
+	}
+	return clients, next, nil
+}
+
 func scanClient(s scanner) (cli storage.Client, err error) {
 	err = s.Scan(
 		&cli.ID, &cli.Secret, decoder(&cli.RedirectURIs), decoder(&cli.TrustedPeers),
-		&cli.Public, &cli.Name, &cli.LogoURL,
+		&cli.Public, &cli.Name, &cli.LogoURL, &cli.BackchannelNotificationEndpoint,
+		&cli.TokenPolicy.IDTokensValidFor, &cli.TokenPolicy.AccessTokensValidFor, &cli.TokenPolicy.AuthCodesValidFor,
+		&cli.TokenPolicy.DisableRefreshTokenRotation, &cli.TokenPolicy.RefreshTokenAbsoluteLifetime,
+		&cli.TokenPolicy.RefreshTokenValidIfNotUsedFor,
+		nullableDecoder(&cli.AllowedConnectors), nullableDecoder(&cli.AllowedGrantTypes),
+		nullableDecoder(&cli.AllowedScopes), nullableDecoder(&cli.AllowedResponseTypes),
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return cli, storage.ErrNotFound
 		}
-		return cli, fmt.Errorf("get client: %v", err)
+		return cli, fmt.Errorf("get client: %w", err)
 	}
 	return cli, nil
 }
@@ -605,7 +896,7 @@ func (c *conn) CreatePassword(p storage.Password) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert password: %v", err)
+		return fmt.Errorf("insert password: %w", err)
 	}
 	return nil
 }
@@ -630,7 +921,7 @@ func (c *conn) UpdatePassword(email string, updater func(p storage.Password) (st
 			np.Hash, np.Username, np.UserID, p.Email,
 		)
 		if err != nil {
-			return fmt.Errorf("update password: %v", err)
+			return fmt.Errorf("update password: %w", err)
 		}
 		return nil
 	})
@@ -673,6 +964,47 @@ func (c *conn) ListPasswords() ([]storage.Password, error) {
 	return passwords, nil
 }
 
+func (c *conn) ListPasswordsPage(opts storage.ListOptions) ([]storage.Password, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var q listQuery
+	if opts.Filter.UserID != "" {
+		q.where("user_id = %s", opts.Filter.UserID)
+	}
+	rows, err := c.Query(`
+		select
+			email, hash, username, user_id
+		from password`+q.page("email", after, opts.PageSize)+`;`,
+		q.args...,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var passwords []storage.Password
+	for rows.Next() {
+		p, err := scanPassword(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		passwords = append(passwords, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(passwords) > opts.PageSize {
+		passwords = passwords[:opts.PageSize]
+		next = storage.NewPageToken(passwords[opts.PageSize-1].Email)
+	}
+	return passwords, next, nil
+}
+
 func scanPassword(s scanner) (p storage.Password, err error) {
 	err = s.Scan(
 		&p.Email, &p.Hash, &p.Username, &p.UserID,
@@ -681,7 +1013,7 @@ func scanPassword(s scanner) (p storage.Password, err error) {
 		if err == sql.ErrNoRows {
 			return p, storage.ErrNotFound
 		}
-		return p, fmt.Errorf("select password: %v", err)
+		return p, fmt.Errorf("select password: %w", err)
 	}
 	return p, nil
 }
@@ -701,7 +1033,7 @@ func (c *conn) CreateOfflineSessions(s storage.OfflineSessions) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert offline session: %v", err)
+		return fmt.Errorf("insert offline session: %w", err)
 	}
 	return nil
 }
@@ -727,7 +1059,7 @@ func (c *conn) UpdateOfflineSessions(userID string, connID string, updater func(
 			encoder(newSession.Refresh), newSession.ConnectorData, s.UserID, s.ConnID,
 		)
 		if err != nil {
-			return fmt.Errorf("update offline session: %v", err)
+			return fmt.Errorf("update offline session: %w", err)
 		}
 		return nil
 	})
@@ -746,6 +1078,59 @@ func getOfflineSessions(q querier, userID string, connID string) (storage.Offlin
 		`, userID, connID))
 }
 
+func (c *conn) ListOfflineSessions() ([]storage.OfflineSessions, error) {
+	rows, err := c.Query(`
+		select
+			user_id, conn_id, refresh, connector_data
+		from offline_session;
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []storage.OfflineSessions
+	for rows.Next() {
+		o, err := scanOfflineSessions(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return sessions, nil
+}
+
+func (c *conn) ListOfflineSessionsForUser(userID string) ([]storage.OfflineSessions, error) {
+	// The primary key of offline_session starts with user_id and serves as
+	// the index of this query.
+	rows, err := c.Query(`
+		select
+			user_id, conn_id, refresh, connector_data
+		from offline_session
+		where user_id = $1;
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []storage.OfflineSessions
+	for rows.Next() {
+		o, err := scanOfflineSessions(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return sessions, nil
+}
+
 func scanOfflineSessions(s scanner) (o storage.OfflineSessions, err error) {
 	err = s.Scan(
 		&o.UserID, &o.ConnID, decoder(&o.Refresh), &o.ConnectorData,
@@ -754,7 +1139,7 @@ func scanOfflineSessions(s scanner) (o storage.OfflineSessions, err error) {
 		if err == sql.ErrNoRows {
 			return o, storage.ErrNotFound
 		}
-		return o, fmt.Errorf("select offline session: %v", err)
+		return o, fmt.Errorf("select offline session: %w", err)
 	}
 	return o, nil
 }
@@ -774,7 +1159,7 @@ func (c *conn) CreateConnector(connector storage.Connector) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert connector: %v", err)
+		return fmt.Errorf("insert connector: %w", err)
 	}
 	return nil
 }
@@ -802,7 +1187,7 @@ func (c *conn) UpdateConnector(id string, updater func(s storage.Connector) (sto
 			newConn.Type, newConn.Name, newConn.ResourceVersion, newConn.Config, connector.ID,
 		)
 		if err != nil {
-			return fmt.Errorf("update connector: %v", err)
+			return fmt.Errorf("update connector: %w", err)
 		}
 		return nil
 	})
@@ -829,7 +1214,7 @@ func scanConnector(s scanner) (c storage.Connector, err error) {
 		if err == sql.ErrNoRows {
 			return c, storage.ErrNotFound
 		}
-		return c, fmt.Errorf("select connector: %v", err)
+		return c, fmt.Errorf("select connector: %w", err)
 	}
 	return c, nil
 }
@@ -859,6 +1244,75 @@ func (c *conn) ListConnectors() ([]storage.Connector, error) {
 	return connectors, nil
 }
 
+func (c *conn) ListConnectorsPage(opts storage.ListOptions) ([]storage.Connector, string, error) {
+	after, err := storage.ParsePageToken(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var q listQuery
+	rows, err := c.Query(`
+		select
+			id, type, name, resource_version, config
+		from connector`+q.page("id", after, opts.PageSize)+`;`,
+		q.args...,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var connectors []storage.Connector
+	for rows.Next() {
+		conn, err := scanConnector(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		connectors = append(connectors, conn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if opts.PageSize > 0 && len(connectors) > opts.PageSize {
+		connectors = connectors[:opts.PageSize]
+		next = storage.NewPageToken(connectors[opts.PageSize-1].ID)
+	}
+	return connectors, next, nil
+}
+
+// listQuery builds the conditions of a paginated list query.
+type listQuery struct {
+	conds []string
+	args  []interface{}
+}
+
+// where adds a condition, the "%s" in cond is replaced by the bind of arg.
+func (q *listQuery) where(cond string, arg interface{}) {
+	q.args = append(q.args, arg)
+	q.conds = append(q.conds, fmt.Sprintf(cond, fmt.Sprintf("$%d", len(q.args))))
+}
+
+// page returns the where, order by and limit clauses of a page following the
+// row with the given key. One more row than the page size is selected to find
+// out if there is a next page.
+func (q *listQuery) page(key, after string, pageSize int) string {
+	if after != "" {
+		q.where(key+" > %s", after)
+	}
+	var clauses string
+	if len(q.conds) > 0 {
+		clauses = " where " + strings.Join(q.conds, " and ")
+	}
+	clauses += " order by " + key
+	if pageSize > 0 {
+		q.args = append(q.args, pageSize+1)
+		clauses += fmt.Sprintf(" limit $%d", len(q.args))
+	}
+	return clauses
+}
+
 func (c *conn) DeleteAuthRequest(id string) error { return c.delete("auth_request", "id", id) }
 func (c *conn) DeleteAuthCode(id string) error    { return c.delete("auth_code", "id", id) }
 func (c *conn) DeleteClient(id string) error      { return c.delete("client", "id", id) }
@@ -878,7 +1332,7 @@ func (c *conn) DeleteOfflineSessions(userID string, connID string) error {
 	// a driver that doesn't implement this, we can run this in a transaction with a get beforehand.
 	n, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("rows affected: %v", err)
+		return fmt.Errorf("rows affected: %w", err)
 	}
 	if n < 1 {
 		return storage.ErrNotFound
@@ -897,7 +1351,7 @@ func (c *conn) delete(table, field, id string) error {
 	// a driver that doesn't implement this, we can run this in a transaction with a get beforehand.
 	n, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("rows affected: %v", err)
+		return fmt.Errorf("rows affected: %w", err)
 	}
 	if n < 1 {
 		return storage.ErrNotFound
@@ -919,7 +1373,7 @@ func (c *conn) CreateDeviceRequest(d storage.DeviceRequest) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert device request: %v", err)
+		return fmt.Errorf("insert device request: %w", err)
 	}
 	return nil
 }
@@ -938,7 +1392,7 @@ func (c *conn) CreateDeviceToken(t storage.DeviceToken) error {
 		if c.alreadyExistsCheck(err) {
 			return storage.ErrAlreadyExists
 		}
-		return fmt.Errorf("insert device token: %v", err)
+		return fmt.Errorf("insert device token: %w", err)
 	}
 	return nil
 }
@@ -959,7 +1413,7 @@ func getDeviceRequest(q querier, userCode string) (d storage.DeviceRequest, err
 		if err == sql.ErrNoRows {
 			return d, storage.ErrNotFound
 		}
-		return d, fmt.Errorf("select device token: %v", err)
+		return d, fmt.Errorf("select device token: %w", err)
 	}
 	d.UserCode = userCode
 	return d, nil
@@ -981,7 +1435,7 @@ func getDeviceToken(q querier, deviceCode string) (a storage.DeviceToken, err er
 		if err == sql.ErrNoRows {
 			return a, storage.ErrNotFound
 		}
-		return a, fmt.Errorf("select device token: %v", err)
+		return a, fmt.Errorf("select device token: %w", err)
 	}
 	a.DeviceCode = deviceCode
 	return a, nil
@@ -1009,8 +1463,157 @@ func (c *conn) UpdateDeviceToken(deviceCode string, updater func(old storage.Dev
 			r.Status, r.Token, r.LastRequestTime, r.PollIntervalSeconds, r.DeviceCode,
 		)
 		if err != nil {
-			return fmt.Errorf("update device token: %v", err)
+			return fmt.Errorf("update device token: %w", err)
 		}
 		return nil
 	})
 }
+
+func (c *conn) CreateCIBARequest(r storage.CIBARequest) error {
+	_, err := c.Exec(`
+		insert into ciba_request (
+			approval_code, auth_req_id, client_id, scopes, login_hint, binding_message, expiry
+		)
+		values (
+			$1, $2, $3, $4, $5, $6, $7
+		);`,
+		r.ApprovalCode, r.AuthReqID, r.ClientID, encoder(r.Scopes), r.LoginHint, r.BindingMessage, r.Expiry,
+	)
+	if err != nil {
+		if c.alreadyExistsCheck(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert ciba request: %w", err)
+	}
+	return nil
+}
+
+func (c *conn) CreateCIBAToken(t storage.CIBAToken) error {
+	_, err := c.Exec(`
+		insert into ciba_token (
+			auth_req_id, client_id, status, token, notification_token, expiry, last_request, poll_interval
+		)
+		values (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		);`,
+		t.AuthReqID, t.ClientID, t.Status, t.Token, t.NotificationToken, t.Expiry, t.LastRequestTime, t.PollIntervalSeconds,
+	)
+	if err != nil {
+		if c.alreadyExistsCheck(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert ciba token: %w", err)
+	}
+	return nil
+}
+
+func (c *conn) GetCIBARequest(approvalCode string) (r storage.CIBARequest, err error) {
+	err = c.QueryRow(`
+		select
+			auth_req_id, client_id, scopes, login_hint, binding_message, expiry
+		from ciba_request where approval_code = $1;
+	`, approvalCode).Scan(
+		&r.AuthReqID, &r.ClientID, decoder(&r.Scopes), &r.LoginHint, &r.BindingMessage, &r.Expiry,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return r, storage.ErrNotFound
+		}
+		return r, fmt.Errorf("select ciba request: %w", err)
+	}
+	r.ApprovalCode = approvalCode
+	return r, nil
+}
+
+func (c *conn) GetCIBAToken(authReqID string) (storage.CIBAToken, error) {
+	return getCIBAToken(c, authReqID)
+}
+
+func getCIBAToken(q querier, authReqID string) (t storage.CIBAToken, err error) {
+	err = q.QueryRow(`
+		select
+			client_id, status, token, notification_token, expiry, last_request, poll_interval
+		from ciba_token where auth_req_id = $1;
+	`, authReqID).Scan(
+		&t.ClientID, &t.Status, &t.Token, &t.NotificationToken, &t.Expiry, &t.LastRequestTime, &t.PollIntervalSeconds,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return t, storage.ErrNotFound
+		}
+		return t, fmt.Errorf("select ciba token: %w", err)
+	}
+	t.AuthReqID = authReqID
+	return t, nil
+}
+
+func (c *conn) UpdateCIBAToken(authReqID string, updater func(old storage.CIBAToken) (storage.CIBAToken, error)) error {
+	return c.ExecTx(func(tx *trans) error {
+		r, err := getCIBAToken(tx, authReqID)
+		if err != nil {
+			return err
+		}
+		if r, err = updater(r); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			update ciba_token
+			set
+				status = $1,
+				token = $2,
+				last_request = $3,
+				poll_interval = $4
+			where
+				auth_req_id = $5
+		`,
+			r.Status, r.Token, r.LastRequestTime, r.PollIntervalSeconds, authReqID,
+		)
+		if err != nil {
+			return fmt.Errorf("update ciba token: %w", err)
+		}
+		return nil
+	})
+}
+
+// AcquireLease takes over the lease row if it's held by the holder or has
+// expired, and inserts it if it doesn't exist yet. Both statements only
+// succeed for one of several concurrent callers.
+func (c *conn) AcquireLease(name, holder string, duration time.Duration) (storage.Lease, error) {
+	now := time.Now()
+	r, err := c.Exec(`
+		update lease
+		set
+			holder = $1,
+			expiry = $2
+		where name = $3 and (holder = $4 or expiry <= $5);
+	`, holder, now.Add(duration), name, holder, now)
+	if err != nil {
+		return storage.Lease{}, fmt.Errorf("update lease: %w", err)
+	}
+	if n, err := r.RowsAffected(); err != nil {
+		return storage.Lease{}, fmt.Errorf("rows affected: %w", err)
+	} else if n == 0 {
+		_, err := c.Exec(`
+			insert into lease (name, holder, expiry)
+			values ($1, $2, $3);
+		`, name, holder, now.Add(duration))
+		if err != nil && !c.alreadyExistsCheck(err) {
+			return storage.Lease{}, fmt.Errorf("insert lease: %w", err)
+		}
+	}
+
+	lease := storage.Lease{Name: name}
+	err = c.QueryRow(`select holder, expiry from lease where name = $1;`, name).Scan(&lease.Holder, &lease.Expiry)
+	if err != nil {
+		return storage.Lease{}, fmt.Errorf("select lease: %w", err)
+	}
+	return lease, nil
+}
+
+func (c *conn) ReleaseLease(name, holder string) error {
+	_, err := c.Exec(`delete from lease where name = $1 and holder = $2;`, name, holder)
+	if err != nil {
+		return fmt.Errorf("delete lease: %w", err)
+	}
+	return nil
+}
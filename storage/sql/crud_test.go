@@ -4,8 +4,12 @@ package sql
 
 import (
 	"database/sql"
+	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 )
 
 func TestDecoder(t *testing.T) {
@@ -55,3 +59,42 @@ func TestEncoder(t *testing.T) {
 		t.Errorf("wanted %q got %q", want, got)
 	}
 }
+
+func TestExecTxRetriesSerializationFailures(t *testing.T) {
+	c, err := (&SQLite3{":memory:"}).open(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	tests := []struct {
+		name     string
+		err      error
+		failures int
+		wantRuns int
+		wantErr  bool
+	}{
+		{"postgres serialization failure", &pq.Error{Code: pgErrSerializationFailure}, 2, 3, false},
+		{"mysql deadlock", fmt.Errorf("update: %w", &mysql.MySQLError{Number: mysqlErrLockDeadlock}), 1, 2, false},
+		{"persistent conflict", &pq.Error{Code: pgErrSerializationFailure}, maxTxAttempts, maxTxAttempts, true},
+		{"other error", &pq.Error{Code: pgErrUniqueViolation}, 1, 1, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := 0
+			err := c.ExecTx(func(tx *trans) error {
+				runs++
+				if runs <= tc.failures {
+					return tc.err
+				}
+				return nil
+			})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if runs != tc.wantRuns {
+				t.Errorf("expected %d runs, got %d", tc.wantRuns, runs)
+			}
+		})
+	}
+}
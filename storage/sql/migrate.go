@@ -6,6 +6,29 @@ import (
 )
 
 func (c *conn) migrate() (int, error) {
+	return c.applyMigrations(c.flavorMigrations())
+}
+
+// flavorMigrations returns the migrations which apply to the flavor of the
+// connection. Migrations are numbered by their position in this list, so new
+// migrations must be added at the end.
+func (c *conn) flavorMigrations() []migration {
+	var flavorMigrations []migration
+	for _, m := range migrations {
+		if c.appliesTo(m) {
+			flavorMigrations = append(flavorMigrations, m)
+		}
+	}
+	return flavorMigrations
+}
+
+func (c *conn) appliesTo(m migration) bool {
+	return m.flavor == nil || m.flavor == c.flavor || m.flavor == c.flavor.base
+}
+
+// applyMigrations applies the given migrations which haven't been applied
+// yet, each in its own transaction, and returns how many were applied.
+func (c *conn) applyMigrations(flavorMigrations []migration) (int, error) {
 	_, err := c.Exec(`
 		create table if not exists migrations (
 			num integer not null,
@@ -19,13 +42,6 @@ func (c *conn) migrate() (int, error) {
 	i := 0
 	done := false
 
-	var flavorMigrations []migration
-	for _, m := range migrations {
-		if m.flavor == nil || m.flavor == c.flavor {
-			flavorMigrations = append(flavorMigrations, m)
-		}
-	}
-
 	for {
 		err := c.ExecTx(func(tx *trans) error {
 			// Within a transaction, perform a single migration.
@@ -281,4 +297,118 @@ var migrations = []migration{
 				add column obsolete_token text default '';`,
 		},
 	},
+	{
+		stmts: []string{
+			`
+			create table ciba_request (
+				approval_code text not null primary key,
+				auth_req_id text not null,
+				client_id text not null,
+				scopes bytea not null, -- JSON array of strings
+				login_hint text not null,
+				binding_message text not null,
+				expiry timestamptz not null
+			);`,
+			`
+			create table ciba_token (
+				auth_req_id text not null primary key,
+				client_id text not null,
+				status text not null,
+				token bytea,
+				notification_token text not null,
+				expiry timestamptz not null,
+				last_request timestamptz not null,
+				poll_interval integer not null
+			);`,
+			`
+			alter table client
+				add column backchannel_notification_endpoint text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column id_tokens_valid_for text not null default '';`,
+			`
+			alter table client
+				add column access_tokens_valid_for text not null default '';`,
+			`
+			alter table client
+				add column auth_codes_valid_for text not null default '';`,
+			`
+			alter table client
+				add column disable_refresh_token_rotation boolean;`,
+			`
+			alter table client
+				add column refresh_token_absolute_lifetime text not null default '';`,
+			`
+			alter table client
+				add column refresh_token_valid_if_not_used_for text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column allowed_connectors bytea;`,
+			`
+			alter table client
+				add column allowed_grant_types bytea;`,
+			`
+			alter table client
+				add column allowed_scopes bytea;`,
+			`
+			alter table client
+				add column allowed_response_types bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create index refresh_token_user_idx
+				on refresh_token (claims_user_id, connector_id);`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create table lease (
+				name text not null primary key,
+				holder text not null,
+				expiry timestamptz not null
+			);`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create function dex_notify_change() returns trigger as $$
+			declare
+				changed record;
+			begin
+				if TG_OP = 'DELETE' then
+					changed := OLD;
+				else
+					changed := NEW;
+				end if;
+				perform pg_notify('dex_changes', TG_TABLE_NAME || ':' || (to_jsonb(changed) ->> TG_ARGV[0]));
+				return null;
+			end;
+			$$ language plpgsql;`,
+			`
+			create trigger client_changed
+				after insert or update or delete on client
+				for each row execute procedure dex_notify_change('id');`,
+			`
+			create trigger connector_changed
+				after insert or update or delete on connector
+				for each row execute procedure dex_notify_change('id');`,
+			`
+			create trigger password_changed
+				after insert or update or delete on password
+				for each row execute procedure dex_notify_change('email');`,
+		},
+		flavor: &flavorPostgres,
+	},
 }
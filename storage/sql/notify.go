@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// changesChannel is notified by triggers whenever a client, connector or
+// password changes, with a payload of "<table>:<id>".
+const changesChannel = "dex_changes"
+
+// pgConn is a Postgres connection, which can listen for notifications on a
+// dedicated connection to the database.
+type pgConn struct {
+	*conn
+
+	dataSourceName string
+}
+
+// WatchChanges listens to the notifications sent by the triggers on the client,
+// connector and password tables.
+func (c *pgConn) WatchChanges(ctx context.Context, fn func(storage.Change)) error {
+	listener := pq.NewListener(c.dataSourceName, time.Second, 30*time.Second, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			c.logger.Errorf("listening to %s: %v", changesChannel, err)
+		}
+	})
+	if err := listener.Listen(changesChannel); err != nil {
+		listener.Close()
+		return err
+	}
+
+	go func() {
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				if n == nil {
+					// The listener reconnected and may have missed notifications.
+					for _, kind := range []string{storage.KindClient, storage.KindConnector, storage.KindPassword} {
+						fn(storage.Change{Kind: kind})
+					}
+					continue
+				}
+				// The table names are the kinds of the objects.
+				parts := strings.SplitN(n.Extra, ":", 2)
+				if len(parts) != 2 {
+					c.logger.Errorf("unexpected notification on %s: %q", changesChannel, n.Extra)
+					continue
+				}
+				fn(storage.Change{Kind: parts[0], ID: parts[1]})
+			case <-time.After(90 * time.Second):
+				// Detect broken connections while no notifications arrive.
+				go listener.Ping()
+			}
+		}
+	}()
+	return nil
+}
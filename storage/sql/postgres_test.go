@@ -3,9 +3,13 @@
 package sql
 
 import (
+	"context"
 	"os"
 	"strconv"
 	"testing"
+	"time"
+
+	"github.com/dexidp/dex/storage"
 )
 
 func TestPostgresTunables(t *testing.T) {
@@ -62,3 +66,60 @@ func TestPostgresTunables(t *testing.T) {
 		}
 	})
 }
+
+func TestPostgresWatchChanges(t *testing.T) {
+	host := os.Getenv(testPostgresEnv)
+	if host == "" {
+		t.Skipf("test environment variable %q not set, skipping", testPostgresEnv)
+	}
+
+	port := uint64(5432)
+	if rawPort := os.Getenv("DEX_POSTGRES_PORT"); rawPort != "" {
+		var err error
+
+		port, err = strconv.ParseUint(rawPort, 10, 32)
+		if err != nil {
+			t.Fatalf("invalid postgres port %q: %s", rawPort, err)
+		}
+	}
+
+	p := &Postgres{
+		NetworkDB: NetworkDB{
+			Database: getenv("DEX_POSTGRES_DATABASE", "postgres"),
+			User:     getenv("DEX_POSTGRES_USER", "postgres"),
+			Password: getenv("DEX_POSTGRES_PASSWORD", "postgres"),
+			Host:     host,
+			Port:     uint16(port),
+		},
+		SSL: SSL{
+			Mode: pgSSLDisable, // Postgres container doesn't support SSL.
+		},
+	}
+	s, err := p.Open(logger)
+	if err != nil {
+		t.Fatalf("error opening storage: %s", err.Error())
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan storage.Change, 10)
+	if err := s.(storage.ChangeWatcher).WatchChanges(ctx, func(c storage.Change) { changes <- c }); err != nil {
+		t.Fatalf("watch changes: %v", err)
+	}
+
+	id := storage.NewID()
+	if err := s.CreateClient(storage.Client{ID: id}); err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	defer s.DeleteClient(id)
+
+	select {
+	case c := <-changes:
+		if want := (storage.Change{Kind: storage.KindClient, ID: id}); c != want {
+			t.Errorf("expected change %v, got %v", want, c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification received")
+	}
+}
@@ -0,0 +1,154 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// migrationFixtures are rows written right after the migration with the same
+// index in migrations, using the columns which existed at the time. They must
+// still be readable once all later migrations are applied.
+var migrationFixtures = map[int][]struct {
+	query string
+	args  []interface{}
+}{
+	0: {
+		{
+			`insert into client (id, secret, redirect_uris, trusted_peers, public, name, logo_url)
+			values ($1, $2, $3, $4, $5, $6, $7);`,
+			[]interface{}{"replay-client", "secret", []byte(`["https://example.com/callback"]`), []byte(`[]`), false, "Replay", ""},
+		},
+		{
+			`insert into password (email, hash, username, user_id) values ($1, $2, $3, $4);`,
+			[]interface{}{"replay@example.com", []byte("hash"), "replay", "replay-user"},
+		},
+		{
+			`insert into refresh_token (
+				id, client_id, scopes, nonce,
+				claims_user_id, claims_username, claims_email, claims_email_verified, claims_groups,
+				connector_id, connector_data
+			)
+			values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`,
+			[]interface{}{
+				"replay-refresh", "replay-client", []byte(`["openid"]`), "",
+				"replay-user", "replay", "replay@example.com", true, []byte(`["admins"]`),
+				"replay-connector", []byte(`{}`),
+			},
+		},
+	},
+	2: {
+		{
+			`insert into offline_session (user_id, conn_id, refresh) values ($1, $2, $3);`,
+			[]interface{}{"replay-user", "replay-connector", []byte(`{"replay-client":{"ID":"replay-refresh","ClientID":"replay-client"}}`)},
+		},
+	},
+	3: {
+		{
+			`insert into connector (id, type, name, resource_version, config) values ($1, $2, $3, $4, $5);`,
+			[]interface{}{"replay-connector", "mockCallback", "Replay", "", []byte(`{}`)},
+		},
+	},
+}
+
+// migrationReplayTables are dropped before replaying the migrations.
+var migrationReplayTables = []string{
+	"client", "auth_request", "auth_code", "refresh_token", "password", "keys",
+	"offline_session", "connector", "device_request", "device_token",
+	"ciba_request", "ciba_token", "lease", "migrations",
+}
+
+// testMigrationReplay drops the schema of c, then applies every migration of
+// its flavor one by one as older dex versions did, writing the fixtures of
+// each step. Every step must be applied exactly once, and the fixtures must
+// survive the later migrations.
+func testMigrationReplay(t *testing.T, c *conn) {
+	for _, table := range migrationReplayTables {
+		if _, err := c.Exec("drop table if exists " + table + ";"); err != nil {
+			t.Fatalf("drop table %s: %v", table, err)
+		}
+	}
+	if c.flavor == &flavorPostgres {
+		if _, err := c.Exec("drop function if exists dex_notify_change();"); err != nil {
+			t.Fatalf("drop function: %v", err)
+		}
+	}
+
+	var applied []migration
+	for i, m := range migrations {
+		if !c.appliesTo(m) {
+			continue
+		}
+		applied = append(applied, m)
+
+		// A restarted dex applies nothing if it's already up to date.
+		for _, want := range []int{1, 0} {
+			n, err := c.applyMigrations(applied)
+			if err != nil {
+				t.Fatalf("migration %d: %v", len(applied), err)
+			}
+			if n != want {
+				t.Fatalf("migration %d: expected %d migrations to be applied, got %d", len(applied), want, n)
+			}
+		}
+
+		for _, f := range migrationFixtures[i] {
+			if _, err := c.Exec(f.query, f.args...); err != nil {
+				t.Fatalf("migration %d: write fixture: %v", len(applied), err)
+			}
+		}
+	}
+
+	if n, err := c.migrate(); err != nil || n != 0 {
+		t.Fatalf("expected replayed schema to be up to date, got %d migrations: %v", n, err)
+	}
+
+	client, err := c.GetClient("replay-client")
+	if err != nil {
+		t.Fatalf("get client: %v", err)
+	}
+	wantClient := storage.Client{
+		ID:           "replay-client",
+		Secret:       "secret",
+		RedirectURIs: []string{"https://example.com/callback"},
+		TrustedPeers: []string{},
+		Name:         "Replay",
+	}
+	if diff := pretty.Compare(wantClient, client); diff != "" {
+		t.Errorf("client diff: %s", diff)
+	}
+
+	password, err := c.GetPassword("replay@example.com")
+	if err != nil {
+		t.Fatalf("get password: %v", err)
+	}
+	if password.UserID != "replay-user" || string(password.Hash) != "hash" {
+		t.Errorf("unexpected password %+v", password)
+	}
+
+	refresh, err := c.GetRefresh("replay-refresh")
+	if err != nil {
+		t.Fatalf("get refresh token: %v", err)
+	}
+	if refresh.Claims.Email != "replay@example.com" || len(refresh.Claims.Groups) != 1 || refresh.Token != "" {
+		t.Errorf("unexpected refresh token %+v", refresh)
+	}
+
+	session, err := c.GetOfflineSessions("replay-user", "replay-connector")
+	if err != nil {
+		t.Fatalf("get offline sessions: %v", err)
+	}
+	if ref := session.Refresh["replay-client"]; ref == nil || ref.ID != "replay-refresh" {
+		t.Errorf("unexpected offline sessions %+v", session)
+	}
+
+	connector, err := c.GetConnector("replay-connector")
+	if err != nil {
+		t.Fatalf("get connector: %v", err)
+	}
+	if connector.Type != "mockCallback" {
+		t.Errorf("unexpected connector %+v", connector)
+	}
+}
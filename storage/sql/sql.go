@@ -24,6 +24,10 @@ type flavor struct {
 
 	// Does the flavor support timezones?
 	supportsTimezones bool
+
+	// Optional flavor this flavor is derived from. Migrations specific to the
+	// base flavor are applied to this flavor as well.
+	base *flavor
 }
 
 // A regexp with a replacement string.
@@ -69,6 +73,15 @@ var (
 		supportsTimezones: true,
 	}
 
+	// CockroachDB speaks the Postgres protocol and dialect. Its transactions
+	// are always serializable, and are aborted with SQLSTATE 40001 rather than
+	// blocking when they conflict, which ExecTx retries.
+	//
+	// Postgres specific migrations (e.g. triggers) aren't applied to CockroachDB.
+	flavorCockroachDB = flavor{
+		supportsTimezones: true,
+	}
+
 	flavorSQLite3 = flavor{
 		queryReplacers: []replacer{
 			{bindRegexp, "?"},
@@ -101,8 +114,19 @@ var (
 			{regexp.MustCompile(`0001-01-01 00:00:00 UTC`), "1000-01-01 00:00:00"},
 		},
 	}
+
+	// MariaDB shares the MySQL dialect and migrations. The storage keeps JSON
+	// in blob columns, so the differences of the MariaDB JSON type don't apply.
+	flavorMariaDB = flavor{
+		queryReplacers: flavorMySQL.queryReplacers,
+		base:           &flavorMySQL,
+	}
 )
 
+// maxTxAttempts is how often ExecTx runs a transaction which keeps conflicting
+// with concurrent transactions.
+const maxTxAttempts = 5
+
 func (f flavor) translate(query string) string {
 	// TODO(ericchiang): Heavy cashing.
 	for _, r := range f.queryReplacers {
@@ -156,8 +180,21 @@ func (c *conn) QueryRow(query string, args ...interface{}) *sql.Row {
 	return c.db.QueryRow(query, c.translateArgs(args)...)
 }
 
-// ExecTx runs a method which operates on a transaction.
+// ExecTx runs a method which operates on a transaction. Transactions aborted
+// because of a serialization failure are retried with a short backoff, so fn
+// must not have side effects outside of the transaction.
 func (c *conn) ExecTx(fn func(tx *trans) error) error {
+	for attempt := 1; ; attempt++ {
+		err := c.execTx(fn)
+		if err == nil || attempt == maxTxAttempts || !isSerializationFailure(err) {
+			return err
+		}
+		c.logger.Debugf("retrying transaction after serialization failure (attempt %d): %v", attempt, err)
+		time.Sleep(time.Duration(attempt) * 10 * time.Millisecond)
+	}
+}
+
+func (c *conn) execTx(fn func(tx *trans) error) error {
 	if c.flavor.executeTx != nil {
 		return c.flavor.executeTx(c.db, func(sqlTx *sql.Tx) error {
 			return fn(&trans{sqlTx, c})
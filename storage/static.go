@@ -60,6 +60,36 @@ func (s staticClientsStorage) ListClients() ([]Client, error) {
 	return append(clients[:n], s.clients...), nil
 }
 
+// ListClientsPage lists the static clients after all clients of the backing
+// storage.
+func (s staticClientsStorage) ListClientsPage(opts ListOptions) ([]Client, string, error) {
+	cursor, err := parseStaticCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var clients []Client
+	if !cursor.static {
+		backingOpts := opts
+		backingOpts.PageToken = cursor.token
+		page, next, err := s.Storage.ListClientsPage(backingOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, client := range page {
+			if !s.isStatic(client.ID) {
+				clients = append(clients, client)
+			}
+		}
+		if next != "" {
+			return clients, storagePageToken(next), nil
+		}
+	}
+
+	start, end, next := staticPage(opts, cursor, len(clients), len(s.clients))
+	return append(clients, s.clients[start:end]...), next, nil
+}
+
 func (s staticClientsStorage) CreateClient(c Client) error {
 	if s.isStatic(c.ID) {
 		return errors.New("static clients: read-only cannot create client")
@@ -140,6 +170,42 @@ func (s staticPasswordsStorage) ListPasswords() ([]Password, error) {
 	return append(passwords[:n], s.passwords...), nil
 }
 
+// ListPasswordsPage lists the static passwords after all passwords of the
+// backing storage.
+func (s staticPasswordsStorage) ListPasswordsPage(opts ListOptions) ([]Password, string, error) {
+	cursor, err := parseStaticCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var passwords []Password
+	if !cursor.static {
+		backingOpts := opts
+		backingOpts.PageToken = cursor.token
+		page, next, err := s.Storage.ListPasswordsPage(backingOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, password := range page {
+			if !s.isStatic(password.Email) {
+				passwords = append(passwords, password)
+			}
+		}
+		if next != "" {
+			return passwords, storagePageToken(next), nil
+		}
+	}
+
+	var static []Password
+	for _, password := range s.passwords {
+		if opts.Filter.MatchPassword(password) {
+			static = append(static, password)
+		}
+	}
+	start, end, next := staticPage(opts, cursor, len(passwords), len(static))
+	return append(passwords, static[start:end]...), next, nil
+}
+
 func (s staticPasswordsStorage) CreatePassword(p Password) error {
 	if s.isStatic(p.Email) {
 		return errors.New("static passwords: read-only cannot create password")
@@ -210,6 +276,36 @@ func (s staticConnectorsStorage) ListConnectors() ([]Connector, error) {
 	return append(connectors[:n], s.connectors...), nil
 }
 
+// ListConnectorsPage lists the static connectors after all connectors of the
+// backing storage.
+func (s staticConnectorsStorage) ListConnectorsPage(opts ListOptions) ([]Connector, string, error) {
+	cursor, err := parseStaticCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var connectors []Connector
+	if !cursor.static {
+		backingOpts := opts
+		backingOpts.PageToken = cursor.token
+		page, next, err := s.Storage.ListConnectorsPage(backingOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, connector := range page {
+			if !s.isStatic(connector.ID) {
+				connectors = append(connectors, connector)
+			}
+		}
+		if next != "" {
+			return connectors, storagePageToken(next), nil
+		}
+	}
+
+	start, end, next := staticPage(opts, cursor, len(connectors), len(s.connectors))
+	return append(connectors, s.connectors[start:end]...), next, nil
+}
+
 func (s staticConnectorsStorage) CreateConnector(c Connector) error {
 	if s.isStatic(c.ID) {
 		return errors.New("static connectors: read-only cannot create connector")
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base32"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"strings"
@@ -49,10 +50,14 @@ func newSecureID(len int) string {
 
 // GCResult returns the number of objects deleted by garbage collection.
 type GCResult struct {
-	AuthRequests   int64
-	AuthCodes      int64
-	DeviceRequests int64
-	DeviceTokens   int64
+	AuthRequests    int64
+	AuthCodes       int64
+	DeviceRequests  int64
+	DeviceTokens    int64
+	CIBARequests    int64
+	CIBATokens      int64
+	RefreshTokens   int64
+	OfflineSessions int64
 }
 
 // IsEmpty returns whether the garbage collection result is empty or not.
@@ -60,7 +65,48 @@ func (g *GCResult) IsEmpty() bool {
 	return g.AuthRequests == 0 &&
 		g.AuthCodes == 0 &&
 		g.DeviceRequests == 0 &&
-		g.DeviceTokens == 0
+		g.DeviceTokens == 0 &&
+		g.CIBARequests == 0 &&
+		g.CIBATokens == 0 &&
+		g.RefreshTokens == 0 &&
+		g.OfflineSessions == 0
+}
+
+// GCOptions holds the lifetimes of objects which don't carry their own expiry.
+// A zero duration disables garbage collection based on that lifetime.
+type GCOptions struct {
+	// RefreshTokenAbsoluteLifetime is measured from the creation of a refresh token.
+	RefreshTokenAbsoluteLifetime time.Duration
+	// RefreshTokenValidIfNotUsedFor is measured from the last use of a refresh token.
+	RefreshTokenValidIfNotUsedFor time.Duration
+}
+
+// RefreshTokenExpired returns whether the refresh token has outlived either lifetime.
+func (o GCOptions) RefreshTokenExpired(r RefreshToken, now time.Time) bool {
+	if o.RefreshTokenAbsoluteLifetime != 0 && now.After(r.CreatedAt.Add(o.RefreshTokenAbsoluteLifetime)) {
+		return true
+	}
+	return o.RefreshTokenValidIfNotUsedFor != 0 && now.After(r.LastUsed.Add(o.RefreshTokenValidIfNotUsedFor))
+}
+
+// OfflineSessionOrphaned returns whether none of the refresh tokens referenced
+// by the session exist anymore. Sessions without any references aren't
+// orphaned: the server writes them at login, with the connector data, before
+// the client exchanges its code for the first refresh token.
+//
+// Backends without serializable transactions must list the sessions before the
+// refresh tokens, and only delete a session if it wasn't modified since it was
+// listed, so a session which got a new refresh token meanwhile is kept.
+func OfflineSessionOrphaned(o OfflineSessions, refreshExists func(id string) bool) bool {
+	if len(o.Refresh) == 0 {
+		return false
+	}
+	for _, ref := range o.Refresh {
+		if refreshExists(ref.ID) {
+			return false
+		}
+	}
+	return true
 }
 
 // Storage is the storage interface used by the server. Implementations are
@@ -79,6 +125,8 @@ type Storage interface {
 	CreateConnector(c Connector) error
 	CreateDeviceRequest(d DeviceRequest) error
 	CreateDeviceToken(d DeviceToken) error
+	CreateCIBARequest(r CIBARequest) error
+	CreateCIBAToken(t CIBAToken) error
 
 	// TODO(ericchiang): return (T, bool, error) so we can indicate not found
 	// requests that way instead of using ErrNotFound.
@@ -92,11 +140,28 @@ type Storage interface {
 	GetConnector(id string) (Connector, error)
 	GetDeviceRequest(userCode string) (DeviceRequest, error)
 	GetDeviceToken(deviceCode string) (DeviceToken, error)
+	GetCIBARequest(approvalCode string) (CIBARequest, error)
+	GetCIBAToken(authReqID string) (CIBAToken, error)
 
 	ListClients() ([]Client, error)
 	ListRefreshTokens() ([]RefreshToken, error)
 	ListPasswords() ([]Password, error)
 	ListConnectors() ([]Connector, error)
+	ListOfflineSessions() ([]OfflineSessions, error)
+
+	// Paginated list methods return objects in a storage specific order and the
+	// token of the next page, which is empty once all objects were listed. Pages
+	// may hold fewer than PageSize objects even if more objects follow.
+	ListClientsPage(opts ListOptions) ([]Client, string, error)
+	ListRefreshTokensPage(opts ListOptions) ([]RefreshToken, string, error)
+	ListPasswordsPage(opts ListOptions) ([]Password, string, error)
+	ListConnectorsPage(opts ListOptions) ([]Connector, string, error)
+
+	// ListRefreshTokensForUser returns the refresh tokens issued to the user
+	// through the connector, and ListOfflineSessionsForUser the offline sessions
+	// of the user with all connectors. Both are served from an index.
+	ListRefreshTokensForUser(userID string, connID string) ([]RefreshToken, error)
+	ListOfflineSessionsForUser(userID string) ([]OfflineSessions, error)
 
 	// Delete methods MUST be atomic.
 	DeleteAuthRequest(id string) error
@@ -129,10 +194,13 @@ type Storage interface {
 	UpdateOfflineSessions(userID string, connID string, updater func(s OfflineSessions) (OfflineSessions, error)) error
 	UpdateConnector(id string, updater func(c Connector) (Connector, error)) error
 	UpdateDeviceToken(deviceCode string, updater func(t DeviceToken) (DeviceToken, error)) error
+	UpdateCIBAToken(authReqID string, updater func(t CIBAToken) (CIBAToken, error)) error
 
-	// GarbageCollect deletes all expired AuthCodes,
-	// AuthRequests, DeviceRequests, and DeviceTokens.
-	GarbageCollect(now time.Time) (GCResult, error)
+	// GarbageCollect deletes all expired AuthCodes, AuthRequests,
+	// DeviceRequests, DeviceTokens, CIBARequests, and CIBATokens, the
+	// RefreshTokens which outlived the lifetimes in opts, and OfflineSessions
+	// whose refresh tokens no longer exist.
+	GarbageCollect(now time.Time, opts GCOptions) (GCResult, error)
 }
 
 // Client represents an OAuth2 client.
@@ -164,6 +232,61 @@ type Client struct {
 	// Name and LogoURL used when displaying this client to the end user.
 	Name    string `json:"name" yaml:"name"`
 	LogoURL string `json:"logoURL" yaml:"logoURL"`
+
+	// BackchannelNotificationEndpoint is the endpoint dex notifies once a backchannel
+	// authentication request completes, when the client uses the CIBA ping mode.
+	BackchannelNotificationEndpoint string `json:"backchannelNotificationEndpoint,omitempty" yaml:"backchannelNotificationEndpoint,omitempty"`
+
+	// TokenPolicy overrides the server wide token lifetimes for this client.
+	TokenPolicy ClientTokenPolicy `json:"tokenPolicy" yaml:"tokenPolicy"`
+
+	// Restrictions on what the client may request. An empty list doesn't
+	// restrict the client beyond the server configuration.
+	AllowedConnectors    []string `json:"allowedConnectors,omitempty" yaml:"allowedConnectors,omitempty"`
+	AllowedGrantTypes    []string `json:"allowedGrantTypes,omitempty" yaml:"allowedGrantTypes,omitempty"`
+	AllowedScopes        []string `json:"allowedScopes,omitempty" yaml:"allowedScopes,omitempty"`
+	AllowedResponseTypes []string `json:"allowedResponseTypes,omitempty" yaml:"allowedResponseTypes,omitempty"`
+}
+
+// ClientTokenPolicy overrides the token lifetimes and the refresh token policy
+// of the server for a single client. Durations use the time.ParseDuration
+// format, empty values and a nil DisableRefreshTokenRotation fall back to the
+// server configuration.
+type ClientTokenPolicy struct {
+	IDTokensValidFor     string `json:"idTokensValidFor,omitempty" yaml:"idTokensValidFor,omitempty"`
+	AccessTokensValidFor string `json:"accessTokensValidFor,omitempty" yaml:"accessTokensValidFor,omitempty"`
+	AuthCodesValidFor    string `json:"authCodesValidFor,omitempty" yaml:"authCodesValidFor,omitempty"`
+
+	DisableRefreshTokenRotation   *bool  `json:"disableRefreshTokenRotation,omitempty" yaml:"disableRefreshTokenRotation,omitempty"`
+	RefreshTokenAbsoluteLifetime  string `json:"refreshTokenAbsoluteLifetime,omitempty" yaml:"refreshTokenAbsoluteLifetime,omitempty"`
+	RefreshTokenValidIfNotUsedFor string `json:"refreshTokenValidIfNotUsedFor,omitempty" yaml:"refreshTokenValidIfNotUsedFor,omitempty"`
+}
+
+// Validate returns an error if one of the durations of the policy is invalid.
+func (p ClientTokenPolicy) Validate() error {
+	durations := []struct {
+		name  string
+		value string
+	}{
+		{"idTokensValidFor", p.IDTokensValidFor},
+		{"accessTokensValidFor", p.AccessTokensValidFor},
+		{"authCodesValidFor", p.AuthCodesValidFor},
+		{"refreshTokenAbsoluteLifetime", p.RefreshTokenAbsoluteLifetime},
+		{"refreshTokenValidIfNotUsedFor", p.RefreshTokenValidIfNotUsedFor},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", d.name, d.value, err)
+		}
+		if v <= 0 {
+			return fmt.Errorf("invalid %s %q: must be positive", d.name, d.value)
+		}
+	}
+	return nil
 }
 
 // Claims represents the ID Token claims supported by the server.
@@ -428,3 +551,37 @@ type DeviceToken struct {
 	LastRequestTime     time.Time
 	PollIntervalSeconds int
 }
+
+// CIBARequest represents an OpenID Connect Client-Initiated Backchannel Authentication request.
+// It holds the state of the request until the user approves or denies it on the approval page
+// or the expiry time passes.
+type CIBARequest struct {
+	// The code identifying the request on the approval page, delivered to the user
+	// by the notification hook
+	ApprovalCode string
+	// The unique identifier returned to the client as auth_req_id
+	AuthReqID string
+	// The client ID the request is for
+	ClientID string
+	// The scopes the client requests
+	Scopes []string
+	// The hint identifying the user to authenticate
+	LoginHint string
+	// The message displayed on both the consumption and the authentication device
+	BindingMessage string
+	// The expire time
+	Expiry time.Time
+}
+
+// CIBAToken is a structure which represents the token of a backchannel authentication request.
+// Clients poll for it with the auth_req_id, or are notified once it completes in ping mode.
+type CIBAToken struct {
+	AuthReqID           string
+	ClientID            string
+	Status              string
+	Token               string
+	NotificationToken   string
+	Expiry              time.Time
+	LastRequestTime     time.Time
+	PollIntervalSeconds int
+}